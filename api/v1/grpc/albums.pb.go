@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -88,6 +89,7 @@ type Album struct {
 	MediaCount     int32                  `protobuf:"varint,8,opt,name=media_count,json=mediaCount,proto3" json:"media_count,omitempty"`                      // Total media count including children
 	MediaIds       []string               `protobuf:"bytes,9,rep,name=media_ids,json=mediaIds,proto3" json:"media_ids,omitempty"`                             // List of media IDs in this album
 	SyncInProgress *bool                  `protobuf:"varint,10,opt,name=sync_in_progress,json=syncInProgress,proto3,oneof" json:"sync_in_progress,omitempty"` // True if a sync job is running for this album
+	Hidden         bool                   `protobuf:"varint,11,opt,name=hidden,proto3" json:"hidden,omitempty"`                                               // Hidden albums and their subalbums are left out of the memories
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -192,6 +194,13 @@ func (x *Album) GetSyncInProgress() bool {
 	return false
 }
 
+func (x *Album) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
 // Request to create a new album
 type CreateAlbumRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -258,6 +267,7 @@ type UpdateAlbumRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Description   *string                `protobuf:"bytes,1,opt,name=description,proto3,oneof" json:"description,omitempty"` // Updated description
 	Thumbnail     *string                `protobuf:"bytes,2,opt,name=thumbnail,proto3,oneof" json:"thumbnail,omitempty"`     // ID of the media to use as thumbnail
+	Hidden        *bool                  `protobuf:"varint,3,opt,name=hidden,proto3,oneof" json:"hidden,omitempty"`          // Leave the album and its subalbums out of the memories
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -306,13 +316,25 @@ func (x *UpdateAlbumRequest) GetThumbnail() string {
 	return ""
 }
 
+func (x *UpdateAlbumRequest) GetHidden() bool {
+	if x != nil && x.Hidden != nil {
+		return *x.Hidden
+	}
+	return false
+}
+
 // Request to list albums
 type ListAlbumsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`                          // Pagination parameters
-	WithParent    *bool                  `protobuf:"varint,2,opt,name=with_parent,json=withParent,proto3,oneof" json:"with_parent,omitempty"` // Include albums with parents (default: false)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Pagination        *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`                                                            // Pagination parameters
+	WithParent        *bool                  `protobuf:"varint,2,opt,name=with_parent,json=withParent,proto3,oneof" json:"with_parent,omitempty"`                                   // Include albums with parents (default: false)
+	Cursor            *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`                                                              // Cursor of the next page, the offset is ignored if set
+	SortBy            *AlbumSortBy           `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=photos_ng.api.v1.grpc.AlbumSortBy,oneof" json:"sort_by,omitempty"`        // Sort field (default: name)
+	SortOrder         *SortOrder             `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=photos_ng.api.v1.grpc.SortOrder,oneof" json:"sort_order,omitempty"` // Sort order (default: asc)
+	SmartAlbumsLimit  *int32                 `protobuf:"varint,6,opt,name=smart_albums_limit,json=smartAlbumsLimit,proto3,oneof" json:"smart_albums_limit,omitempty"`               // Maximum number of smart albums to return, 0 returns none (default: 20)
+	SmartAlbumsOffset *int32                 `protobuf:"varint,7,opt,name=smart_albums_offset,json=smartAlbumsOffset,proto3,oneof" json:"smart_albums_offset,omitempty"`            // Number of smart albums to skip, paged independently of the albums (default: 0)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListAlbumsRequest) Reset() {
@@ -359,13 +381,51 @@ func (x *ListAlbumsRequest) GetWithParent() bool {
 	return false
 }
 
+func (x *ListAlbumsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAlbumsRequest) GetSortBy() AlbumSortBy {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return AlbumSortBy_ALBUM_SORT_BY_UNSPECIFIED
+}
+
+func (x *ListAlbumsRequest) GetSortOrder() SortOrder {
+	if x != nil && x.SortOrder != nil {
+		return *x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *ListAlbumsRequest) GetSmartAlbumsLimit() int32 {
+	if x != nil && x.SmartAlbumsLimit != nil {
+		return *x.SmartAlbumsLimit
+	}
+	return 0
+}
+
+func (x *ListAlbumsRequest) GetSmartAlbumsOffset() int32 {
+	if x != nil && x.SmartAlbumsOffset != nil {
+		return *x.SmartAlbumsOffset
+	}
+	return 0
+}
+
 // Response containing list of albums
 type ListAlbumsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Albums        []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"`         // List of albums
-	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"` // Pagination metadata
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Albums                []*Album               `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"`                                                              // List of albums
+	Pagination            *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`                                                      // Pagination metadata
+	SmartAlbums           []*SmartAlbum          `protobuf:"bytes,3,rep,name=smart_albums,json=smartAlbums,proto3" json:"smart_albums,omitempty"`                                 // Smart albums, paged with smart_albums_limit and smart_albums_offset
+	NextCursor            *string                `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`                              // Cursor of the next page, not set on the last page
+	SmartAlbumsPagination *PaginationResponse    `protobuf:"bytes,5,opt,name=smart_albums_pagination,json=smartAlbumsPagination,proto3" json:"smart_albums_pagination,omitempty"` // Pagination metadata of the smart albums, not set if none are requested
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *ListAlbumsResponse) Reset() {
@@ -412,6 +472,27 @@ func (x *ListAlbumsResponse) GetPagination() *PaginationResponse {
 	return nil
 }
 
+func (x *ListAlbumsResponse) GetSmartAlbums() []*SmartAlbum {
+	if x != nil {
+		return x.SmartAlbums
+	}
+	return nil
+}
+
+func (x *ListAlbumsResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListAlbumsResponse) GetSmartAlbumsPagination() *PaginationResponse {
+	if x != nil {
+		return x.SmartAlbumsPagination
+	}
+	return nil
+}
+
 // Request to get a specific album by ID
 type GetAlbumRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -653,15 +734,230 @@ func (x *SyncAlbumResponse) GetSyncedItems() int32 {
 	return 0
 }
 
+// Request to get the album tree
+type GetAlbumTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootId        *string                `protobuf:"bytes,1,opt,name=root_id,json=rootId,proto3,oneof" json:"root_id,omitempty"` // Return the subtree of this album instead of the whole tree
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`                      // Maximum number of levels of the tree, 0 returns all the levels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlbumTreeRequest) Reset() {
+	*x = GetAlbumTreeRequest{}
+	mi := &file_albums_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlbumTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlbumTreeRequest) ProtoMessage() {}
+
+func (x *GetAlbumTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_albums_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlbumTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetAlbumTreeRequest) Descriptor() ([]byte, []int) {
+	return file_albums_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAlbumTreeRequest) GetRootId() string {
+	if x != nil && x.RootId != nil {
+		return *x.RootId
+	}
+	return ""
+}
+
+func (x *GetAlbumTreeRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// Album of the album tree with the statistics of its media
+type AlbumTreeNode struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                   // Unique identifier for the album
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                               // Display name of the album
+	Path               string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`                                                               // File system path of the album
+	Description        *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`                                           // Album description
+	MediaCount         int32                  `protobuf:"varint,5,opt,name=media_count,json=mediaCount,proto3" json:"media_count,omitempty"`                                // Number of media of the album itself
+	SubtreeMediaCount  int32                  `protobuf:"varint,6,opt,name=subtree_media_count,json=subtreeMediaCount,proto3" json:"subtree_media_count,omitempty"`         // Number of media of the album and of all its descendants
+	EarliestCapturedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=earliest_captured_at,json=earliestCapturedAt,proto3,oneof" json:"earliest_captured_at,omitempty"` // Capture date of the oldest media of the subtree
+	LatestCapturedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=latest_captured_at,json=latestCapturedAt,proto3,oneof" json:"latest_captured_at,omitempty"`       // Capture date of the most recent media of the subtree
+	CoverId            *string                `protobuf:"bytes,9,opt,name=cover_id,json=coverId,proto3,oneof" json:"cover_id,omitempty"`                                    // Thumbnail of the album or else the most recent media of the subtree
+	Children           []*AlbumTreeNode       `protobuf:"bytes,10,rep,name=children,proto3" json:"children,omitempty"`                                                      // Subalbums, empty below the depth limit
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AlbumTreeNode) Reset() {
+	*x = AlbumTreeNode{}
+	mi := &file_albums_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlbumTreeNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlbumTreeNode) ProtoMessage() {}
+
+func (x *AlbumTreeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_albums_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlbumTreeNode.ProtoReflect.Descriptor instead.
+func (*AlbumTreeNode) Descriptor() ([]byte, []int) {
+	return file_albums_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AlbumTreeNode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlbumTreeNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlbumTreeNode) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AlbumTreeNode) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *AlbumTreeNode) GetMediaCount() int32 {
+	if x != nil {
+		return x.MediaCount
+	}
+	return 0
+}
+
+func (x *AlbumTreeNode) GetSubtreeMediaCount() int32 {
+	if x != nil {
+		return x.SubtreeMediaCount
+	}
+	return 0
+}
+
+func (x *AlbumTreeNode) GetEarliestCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EarliestCapturedAt
+	}
+	return nil
+}
+
+func (x *AlbumTreeNode) GetLatestCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestCapturedAt
+	}
+	return nil
+}
+
+func (x *AlbumTreeNode) GetCoverId() string {
+	if x != nil && x.CoverId != nil {
+		return *x.CoverId
+	}
+	return ""
+}
+
+func (x *AlbumTreeNode) GetChildren() []*AlbumTreeNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Response containing the roots of the album tree
+type GetAlbumTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Albums        []*AlbumTreeNode       `protobuf:"bytes,1,rep,name=albums,proto3" json:"albums,omitempty"` // Roots of the tree
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlbumTreeResponse) Reset() {
+	*x = GetAlbumTreeResponse{}
+	mi := &file_albums_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlbumTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlbumTreeResponse) ProtoMessage() {}
+
+func (x *GetAlbumTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_albums_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlbumTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetAlbumTreeResponse) Descriptor() ([]byte, []int) {
+	return file_albums_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetAlbumTreeResponse) GetAlbums() []*AlbumTreeNode {
+	if x != nil {
+		return x.Albums
+	}
+	return nil
+}
+
 var File_albums_proto protoreflect.FileDescriptor
 
 const file_albums_proto_rawDesc = "" +
 	"\n" +
-	"\falbums.proto\x12\x15photos_ng.api.v1.grpc\x1a\fcommon.proto\x1a\x1bgoogle/protobuf/empty.proto\"0\n" +
+	"\falbums.proto\x12\x15photos_ng.api.v1.grpc\x1a\fcommon.proto\x1a\x12smart_albums.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"0\n" +
 	"\n" +
 	"AlbumChild\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\x98\x03\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xb0\x03\n" +
 	"\x05Album\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -674,7 +970,8 @@ const file_albums_proto_rawDesc = "" +
 	"mediaCount\x12\x1b\n" +
 	"\tmedia_ids\x18\t \x03(\tR\bmediaIds\x12-\n" +
 	"\x10sync_in_progress\x18\n" +
-	" \x01(\bH\x03R\x0esyncInProgress\x88\x01\x01B\f\n" +
+	" \x01(\bH\x03R\x0esyncInProgress\x88\x01\x01\x12\x16\n" +
+	"\x06hidden\x18\v \x01(\bR\x06hiddenB\f\n" +
 	"\n" +
 	"_parent_idB\f\n" +
 	"\n" +
@@ -687,25 +984,44 @@ const file_albums_proto_rawDesc = "" +
 	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\f\n" +
 	"\n" +
 	"_parent_idB\x0e\n" +
-	"\f_description\"|\n" +
+	"\f_description\"\xa4\x01\n" +
 	"\x12UpdateAlbumRequest\x12%\n" +
 	"\vdescription\x18\x01 \x01(\tH\x00R\vdescription\x88\x01\x01\x12!\n" +
-	"\tthumbnail\x18\x02 \x01(\tH\x01R\tthumbnail\x88\x01\x01B\x0e\n" +
+	"\tthumbnail\x18\x02 \x01(\tH\x01R\tthumbnail\x88\x01\x01\x12\x1b\n" +
+	"\x06hidden\x18\x03 \x01(\bH\x02R\x06hidden\x88\x01\x01B\x0e\n" +
 	"\f_descriptionB\f\n" +
 	"\n" +
-	"_thumbnail\"\x93\x01\n" +
+	"_thumbnailB\t\n" +
+	"\a_hidden\"\xf5\x03\n" +
 	"\x11ListAlbumsRequest\x12H\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2(.photos_ng.api.v1.grpc.PaginationRequestR\n" +
 	"pagination\x12$\n" +
 	"\vwith_parent\x18\x02 \x01(\bH\x00R\n" +
-	"withParent\x88\x01\x01B\x0e\n" +
-	"\f_with_parent\"\x95\x01\n" +
+	"withParent\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01\x12@\n" +
+	"\asort_by\x18\x04 \x01(\x0e2\".photos_ng.api.v1.grpc.AlbumSortByH\x02R\x06sortBy\x88\x01\x01\x12D\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\x0e2 .photos_ng.api.v1.grpc.SortOrderH\x03R\tsortOrder\x88\x01\x01\x121\n" +
+	"\x12smart_albums_limit\x18\x06 \x01(\x05H\x04R\x10smartAlbumsLimit\x88\x01\x01\x123\n" +
+	"\x13smart_albums_offset\x18\a \x01(\x05H\x05R\x11smartAlbumsOffset\x88\x01\x01B\x0e\n" +
+	"\f_with_parentB\t\n" +
+	"\a_cursorB\n" +
+	"\n" +
+	"\b_sort_byB\r\n" +
+	"\v_sort_orderB\x15\n" +
+	"\x13_smart_albums_limitB\x16\n" +
+	"\x14_smart_albums_offset\"\xf4\x02\n" +
 	"\x12ListAlbumsResponse\x124\n" +
 	"\x06albums\x18\x01 \x03(\v2\x1c.photos_ng.api.v1.grpc.AlbumR\x06albums\x12I\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2).photos_ng.api.v1.grpc.PaginationResponseR\n" +
-	"pagination\"!\n" +
+	"pagination\x12D\n" +
+	"\fsmart_albums\x18\x03 \x03(\v2!.photos_ng.api.v1.grpc.SmartAlbumR\vsmartAlbums\x12$\n" +
+	"\vnext_cursor\x18\x04 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01\x12a\n" +
+	"\x17smart_albums_pagination\x18\x05 \x01(\v2).photos_ng.api.v1.grpc.PaginationResponseR\x15smartAlbumsPaginationB\x0e\n" +
+	"\f_next_cursor\"!\n" +
 	"\x0fGetAlbumRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"k\n" +
 	"\x16UpdateAlbumByIdRequest\x12\x0e\n" +
@@ -717,7 +1033,31 @@ const file_albums_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"P\n" +
 	"\x11SyncAlbumResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
-	"\fsynced_items\x18\x02 \x01(\x05R\vsyncedItems2\xaa\x04\n" +
+	"\fsynced_items\x18\x02 \x01(\x05R\vsyncedItems\"U\n" +
+	"\x13GetAlbumTreeRequest\x12\x1c\n" +
+	"\aroot_id\x18\x01 \x01(\tH\x00R\x06rootId\x88\x01\x01\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depthB\n" +
+	"\n" +
+	"\b_root_id\"\x90\x04\n" +
+	"\rAlbumTreeNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\vmedia_count\x18\x05 \x01(\x05R\n" +
+	"mediaCount\x12.\n" +
+	"\x13subtree_media_count\x18\x06 \x01(\x05R\x11subtreeMediaCount\x12Q\n" +
+	"\x14earliest_captured_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x12earliestCapturedAt\x88\x01\x01\x12M\n" +
+	"\x12latest_captured_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x10latestCapturedAt\x88\x01\x01\x12\x1e\n" +
+	"\bcover_id\x18\t \x01(\tH\x03R\acoverId\x88\x01\x01\x12@\n" +
+	"\bchildren\x18\n" +
+	" \x03(\v2$.photos_ng.api.v1.grpc.AlbumTreeNodeR\bchildrenB\x0e\n" +
+	"\f_descriptionB\x17\n" +
+	"\x15_earliest_captured_atB\x15\n" +
+	"\x13_latest_captured_atB\v\n" +
+	"\t_cover_id\"T\n" +
+	"\x14GetAlbumTreeResponse\x12<\n" +
+	"\x06albums\x18\x01 \x03(\v2$.photos_ng.api.v1.grpc.AlbumTreeNodeR\x06albums2\xaa\x04\n" +
 	"\rAlbumsService\x12a\n" +
 	"\n" +
 	"ListAlbums\x12(.photos_ng.api.v1.grpc.ListAlbumsRequest\x1a).photos_ng.api.v1.grpc.ListAlbumsResponse\x12V\n" +
@@ -740,7 +1080,7 @@ func file_albums_proto_rawDescGZIP() []byte {
 	return file_albums_proto_rawDescData
 }
 
-var file_albums_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_albums_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_albums_proto_goTypes = []any{
 	(*AlbumChild)(nil),             // 0: photos_ng.api.v1.grpc.AlbumChild
 	(*Album)(nil),                  // 1: photos_ng.api.v1.grpc.Album
@@ -753,33 +1093,48 @@ var file_albums_proto_goTypes = []any{
 	(*DeleteAlbumRequest)(nil),     // 8: photos_ng.api.v1.grpc.DeleteAlbumRequest
 	(*SyncAlbumRequest)(nil),       // 9: photos_ng.api.v1.grpc.SyncAlbumRequest
 	(*SyncAlbumResponse)(nil),      // 10: photos_ng.api.v1.grpc.SyncAlbumResponse
-	(*PaginationRequest)(nil),      // 11: photos_ng.api.v1.grpc.PaginationRequest
-	(*PaginationResponse)(nil),     // 12: photos_ng.api.v1.grpc.PaginationResponse
-	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
+	(*GetAlbumTreeRequest)(nil),    // 11: photos_ng.api.v1.grpc.GetAlbumTreeRequest
+	(*AlbumTreeNode)(nil),          // 12: photos_ng.api.v1.grpc.AlbumTreeNode
+	(*GetAlbumTreeResponse)(nil),   // 13: photos_ng.api.v1.grpc.GetAlbumTreeResponse
+	(*PaginationRequest)(nil),      // 14: photos_ng.api.v1.grpc.PaginationRequest
+	(AlbumSortBy)(0),               // 15: photos_ng.api.v1.grpc.AlbumSortBy
+	(SortOrder)(0),                 // 16: photos_ng.api.v1.grpc.SortOrder
+	(*PaginationResponse)(nil),     // 17: photos_ng.api.v1.grpc.PaginationResponse
+	(*SmartAlbum)(nil),             // 18: photos_ng.api.v1.grpc.SmartAlbum
+	(*timestamppb.Timestamp)(nil),  // 19: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 20: google.protobuf.Empty
 }
 var file_albums_proto_depIdxs = []int32{
 	0,  // 0: photos_ng.api.v1.grpc.Album.children:type_name -> photos_ng.api.v1.grpc.AlbumChild
-	11, // 1: photos_ng.api.v1.grpc.ListAlbumsRequest.pagination:type_name -> photos_ng.api.v1.grpc.PaginationRequest
-	1,  // 2: photos_ng.api.v1.grpc.ListAlbumsResponse.albums:type_name -> photos_ng.api.v1.grpc.Album
-	12, // 3: photos_ng.api.v1.grpc.ListAlbumsResponse.pagination:type_name -> photos_ng.api.v1.grpc.PaginationResponse
-	3,  // 4: photos_ng.api.v1.grpc.UpdateAlbumByIdRequest.update:type_name -> photos_ng.api.v1.grpc.UpdateAlbumRequest
-	4,  // 5: photos_ng.api.v1.grpc.AlbumsService.ListAlbums:input_type -> photos_ng.api.v1.grpc.ListAlbumsRequest
-	2,  // 6: photos_ng.api.v1.grpc.AlbumsService.CreateAlbum:input_type -> photos_ng.api.v1.grpc.CreateAlbumRequest
-	6,  // 7: photos_ng.api.v1.grpc.AlbumsService.GetAlbum:input_type -> photos_ng.api.v1.grpc.GetAlbumRequest
-	7,  // 8: photos_ng.api.v1.grpc.AlbumsService.UpdateAlbum:input_type -> photos_ng.api.v1.grpc.UpdateAlbumByIdRequest
-	8,  // 9: photos_ng.api.v1.grpc.AlbumsService.DeleteAlbum:input_type -> photos_ng.api.v1.grpc.DeleteAlbumRequest
-	9,  // 10: photos_ng.api.v1.grpc.AlbumsService.SyncAlbum:input_type -> photos_ng.api.v1.grpc.SyncAlbumRequest
-	5,  // 11: photos_ng.api.v1.grpc.AlbumsService.ListAlbums:output_type -> photos_ng.api.v1.grpc.ListAlbumsResponse
-	1,  // 12: photos_ng.api.v1.grpc.AlbumsService.CreateAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	1,  // 13: photos_ng.api.v1.grpc.AlbumsService.GetAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	1,  // 14: photos_ng.api.v1.grpc.AlbumsService.UpdateAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	13, // 15: photos_ng.api.v1.grpc.AlbumsService.DeleteAlbum:output_type -> google.protobuf.Empty
-	10, // 16: photos_ng.api.v1.grpc.AlbumsService.SyncAlbum:output_type -> photos_ng.api.v1.grpc.SyncAlbumResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	14, // 1: photos_ng.api.v1.grpc.ListAlbumsRequest.pagination:type_name -> photos_ng.api.v1.grpc.PaginationRequest
+	15, // 2: photos_ng.api.v1.grpc.ListAlbumsRequest.sort_by:type_name -> photos_ng.api.v1.grpc.AlbumSortBy
+	16, // 3: photos_ng.api.v1.grpc.ListAlbumsRequest.sort_order:type_name -> photos_ng.api.v1.grpc.SortOrder
+	1,  // 4: photos_ng.api.v1.grpc.ListAlbumsResponse.albums:type_name -> photos_ng.api.v1.grpc.Album
+	17, // 5: photos_ng.api.v1.grpc.ListAlbumsResponse.pagination:type_name -> photos_ng.api.v1.grpc.PaginationResponse
+	18, // 6: photos_ng.api.v1.grpc.ListAlbumsResponse.smart_albums:type_name -> photos_ng.api.v1.grpc.SmartAlbum
+	17, // 7: photos_ng.api.v1.grpc.ListAlbumsResponse.smart_albums_pagination:type_name -> photos_ng.api.v1.grpc.PaginationResponse
+	3,  // 8: photos_ng.api.v1.grpc.UpdateAlbumByIdRequest.update:type_name -> photos_ng.api.v1.grpc.UpdateAlbumRequest
+	19, // 9: photos_ng.api.v1.grpc.AlbumTreeNode.earliest_captured_at:type_name -> google.protobuf.Timestamp
+	19, // 10: photos_ng.api.v1.grpc.AlbumTreeNode.latest_captured_at:type_name -> google.protobuf.Timestamp
+	12, // 11: photos_ng.api.v1.grpc.AlbumTreeNode.children:type_name -> photos_ng.api.v1.grpc.AlbumTreeNode
+	12, // 12: photos_ng.api.v1.grpc.GetAlbumTreeResponse.albums:type_name -> photos_ng.api.v1.grpc.AlbumTreeNode
+	4,  // 13: photos_ng.api.v1.grpc.AlbumsService.ListAlbums:input_type -> photos_ng.api.v1.grpc.ListAlbumsRequest
+	2,  // 14: photos_ng.api.v1.grpc.AlbumsService.CreateAlbum:input_type -> photos_ng.api.v1.grpc.CreateAlbumRequest
+	6,  // 15: photos_ng.api.v1.grpc.AlbumsService.GetAlbum:input_type -> photos_ng.api.v1.grpc.GetAlbumRequest
+	7,  // 16: photos_ng.api.v1.grpc.AlbumsService.UpdateAlbum:input_type -> photos_ng.api.v1.grpc.UpdateAlbumByIdRequest
+	8,  // 17: photos_ng.api.v1.grpc.AlbumsService.DeleteAlbum:input_type -> photos_ng.api.v1.grpc.DeleteAlbumRequest
+	9,  // 18: photos_ng.api.v1.grpc.AlbumsService.SyncAlbum:input_type -> photos_ng.api.v1.grpc.SyncAlbumRequest
+	5,  // 19: photos_ng.api.v1.grpc.AlbumsService.ListAlbums:output_type -> photos_ng.api.v1.grpc.ListAlbumsResponse
+	1,  // 20: photos_ng.api.v1.grpc.AlbumsService.CreateAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	1,  // 21: photos_ng.api.v1.grpc.AlbumsService.GetAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	1,  // 22: photos_ng.api.v1.grpc.AlbumsService.UpdateAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	20, // 23: photos_ng.api.v1.grpc.AlbumsService.DeleteAlbum:output_type -> google.protobuf.Empty
+	10, // 24: photos_ng.api.v1.grpc.AlbumsService.SyncAlbum:output_type -> photos_ng.api.v1.grpc.SyncAlbumResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_albums_proto_init() }
@@ -788,17 +1143,21 @@ func file_albums_proto_init() {
 		return
 	}
 	file_common_proto_init()
+	file_smart_albums_proto_init()
 	file_albums_proto_msgTypes[1].OneofWrappers = []any{}
 	file_albums_proto_msgTypes[2].OneofWrappers = []any{}
 	file_albums_proto_msgTypes[3].OneofWrappers = []any{}
 	file_albums_proto_msgTypes[4].OneofWrappers = []any{}
+	file_albums_proto_msgTypes[5].OneofWrappers = []any{}
+	file_albums_proto_msgTypes[11].OneofWrappers = []any{}
+	file_albums_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_albums_proto_rawDesc), len(file_albums_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
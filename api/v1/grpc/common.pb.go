@@ -175,6 +175,62 @@ func (MediaSortBy) EnumDescriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{2}
 }
 
+// Album sort field enumeration, the media count and the latest capture date include the subalbums
+type AlbumSortBy int32
+
+const (
+	AlbumSortBy_ALBUM_SORT_BY_UNSPECIFIED    AlbumSortBy = 0
+	AlbumSortBy_ALBUM_SORT_BY_NAME           AlbumSortBy = 1
+	AlbumSortBy_ALBUM_SORT_BY_CREATED_AT     AlbumSortBy = 2
+	AlbumSortBy_ALBUM_SORT_BY_LATEST_CAPTURE AlbumSortBy = 3
+	AlbumSortBy_ALBUM_SORT_BY_MEDIA_COUNT    AlbumSortBy = 4
+)
+
+// Enum value maps for AlbumSortBy.
+var (
+	AlbumSortBy_name = map[int32]string{
+		0: "ALBUM_SORT_BY_UNSPECIFIED",
+		1: "ALBUM_SORT_BY_NAME",
+		2: "ALBUM_SORT_BY_CREATED_AT",
+		3: "ALBUM_SORT_BY_LATEST_CAPTURE",
+		4: "ALBUM_SORT_BY_MEDIA_COUNT",
+	}
+	AlbumSortBy_value = map[string]int32{
+		"ALBUM_SORT_BY_UNSPECIFIED":    0,
+		"ALBUM_SORT_BY_NAME":           1,
+		"ALBUM_SORT_BY_CREATED_AT":     2,
+		"ALBUM_SORT_BY_LATEST_CAPTURE": 3,
+		"ALBUM_SORT_BY_MEDIA_COUNT":    4,
+	}
+)
+
+func (x AlbumSortBy) Enum() *AlbumSortBy {
+	p := new(AlbumSortBy)
+	*p = x
+	return p
+}
+
+func (x AlbumSortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlbumSortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_common_proto_enumTypes[3].Descriptor()
+}
+
+func (AlbumSortBy) Type() protoreflect.EnumType {
+	return &file_common_proto_enumTypes[3]
+}
+
+func (x AlbumSortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlbumSortBy.Descriptor instead.
+func (AlbumSortBy) EnumDescriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{3}
+}
+
 // Sync job status enumeration
 type SyncJobStatus int32
 
@@ -185,6 +241,7 @@ const (
 	SyncJobStatus_SYNC_JOB_STATUS_COMPLETED   SyncJobStatus = 3
 	SyncJobStatus_SYNC_JOB_STATUS_FAILED      SyncJobStatus = 4
 	SyncJobStatus_SYNC_JOB_STATUS_STOPPED     SyncJobStatus = 5
+	SyncJobStatus_SYNC_JOB_STATUS_INTERRUPTED SyncJobStatus = 6 // The job was running or waiting when the server stopped
 )
 
 // Enum value maps for SyncJobStatus.
@@ -196,6 +253,7 @@ var (
 		3: "SYNC_JOB_STATUS_COMPLETED",
 		4: "SYNC_JOB_STATUS_FAILED",
 		5: "SYNC_JOB_STATUS_STOPPED",
+		6: "SYNC_JOB_STATUS_INTERRUPTED",
 	}
 	SyncJobStatus_value = map[string]int32{
 		"SYNC_JOB_STATUS_UNSPECIFIED": 0,
@@ -204,6 +262,7 @@ var (
 		"SYNC_JOB_STATUS_COMPLETED":   3,
 		"SYNC_JOB_STATUS_FAILED":      4,
 		"SYNC_JOB_STATUS_STOPPED":     5,
+		"SYNC_JOB_STATUS_INTERRUPTED": 6,
 	}
 )
 
@@ -218,11 +277,11 @@ func (x SyncJobStatus) String() string {
 }
 
 func (SyncJobStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_proto_enumTypes[3].Descriptor()
+	return file_common_proto_enumTypes[4].Descriptor()
 }
 
 func (SyncJobStatus) Type() protoreflect.EnumType {
-	return &file_common_proto_enumTypes[3]
+	return &file_common_proto_enumTypes[4]
 }
 
 func (x SyncJobStatus) Number() protoreflect.EnumNumber {
@@ -231,7 +290,7 @@ func (x SyncJobStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SyncJobStatus.Descriptor instead.
 func (SyncJobStatus) EnumDescriptor() ([]byte, []int) {
-	return file_common_proto_rawDescGZIP(), []int{3}
+	return file_common_proto_rawDescGZIP(), []int{4}
 }
 
 // Task result item type enumeration
@@ -268,11 +327,11 @@ func (x TaskResultItemType) String() string {
 }
 
 func (TaskResultItemType) Descriptor() protoreflect.EnumDescriptor {
-	return file_common_proto_enumTypes[4].Descriptor()
+	return file_common_proto_enumTypes[5].Descriptor()
 }
 
 func (TaskResultItemType) Type() protoreflect.EnumType {
-	return &file_common_proto_enumTypes[4]
+	return &file_common_proto_enumTypes[5]
 }
 
 func (x TaskResultItemType) Number() protoreflect.EnumNumber {
@@ -281,7 +340,7 @@ func (x TaskResultItemType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TaskResultItemType.Descriptor instead.
 func (TaskResultItemType) EnumDescriptor() ([]byte, []int) {
-	return file_common_proto_rawDescGZIP(), []int{4}
+	return file_common_proto_rawDescGZIP(), []int{5}
 }
 
 // Common error message structure
@@ -760,14 +819,21 @@ const file_common_proto_rawDesc = "" +
 	"\x19MEDIA_SORT_BY_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19MEDIA_SORT_BY_CAPTURED_AT\x10\x01\x12\x1a\n" +
 	"\x16MEDIA_SORT_BY_FILENAME\x10\x02\x12\x16\n" +
-	"\x12MEDIA_SORT_BY_TYPE\x10\x03*\xc2\x01\n" +
+	"\x12MEDIA_SORT_BY_TYPE\x10\x03*\xa3\x01\n" +
+	"\vAlbumSortBy\x12\x1d\n" +
+	"\x19ALBUM_SORT_BY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ALBUM_SORT_BY_NAME\x10\x01\x12\x1c\n" +
+	"\x18ALBUM_SORT_BY_CREATED_AT\x10\x02\x12 \n" +
+	"\x1cALBUM_SORT_BY_LATEST_CAPTURE\x10\x03\x12\x1d\n" +
+	"\x19ALBUM_SORT_BY_MEDIA_COUNT\x10\x04*\xe3\x01\n" +
 	"\rSyncJobStatus\x12\x1f\n" +
 	"\x1bSYNC_JOB_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17SYNC_JOB_STATUS_PENDING\x10\x01\x12\x1b\n" +
 	"\x17SYNC_JOB_STATUS_RUNNING\x10\x02\x12\x1d\n" +
 	"\x19SYNC_JOB_STATUS_COMPLETED\x10\x03\x12\x1a\n" +
 	"\x16SYNC_JOB_STATUS_FAILED\x10\x04\x12\x1b\n" +
-	"\x17SYNC_JOB_STATUS_STOPPED\x10\x05*}\n" +
+	"\x17SYNC_JOB_STATUS_STOPPED\x10\x05\x12\x1f\n" +
+	"\x1bSYNC_JOB_STATUS_INTERRUPTED\x10\x06*}\n" +
 	"\x12TaskResultItemType\x12%\n" +
 	"!TASK_RESULT_ITEM_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aTASK_RESULT_ITEM_TYPE_FILE\x10\x01\x12 \n" +
@@ -786,25 +852,26 @@ func file_common_proto_rawDescGZIP() []byte {
 	return file_common_proto_rawDescData
 }
 
-var file_common_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_common_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
 var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_common_proto_goTypes = []any{
 	(MediaType)(0),                   // 0: photos_ng.api.v1.grpc.MediaType
 	(SortOrder)(0),                   // 1: photos_ng.api.v1.grpc.SortOrder
 	(MediaSortBy)(0),                 // 2: photos_ng.api.v1.grpc.MediaSortBy
-	(SyncJobStatus)(0),               // 3: photos_ng.api.v1.grpc.SyncJobStatus
-	(TaskResultItemType)(0),          // 4: photos_ng.api.v1.grpc.TaskResultItemType
-	(*Error)(nil),                    // 5: photos_ng.api.v1.grpc.Error
-	(*ExifHeader)(nil),               // 6: photos_ng.api.v1.grpc.ExifHeader
-	(*PaginationRequest)(nil),        // 7: photos_ng.api.v1.grpc.PaginationRequest
-	(*PaginationResponse)(nil),       // 8: photos_ng.api.v1.grpc.PaginationResponse
-	(*CursorPaginationRequest)(nil),  // 9: photos_ng.api.v1.grpc.CursorPaginationRequest
-	(*CursorPaginationResponse)(nil), // 10: photos_ng.api.v1.grpc.CursorPaginationResponse
-	(*TaskResultStatus)(nil),         // 11: photos_ng.api.v1.grpc.TaskResultStatus
-	nil,                              // 12: photos_ng.api.v1.grpc.Error.DetailsEntry
+	(AlbumSortBy)(0),                 // 3: photos_ng.api.v1.grpc.AlbumSortBy
+	(SyncJobStatus)(0),               // 4: photos_ng.api.v1.grpc.SyncJobStatus
+	(TaskResultItemType)(0),          // 5: photos_ng.api.v1.grpc.TaskResultItemType
+	(*Error)(nil),                    // 6: photos_ng.api.v1.grpc.Error
+	(*ExifHeader)(nil),               // 7: photos_ng.api.v1.grpc.ExifHeader
+	(*PaginationRequest)(nil),        // 8: photos_ng.api.v1.grpc.PaginationRequest
+	(*PaginationResponse)(nil),       // 9: photos_ng.api.v1.grpc.PaginationResponse
+	(*CursorPaginationRequest)(nil),  // 10: photos_ng.api.v1.grpc.CursorPaginationRequest
+	(*CursorPaginationResponse)(nil), // 11: photos_ng.api.v1.grpc.CursorPaginationResponse
+	(*TaskResultStatus)(nil),         // 12: photos_ng.api.v1.grpc.TaskResultStatus
+	nil,                              // 13: photos_ng.api.v1.grpc.Error.DetailsEntry
 }
 var file_common_proto_depIdxs = []int32{
-	12, // 0: photos_ng.api.v1.grpc.Error.details:type_name -> photos_ng.api.v1.grpc.Error.DetailsEntry
+	13, // 0: photos_ng.api.v1.grpc.Error.details:type_name -> photos_ng.api.v1.grpc.Error.DetailsEntry
 	1,  // [1:1] is the sub-list for method output_type
 	1,  // [1:1] is the sub-list for method input_type
 	1,  // [1:1] is the sub-list for extension type_name
@@ -828,7 +895,7 @@ func file_common_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_proto_rawDesc), len(file_common_proto_rawDesc)),
-			NumEnums:      5,
+			NumEnums:      6,
 			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
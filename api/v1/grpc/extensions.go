@@ -23,6 +23,7 @@ func NewAlbum(album entity.Album, syncInProgress bool) *Album {
 		ParentId:       album.ParentId,
 		Thumbnail:      album.Thumbnail,
 		SyncInProgress: &syncInProgress,
+		Hidden:         album.Hidden,
 	}
 
 	// Convert children
@@ -74,21 +75,163 @@ func NewMedia(media entity.Media) *Media {
 		mediaType = MediaType_MEDIA_TYPE_UNSPECIFIED
 	}
 
-	return &Media{
+	grpcMedia := &Media{
 		Id:            media.ID,
 		Filename:      media.Filename,
 		AlbumId:       media.Album.ID,
 		CapturedAt:    capturedAt,
 		Type:          mediaType,
 		Exif:          exifHeaders,
+		Caption:       media.Caption,
+		Camera:        media.Camera,
+		Tags:          media.Tags,
 		ThumbnailData: media.Thumbnail,
+		Favorite:      media.Favorite,
 	}
+
+	if media.Rating != nil {
+		rating := int32(*media.Rating)
+		grpcMedia.Rating = &rating
+	}
+
+	return grpcMedia
+}
+
+// Entity converts a gRPC AlbumSortBy to the entity.AlbumSortBy of the album listing.
+// ALBUM_SORT_BY_UNSPECIFIED sorts by name.
+func (s AlbumSortBy) Entity() entity.AlbumSortBy {
+	switch s {
+	case AlbumSortBy_ALBUM_SORT_BY_CREATED_AT:
+		return entity.AlbumSortByCreatedAt
+	case AlbumSortBy_ALBUM_SORT_BY_LATEST_CAPTURE:
+		return entity.AlbumSortByLatestCapture
+	case AlbumSortBy_ALBUM_SORT_BY_MEDIA_COUNT:
+		return entity.AlbumSortByMediaCount
+	default:
+		return entity.AlbumSortByName
+	}
+}
+
+// NewAlbumTreeNode converts an entity.AlbumTreeNode and its children to a gRPC AlbumTreeNode
+func NewAlbumTreeNode(node entity.AlbumTreeNode) *AlbumTreeNode {
+	_, name := path.Split(node.Album.Path)
+
+	grpcNode := &AlbumTreeNode{
+		Id:                node.Album.ID,
+		Name:              name,
+		Path:              node.Album.Path,
+		Description:       node.Album.Description,
+		MediaCount:        int32(node.MediaCount),
+		SubtreeMediaCount: int32(node.SubtreeMediaCount),
+		CoverId:           node.CoverID,
+		Children:          make([]*AlbumTreeNode, 0, len(node.Children)),
+	}
+
+	if node.EarliestCapturedAt != nil {
+		grpcNode.EarliestCapturedAt = timestamppb.New(*node.EarliestCapturedAt)
+	}
+	if node.LatestCapturedAt != nil {
+		grpcNode.LatestCapturedAt = timestamppb.New(*node.LatestCapturedAt)
+	}
+
+	for _, child := range node.Children {
+		grpcNode.Children = append(grpcNode.Children, NewAlbumTreeNode(child))
+	}
+
+	return grpcNode
+}
+
+// NewGetMemoriesResponse converts the memories to a gRPC GetMemoriesResponse
+func NewGetMemoriesResponse(memories []entity.Memory) *GetMemoriesResponse {
+	grpcMemories := make([]*Memory, 0, len(memories))
+	for _, memory := range memories {
+		media := make([]*Media, 0, len(memory.Media))
+		for _, m := range memory.Media {
+			media = append(media, NewMedia(m))
+		}
+		grpcMemories = append(grpcMemories, &Memory{Year: int32(memory.Year), Media: media})
+	}
+	return &GetMemoriesResponse{Memories: grpcMemories}
+}
+
+// NewSmartAlbum converts an entity.SmartAlbum to a gRPC SmartAlbum for API responses
+func NewSmartAlbum(smartAlbum entity.SmartAlbum) *SmartAlbum {
+	filter := smartAlbum.Filter
+
+	grpcFilter := &SmartAlbumFilter{
+		AlbumId: filter.AlbumID,
+		Camera:  filter.Camera,
+		Tags:    filter.Tags,
+	}
+
+	if filter.StartDate != nil {
+		grpcFilter.StartDate = timestamppb.New(*filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		grpcFilter.EndDate = timestamppb.New(*filter.EndDate)
+	}
+
+	if filter.MediaType != nil {
+		var mediaType MediaType
+		switch strings.ToLower(*filter.MediaType) {
+		case "photo":
+			mediaType = MediaType_MEDIA_TYPE_PHOTO
+		case "video":
+			mediaType = MediaType_MEDIA_TYPE_VIDEO
+		default:
+			mediaType = MediaType_MEDIA_TYPE_UNSPECIFIED
+		}
+		grpcFilter.Type = &mediaType
+	}
+
+	if filter.MinRating != nil {
+		minRating := int32(*filter.MinRating)
+		grpcFilter.MinRating = &minRating
+	}
+
+	if filter.Area != nil {
+		grpcFilter.Area = &BoundingBox{
+			MinLatitude:  filter.Area.MinLatitude,
+			MinLongitude: filter.Area.MinLongitude,
+			MaxLatitude:  filter.Area.MaxLatitude,
+			MaxLongitude: filter.Area.MaxLongitude,
+		}
+	}
+
+	return &SmartAlbum{
+		Id:          smartAlbum.ID,
+		Name:        smartAlbum.Name,
+		Description: smartAlbum.Description,
+		Filter:      grpcFilter,
+		CreatedAt:   timestamppb.New(smartAlbum.CreatedAt),
+	}
+}
+
+// NewSearchResult converts an entity.SearchResult to a gRPC SearchResult for API responses.
+// The sync status of the albums is not reported in search results.
+func NewSearchResult(result entity.SearchResult) *SearchResult {
+	grpcResult := &SearchResult{
+		Rank: result.Rank,
+	}
+
+	switch {
+	case result.Album != nil:
+		album := NewAlbum(*result.Album, false)
+		album.SyncInProgress = nil
+		grpcResult.Kind = SearchResultKind_SEARCH_RESULT_KIND_ALBUM
+		grpcResult.Resource = &SearchResult_Album{Album: album}
+	case result.Media != nil:
+		grpcResult.Kind = SearchResultKind_SEARCH_RESULT_KIND_MEDIA
+		grpcResult.Resource = &SearchResult_Media{Media: NewMedia(*result.Media)}
+	}
+
+	return grpcResult
 }
 
 // Entity converts a gRPC CreateAlbumRequest to an entity.Album for business logic processing
 func (r *CreateAlbumRequest) Entity() entity.Album {
 	album := entity.Album{
-		ID:          entity.GenerateId(r.Name),
+		ID:          entity.NewId(),
 		Path:        r.Name,
 		Description: r.Description,
 		Children:    []entity.Album{},
@@ -111,6 +254,9 @@ func (r *UpdateAlbumRequest) ApplyTo(album *entity.Album) {
 	if r.Thumbnail != nil {
 		album.Thumbnail = r.Thumbnail
 	}
+	if r.Hidden != nil {
+		album.Hidden = *r.Hidden
+	}
 }
 
 // ApplyTo applies a gRPC UpdateMediaRequest to an existing entity.Media
@@ -126,6 +272,104 @@ func (r *UpdateMediaRequest) ApplyTo(media *entity.Media) {
 			media.Exif[exif.Key] = exif.Value
 		}
 	}
+	if len(r.Tags) > 0 {
+		media.Tags = entity.NormalizeTags(r.Tags)
+	}
+	if r.Rating != nil {
+		rating := int(*r.Rating)
+		media.Rating = &rating
+	}
+	if r.Caption != nil {
+		media.Caption = nil
+		if caption := strings.TrimSpace(*r.Caption); caption != "" {
+			media.Caption = &caption
+		}
+	}
+	if r.Favorite != nil {
+		media.Favorite = *r.Favorite
+	}
+}
+
+// bulkOperations maps the gRPC bulk operations to the entity operations
+var bulkOperations = map[BulkOperation]entity.BulkOperation{
+	BulkOperation_BULK_OPERATION_DELETE:     entity.BulkDelete,
+	BulkOperation_BULK_OPERATION_MOVE:       entity.BulkMove,
+	BulkOperation_BULK_OPERATION_TAG:        entity.BulkTag,
+	BulkOperation_BULK_OPERATION_FAVORITE:   entity.BulkFavorite,
+	BulkOperation_BULK_OPERATION_DATE_SHIFT: entity.BulkDateShift,
+	BulkOperation_BULK_OPERATION_PERMISSION: entity.BulkPermission,
+}
+
+// Entity converts a gRPC BulkMediaRequest to an entity.BulkRequest for business logic processing.
+// An unspecified operation is left empty and rejected by the service.
+func (r *BulkMediaRequest) Entity() entity.BulkRequest {
+	req := entity.BulkRequest{
+		Operation:  bulkOperations[r.Operation],
+		MediaIDs:   r.MediaIds,
+		AddTags:    r.AddTags,
+		RemoveTags: r.RemoveTags,
+	}
+
+	if r.AlbumId != nil {
+		req.AlbumID = *r.AlbumId
+	}
+	if r.Favorite != nil {
+		req.Favorite = *r.Favorite
+	}
+	if r.ShiftSeconds != nil {
+		req.Shift = time.Duration(*r.ShiftSeconds) * time.Second
+	}
+	if r.Permission != nil {
+		req.Subject = entity.NewUserSubject(r.Permission.User)
+		req.Grant = r.Permission.Grant
+		switch r.Permission.Role {
+		case BulkPermissionRole_BULK_PERMISSION_ROLE_VIEWER:
+			req.Relationship = entity.ViewerRelationship
+		case BulkPermissionRole_BULK_PERMISSION_ROLE_EDITOR:
+			req.Relationship = entity.EditorRelationship
+		}
+	}
+
+	return req
+}
+
+// NewBulkJob converts an entity.BulkJob to a gRPC BulkJob for API responses
+func NewBulkJob(job entity.BulkJob) *BulkJob {
+	results := make([]*BulkItemResult, 0, len(job.Items))
+	for _, item := range job.Items {
+		result := &BulkItemResult{MediaId: item.MediaID}
+		if item.Err != nil {
+			errMsg := item.Err.Error()
+			result.Error = &errMsg
+		}
+		results = append(results, result)
+	}
+
+	grpcJob := &BulkJob{
+		Id:        job.Id.String(),
+		Status:    ConvertJobStatusToAPI(job.Status),
+		Total:     int32(job.Total),
+		Remaining: int32(job.Remaining),
+		Succeeded: int32(job.Succeeded()),
+		Failed:    int32(job.Failed()),
+		Results:   results,
+		CreatedAt: timestamppb.New(job.CreatedAt),
+	}
+
+	for operation, entityOperation := range bulkOperations {
+		if entityOperation == job.Operation {
+			grpcJob.Operation = operation
+		}
+	}
+
+	if job.StartedAt != nil {
+		grpcJob.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.CompletedAt != nil {
+		grpcJob.FinishedAt = timestamppb.New(*job.CompletedAt)
+	}
+
+	return grpcJob
 }
 
 // ToMediaEntity converts upload request data to an entity.Media for business logic processing
@@ -157,6 +401,8 @@ func ConvertJobStatusToAPI(status entity.JobStatus) SyncJobStatus {
 		return SyncJobStatus_SYNC_JOB_STATUS_FAILED
 	case entity.StatusStopped:
 		return SyncJobStatus_SYNC_JOB_STATUS_STOPPED
+	case entity.StatusInterrupted:
+		return SyncJobStatus_SYNC_JOB_STATUS_INTERRUPTED
 	default:
 		return SyncJobStatus_SYNC_JOB_STATUS_UNSPECIFIED
 	}
@@ -272,8 +518,63 @@ func NewSyncJob(job entity.JobProgress) *SyncJob {
 		grpcJob.Error = &job.Reason
 	}
 
+	if parentID, ok := job.Metadata["parentId"]; ok {
+		grpcJob.ParentId = &parentID
+	}
+
 	// Note: RemainingTime calculation would need to be added to JobProgress struct
 	// For now, we'll skip this field
 
 	return grpcJob
 }
+
+// NewSyncJobEvent converts a job event
+func NewSyncJobEvent(event entity.JobEvent) *SyncJobEvent {
+	grpcEvent := &SyncJobEvent{
+		Type: ConvertJobEventTypeToAPI(event.Type),
+		Job:  NewSyncJob(event.Job),
+		At:   timestamppb.New(event.At),
+	}
+
+	if event.Result != nil {
+		grpcEvent.Task = ConvertJobResultToTaskResult(*event.Result)
+	}
+
+	return grpcEvent
+}
+
+// ConvertJobEventTypeToAPI converts the type of a job event to the gRPC enum
+func ConvertJobEventTypeToAPI(eventType entity.JobEventType) SyncJobEventType {
+	switch eventType {
+	case entity.JobAddedEvent:
+		return SyncJobEventType_SYNC_JOB_EVENT_TYPE_ADDED
+	case entity.JobChangedEvent:
+		return SyncJobEventType_SYNC_JOB_EVENT_TYPE_CHANGED
+	case entity.TaskDoneEvent:
+		return SyncJobEventType_SYNC_JOB_EVENT_TYPE_TASK_DONE
+	case entity.JobRemovedEvent:
+		return SyncJobEventType_SYNC_JOB_EVENT_TYPE_REMOVED
+	default:
+		return SyncJobEventType_SYNC_JOB_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+// NewSyncReport converts a sync dry-run report
+func NewSyncReport(report entity.SyncReport) *SyncReport {
+	moves := make([]*SyncMove, 0, len(report.MediaToMove))
+	for _, move := range report.MediaToMove {
+		moves = append(moves, &SyncMove{From: move.From, To: move.To})
+	}
+
+	return &SyncReport{
+		Path:             report.Path,
+		AlbumsToCreate:   report.AlbumsToCreate,
+		AlbumsToRemove:   report.AlbumsToRemove,
+		MediaToAdd:       report.MediaToAdd,
+		MediaToUpdate:    report.MediaToUpdate,
+		MediaToMove:      moves,
+		MediaToRemove:    report.MediaToRemove,
+		UnreadableItems:  report.UnreadableItems,
+		UnsupportedFiles: report.UnsupportedFiles,
+	}
+}
@@ -32,7 +32,12 @@ type Media struct {
 	Type          MediaType              `protobuf:"varint,4,opt,name=type,proto3,enum=photos_ng.api.v1.grpc.MediaType" json:"type,omitempty"` // Type of media (photo/video)
 	Filename      string                 `protobuf:"bytes,5,opt,name=filename,proto3" json:"filename,omitempty"`                               // Full path of the media file on disk
 	Exif          []*ExifHeader          `protobuf:"bytes,6,rep,name=exif,proto3" json:"exif,omitempty"`                                       // EXIF metadata
+	Camera        *string                `protobuf:"bytes,7,opt,name=camera,proto3,oneof" json:"camera,omitempty"`                             // Camera model
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`                                       // Tags of the media
+	Rating        *int32                 `protobuf:"varint,9,opt,name=rating,proto3,oneof" json:"rating,omitempty"`                            // Rating (0-5)
 	ThumbnailData []byte                 `protobuf:"bytes,10,opt,name=thumbnail_data,json=thumbnailData,proto3" json:"thumbnail_data,omitempty"`
+	Caption       *string                `protobuf:"bytes,11,opt,name=caption,proto3,oneof" json:"caption,omitempty"` // Caption of the media
+	Favorite      bool                   `protobuf:"varint,12,opt,name=favorite,proto3" json:"favorite,omitempty"`    // True if the media is marked as favorite
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,6 +114,27 @@ func (x *Media) GetExif() []*ExifHeader {
 	return nil
 }
 
+func (x *Media) GetCamera() string {
+	if x != nil && x.Camera != nil {
+		return *x.Camera
+	}
+	return ""
+}
+
+func (x *Media) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Media) GetRating() int32 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
 func (x *Media) GetThumbnailData() []byte {
 	if x != nil {
 		return x.ThumbnailData
@@ -116,6 +142,20 @@ func (x *Media) GetThumbnailData() []byte {
 	return nil
 }
 
+func (x *Media) GetCaption() string {
+	if x != nil && x.Caption != nil {
+		return *x.Caption
+	}
+	return ""
+}
+
+func (x *Media) GetFavorite() bool {
+	if x != nil {
+		return x.Favorite
+	}
+	return false
+}
+
 // Request to list media with filtering and cursor-based pagination
 type ListMediaRequest struct {
 	state         protoimpl.MessageState   `protogen:"open.v1"`
@@ -124,6 +164,7 @@ type ListMediaRequest struct {
 	Type          *MediaType               `protobuf:"varint,3,opt,name=type,proto3,enum=photos_ng.api.v1.grpc.MediaType,oneof" json:"type,omitempty"`                            // Filter by media type
 	SortBy        *MediaSortBy             `protobuf:"varint,4,opt,name=sort_by,json=sortBy,proto3,enum=photos_ng.api.v1.grpc.MediaSortBy,oneof" json:"sort_by,omitempty"`        // Sort field (default: captured_at)
 	SortOrder     *SortOrder               `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=photos_ng.api.v1.grpc.SortOrder,oneof" json:"sort_order,omitempty"` // Sort order (default: desc)
+	Query         *string                  `protobuf:"bytes,6,opt,name=query,proto3,oneof" json:"query,omitempty"`                                                                // Filter query, e.g. camera:"X-T4" after:2022-05 type:video tag:beach -tag:work
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -193,6 +234,13 @@ func (x *ListMediaRequest) GetSortOrder() SortOrder {
 	return SortOrder_SORT_ORDER_UNSPECIFIED
 }
 
+func (x *ListMediaRequest) GetQuery() string {
+	if x != nil && x.Query != nil {
+		return *x.Query
+	}
+	return ""
+}
+
 // Response containing list of media
 type ListMediaResponse struct {
 	state         protoimpl.MessageState    `protogen:"open.v1"`
@@ -246,6 +294,173 @@ func (x *ListMediaResponse) GetPagination() *CursorPaginationResponse {
 	return nil
 }
 
+// Request to get the media captured on a day in the previous years
+type GetMemoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3,oneof" json:"date,omitempty"`                             // Day of the memories (default: today)
+	Favorite      *bool                  `protobuf:"varint,2,opt,name=favorite,proto3,oneof" json:"favorite,omitempty"`                    // Keep only the favorite media, or the media rated at least min_rating if set too
+	MinRating     *int32                 `protobuf:"varint,3,opt,name=min_rating,json=minRating,proto3,oneof" json:"min_rating,omitempty"` // Keep only the media rated at least min_rating, or the favorite media if favorite is set too
+	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`                          // Maximum number of media (default: 100)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoriesRequest) Reset() {
+	*x = GetMemoriesRequest{}
+	mi := &file_media_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoriesRequest) ProtoMessage() {}
+
+func (x *GetMemoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoriesRequest.ProtoReflect.Descriptor instead.
+func (*GetMemoriesRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetMemoriesRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetMemoriesRequest) GetFavorite() bool {
+	if x != nil && x.Favorite != nil {
+		return *x.Favorite
+	}
+	return false
+}
+
+func (x *GetMemoriesRequest) GetMinRating() int32 {
+	if x != nil && x.MinRating != nil {
+		return *x.MinRating
+	}
+	return 0
+}
+
+func (x *GetMemoriesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+// Media captured in a year on the day of the memories
+type Memory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`  // Year of the media
+	Media         []*Media               `protobuf:"bytes,2,rep,name=media,proto3" json:"media,omitempty"` // Media, the most recent first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Memory) Reset() {
+	*x = Memory{}
+	mi := &file_media_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memory) ProtoMessage() {}
+
+func (x *Memory) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memory.ProtoReflect.Descriptor instead.
+func (*Memory) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Memory) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Memory) GetMedia() []*Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+// Response containing the memories, the most recent year first
+type GetMemoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Memories      []*Memory              `protobuf:"bytes,1,rep,name=memories,proto3" json:"memories,omitempty"` // Memories grouped by year
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMemoriesResponse) Reset() {
+	*x = GetMemoriesResponse{}
+	mi := &file_media_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMemoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMemoriesResponse) ProtoMessage() {}
+
+func (x *GetMemoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMemoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetMemoriesResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMemoriesResponse) GetMemories() []*Memory {
+	if x != nil {
+		return x.Memories
+	}
+	return nil
+}
+
 // Request to upload new media
 type UploadMediaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -259,7 +474,7 @@ type UploadMediaRequest struct {
 
 func (x *UploadMediaRequest) Reset() {
 	*x = UploadMediaRequest{}
-	mi := &file_media_proto_msgTypes[3]
+	mi := &file_media_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +486,7 @@ func (x *UploadMediaRequest) String() string {
 func (*UploadMediaRequest) ProtoMessage() {}
 
 func (x *UploadMediaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[3]
+	mi := &file_media_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +499,7 @@ func (x *UploadMediaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMediaRequest.ProtoReflect.Descriptor instead.
 func (*UploadMediaRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{3}
+	return file_media_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UploadMediaRequest) GetFilename() string {
@@ -325,7 +540,7 @@ type GetMediaRequest struct {
 
 func (x *GetMediaRequest) Reset() {
 	*x = GetMediaRequest{}
-	mi := &file_media_proto_msgTypes[4]
+	mi := &file_media_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +552,7 @@ func (x *GetMediaRequest) String() string {
 func (*GetMediaRequest) ProtoMessage() {}
 
 func (x *GetMediaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[4]
+	mi := &file_media_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +565,7 @@ func (x *GetMediaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMediaRequest.ProtoReflect.Descriptor instead.
 func (*GetMediaRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{4}
+	return file_media_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetMediaRequest) GetId() string {
@@ -365,13 +580,17 @@ type UpdateMediaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CapturedAt    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=captured_at,json=capturedAt,proto3,oneof" json:"captured_at,omitempty"` // Updated capture date
 	Exif          []*ExifHeader          `protobuf:"bytes,2,rep,name=exif,proto3" json:"exif,omitempty"`                                     // Updated EXIF data
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                                     // Updated tags, replaces the existing tags when not empty
+	Rating        *int32                 `protobuf:"varint,4,opt,name=rating,proto3,oneof" json:"rating,omitempty"`                          // Updated rating (0-5)
+	Caption       *string                `protobuf:"bytes,5,opt,name=caption,proto3,oneof" json:"caption,omitempty"`                         // Updated caption, an empty caption removes it
+	Favorite      *bool                  `protobuf:"varint,6,opt,name=favorite,proto3,oneof" json:"favorite,omitempty"`                      // Mark or unmark the media as favorite
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateMediaRequest) Reset() {
 	*x = UpdateMediaRequest{}
-	mi := &file_media_proto_msgTypes[5]
+	mi := &file_media_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -383,7 +602,7 @@ func (x *UpdateMediaRequest) String() string {
 func (*UpdateMediaRequest) ProtoMessage() {}
 
 func (x *UpdateMediaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[5]
+	mi := &file_media_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,7 +615,7 @@ func (x *UpdateMediaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMediaRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMediaRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{5}
+	return file_media_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateMediaRequest) GetCapturedAt() *timestamppb.Timestamp {
@@ -413,6 +632,34 @@ func (x *UpdateMediaRequest) GetExif() []*ExifHeader {
 	return nil
 }
 
+func (x *UpdateMediaRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateMediaRequest) GetRating() int32 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *UpdateMediaRequest) GetCaption() string {
+	if x != nil && x.Caption != nil {
+		return *x.Caption
+	}
+	return ""
+}
+
+func (x *UpdateMediaRequest) GetFavorite() bool {
+	if x != nil && x.Favorite != nil {
+		return *x.Favorite
+	}
+	return false
+}
+
 // Request to update a specific media item by ID
 type UpdateMediaByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -424,7 +671,7 @@ type UpdateMediaByIdRequest struct {
 
 func (x *UpdateMediaByIdRequest) Reset() {
 	*x = UpdateMediaByIdRequest{}
-	mi := &file_media_proto_msgTypes[6]
+	mi := &file_media_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +683,7 @@ func (x *UpdateMediaByIdRequest) String() string {
 func (*UpdateMediaByIdRequest) ProtoMessage() {}
 
 func (x *UpdateMediaByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[6]
+	mi := &file_media_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +696,7 @@ func (x *UpdateMediaByIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMediaByIdRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMediaByIdRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{6}
+	return file_media_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateMediaByIdRequest) GetId() string {
@@ -476,7 +723,7 @@ type DeleteMediaRequest struct {
 
 func (x *DeleteMediaRequest) Reset() {
 	*x = DeleteMediaRequest{}
-	mi := &file_media_proto_msgTypes[7]
+	mi := &file_media_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -488,7 +735,7 @@ func (x *DeleteMediaRequest) String() string {
 func (*DeleteMediaRequest) ProtoMessage() {}
 
 func (x *DeleteMediaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[7]
+	mi := &file_media_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +748,7 @@ func (x *DeleteMediaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMediaRequest.ProtoReflect.Descriptor instead.
 func (*DeleteMediaRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{7}
+	return file_media_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteMediaRequest) GetId() string {
@@ -521,7 +768,7 @@ type GetMediaThumbnailRequest struct {
 
 func (x *GetMediaThumbnailRequest) Reset() {
 	*x = GetMediaThumbnailRequest{}
-	mi := &file_media_proto_msgTypes[8]
+	mi := &file_media_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -533,7 +780,7 @@ func (x *GetMediaThumbnailRequest) String() string {
 func (*GetMediaThumbnailRequest) ProtoMessage() {}
 
 func (x *GetMediaThumbnailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[8]
+	mi := &file_media_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +793,7 @@ func (x *GetMediaThumbnailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMediaThumbnailRequest.ProtoReflect.Descriptor instead.
 func (*GetMediaThumbnailRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{8}
+	return file_media_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetMediaThumbnailRequest) GetId() string {
@@ -566,7 +813,7 @@ type GetMediaContentRequest struct {
 
 func (x *GetMediaContentRequest) Reset() {
 	*x = GetMediaContentRequest{}
-	mi := &file_media_proto_msgTypes[9]
+	mi := &file_media_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +825,7 @@ func (x *GetMediaContentRequest) String() string {
 func (*GetMediaContentRequest) ProtoMessage() {}
 
 func (x *GetMediaContentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[9]
+	mi := &file_media_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +838,7 @@ func (x *GetMediaContentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMediaContentRequest.ProtoReflect.Descriptor instead.
 func (*GetMediaContentRequest) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{9}
+	return file_media_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetMediaContentRequest) GetId() string {
@@ -613,7 +860,7 @@ type BinaryDataResponse struct {
 
 func (x *BinaryDataResponse) Reset() {
 	*x = BinaryDataResponse{}
-	mi := &file_media_proto_msgTypes[10]
+	mi := &file_media_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -625,7 +872,7 @@ func (x *BinaryDataResponse) String() string {
 func (*BinaryDataResponse) ProtoMessage() {}
 
 func (x *BinaryDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[10]
+	mi := &file_media_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +885,7 @@ func (x *BinaryDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BinaryDataResponse.ProtoReflect.Descriptor instead.
 func (*BinaryDataResponse) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{10}
+	return file_media_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BinaryDataResponse) GetData() []byte {
@@ -677,7 +924,7 @@ type BinaryDataChunk struct {
 
 func (x *BinaryDataChunk) Reset() {
 	*x = BinaryDataChunk{}
-	mi := &file_media_proto_msgTypes[11]
+	mi := &file_media_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -689,7 +936,7 @@ func (x *BinaryDataChunk) String() string {
 func (*BinaryDataChunk) ProtoMessage() {}
 
 func (x *BinaryDataChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_media_proto_msgTypes[11]
+	mi := &file_media_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -702,7 +949,7 @@ func (x *BinaryDataChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BinaryDataChunk.ProtoReflect.Descriptor instead.
 func (*BinaryDataChunk) Descriptor() ([]byte, []int) {
-	return file_media_proto_rawDescGZIP(), []int{11}
+	return file_media_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BinaryDataChunk) GetChunk() []byte {
@@ -751,7 +998,7 @@ var File_media_proto protoreflect.FileDescriptor
 
 const file_media_proto_rawDesc = "" +
 	"\n" +
-	"\vmedia.proto\x12\x15photos_ng.api.v1.grpc\x1a\fcommon.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x02\n" +
+	"\vmedia.proto\x12\x15photos_ng.api.v1.grpc\x1a\fcommon.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x03\n" +
 	"\x05Media\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\balbum_id\x18\x02 \x01(\tR\aalbumId\x12;\n" +
@@ -759,9 +1006,18 @@ const file_media_proto_rawDesc = "" +
 	"capturedAt\x124\n" +
 	"\x04type\x18\x04 \x01(\x0e2 .photos_ng.api.v1.grpc.MediaTypeR\x04type\x12\x1a\n" +
 	"\bfilename\x18\x05 \x01(\tR\bfilename\x125\n" +
-	"\x04exif\x18\x06 \x03(\v2!.photos_ng.api.v1.grpc.ExifHeaderR\x04exif\x12%\n" +
+	"\x04exif\x18\x06 \x03(\v2!.photos_ng.api.v1.grpc.ExifHeaderR\x04exif\x12\x1b\n" +
+	"\x06camera\x18\a \x01(\tH\x00R\x06camera\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1b\n" +
+	"\x06rating\x18\t \x01(\x05H\x01R\x06rating\x88\x01\x01\x12%\n" +
 	"\x0ethumbnail_data\x18\n" +
-	" \x01(\fR\rthumbnailData\"\xf6\x02\n" +
+	" \x01(\fR\rthumbnailData\x12\x1d\n" +
+	"\acaption\x18\v \x01(\tH\x02R\acaption\x88\x01\x01\x12\x1a\n" +
+	"\bfavorite\x18\f \x01(\bR\bfavoriteB\t\n" +
+	"\a_cameraB\t\n" +
+	"\a_ratingB\n" +
+	"\n" +
+	"\b_caption\"\x9b\x03\n" +
 	"\x10ListMediaRequest\x12N\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2..photos_ng.api.v1.grpc.CursorPaginationRequestR\n" +
@@ -770,29 +1026,54 @@ const file_media_proto_rawDesc = "" +
 	"\x04type\x18\x03 \x01(\x0e2 .photos_ng.api.v1.grpc.MediaTypeH\x01R\x04type\x88\x01\x01\x12@\n" +
 	"\asort_by\x18\x04 \x01(\x0e2\".photos_ng.api.v1.grpc.MediaSortByH\x02R\x06sortBy\x88\x01\x01\x12D\n" +
 	"\n" +
-	"sort_order\x18\x05 \x01(\x0e2 .photos_ng.api.v1.grpc.SortOrderH\x03R\tsortOrder\x88\x01\x01B\v\n" +
+	"sort_order\x18\x05 \x01(\x0e2 .photos_ng.api.v1.grpc.SortOrderH\x03R\tsortOrder\x88\x01\x01\x12\x19\n" +
+	"\x05query\x18\x06 \x01(\tH\x04R\x05query\x88\x01\x01B\v\n" +
 	"\t_album_idB\a\n" +
 	"\x05_typeB\n" +
 	"\n" +
 	"\b_sort_byB\r\n" +
-	"\v_sort_order\"\x98\x01\n" +
+	"\v_sort_orderB\b\n" +
+	"\x06_query\"\x98\x01\n" +
 	"\x11ListMediaResponse\x122\n" +
 	"\x05media\x18\x01 \x03(\v2\x1c.photos_ng.api.v1.grpc.MediaR\x05media\x12O\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2/.photos_ng.api.v1.grpc.CursorPaginationResponseR\n" +
-	"pagination\"\x91\x01\n" +
+	"pagination\"\xd8\x01\n" +
+	"\x12GetMemoriesRequest\x123\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04date\x88\x01\x01\x12\x1f\n" +
+	"\bfavorite\x18\x02 \x01(\bH\x01R\bfavorite\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"min_rating\x18\x03 \x01(\x05H\x02R\tminRating\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x03R\x05limit\x88\x01\x01B\a\n" +
+	"\x05_dateB\v\n" +
+	"\t_favoriteB\r\n" +
+	"\v_min_ratingB\b\n" +
+	"\x06_limit\"P\n" +
+	"\x06Memory\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x122\n" +
+	"\x05media\x18\x02 \x03(\v2\x1c.photos_ng.api.v1.grpc.MediaR\x05media\"P\n" +
+	"\x13GetMemoriesResponse\x129\n" +
+	"\bmemories\x18\x01 \x03(\v2\x1d.photos_ng.api.v1.grpc.MemoryR\bmemories\"\x91\x01\n" +
 	"\x12UploadMediaRequest\x12\x1a\n" +
 	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x19\n" +
 	"\balbum_id\x18\x02 \x01(\tR\aalbumId\x12!\n" +
 	"\ffile_content\x18\x03 \x01(\fR\vfileContent\x12!\n" +
 	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\"!\n" +
 	"\x0fGetMediaRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9d\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb2\x02\n" +
 	"\x12UpdateMediaRequest\x12@\n" +
 	"\vcaptured_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
 	"capturedAt\x88\x01\x01\x125\n" +
-	"\x04exif\x18\x02 \x03(\v2!.photos_ng.api.v1.grpc.ExifHeaderR\x04exifB\x0e\n" +
-	"\f_captured_at\"k\n" +
+	"\x04exif\x18\x02 \x03(\v2!.photos_ng.api.v1.grpc.ExifHeaderR\x04exif\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1b\n" +
+	"\x06rating\x18\x04 \x01(\x05H\x01R\x06rating\x88\x01\x01\x12\x1d\n" +
+	"\acaption\x18\x05 \x01(\tH\x02R\acaption\x88\x01\x01\x12\x1f\n" +
+	"\bfavorite\x18\x06 \x01(\bH\x03R\bfavorite\x88\x01\x01B\x0e\n" +
+	"\f_captured_atB\t\n" +
+	"\a_ratingB\n" +
+	"\n" +
+	"\b_captionB\v\n" +
+	"\t_favorite\"k\n" +
 	"\x16UpdateMediaByIdRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12A\n" +
 	"\x06update\x18\x02 \x01(\v2).photos_ng.api.v1.grpc.UpdateMediaRequestR\x06update\"$\n" +
@@ -838,61 +1119,67 @@ func file_media_proto_rawDescGZIP() []byte {
 	return file_media_proto_rawDescData
 }
 
-var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_media_proto_goTypes = []any{
 	(*Media)(nil),                    // 0: photos_ng.api.v1.grpc.Media
 	(*ListMediaRequest)(nil),         // 1: photos_ng.api.v1.grpc.ListMediaRequest
 	(*ListMediaResponse)(nil),        // 2: photos_ng.api.v1.grpc.ListMediaResponse
-	(*UploadMediaRequest)(nil),       // 3: photos_ng.api.v1.grpc.UploadMediaRequest
-	(*GetMediaRequest)(nil),          // 4: photos_ng.api.v1.grpc.GetMediaRequest
-	(*UpdateMediaRequest)(nil),       // 5: photos_ng.api.v1.grpc.UpdateMediaRequest
-	(*UpdateMediaByIdRequest)(nil),   // 6: photos_ng.api.v1.grpc.UpdateMediaByIdRequest
-	(*DeleteMediaRequest)(nil),       // 7: photos_ng.api.v1.grpc.DeleteMediaRequest
-	(*GetMediaThumbnailRequest)(nil), // 8: photos_ng.api.v1.grpc.GetMediaThumbnailRequest
-	(*GetMediaContentRequest)(nil),   // 9: photos_ng.api.v1.grpc.GetMediaContentRequest
-	(*BinaryDataResponse)(nil),       // 10: photos_ng.api.v1.grpc.BinaryDataResponse
-	(*BinaryDataChunk)(nil),          // 11: photos_ng.api.v1.grpc.BinaryDataChunk
-	(*timestamppb.Timestamp)(nil),    // 12: google.protobuf.Timestamp
-	(MediaType)(0),                   // 13: photos_ng.api.v1.grpc.MediaType
-	(*ExifHeader)(nil),               // 14: photos_ng.api.v1.grpc.ExifHeader
-	(*CursorPaginationRequest)(nil),  // 15: photos_ng.api.v1.grpc.CursorPaginationRequest
-	(MediaSortBy)(0),                 // 16: photos_ng.api.v1.grpc.MediaSortBy
-	(SortOrder)(0),                   // 17: photos_ng.api.v1.grpc.SortOrder
-	(*CursorPaginationResponse)(nil), // 18: photos_ng.api.v1.grpc.CursorPaginationResponse
-	(*emptypb.Empty)(nil),            // 19: google.protobuf.Empty
+	(*GetMemoriesRequest)(nil),       // 3: photos_ng.api.v1.grpc.GetMemoriesRequest
+	(*Memory)(nil),                   // 4: photos_ng.api.v1.grpc.Memory
+	(*GetMemoriesResponse)(nil),      // 5: photos_ng.api.v1.grpc.GetMemoriesResponse
+	(*UploadMediaRequest)(nil),       // 6: photos_ng.api.v1.grpc.UploadMediaRequest
+	(*GetMediaRequest)(nil),          // 7: photos_ng.api.v1.grpc.GetMediaRequest
+	(*UpdateMediaRequest)(nil),       // 8: photos_ng.api.v1.grpc.UpdateMediaRequest
+	(*UpdateMediaByIdRequest)(nil),   // 9: photos_ng.api.v1.grpc.UpdateMediaByIdRequest
+	(*DeleteMediaRequest)(nil),       // 10: photos_ng.api.v1.grpc.DeleteMediaRequest
+	(*GetMediaThumbnailRequest)(nil), // 11: photos_ng.api.v1.grpc.GetMediaThumbnailRequest
+	(*GetMediaContentRequest)(nil),   // 12: photos_ng.api.v1.grpc.GetMediaContentRequest
+	(*BinaryDataResponse)(nil),       // 13: photos_ng.api.v1.grpc.BinaryDataResponse
+	(*BinaryDataChunk)(nil),          // 14: photos_ng.api.v1.grpc.BinaryDataChunk
+	(*timestamppb.Timestamp)(nil),    // 15: google.protobuf.Timestamp
+	(MediaType)(0),                   // 16: photos_ng.api.v1.grpc.MediaType
+	(*ExifHeader)(nil),               // 17: photos_ng.api.v1.grpc.ExifHeader
+	(*CursorPaginationRequest)(nil),  // 18: photos_ng.api.v1.grpc.CursorPaginationRequest
+	(MediaSortBy)(0),                 // 19: photos_ng.api.v1.grpc.MediaSortBy
+	(SortOrder)(0),                   // 20: photos_ng.api.v1.grpc.SortOrder
+	(*CursorPaginationResponse)(nil), // 21: photos_ng.api.v1.grpc.CursorPaginationResponse
+	(*emptypb.Empty)(nil),            // 22: google.protobuf.Empty
 }
 var file_media_proto_depIdxs = []int32{
-	12, // 0: photos_ng.api.v1.grpc.Media.captured_at:type_name -> google.protobuf.Timestamp
-	13, // 1: photos_ng.api.v1.grpc.Media.type:type_name -> photos_ng.api.v1.grpc.MediaType
-	14, // 2: photos_ng.api.v1.grpc.Media.exif:type_name -> photos_ng.api.v1.grpc.ExifHeader
-	15, // 3: photos_ng.api.v1.grpc.ListMediaRequest.pagination:type_name -> photos_ng.api.v1.grpc.CursorPaginationRequest
-	13, // 4: photos_ng.api.v1.grpc.ListMediaRequest.type:type_name -> photos_ng.api.v1.grpc.MediaType
-	16, // 5: photos_ng.api.v1.grpc.ListMediaRequest.sort_by:type_name -> photos_ng.api.v1.grpc.MediaSortBy
-	17, // 6: photos_ng.api.v1.grpc.ListMediaRequest.sort_order:type_name -> photos_ng.api.v1.grpc.SortOrder
+	15, // 0: photos_ng.api.v1.grpc.Media.captured_at:type_name -> google.protobuf.Timestamp
+	16, // 1: photos_ng.api.v1.grpc.Media.type:type_name -> photos_ng.api.v1.grpc.MediaType
+	17, // 2: photos_ng.api.v1.grpc.Media.exif:type_name -> photos_ng.api.v1.grpc.ExifHeader
+	18, // 3: photos_ng.api.v1.grpc.ListMediaRequest.pagination:type_name -> photos_ng.api.v1.grpc.CursorPaginationRequest
+	16, // 4: photos_ng.api.v1.grpc.ListMediaRequest.type:type_name -> photos_ng.api.v1.grpc.MediaType
+	19, // 5: photos_ng.api.v1.grpc.ListMediaRequest.sort_by:type_name -> photos_ng.api.v1.grpc.MediaSortBy
+	20, // 6: photos_ng.api.v1.grpc.ListMediaRequest.sort_order:type_name -> photos_ng.api.v1.grpc.SortOrder
 	0,  // 7: photos_ng.api.v1.grpc.ListMediaResponse.media:type_name -> photos_ng.api.v1.grpc.Media
-	18, // 8: photos_ng.api.v1.grpc.ListMediaResponse.pagination:type_name -> photos_ng.api.v1.grpc.CursorPaginationResponse
-	12, // 9: photos_ng.api.v1.grpc.UpdateMediaRequest.captured_at:type_name -> google.protobuf.Timestamp
-	14, // 10: photos_ng.api.v1.grpc.UpdateMediaRequest.exif:type_name -> photos_ng.api.v1.grpc.ExifHeader
-	5,  // 11: photos_ng.api.v1.grpc.UpdateMediaByIdRequest.update:type_name -> photos_ng.api.v1.grpc.UpdateMediaRequest
-	1,  // 12: photos_ng.api.v1.grpc.MediaService.ListMedia:input_type -> photos_ng.api.v1.grpc.ListMediaRequest
-	3,  // 13: photos_ng.api.v1.grpc.MediaService.UploadMedia:input_type -> photos_ng.api.v1.grpc.UploadMediaRequest
-	4,  // 14: photos_ng.api.v1.grpc.MediaService.GetMedia:input_type -> photos_ng.api.v1.grpc.GetMediaRequest
-	6,  // 15: photos_ng.api.v1.grpc.MediaService.UpdateMedia:input_type -> photos_ng.api.v1.grpc.UpdateMediaByIdRequest
-	7,  // 16: photos_ng.api.v1.grpc.MediaService.DeleteMedia:input_type -> photos_ng.api.v1.grpc.DeleteMediaRequest
-	8,  // 17: photos_ng.api.v1.grpc.MediaService.GetMediaThumbnail:input_type -> photos_ng.api.v1.grpc.GetMediaThumbnailRequest
-	9,  // 18: photos_ng.api.v1.grpc.MediaService.GetMediaContent:input_type -> photos_ng.api.v1.grpc.GetMediaContentRequest
-	2,  // 19: photos_ng.api.v1.grpc.MediaService.ListMedia:output_type -> photos_ng.api.v1.grpc.ListMediaResponse
-	0,  // 20: photos_ng.api.v1.grpc.MediaService.UploadMedia:output_type -> photos_ng.api.v1.grpc.Media
-	0,  // 21: photos_ng.api.v1.grpc.MediaService.GetMedia:output_type -> photos_ng.api.v1.grpc.Media
-	0,  // 22: photos_ng.api.v1.grpc.MediaService.UpdateMedia:output_type -> photos_ng.api.v1.grpc.Media
-	19, // 23: photos_ng.api.v1.grpc.MediaService.DeleteMedia:output_type -> google.protobuf.Empty
-	10, // 24: photos_ng.api.v1.grpc.MediaService.GetMediaThumbnail:output_type -> photos_ng.api.v1.grpc.BinaryDataResponse
-	11, // 25: photos_ng.api.v1.grpc.MediaService.GetMediaContent:output_type -> photos_ng.api.v1.grpc.BinaryDataChunk
-	19, // [19:26] is the sub-list for method output_type
-	12, // [12:19] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	21, // 8: photos_ng.api.v1.grpc.ListMediaResponse.pagination:type_name -> photos_ng.api.v1.grpc.CursorPaginationResponse
+	15, // 9: photos_ng.api.v1.grpc.GetMemoriesRequest.date:type_name -> google.protobuf.Timestamp
+	0,  // 10: photos_ng.api.v1.grpc.Memory.media:type_name -> photos_ng.api.v1.grpc.Media
+	4,  // 11: photos_ng.api.v1.grpc.GetMemoriesResponse.memories:type_name -> photos_ng.api.v1.grpc.Memory
+	15, // 12: photos_ng.api.v1.grpc.UpdateMediaRequest.captured_at:type_name -> google.protobuf.Timestamp
+	17, // 13: photos_ng.api.v1.grpc.UpdateMediaRequest.exif:type_name -> photos_ng.api.v1.grpc.ExifHeader
+	8,  // 14: photos_ng.api.v1.grpc.UpdateMediaByIdRequest.update:type_name -> photos_ng.api.v1.grpc.UpdateMediaRequest
+	1,  // 15: photos_ng.api.v1.grpc.MediaService.ListMedia:input_type -> photos_ng.api.v1.grpc.ListMediaRequest
+	6,  // 16: photos_ng.api.v1.grpc.MediaService.UploadMedia:input_type -> photos_ng.api.v1.grpc.UploadMediaRequest
+	7,  // 17: photos_ng.api.v1.grpc.MediaService.GetMedia:input_type -> photos_ng.api.v1.grpc.GetMediaRequest
+	9,  // 18: photos_ng.api.v1.grpc.MediaService.UpdateMedia:input_type -> photos_ng.api.v1.grpc.UpdateMediaByIdRequest
+	10, // 19: photos_ng.api.v1.grpc.MediaService.DeleteMedia:input_type -> photos_ng.api.v1.grpc.DeleteMediaRequest
+	11, // 20: photos_ng.api.v1.grpc.MediaService.GetMediaThumbnail:input_type -> photos_ng.api.v1.grpc.GetMediaThumbnailRequest
+	12, // 21: photos_ng.api.v1.grpc.MediaService.GetMediaContent:input_type -> photos_ng.api.v1.grpc.GetMediaContentRequest
+	2,  // 22: photos_ng.api.v1.grpc.MediaService.ListMedia:output_type -> photos_ng.api.v1.grpc.ListMediaResponse
+	0,  // 23: photos_ng.api.v1.grpc.MediaService.UploadMedia:output_type -> photos_ng.api.v1.grpc.Media
+	0,  // 24: photos_ng.api.v1.grpc.MediaService.GetMedia:output_type -> photos_ng.api.v1.grpc.Media
+	0,  // 25: photos_ng.api.v1.grpc.MediaService.UpdateMedia:output_type -> photos_ng.api.v1.grpc.Media
+	22, // 26: photos_ng.api.v1.grpc.MediaService.DeleteMedia:output_type -> google.protobuf.Empty
+	13, // 27: photos_ng.api.v1.grpc.MediaService.GetMediaThumbnail:output_type -> photos_ng.api.v1.grpc.BinaryDataResponse
+	14, // 28: photos_ng.api.v1.grpc.MediaService.GetMediaContent:output_type -> photos_ng.api.v1.grpc.BinaryDataChunk
+	22, // [22:29] is the sub-list for method output_type
+	15, // [15:22] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_media_proto_init() }
@@ -901,15 +1188,17 @@ func file_media_proto_init() {
 		return
 	}
 	file_common_proto_init()
+	file_media_proto_msgTypes[0].OneofWrappers = []any{}
 	file_media_proto_msgTypes[1].OneofWrappers = []any{}
-	file_media_proto_msgTypes[5].OneofWrappers = []any{}
+	file_media_proto_msgTypes[3].OneofWrappers = []any{}
+	file_media_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_proto_rawDesc), len(file_media_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,7 +26,8 @@ var File_photos_ng_proto protoreflect.FileDescriptor
 const file_photos_ng_proto_rawDesc = "" +
 	"\n" +
 	"\x0fphotos_ng.proto\x12\x15photos_ng.api.v1.grpc\x1a\falbums.proto\x1a\vmedia.proto\x1a\n" +
-	"sync.proto\x1a\vstats.proto\x1a\x1bgoogle/protobuf/empty.proto2\xf6\x10\n" +
+	"sync.proto\x1a\x12smart_albums.proto\x1a\fsearch.proto\x1a\vstats.proto\x1a\n" +
+	"bulk.proto\x1a\x1bgoogle/protobuf/empty.proto2\xab\x16\n" +
 	"\x0fPhotosNGService\x12a\n" +
 	"\n" +
 	"ListAlbums\x12(.photos_ng.api.v1.grpc.ListAlbumsRequest\x1a).photos_ng.api.v1.grpc.ListAlbumsResponse\x12V\n" +
@@ -34,14 +35,20 @@ const file_photos_ng_proto_rawDesc = "" +
 	"\bGetAlbum\x12&.photos_ng.api.v1.grpc.GetAlbumRequest\x1a\x1c.photos_ng.api.v1.grpc.Album\x12Z\n" +
 	"\vUpdateAlbum\x12-.photos_ng.api.v1.grpc.UpdateAlbumByIdRequest\x1a\x1c.photos_ng.api.v1.grpc.Album\x12P\n" +
 	"\vDeleteAlbum\x12).photos_ng.api.v1.grpc.DeleteAlbumRequest\x1a\x16.google.protobuf.Empty\x12^\n" +
-	"\tSyncAlbum\x12'.photos_ng.api.v1.grpc.SyncAlbumRequest\x1a(.photos_ng.api.v1.grpc.SyncAlbumResponse\x12T\n" +
+	"\tSyncAlbum\x12'.photos_ng.api.v1.grpc.SyncAlbumRequest\x1a(.photos_ng.api.v1.grpc.SyncAlbumResponse\x12g\n" +
+	"\fGetAlbumTree\x12*.photos_ng.api.v1.grpc.GetAlbumTreeRequest\x1a+.photos_ng.api.v1.grpc.GetAlbumTreeResponse\x12T\n" +
 	"\tListMedia\x12'.photos_ng.api.v1.grpc.ListMediaRequest\x1a\x1c.photos_ng.api.v1.grpc.Media0\x01\x12V\n" +
 	"\vUploadMedia\x12).photos_ng.api.v1.grpc.UploadMediaRequest\x1a\x1c.photos_ng.api.v1.grpc.Media\x12P\n" +
 	"\bGetMedia\x12&.photos_ng.api.v1.grpc.GetMediaRequest\x1a\x1c.photos_ng.api.v1.grpc.Media\x12Z\n" +
 	"\vUpdateMedia\x12-.photos_ng.api.v1.grpc.UpdateMediaByIdRequest\x1a\x1c.photos_ng.api.v1.grpc.Media\x12P\n" +
 	"\vDeleteMedia\x12).photos_ng.api.v1.grpc.DeleteMediaRequest\x1a\x16.google.protobuf.Empty\x12o\n" +
 	"\x11GetMediaThumbnail\x12/.photos_ng.api.v1.grpc.GetMediaThumbnailRequest\x1a).photos_ng.api.v1.grpc.BinaryDataResponse\x12j\n" +
-	"\x0fGetMediaContent\x12-.photos_ng.api.v1.grpc.GetMediaContentRequest\x1a&.photos_ng.api.v1.grpc.BinaryDataChunk0\x01\x12a\n" +
+	"\x0fGetMediaContent\x12-.photos_ng.api.v1.grpc.GetMediaContentRequest\x1a&.photos_ng.api.v1.grpc.BinaryDataChunk0\x01\x12d\n" +
+	"\vGetMemories\x12).photos_ng.api.v1.grpc.GetMemoriesRequest\x1a*.photos_ng.api.v1.grpc.GetMemoriesResponse\x12\\\n" +
+	"\x11StartBulkMediaJob\x12'.photos_ng.api.v1.grpc.BulkMediaRequest\x1a\x1e.photos_ng.api.v1.grpc.BulkJob\x12`\n" +
+	"\x0fGetBulkMediaJob\x12-.photos_ng.api.v1.grpc.GetBulkMediaJobRequest\x1a\x1e.photos_ng.api.v1.grpc.BulkJob\x12h\n" +
+	"\x13ListSmartAlbumMedia\x121.photos_ng.api.v1.grpc.ListSmartAlbumMediaRequest\x1a\x1c.photos_ng.api.v1.grpc.Media0\x01\x12U\n" +
+	"\x06Search\x12$.photos_ng.api.v1.grpc.SearchRequest\x1a%.photos_ng.api.v1.grpc.SearchResponse\x12a\n" +
 	"\fStartSyncJob\x12'.photos_ng.api.v1.grpc.StartSyncRequest\x1a(.photos_ng.api.v1.grpc.StartSyncResponse\x12g\n" +
 	"\fListSyncJobs\x12*.photos_ng.api.v1.grpc.ListSyncJobsRequest\x1a+.photos_ng.api.v1.grpc.ListSyncJobsResponse\x12V\n" +
 	"\n" +
@@ -50,7 +57,8 @@ const file_photos_ng_proto_rawDesc = "" +
 	"\rActionSyncJob\x12+.photos_ng.api.v1.grpc.ActionSyncJobRequest\x1a,.photos_ng.api.v1.grpc.ActionSyncJobResponse\x12\x82\x01\n" +
 	"\x15ClearFinishedSyncJobs\x123.photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest\x1a4.photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse\x12d\n" +
 	"\vStopSyncJob\x12).photos_ng.api.v1.grpc.StopSyncJobRequest\x1a*.photos_ng.api.v1.grpc.StopSyncJobResponse\x12p\n" +
-	"\x0fStopAllSyncJobs\x12-.photos_ng.api.v1.grpc.StopAllSyncJobsRequest\x1a..photos_ng.api.v1.grpc.StopAllSyncJobsResponse\x12X\n" +
+	"\x0fStopAllSyncJobs\x12-.photos_ng.api.v1.grpc.StopAllSyncJobsRequest\x1a..photos_ng.api.v1.grpc.StopAllSyncJobsResponse\x12c\n" +
+	"\rWatchSyncJobs\x12+.photos_ng.api.v1.grpc.WatchSyncJobsRequest\x1a#.photos_ng.api.v1.grpc.SyncJobEvent0\x01\x12X\n" +
 	"\bGetStats\x12&.photos_ng.api.v1.grpc.GetStatsRequest\x1a$.photos_ng.api.v1.grpc.StatsResponseB\xd0\x01\n" +
 	"\x19com.photos_ng.api.v1.grpcB\rPhotosNgProtoP\x01Z0git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/grpc\xa2\x02\x04PAVG\xaa\x02\x14PhotosNg.Api.V1.Grpc\xca\x02\x14PhotosNg\\Api\\V1\\Grpc\xe2\x02 PhotosNg\\Api\\V1\\Grpc\\GPBMetadata\xea\x02\x17PhotosNg::Api::V1::Grpcb\x06proto3"
 
@@ -61,38 +69,50 @@ var file_photos_ng_proto_goTypes = []any{
 	(*UpdateAlbumByIdRequest)(nil),        // 3: photos_ng.api.v1.grpc.UpdateAlbumByIdRequest
 	(*DeleteAlbumRequest)(nil),            // 4: photos_ng.api.v1.grpc.DeleteAlbumRequest
 	(*SyncAlbumRequest)(nil),              // 5: photos_ng.api.v1.grpc.SyncAlbumRequest
-	(*ListMediaRequest)(nil),              // 6: photos_ng.api.v1.grpc.ListMediaRequest
-	(*UploadMediaRequest)(nil),            // 7: photos_ng.api.v1.grpc.UploadMediaRequest
-	(*GetMediaRequest)(nil),               // 8: photos_ng.api.v1.grpc.GetMediaRequest
-	(*UpdateMediaByIdRequest)(nil),        // 9: photos_ng.api.v1.grpc.UpdateMediaByIdRequest
-	(*DeleteMediaRequest)(nil),            // 10: photos_ng.api.v1.grpc.DeleteMediaRequest
-	(*GetMediaThumbnailRequest)(nil),      // 11: photos_ng.api.v1.grpc.GetMediaThumbnailRequest
-	(*GetMediaContentRequest)(nil),        // 12: photos_ng.api.v1.grpc.GetMediaContentRequest
-	(*StartSyncRequest)(nil),              // 13: photos_ng.api.v1.grpc.StartSyncRequest
-	(*ListSyncJobsRequest)(nil),           // 14: photos_ng.api.v1.grpc.ListSyncJobsRequest
-	(*GetSyncJobRequest)(nil),             // 15: photos_ng.api.v1.grpc.GetSyncJobRequest
-	(*ActionAllSyncJobsRequest)(nil),      // 16: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
-	(*ActionSyncJobRequest)(nil),          // 17: photos_ng.api.v1.grpc.ActionSyncJobRequest
-	(*ClearFinishedSyncJobsRequest)(nil),  // 18: photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
-	(*StopSyncJobRequest)(nil),            // 19: photos_ng.api.v1.grpc.StopSyncJobRequest
-	(*StopAllSyncJobsRequest)(nil),        // 20: photos_ng.api.v1.grpc.StopAllSyncJobsRequest
-	(*GetStatsRequest)(nil),               // 21: photos_ng.api.v1.grpc.GetStatsRequest
-	(*ListAlbumsResponse)(nil),            // 22: photos_ng.api.v1.grpc.ListAlbumsResponse
-	(*Album)(nil),                         // 23: photos_ng.api.v1.grpc.Album
-	(*emptypb.Empty)(nil),                 // 24: google.protobuf.Empty
-	(*SyncAlbumResponse)(nil),             // 25: photos_ng.api.v1.grpc.SyncAlbumResponse
-	(*Media)(nil),                         // 26: photos_ng.api.v1.grpc.Media
-	(*BinaryDataResponse)(nil),            // 27: photos_ng.api.v1.grpc.BinaryDataResponse
-	(*BinaryDataChunk)(nil),               // 28: photos_ng.api.v1.grpc.BinaryDataChunk
-	(*StartSyncResponse)(nil),             // 29: photos_ng.api.v1.grpc.StartSyncResponse
-	(*ListSyncJobsResponse)(nil),          // 30: photos_ng.api.v1.grpc.ListSyncJobsResponse
-	(*SyncJob)(nil),                       // 31: photos_ng.api.v1.grpc.SyncJob
-	(*ActionAllSyncJobsResponse)(nil),     // 32: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
-	(*ActionSyncJobResponse)(nil),         // 33: photos_ng.api.v1.grpc.ActionSyncJobResponse
-	(*ClearFinishedSyncJobsResponse)(nil), // 34: photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
-	(*StopSyncJobResponse)(nil),           // 35: photos_ng.api.v1.grpc.StopSyncJobResponse
-	(*StopAllSyncJobsResponse)(nil),       // 36: photos_ng.api.v1.grpc.StopAllSyncJobsResponse
-	(*StatsResponse)(nil),                 // 37: photos_ng.api.v1.grpc.StatsResponse
+	(*GetAlbumTreeRequest)(nil),           // 6: photos_ng.api.v1.grpc.GetAlbumTreeRequest
+	(*ListMediaRequest)(nil),              // 7: photos_ng.api.v1.grpc.ListMediaRequest
+	(*UploadMediaRequest)(nil),            // 8: photos_ng.api.v1.grpc.UploadMediaRequest
+	(*GetMediaRequest)(nil),               // 9: photos_ng.api.v1.grpc.GetMediaRequest
+	(*UpdateMediaByIdRequest)(nil),        // 10: photos_ng.api.v1.grpc.UpdateMediaByIdRequest
+	(*DeleteMediaRequest)(nil),            // 11: photos_ng.api.v1.grpc.DeleteMediaRequest
+	(*GetMediaThumbnailRequest)(nil),      // 12: photos_ng.api.v1.grpc.GetMediaThumbnailRequest
+	(*GetMediaContentRequest)(nil),        // 13: photos_ng.api.v1.grpc.GetMediaContentRequest
+	(*GetMemoriesRequest)(nil),            // 14: photos_ng.api.v1.grpc.GetMemoriesRequest
+	(*BulkMediaRequest)(nil),              // 15: photos_ng.api.v1.grpc.BulkMediaRequest
+	(*GetBulkMediaJobRequest)(nil),        // 16: photos_ng.api.v1.grpc.GetBulkMediaJobRequest
+	(*ListSmartAlbumMediaRequest)(nil),    // 17: photos_ng.api.v1.grpc.ListSmartAlbumMediaRequest
+	(*SearchRequest)(nil),                 // 18: photos_ng.api.v1.grpc.SearchRequest
+	(*StartSyncRequest)(nil),              // 19: photos_ng.api.v1.grpc.StartSyncRequest
+	(*ListSyncJobsRequest)(nil),           // 20: photos_ng.api.v1.grpc.ListSyncJobsRequest
+	(*GetSyncJobRequest)(nil),             // 21: photos_ng.api.v1.grpc.GetSyncJobRequest
+	(*ActionAllSyncJobsRequest)(nil),      // 22: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
+	(*ActionSyncJobRequest)(nil),          // 23: photos_ng.api.v1.grpc.ActionSyncJobRequest
+	(*ClearFinishedSyncJobsRequest)(nil),  // 24: photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
+	(*StopSyncJobRequest)(nil),            // 25: photos_ng.api.v1.grpc.StopSyncJobRequest
+	(*StopAllSyncJobsRequest)(nil),        // 26: photos_ng.api.v1.grpc.StopAllSyncJobsRequest
+	(*WatchSyncJobsRequest)(nil),          // 27: photos_ng.api.v1.grpc.WatchSyncJobsRequest
+	(*GetStatsRequest)(nil),               // 28: photos_ng.api.v1.grpc.GetStatsRequest
+	(*ListAlbumsResponse)(nil),            // 29: photos_ng.api.v1.grpc.ListAlbumsResponse
+	(*Album)(nil),                         // 30: photos_ng.api.v1.grpc.Album
+	(*emptypb.Empty)(nil),                 // 31: google.protobuf.Empty
+	(*SyncAlbumResponse)(nil),             // 32: photos_ng.api.v1.grpc.SyncAlbumResponse
+	(*GetAlbumTreeResponse)(nil),          // 33: photos_ng.api.v1.grpc.GetAlbumTreeResponse
+	(*Media)(nil),                         // 34: photos_ng.api.v1.grpc.Media
+	(*BinaryDataResponse)(nil),            // 35: photos_ng.api.v1.grpc.BinaryDataResponse
+	(*BinaryDataChunk)(nil),               // 36: photos_ng.api.v1.grpc.BinaryDataChunk
+	(*GetMemoriesResponse)(nil),           // 37: photos_ng.api.v1.grpc.GetMemoriesResponse
+	(*BulkJob)(nil),                       // 38: photos_ng.api.v1.grpc.BulkJob
+	(*SearchResponse)(nil),                // 39: photos_ng.api.v1.grpc.SearchResponse
+	(*StartSyncResponse)(nil),             // 40: photos_ng.api.v1.grpc.StartSyncResponse
+	(*ListSyncJobsResponse)(nil),          // 41: photos_ng.api.v1.grpc.ListSyncJobsResponse
+	(*SyncJob)(nil),                       // 42: photos_ng.api.v1.grpc.SyncJob
+	(*ActionAllSyncJobsResponse)(nil),     // 43: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
+	(*ActionSyncJobResponse)(nil),         // 44: photos_ng.api.v1.grpc.ActionSyncJobResponse
+	(*ClearFinishedSyncJobsResponse)(nil), // 45: photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
+	(*StopSyncJobResponse)(nil),           // 46: photos_ng.api.v1.grpc.StopSyncJobResponse
+	(*StopAllSyncJobsResponse)(nil),       // 47: photos_ng.api.v1.grpc.StopAllSyncJobsResponse
+	(*SyncJobEvent)(nil),                  // 48: photos_ng.api.v1.grpc.SyncJobEvent
+	(*StatsResponse)(nil),                 // 49: photos_ng.api.v1.grpc.StatsResponse
 }
 var file_photos_ng_proto_depIdxs = []int32{
 	0,  // 0: photos_ng.api.v1.grpc.PhotosNGService.ListAlbums:input_type -> photos_ng.api.v1.grpc.ListAlbumsRequest
@@ -101,46 +121,60 @@ var file_photos_ng_proto_depIdxs = []int32{
 	3,  // 3: photos_ng.api.v1.grpc.PhotosNGService.UpdateAlbum:input_type -> photos_ng.api.v1.grpc.UpdateAlbumByIdRequest
 	4,  // 4: photos_ng.api.v1.grpc.PhotosNGService.DeleteAlbum:input_type -> photos_ng.api.v1.grpc.DeleteAlbumRequest
 	5,  // 5: photos_ng.api.v1.grpc.PhotosNGService.SyncAlbum:input_type -> photos_ng.api.v1.grpc.SyncAlbumRequest
-	6,  // 6: photos_ng.api.v1.grpc.PhotosNGService.ListMedia:input_type -> photos_ng.api.v1.grpc.ListMediaRequest
-	7,  // 7: photos_ng.api.v1.grpc.PhotosNGService.UploadMedia:input_type -> photos_ng.api.v1.grpc.UploadMediaRequest
-	8,  // 8: photos_ng.api.v1.grpc.PhotosNGService.GetMedia:input_type -> photos_ng.api.v1.grpc.GetMediaRequest
-	9,  // 9: photos_ng.api.v1.grpc.PhotosNGService.UpdateMedia:input_type -> photos_ng.api.v1.grpc.UpdateMediaByIdRequest
-	10, // 10: photos_ng.api.v1.grpc.PhotosNGService.DeleteMedia:input_type -> photos_ng.api.v1.grpc.DeleteMediaRequest
-	11, // 11: photos_ng.api.v1.grpc.PhotosNGService.GetMediaThumbnail:input_type -> photos_ng.api.v1.grpc.GetMediaThumbnailRequest
-	12, // 12: photos_ng.api.v1.grpc.PhotosNGService.GetMediaContent:input_type -> photos_ng.api.v1.grpc.GetMediaContentRequest
-	13, // 13: photos_ng.api.v1.grpc.PhotosNGService.StartSyncJob:input_type -> photos_ng.api.v1.grpc.StartSyncRequest
-	14, // 14: photos_ng.api.v1.grpc.PhotosNGService.ListSyncJobs:input_type -> photos_ng.api.v1.grpc.ListSyncJobsRequest
-	15, // 15: photos_ng.api.v1.grpc.PhotosNGService.GetSyncJob:input_type -> photos_ng.api.v1.grpc.GetSyncJobRequest
-	16, // 16: photos_ng.api.v1.grpc.PhotosNGService.ActionAllSyncJobs:input_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
-	17, // 17: photos_ng.api.v1.grpc.PhotosNGService.ActionSyncJob:input_type -> photos_ng.api.v1.grpc.ActionSyncJobRequest
-	18, // 18: photos_ng.api.v1.grpc.PhotosNGService.ClearFinishedSyncJobs:input_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
-	19, // 19: photos_ng.api.v1.grpc.PhotosNGService.StopSyncJob:input_type -> photos_ng.api.v1.grpc.StopSyncJobRequest
-	20, // 20: photos_ng.api.v1.grpc.PhotosNGService.StopAllSyncJobs:input_type -> photos_ng.api.v1.grpc.StopAllSyncJobsRequest
-	21, // 21: photos_ng.api.v1.grpc.PhotosNGService.GetStats:input_type -> photos_ng.api.v1.grpc.GetStatsRequest
-	22, // 22: photos_ng.api.v1.grpc.PhotosNGService.ListAlbums:output_type -> photos_ng.api.v1.grpc.ListAlbumsResponse
-	23, // 23: photos_ng.api.v1.grpc.PhotosNGService.CreateAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	23, // 24: photos_ng.api.v1.grpc.PhotosNGService.GetAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	23, // 25: photos_ng.api.v1.grpc.PhotosNGService.UpdateAlbum:output_type -> photos_ng.api.v1.grpc.Album
-	24, // 26: photos_ng.api.v1.grpc.PhotosNGService.DeleteAlbum:output_type -> google.protobuf.Empty
-	25, // 27: photos_ng.api.v1.grpc.PhotosNGService.SyncAlbum:output_type -> photos_ng.api.v1.grpc.SyncAlbumResponse
-	26, // 28: photos_ng.api.v1.grpc.PhotosNGService.ListMedia:output_type -> photos_ng.api.v1.grpc.Media
-	26, // 29: photos_ng.api.v1.grpc.PhotosNGService.UploadMedia:output_type -> photos_ng.api.v1.grpc.Media
-	26, // 30: photos_ng.api.v1.grpc.PhotosNGService.GetMedia:output_type -> photos_ng.api.v1.grpc.Media
-	26, // 31: photos_ng.api.v1.grpc.PhotosNGService.UpdateMedia:output_type -> photos_ng.api.v1.grpc.Media
-	24, // 32: photos_ng.api.v1.grpc.PhotosNGService.DeleteMedia:output_type -> google.protobuf.Empty
-	27, // 33: photos_ng.api.v1.grpc.PhotosNGService.GetMediaThumbnail:output_type -> photos_ng.api.v1.grpc.BinaryDataResponse
-	28, // 34: photos_ng.api.v1.grpc.PhotosNGService.GetMediaContent:output_type -> photos_ng.api.v1.grpc.BinaryDataChunk
-	29, // 35: photos_ng.api.v1.grpc.PhotosNGService.StartSyncJob:output_type -> photos_ng.api.v1.grpc.StartSyncResponse
-	30, // 36: photos_ng.api.v1.grpc.PhotosNGService.ListSyncJobs:output_type -> photos_ng.api.v1.grpc.ListSyncJobsResponse
-	31, // 37: photos_ng.api.v1.grpc.PhotosNGService.GetSyncJob:output_type -> photos_ng.api.v1.grpc.SyncJob
-	32, // 38: photos_ng.api.v1.grpc.PhotosNGService.ActionAllSyncJobs:output_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
-	33, // 39: photos_ng.api.v1.grpc.PhotosNGService.ActionSyncJob:output_type -> photos_ng.api.v1.grpc.ActionSyncJobResponse
-	34, // 40: photos_ng.api.v1.grpc.PhotosNGService.ClearFinishedSyncJobs:output_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
-	35, // 41: photos_ng.api.v1.grpc.PhotosNGService.StopSyncJob:output_type -> photos_ng.api.v1.grpc.StopSyncJobResponse
-	36, // 42: photos_ng.api.v1.grpc.PhotosNGService.StopAllSyncJobs:output_type -> photos_ng.api.v1.grpc.StopAllSyncJobsResponse
-	37, // 43: photos_ng.api.v1.grpc.PhotosNGService.GetStats:output_type -> photos_ng.api.v1.grpc.StatsResponse
-	22, // [22:44] is the sub-list for method output_type
-	0,  // [0:22] is the sub-list for method input_type
+	6,  // 6: photos_ng.api.v1.grpc.PhotosNGService.GetAlbumTree:input_type -> photos_ng.api.v1.grpc.GetAlbumTreeRequest
+	7,  // 7: photos_ng.api.v1.grpc.PhotosNGService.ListMedia:input_type -> photos_ng.api.v1.grpc.ListMediaRequest
+	8,  // 8: photos_ng.api.v1.grpc.PhotosNGService.UploadMedia:input_type -> photos_ng.api.v1.grpc.UploadMediaRequest
+	9,  // 9: photos_ng.api.v1.grpc.PhotosNGService.GetMedia:input_type -> photos_ng.api.v1.grpc.GetMediaRequest
+	10, // 10: photos_ng.api.v1.grpc.PhotosNGService.UpdateMedia:input_type -> photos_ng.api.v1.grpc.UpdateMediaByIdRequest
+	11, // 11: photos_ng.api.v1.grpc.PhotosNGService.DeleteMedia:input_type -> photos_ng.api.v1.grpc.DeleteMediaRequest
+	12, // 12: photos_ng.api.v1.grpc.PhotosNGService.GetMediaThumbnail:input_type -> photos_ng.api.v1.grpc.GetMediaThumbnailRequest
+	13, // 13: photos_ng.api.v1.grpc.PhotosNGService.GetMediaContent:input_type -> photos_ng.api.v1.grpc.GetMediaContentRequest
+	14, // 14: photos_ng.api.v1.grpc.PhotosNGService.GetMemories:input_type -> photos_ng.api.v1.grpc.GetMemoriesRequest
+	15, // 15: photos_ng.api.v1.grpc.PhotosNGService.StartBulkMediaJob:input_type -> photos_ng.api.v1.grpc.BulkMediaRequest
+	16, // 16: photos_ng.api.v1.grpc.PhotosNGService.GetBulkMediaJob:input_type -> photos_ng.api.v1.grpc.GetBulkMediaJobRequest
+	17, // 17: photos_ng.api.v1.grpc.PhotosNGService.ListSmartAlbumMedia:input_type -> photos_ng.api.v1.grpc.ListSmartAlbumMediaRequest
+	18, // 18: photos_ng.api.v1.grpc.PhotosNGService.Search:input_type -> photos_ng.api.v1.grpc.SearchRequest
+	19, // 19: photos_ng.api.v1.grpc.PhotosNGService.StartSyncJob:input_type -> photos_ng.api.v1.grpc.StartSyncRequest
+	20, // 20: photos_ng.api.v1.grpc.PhotosNGService.ListSyncJobs:input_type -> photos_ng.api.v1.grpc.ListSyncJobsRequest
+	21, // 21: photos_ng.api.v1.grpc.PhotosNGService.GetSyncJob:input_type -> photos_ng.api.v1.grpc.GetSyncJobRequest
+	22, // 22: photos_ng.api.v1.grpc.PhotosNGService.ActionAllSyncJobs:input_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
+	23, // 23: photos_ng.api.v1.grpc.PhotosNGService.ActionSyncJob:input_type -> photos_ng.api.v1.grpc.ActionSyncJobRequest
+	24, // 24: photos_ng.api.v1.grpc.PhotosNGService.ClearFinishedSyncJobs:input_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
+	25, // 25: photos_ng.api.v1.grpc.PhotosNGService.StopSyncJob:input_type -> photos_ng.api.v1.grpc.StopSyncJobRequest
+	26, // 26: photos_ng.api.v1.grpc.PhotosNGService.StopAllSyncJobs:input_type -> photos_ng.api.v1.grpc.StopAllSyncJobsRequest
+	27, // 27: photos_ng.api.v1.grpc.PhotosNGService.WatchSyncJobs:input_type -> photos_ng.api.v1.grpc.WatchSyncJobsRequest
+	28, // 28: photos_ng.api.v1.grpc.PhotosNGService.GetStats:input_type -> photos_ng.api.v1.grpc.GetStatsRequest
+	29, // 29: photos_ng.api.v1.grpc.PhotosNGService.ListAlbums:output_type -> photos_ng.api.v1.grpc.ListAlbumsResponse
+	30, // 30: photos_ng.api.v1.grpc.PhotosNGService.CreateAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	30, // 31: photos_ng.api.v1.grpc.PhotosNGService.GetAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	30, // 32: photos_ng.api.v1.grpc.PhotosNGService.UpdateAlbum:output_type -> photos_ng.api.v1.grpc.Album
+	31, // 33: photos_ng.api.v1.grpc.PhotosNGService.DeleteAlbum:output_type -> google.protobuf.Empty
+	32, // 34: photos_ng.api.v1.grpc.PhotosNGService.SyncAlbum:output_type -> photos_ng.api.v1.grpc.SyncAlbumResponse
+	33, // 35: photos_ng.api.v1.grpc.PhotosNGService.GetAlbumTree:output_type -> photos_ng.api.v1.grpc.GetAlbumTreeResponse
+	34, // 36: photos_ng.api.v1.grpc.PhotosNGService.ListMedia:output_type -> photos_ng.api.v1.grpc.Media
+	34, // 37: photos_ng.api.v1.grpc.PhotosNGService.UploadMedia:output_type -> photos_ng.api.v1.grpc.Media
+	34, // 38: photos_ng.api.v1.grpc.PhotosNGService.GetMedia:output_type -> photos_ng.api.v1.grpc.Media
+	34, // 39: photos_ng.api.v1.grpc.PhotosNGService.UpdateMedia:output_type -> photos_ng.api.v1.grpc.Media
+	31, // 40: photos_ng.api.v1.grpc.PhotosNGService.DeleteMedia:output_type -> google.protobuf.Empty
+	35, // 41: photos_ng.api.v1.grpc.PhotosNGService.GetMediaThumbnail:output_type -> photos_ng.api.v1.grpc.BinaryDataResponse
+	36, // 42: photos_ng.api.v1.grpc.PhotosNGService.GetMediaContent:output_type -> photos_ng.api.v1.grpc.BinaryDataChunk
+	37, // 43: photos_ng.api.v1.grpc.PhotosNGService.GetMemories:output_type -> photos_ng.api.v1.grpc.GetMemoriesResponse
+	38, // 44: photos_ng.api.v1.grpc.PhotosNGService.StartBulkMediaJob:output_type -> photos_ng.api.v1.grpc.BulkJob
+	38, // 45: photos_ng.api.v1.grpc.PhotosNGService.GetBulkMediaJob:output_type -> photos_ng.api.v1.grpc.BulkJob
+	34, // 46: photos_ng.api.v1.grpc.PhotosNGService.ListSmartAlbumMedia:output_type -> photos_ng.api.v1.grpc.Media
+	39, // 47: photos_ng.api.v1.grpc.PhotosNGService.Search:output_type -> photos_ng.api.v1.grpc.SearchResponse
+	40, // 48: photos_ng.api.v1.grpc.PhotosNGService.StartSyncJob:output_type -> photos_ng.api.v1.grpc.StartSyncResponse
+	41, // 49: photos_ng.api.v1.grpc.PhotosNGService.ListSyncJobs:output_type -> photos_ng.api.v1.grpc.ListSyncJobsResponse
+	42, // 50: photos_ng.api.v1.grpc.PhotosNGService.GetSyncJob:output_type -> photos_ng.api.v1.grpc.SyncJob
+	43, // 51: photos_ng.api.v1.grpc.PhotosNGService.ActionAllSyncJobs:output_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
+	44, // 52: photos_ng.api.v1.grpc.PhotosNGService.ActionSyncJob:output_type -> photos_ng.api.v1.grpc.ActionSyncJobResponse
+	45, // 53: photos_ng.api.v1.grpc.PhotosNGService.ClearFinishedSyncJobs:output_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
+	46, // 54: photos_ng.api.v1.grpc.PhotosNGService.StopSyncJob:output_type -> photos_ng.api.v1.grpc.StopSyncJobResponse
+	47, // 55: photos_ng.api.v1.grpc.PhotosNGService.StopAllSyncJobs:output_type -> photos_ng.api.v1.grpc.StopAllSyncJobsResponse
+	48, // 56: photos_ng.api.v1.grpc.PhotosNGService.WatchSyncJobs:output_type -> photos_ng.api.v1.grpc.SyncJobEvent
+	49, // 57: photos_ng.api.v1.grpc.PhotosNGService.GetStats:output_type -> photos_ng.api.v1.grpc.StatsResponse
+	29, // [29:58] is the sub-list for method output_type
+	0,  // [0:29] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -154,7 +188,10 @@ func file_photos_ng_proto_init() {
 	file_albums_proto_init()
 	file_media_proto_init()
 	file_sync_proto_init()
+	file_smart_albums_proto_init()
+	file_search_proto_init()
 	file_stats_proto_init()
+	file_bulk_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
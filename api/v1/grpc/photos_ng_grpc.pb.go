@@ -26,6 +26,7 @@ const (
 	PhotosNGService_UpdateAlbum_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/UpdateAlbum"
 	PhotosNGService_DeleteAlbum_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/DeleteAlbum"
 	PhotosNGService_SyncAlbum_FullMethodName             = "/photos_ng.api.v1.grpc.PhotosNGService/SyncAlbum"
+	PhotosNGService_GetAlbumTree_FullMethodName          = "/photos_ng.api.v1.grpc.PhotosNGService/GetAlbumTree"
 	PhotosNGService_ListMedia_FullMethodName             = "/photos_ng.api.v1.grpc.PhotosNGService/ListMedia"
 	PhotosNGService_UploadMedia_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/UploadMedia"
 	PhotosNGService_GetMedia_FullMethodName              = "/photos_ng.api.v1.grpc.PhotosNGService/GetMedia"
@@ -33,6 +34,11 @@ const (
 	PhotosNGService_DeleteMedia_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/DeleteMedia"
 	PhotosNGService_GetMediaThumbnail_FullMethodName     = "/photos_ng.api.v1.grpc.PhotosNGService/GetMediaThumbnail"
 	PhotosNGService_GetMediaContent_FullMethodName       = "/photos_ng.api.v1.grpc.PhotosNGService/GetMediaContent"
+	PhotosNGService_GetMemories_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/GetMemories"
+	PhotosNGService_StartBulkMediaJob_FullMethodName     = "/photos_ng.api.v1.grpc.PhotosNGService/StartBulkMediaJob"
+	PhotosNGService_GetBulkMediaJob_FullMethodName       = "/photos_ng.api.v1.grpc.PhotosNGService/GetBulkMediaJob"
+	PhotosNGService_ListSmartAlbumMedia_FullMethodName   = "/photos_ng.api.v1.grpc.PhotosNGService/ListSmartAlbumMedia"
+	PhotosNGService_Search_FullMethodName                = "/photos_ng.api.v1.grpc.PhotosNGService/Search"
 	PhotosNGService_StartSyncJob_FullMethodName          = "/photos_ng.api.v1.grpc.PhotosNGService/StartSyncJob"
 	PhotosNGService_ListSyncJobs_FullMethodName          = "/photos_ng.api.v1.grpc.PhotosNGService/ListSyncJobs"
 	PhotosNGService_GetSyncJob_FullMethodName            = "/photos_ng.api.v1.grpc.PhotosNGService/GetSyncJob"
@@ -41,6 +47,7 @@ const (
 	PhotosNGService_ClearFinishedSyncJobs_FullMethodName = "/photos_ng.api.v1.grpc.PhotosNGService/ClearFinishedSyncJobs"
 	PhotosNGService_StopSyncJob_FullMethodName           = "/photos_ng.api.v1.grpc.PhotosNGService/StopSyncJob"
 	PhotosNGService_StopAllSyncJobs_FullMethodName       = "/photos_ng.api.v1.grpc.PhotosNGService/StopAllSyncJobs"
+	PhotosNGService_WatchSyncJobs_FullMethodName         = "/photos_ng.api.v1.grpc.PhotosNGService/WatchSyncJobs"
 	PhotosNGService_GetStats_FullMethodName              = "/photos_ng.api.v1.grpc.PhotosNGService/GetStats"
 )
 
@@ -57,6 +64,7 @@ type PhotosNGServiceClient interface {
 	UpdateAlbum(ctx context.Context, in *UpdateAlbumByIdRequest, opts ...grpc.CallOption) (*Album, error)
 	DeleteAlbum(ctx context.Context, in *DeleteAlbumRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SyncAlbum(ctx context.Context, in *SyncAlbumRequest, opts ...grpc.CallOption) (*SyncAlbumResponse, error)
+	GetAlbumTree(ctx context.Context, in *GetAlbumTreeRequest, opts ...grpc.CallOption) (*GetAlbumTreeResponse, error)
 	// Media operations
 	ListMedia(ctx context.Context, in *ListMediaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Media], error)
 	UploadMedia(ctx context.Context, in *UploadMediaRequest, opts ...grpc.CallOption) (*Media, error)
@@ -65,6 +73,14 @@ type PhotosNGServiceClient interface {
 	DeleteMedia(ctx context.Context, in *DeleteMediaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetMediaThumbnail(ctx context.Context, in *GetMediaThumbnailRequest, opts ...grpc.CallOption) (*BinaryDataResponse, error)
 	GetMediaContent(ctx context.Context, in *GetMediaContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BinaryDataChunk], error)
+	GetMemories(ctx context.Context, in *GetMemoriesRequest, opts ...grpc.CallOption) (*GetMemoriesResponse, error)
+	// Bulk operations
+	StartBulkMediaJob(ctx context.Context, in *BulkMediaRequest, opts ...grpc.CallOption) (*BulkJob, error)
+	GetBulkMediaJob(ctx context.Context, in *GetBulkMediaJobRequest, opts ...grpc.CallOption) (*BulkJob, error)
+	// Smart album operations
+	ListSmartAlbumMedia(ctx context.Context, in *ListSmartAlbumMediaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Media], error)
+	// Search operations
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	// Sync operations
 	StartSyncJob(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (*StartSyncResponse, error)
 	ListSyncJobs(ctx context.Context, in *ListSyncJobsRequest, opts ...grpc.CallOption) (*ListSyncJobsResponse, error)
@@ -74,6 +90,7 @@ type PhotosNGServiceClient interface {
 	ClearFinishedSyncJobs(ctx context.Context, in *ClearFinishedSyncJobsRequest, opts ...grpc.CallOption) (*ClearFinishedSyncJobsResponse, error)
 	StopSyncJob(ctx context.Context, in *StopSyncJobRequest, opts ...grpc.CallOption) (*StopSyncJobResponse, error)
 	StopAllSyncJobs(ctx context.Context, in *StopAllSyncJobsRequest, opts ...grpc.CallOption) (*StopAllSyncJobsResponse, error)
+	WatchSyncJobs(ctx context.Context, in *WatchSyncJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncJobEvent], error)
 	// Stats operations
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 }
@@ -146,6 +163,16 @@ func (c *photosNGServiceClient) SyncAlbum(ctx context.Context, in *SyncAlbumRequ
 	return out, nil
 }
 
+func (c *photosNGServiceClient) GetAlbumTree(ctx context.Context, in *GetAlbumTreeRequest, opts ...grpc.CallOption) (*GetAlbumTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlbumTreeResponse)
+	err := c.cc.Invoke(ctx, PhotosNGService_GetAlbumTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *photosNGServiceClient) ListMedia(ctx context.Context, in *ListMediaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Media], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &PhotosNGService_ServiceDesc.Streams[0], PhotosNGService_ListMedia_FullMethodName, cOpts...)
@@ -234,6 +261,65 @@ func (c *photosNGServiceClient) GetMediaContent(ctx context.Context, in *GetMedi
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type PhotosNGService_GetMediaContentClient = grpc.ServerStreamingClient[BinaryDataChunk]
 
+func (c *photosNGServiceClient) GetMemories(ctx context.Context, in *GetMemoriesRequest, opts ...grpc.CallOption) (*GetMemoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMemoriesResponse)
+	err := c.cc.Invoke(ctx, PhotosNGService_GetMemories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *photosNGServiceClient) StartBulkMediaJob(ctx context.Context, in *BulkMediaRequest, opts ...grpc.CallOption) (*BulkJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkJob)
+	err := c.cc.Invoke(ctx, PhotosNGService_StartBulkMediaJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *photosNGServiceClient) GetBulkMediaJob(ctx context.Context, in *GetBulkMediaJobRequest, opts ...grpc.CallOption) (*BulkJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkJob)
+	err := c.cc.Invoke(ctx, PhotosNGService_GetBulkMediaJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *photosNGServiceClient) ListSmartAlbumMedia(ctx context.Context, in *ListSmartAlbumMediaRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Media], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PhotosNGService_ServiceDesc.Streams[2], PhotosNGService_ListSmartAlbumMedia_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSmartAlbumMediaRequest, Media]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PhotosNGService_ListSmartAlbumMediaClient = grpc.ServerStreamingClient[Media]
+
+func (c *photosNGServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, PhotosNGService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *photosNGServiceClient) StartSyncJob(ctx context.Context, in *StartSyncRequest, opts ...grpc.CallOption) (*StartSyncResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartSyncResponse)
@@ -314,6 +400,25 @@ func (c *photosNGServiceClient) StopAllSyncJobs(ctx context.Context, in *StopAll
 	return out, nil
 }
 
+func (c *photosNGServiceClient) WatchSyncJobs(ctx context.Context, in *WatchSyncJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncJobEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PhotosNGService_ServiceDesc.Streams[3], PhotosNGService_WatchSyncJobs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSyncJobsRequest, SyncJobEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PhotosNGService_WatchSyncJobsClient = grpc.ServerStreamingClient[SyncJobEvent]
+
 func (c *photosNGServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatsResponse)
@@ -337,6 +442,7 @@ type PhotosNGServiceServer interface {
 	UpdateAlbum(context.Context, *UpdateAlbumByIdRequest) (*Album, error)
 	DeleteAlbum(context.Context, *DeleteAlbumRequest) (*emptypb.Empty, error)
 	SyncAlbum(context.Context, *SyncAlbumRequest) (*SyncAlbumResponse, error)
+	GetAlbumTree(context.Context, *GetAlbumTreeRequest) (*GetAlbumTreeResponse, error)
 	// Media operations
 	ListMedia(*ListMediaRequest, grpc.ServerStreamingServer[Media]) error
 	UploadMedia(context.Context, *UploadMediaRequest) (*Media, error)
@@ -345,6 +451,14 @@ type PhotosNGServiceServer interface {
 	DeleteMedia(context.Context, *DeleteMediaRequest) (*emptypb.Empty, error)
 	GetMediaThumbnail(context.Context, *GetMediaThumbnailRequest) (*BinaryDataResponse, error)
 	GetMediaContent(*GetMediaContentRequest, grpc.ServerStreamingServer[BinaryDataChunk]) error
+	GetMemories(context.Context, *GetMemoriesRequest) (*GetMemoriesResponse, error)
+	// Bulk operations
+	StartBulkMediaJob(context.Context, *BulkMediaRequest) (*BulkJob, error)
+	GetBulkMediaJob(context.Context, *GetBulkMediaJobRequest) (*BulkJob, error)
+	// Smart album operations
+	ListSmartAlbumMedia(*ListSmartAlbumMediaRequest, grpc.ServerStreamingServer[Media]) error
+	// Search operations
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
 	// Sync operations
 	StartSyncJob(context.Context, *StartSyncRequest) (*StartSyncResponse, error)
 	ListSyncJobs(context.Context, *ListSyncJobsRequest) (*ListSyncJobsResponse, error)
@@ -354,6 +468,7 @@ type PhotosNGServiceServer interface {
 	ClearFinishedSyncJobs(context.Context, *ClearFinishedSyncJobsRequest) (*ClearFinishedSyncJobsResponse, error)
 	StopSyncJob(context.Context, *StopSyncJobRequest) (*StopSyncJobResponse, error)
 	StopAllSyncJobs(context.Context, *StopAllSyncJobsRequest) (*StopAllSyncJobsResponse, error)
+	WatchSyncJobs(*WatchSyncJobsRequest, grpc.ServerStreamingServer[SyncJobEvent]) error
 	// Stats operations
 	GetStats(context.Context, *GetStatsRequest) (*StatsResponse, error)
 	mustEmbedUnimplementedPhotosNGServiceServer()
@@ -384,6 +499,9 @@ func (UnimplementedPhotosNGServiceServer) DeleteAlbum(context.Context, *DeleteAl
 func (UnimplementedPhotosNGServiceServer) SyncAlbum(context.Context, *SyncAlbumRequest) (*SyncAlbumResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncAlbum not implemented")
 }
+func (UnimplementedPhotosNGServiceServer) GetAlbumTree(context.Context, *GetAlbumTreeRequest) (*GetAlbumTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlbumTree not implemented")
+}
 func (UnimplementedPhotosNGServiceServer) ListMedia(*ListMediaRequest, grpc.ServerStreamingServer[Media]) error {
 	return status.Errorf(codes.Unimplemented, "method ListMedia not implemented")
 }
@@ -405,6 +523,21 @@ func (UnimplementedPhotosNGServiceServer) GetMediaThumbnail(context.Context, *Ge
 func (UnimplementedPhotosNGServiceServer) GetMediaContent(*GetMediaContentRequest, grpc.ServerStreamingServer[BinaryDataChunk]) error {
 	return status.Errorf(codes.Unimplemented, "method GetMediaContent not implemented")
 }
+func (UnimplementedPhotosNGServiceServer) GetMemories(context.Context, *GetMemoriesRequest) (*GetMemoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemories not implemented")
+}
+func (UnimplementedPhotosNGServiceServer) StartBulkMediaJob(context.Context, *BulkMediaRequest) (*BulkJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBulkMediaJob not implemented")
+}
+func (UnimplementedPhotosNGServiceServer) GetBulkMediaJob(context.Context, *GetBulkMediaJobRequest) (*BulkJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBulkMediaJob not implemented")
+}
+func (UnimplementedPhotosNGServiceServer) ListSmartAlbumMedia(*ListSmartAlbumMediaRequest, grpc.ServerStreamingServer[Media]) error {
+	return status.Errorf(codes.Unimplemented, "method ListSmartAlbumMedia not implemented")
+}
+func (UnimplementedPhotosNGServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedPhotosNGServiceServer) StartSyncJob(context.Context, *StartSyncRequest) (*StartSyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartSyncJob not implemented")
 }
@@ -429,6 +562,9 @@ func (UnimplementedPhotosNGServiceServer) StopSyncJob(context.Context, *StopSync
 func (UnimplementedPhotosNGServiceServer) StopAllSyncJobs(context.Context, *StopAllSyncJobsRequest) (*StopAllSyncJobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopAllSyncJobs not implemented")
 }
+func (UnimplementedPhotosNGServiceServer) WatchSyncJobs(*WatchSyncJobsRequest, grpc.ServerStreamingServer[SyncJobEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSyncJobs not implemented")
+}
 func (UnimplementedPhotosNGServiceServer) GetStats(context.Context, *GetStatsRequest) (*StatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
@@ -561,6 +697,24 @@ func _PhotosNGService_SyncAlbum_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PhotosNGService_GetAlbumTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlbumTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotosNGServiceServer).GetAlbumTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PhotosNGService_GetAlbumTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotosNGServiceServer).GetAlbumTree(ctx, req.(*GetAlbumTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PhotosNGService_ListMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListMediaRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -673,6 +827,89 @@ func _PhotosNGService_GetMediaContent_Handler(srv interface{}, stream grpc.Serve
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type PhotosNGService_GetMediaContentServer = grpc.ServerStreamingServer[BinaryDataChunk]
 
+func _PhotosNGService_GetMemories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotosNGServiceServer).GetMemories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PhotosNGService_GetMemories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotosNGServiceServer).GetMemories(ctx, req.(*GetMemoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhotosNGService_StartBulkMediaJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotosNGServiceServer).StartBulkMediaJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PhotosNGService_StartBulkMediaJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotosNGServiceServer).StartBulkMediaJob(ctx, req.(*BulkMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhotosNGService_GetBulkMediaJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkMediaJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotosNGServiceServer).GetBulkMediaJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PhotosNGService_GetBulkMediaJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotosNGServiceServer).GetBulkMediaJob(ctx, req.(*GetBulkMediaJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PhotosNGService_ListSmartAlbumMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSmartAlbumMediaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PhotosNGServiceServer).ListSmartAlbumMedia(m, &grpc.GenericServerStream[ListSmartAlbumMediaRequest, Media]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PhotosNGService_ListSmartAlbumMediaServer = grpc.ServerStreamingServer[Media]
+
+func _PhotosNGService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PhotosNGServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PhotosNGService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PhotosNGServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PhotosNGService_StartSyncJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartSyncRequest)
 	if err := dec(in); err != nil {
@@ -817,6 +1054,17 @@ func _PhotosNGService_StopAllSyncJobs_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PhotosNGService_WatchSyncJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSyncJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PhotosNGServiceServer).WatchSyncJobs(m, &grpc.GenericServerStream[WatchSyncJobsRequest, SyncJobEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PhotosNGService_WatchSyncJobsServer = grpc.ServerStreamingServer[SyncJobEvent]
+
 func _PhotosNGService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
@@ -866,6 +1114,10 @@ var PhotosNGService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncAlbum",
 			Handler:    _PhotosNGService_SyncAlbum_Handler,
 		},
+		{
+			MethodName: "GetAlbumTree",
+			Handler:    _PhotosNGService_GetAlbumTree_Handler,
+		},
 		{
 			MethodName: "UploadMedia",
 			Handler:    _PhotosNGService_UploadMedia_Handler,
@@ -886,6 +1138,22 @@ var PhotosNGService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMediaThumbnail",
 			Handler:    _PhotosNGService_GetMediaThumbnail_Handler,
 		},
+		{
+			MethodName: "GetMemories",
+			Handler:    _PhotosNGService_GetMemories_Handler,
+		},
+		{
+			MethodName: "StartBulkMediaJob",
+			Handler:    _PhotosNGService_StartBulkMediaJob_Handler,
+		},
+		{
+			MethodName: "GetBulkMediaJob",
+			Handler:    _PhotosNGService_GetBulkMediaJob_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _PhotosNGService_Search_Handler,
+		},
 		{
 			MethodName: "StartSyncJob",
 			Handler:    _PhotosNGService_StartSyncJob_Handler,
@@ -934,6 +1202,16 @@ var PhotosNGService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _PhotosNGService_GetMediaContent_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListSmartAlbumMedia",
+			Handler:       _PhotosNGService_ListSmartAlbumMedia_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchSyncJobs",
+			Handler:       _PhotosNGService_WatchSyncJobs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "photos_ng.proto",
 }
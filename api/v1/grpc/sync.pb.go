@@ -22,6 +22,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Kind of change a sync job event tells about
+type SyncJobEventType int32
+
+const (
+	SyncJobEventType_SYNC_JOB_EVENT_TYPE_UNSPECIFIED SyncJobEventType = 0
+	SyncJobEventType_SYNC_JOB_EVENT_TYPE_ADDED       SyncJobEventType = 1
+	SyncJobEventType_SYNC_JOB_EVENT_TYPE_CHANGED     SyncJobEventType = 2
+	SyncJobEventType_SYNC_JOB_EVENT_TYPE_TASK_DONE   SyncJobEventType = 3
+	SyncJobEventType_SYNC_JOB_EVENT_TYPE_REMOVED     SyncJobEventType = 4
+)
+
+// Enum value maps for SyncJobEventType.
+var (
+	SyncJobEventType_name = map[int32]string{
+		0: "SYNC_JOB_EVENT_TYPE_UNSPECIFIED",
+		1: "SYNC_JOB_EVENT_TYPE_ADDED",
+		2: "SYNC_JOB_EVENT_TYPE_CHANGED",
+		3: "SYNC_JOB_EVENT_TYPE_TASK_DONE",
+		4: "SYNC_JOB_EVENT_TYPE_REMOVED",
+	}
+	SyncJobEventType_value = map[string]int32{
+		"SYNC_JOB_EVENT_TYPE_UNSPECIFIED": 0,
+		"SYNC_JOB_EVENT_TYPE_ADDED":       1,
+		"SYNC_JOB_EVENT_TYPE_CHANGED":     2,
+		"SYNC_JOB_EVENT_TYPE_TASK_DONE":   3,
+		"SYNC_JOB_EVENT_TYPE_REMOVED":     4,
+	}
+)
+
+func (x SyncJobEventType) Enum() *SyncJobEventType {
+	p := new(SyncJobEventType)
+	*p = x
+	return p
+}
+
+func (x SyncJobEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyncJobEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_sync_proto_enumTypes[0].Descriptor()
+}
+
+func (SyncJobEventType) Type() protoreflect.EnumType {
+	return &file_sync_proto_enumTypes[0]
+}
+
+func (x SyncJobEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyncJobEventType.Descriptor instead.
+func (SyncJobEventType) EnumDescriptor() ([]byte, []int) {
+	return file_sync_proto_rawDescGZIP(), []int{0}
+}
+
 // Sync job action enumeration
 type SyncJobAction int32
 
@@ -56,11 +112,11 @@ func (x SyncJobAction) String() string {
 }
 
 func (SyncJobAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_sync_proto_enumTypes[0].Descriptor()
+	return file_sync_proto_enumTypes[1].Descriptor()
 }
 
 func (SyncJobAction) Type() protoreflect.EnumType {
-	return &file_sync_proto_enumTypes[0]
+	return &file_sync_proto_enumTypes[1]
 }
 
 func (x SyncJobAction) Number() protoreflect.EnumNumber {
@@ -69,7 +125,7 @@ func (x SyncJobAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SyncJobAction.Descriptor instead.
 func (SyncJobAction) EnumDescriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{0}
+	return file_sync_proto_rawDescGZIP(), []int{1}
 }
 
 // Task result for sync operations
@@ -156,6 +212,7 @@ type SyncJob struct {
 	Path           string                 `protobuf:"bytes,10,opt,name=path,proto3" json:"path,omitempty"`                                              // The folder path being synchronized
 	Duration       *int32                 `protobuf:"varint,11,opt,name=duration,proto3,oneof" json:"duration,omitempty"`                               // Duration of the sync job in seconds
 	Error          *string                `protobuf:"bytes,12,opt,name=error,proto3,oneof" json:"error,omitempty"`                                      // Error message if the job failed
+	ParentId       *string                `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`                // ID of the parent job of a sub-job
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -274,10 +331,19 @@ func (x *SyncJob) GetError() string {
 	return ""
 }
 
+func (x *SyncJob) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
 // Request to start a new sync job
 type StartSyncRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // File system path to sync
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                // File system path to sync
+	FullRescan    bool                   `protobuf:"varint,2,opt,name=full_rescan,json=fullRescan,proto3" json:"full_rescan,omitempty"` // Read and hash every file instead of skipping the unchanged ones
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`             // Report what the sync would do without doing it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -319,10 +385,25 @@ func (x *StartSyncRequest) GetPath() string {
 	return ""
 }
 
+func (x *StartSyncRequest) GetFullRescan() bool {
+	if x != nil {
+		return x.FullRescan
+	}
+	return false
+}
+
+func (x *StartSyncRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 // Response when starting a sync job
 type StartSyncResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Unique identifier for the created sync job
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`               // Unique identifier for the created sync job, empty for a dry run
+	Report        *SyncReport            `protobuf:"bytes,2,opt,name=report,proto3,oneof" json:"report,omitempty"` // What the sync would do, set for a dry run
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -364,16 +445,186 @@ func (x *StartSyncResponse) GetId() string {
 	return ""
 }
 
-// Request to list all sync jobs
+func (x *StartSyncResponse) GetReport() *SyncReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// Media whose file was moved to another folder
+type SyncMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Former path of the file
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // New path of the file
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncMove) Reset() {
+	*x = SyncMove{}
+	mi := &file_sync_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncMove) ProtoMessage() {}
+
+func (x *SyncMove) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncMove.ProtoReflect.Descriptor instead.
+func (*SyncMove) Descriptor() ([]byte, []int) {
+	return file_sync_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SyncMove) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SyncMove) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// What a sync would do, all the items are paths relative to the data folder
+type SyncReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Path             string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                                 // The folder path being compared
+	AlbumsToCreate   []string               `protobuf:"bytes,2,rep,name=albums_to_create,json=albumsToCreate,proto3" json:"albums_to_create,omitempty"`     // Folders which have no album yet
+	AlbumsToRemove   []string               `protobuf:"bytes,3,rep,name=albums_to_remove,json=albumsToRemove,proto3" json:"albums_to_remove,omitempty"`     // Albums whose folder is gone, with their subalbums and media
+	MediaToAdd       []string               `protobuf:"bytes,4,rep,name=media_to_add,json=mediaToAdd,proto3" json:"media_to_add,omitempty"`                 // Media files which have no media yet
+	MediaToUpdate    []string               `protobuf:"bytes,5,rep,name=media_to_update,json=mediaToUpdate,proto3" json:"media_to_update,omitempty"`        // Media files changed since they were synced
+	MediaToMove      []*SyncMove            `protobuf:"bytes,6,rep,name=media_to_move,json=mediaToMove,proto3" json:"media_to_move,omitempty"`              // Media whose file was moved to another folder
+	MediaToRemove    []string               `protobuf:"bytes,7,rep,name=media_to_remove,json=mediaToRemove,proto3" json:"media_to_remove,omitempty"`        // Media whose file is gone
+	UnreadableItems  []string               `protobuf:"bytes,8,rep,name=unreadable_items,json=unreadableItems,proto3" json:"unreadable_items,omitempty"`    // Folders and files which cannot be read
+	UnsupportedFiles []string               `protobuf:"bytes,9,rep,name=unsupported_files,json=unsupportedFiles,proto3" json:"unsupported_files,omitempty"` // Files which are not media files of a supported format
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SyncReport) Reset() {
+	*x = SyncReport{}
+	mi := &file_sync_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncReport) ProtoMessage() {}
+
+func (x *SyncReport) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncReport.ProtoReflect.Descriptor instead.
+func (*SyncReport) Descriptor() ([]byte, []int) {
+	return file_sync_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SyncReport) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SyncReport) GetAlbumsToCreate() []string {
+	if x != nil {
+		return x.AlbumsToCreate
+	}
+	return nil
+}
+
+func (x *SyncReport) GetAlbumsToRemove() []string {
+	if x != nil {
+		return x.AlbumsToRemove
+	}
+	return nil
+}
+
+func (x *SyncReport) GetMediaToAdd() []string {
+	if x != nil {
+		return x.MediaToAdd
+	}
+	return nil
+}
+
+func (x *SyncReport) GetMediaToUpdate() []string {
+	if x != nil {
+		return x.MediaToUpdate
+	}
+	return nil
+}
+
+func (x *SyncReport) GetMediaToMove() []*SyncMove {
+	if x != nil {
+		return x.MediaToMove
+	}
+	return nil
+}
+
+func (x *SyncReport) GetMediaToRemove() []string {
+	if x != nil {
+		return x.MediaToRemove
+	}
+	return nil
+}
+
+func (x *SyncReport) GetUnreadableItems() []string {
+	if x != nil {
+		return x.UnreadableItems
+	}
+	return nil
+}
+
+func (x *SyncReport) GetUnsupportedFiles() []string {
+	if x != nil {
+		return x.UnsupportedFiles
+	}
+	return nil
+}
+
+// Request to list the sync jobs, the pagination applies to the finished jobs of the history
 type ListSyncJobsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"` // Pagination parameters of the history
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSyncJobsRequest) Reset() {
 	*x = ListSyncJobsRequest{}
-	mi := &file_sync_proto_msgTypes[4]
+	mi := &file_sync_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -385,7 +636,7 @@ func (x *ListSyncJobsRequest) String() string {
 func (*ListSyncJobsRequest) ProtoMessage() {}
 
 func (x *ListSyncJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[4]
+	mi := &file_sync_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,7 +649,14 @@ func (x *ListSyncJobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSyncJobsRequest.ProtoReflect.Descriptor instead.
 func (*ListSyncJobsRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{4}
+	return file_sync_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSyncJobsRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
 }
 
 // Response containing list of sync jobs
@@ -411,7 +669,7 @@ type ListSyncJobsResponse struct {
 
 func (x *ListSyncJobsResponse) Reset() {
 	*x = ListSyncJobsResponse{}
-	mi := &file_sync_proto_msgTypes[5]
+	mi := &file_sync_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -423,7 +681,7 @@ func (x *ListSyncJobsResponse) String() string {
 func (*ListSyncJobsResponse) ProtoMessage() {}
 
 func (x *ListSyncJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[5]
+	mi := &file_sync_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +694,7 @@ func (x *ListSyncJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSyncJobsResponse.ProtoReflect.Descriptor instead.
 func (*ListSyncJobsResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{5}
+	return file_sync_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListSyncJobsResponse) GetJobs() []*SyncJob {
@@ -456,7 +714,7 @@ type GetSyncJobRequest struct {
 
 func (x *GetSyncJobRequest) Reset() {
 	*x = GetSyncJobRequest{}
-	mi := &file_sync_proto_msgTypes[6]
+	mi := &file_sync_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +726,7 @@ func (x *GetSyncJobRequest) String() string {
 func (*GetSyncJobRequest) ProtoMessage() {}
 
 func (x *GetSyncJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[6]
+	mi := &file_sync_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +739,7 @@ func (x *GetSyncJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSyncJobRequest.ProtoReflect.Descriptor instead.
 func (*GetSyncJobRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{6}
+	return file_sync_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetSyncJobRequest) GetId() string {
@@ -501,7 +759,7 @@ type StopSyncJobRequest struct {
 
 func (x *StopSyncJobRequest) Reset() {
 	*x = StopSyncJobRequest{}
-	mi := &file_sync_proto_msgTypes[7]
+	mi := &file_sync_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -513,7 +771,7 @@ func (x *StopSyncJobRequest) String() string {
 func (*StopSyncJobRequest) ProtoMessage() {}
 
 func (x *StopSyncJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[7]
+	mi := &file_sync_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -526,7 +784,7 @@ func (x *StopSyncJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopSyncJobRequest.ProtoReflect.Descriptor instead.
 func (*StopSyncJobRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{7}
+	return file_sync_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *StopSyncJobRequest) GetId() string {
@@ -547,7 +805,7 @@ type StopSyncJobResponse struct {
 
 func (x *StopSyncJobResponse) Reset() {
 	*x = StopSyncJobResponse{}
-	mi := &file_sync_proto_msgTypes[8]
+	mi := &file_sync_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -559,7 +817,7 @@ func (x *StopSyncJobResponse) String() string {
 func (*StopSyncJobResponse) ProtoMessage() {}
 
 func (x *StopSyncJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[8]
+	mi := &file_sync_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,7 +830,7 @@ func (x *StopSyncJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopSyncJobResponse.ProtoReflect.Descriptor instead.
 func (*StopSyncJobResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{8}
+	return file_sync_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StopSyncJobResponse) GetMessage() string {
@@ -598,7 +856,7 @@ type StopAllSyncJobsRequest struct {
 
 func (x *StopAllSyncJobsRequest) Reset() {
 	*x = StopAllSyncJobsRequest{}
-	mi := &file_sync_proto_msgTypes[9]
+	mi := &file_sync_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -610,7 +868,7 @@ func (x *StopAllSyncJobsRequest) String() string {
 func (*StopAllSyncJobsRequest) ProtoMessage() {}
 
 func (x *StopAllSyncJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[9]
+	mi := &file_sync_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,7 +881,7 @@ func (x *StopAllSyncJobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAllSyncJobsRequest.ProtoReflect.Descriptor instead.
 func (*StopAllSyncJobsRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{9}
+	return file_sync_proto_rawDescGZIP(), []int{11}
 }
 
 // Response when stopping all sync jobs
@@ -637,7 +895,7 @@ type StopAllSyncJobsResponse struct {
 
 func (x *StopAllSyncJobsResponse) Reset() {
 	*x = StopAllSyncJobsResponse{}
-	mi := &file_sync_proto_msgTypes[10]
+	mi := &file_sync_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -649,7 +907,7 @@ func (x *StopAllSyncJobsResponse) String() string {
 func (*StopAllSyncJobsResponse) ProtoMessage() {}
 
 func (x *StopAllSyncJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[10]
+	mi := &file_sync_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -662,7 +920,7 @@ func (x *StopAllSyncJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAllSyncJobsResponse.ProtoReflect.Descriptor instead.
 func (*StopAllSyncJobsResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{10}
+	return file_sync_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *StopAllSyncJobsResponse) GetMessage() string {
@@ -689,7 +947,7 @@ type ActionAllSyncJobsRequest struct {
 
 func (x *ActionAllSyncJobsRequest) Reset() {
 	*x = ActionAllSyncJobsRequest{}
-	mi := &file_sync_proto_msgTypes[11]
+	mi := &file_sync_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -701,7 +959,7 @@ func (x *ActionAllSyncJobsRequest) String() string {
 func (*ActionAllSyncJobsRequest) ProtoMessage() {}
 
 func (x *ActionAllSyncJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[11]
+	mi := &file_sync_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -714,7 +972,7 @@ func (x *ActionAllSyncJobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionAllSyncJobsRequest.ProtoReflect.Descriptor instead.
 func (*ActionAllSyncJobsRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{11}
+	return file_sync_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ActionAllSyncJobsRequest) GetAction() SyncJobAction {
@@ -736,7 +994,7 @@ type ActionAllSyncJobsResponse struct {
 
 func (x *ActionAllSyncJobsResponse) Reset() {
 	*x = ActionAllSyncJobsResponse{}
-	mi := &file_sync_proto_msgTypes[12]
+	mi := &file_sync_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -748,7 +1006,7 @@ func (x *ActionAllSyncJobsResponse) String() string {
 func (*ActionAllSyncJobsResponse) ProtoMessage() {}
 
 func (x *ActionAllSyncJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[12]
+	mi := &file_sync_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -761,7 +1019,7 @@ func (x *ActionAllSyncJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionAllSyncJobsResponse.ProtoReflect.Descriptor instead.
 func (*ActionAllSyncJobsResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{12}
+	return file_sync_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ActionAllSyncJobsResponse) GetMessage() string {
@@ -796,7 +1054,7 @@ type ActionSyncJobRequest struct {
 
 func (x *ActionSyncJobRequest) Reset() {
 	*x = ActionSyncJobRequest{}
-	mi := &file_sync_proto_msgTypes[13]
+	mi := &file_sync_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -808,7 +1066,7 @@ func (x *ActionSyncJobRequest) String() string {
 func (*ActionSyncJobRequest) ProtoMessage() {}
 
 func (x *ActionSyncJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[13]
+	mi := &file_sync_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -821,7 +1079,7 @@ func (x *ActionSyncJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionSyncJobRequest.ProtoReflect.Descriptor instead.
 func (*ActionSyncJobRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{13}
+	return file_sync_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ActionSyncJobRequest) GetId() string {
@@ -850,7 +1108,7 @@ type ActionSyncJobResponse struct {
 
 func (x *ActionSyncJobResponse) Reset() {
 	*x = ActionSyncJobResponse{}
-	mi := &file_sync_proto_msgTypes[14]
+	mi := &file_sync_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -862,7 +1120,7 @@ func (x *ActionSyncJobResponse) String() string {
 func (*ActionSyncJobResponse) ProtoMessage() {}
 
 func (x *ActionSyncJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[14]
+	mi := &file_sync_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -875,7 +1133,7 @@ func (x *ActionSyncJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActionSyncJobResponse.ProtoReflect.Descriptor instead.
 func (*ActionSyncJobResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{14}
+	return file_sync_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ActionSyncJobResponse) GetMessage() string {
@@ -908,7 +1166,7 @@ type ClearFinishedSyncJobsRequest struct {
 
 func (x *ClearFinishedSyncJobsRequest) Reset() {
 	*x = ClearFinishedSyncJobsRequest{}
-	mi := &file_sync_proto_msgTypes[15]
+	mi := &file_sync_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -920,7 +1178,7 @@ func (x *ClearFinishedSyncJobsRequest) String() string {
 func (*ClearFinishedSyncJobsRequest) ProtoMessage() {}
 
 func (x *ClearFinishedSyncJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[15]
+	mi := &file_sync_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1191,7 @@ func (x *ClearFinishedSyncJobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearFinishedSyncJobsRequest.ProtoReflect.Descriptor instead.
 func (*ClearFinishedSyncJobsRequest) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{15}
+	return file_sync_proto_rawDescGZIP(), []int{17}
 }
 
 // Response when clearing finished sync jobs
@@ -947,7 +1205,7 @@ type ClearFinishedSyncJobsResponse struct {
 
 func (x *ClearFinishedSyncJobsResponse) Reset() {
 	*x = ClearFinishedSyncJobsResponse{}
-	mi := &file_sync_proto_msgTypes[16]
+	mi := &file_sync_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -959,7 +1217,7 @@ func (x *ClearFinishedSyncJobsResponse) String() string {
 func (*ClearFinishedSyncJobsResponse) ProtoMessage() {}
 
 func (x *ClearFinishedSyncJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_sync_proto_msgTypes[16]
+	mi := &file_sync_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -972,7 +1230,7 @@ func (x *ClearFinishedSyncJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearFinishedSyncJobsResponse.ProtoReflect.Descriptor instead.
 func (*ClearFinishedSyncJobsResponse) Descriptor() ([]byte, []int) {
-	return file_sync_proto_rawDescGZIP(), []int{16}
+	return file_sync_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ClearFinishedSyncJobsResponse) GetMessage() string {
@@ -989,6 +1247,120 @@ func (x *ClearFinishedSyncJobsResponse) GetClearedCount() int32 {
 	return 0
 }
 
+// Request to watch the progress of the sync jobs
+type WatchSyncJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"` // Sync job ID, the events of its sub-jobs are sent too. All the jobs if not set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSyncJobsRequest) Reset() {
+	*x = WatchSyncJobsRequest{}
+	mi := &file_sync_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSyncJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSyncJobsRequest) ProtoMessage() {}
+
+func (x *WatchSyncJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSyncJobsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSyncJobsRequest) Descriptor() ([]byte, []int) {
+	return file_sync_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchSyncJobsRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+// Change of a sync job as it happens
+type SyncJobEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          SyncJobEventType       `protobuf:"varint,1,opt,name=type,proto3,enum=photos_ng.api.v1.grpc.SyncJobEventType" json:"type,omitempty"` // Kind of change
+	Job           *SyncJob               `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`                                                // The job after the change, without its completed tasks
+	Task          *TaskResult            `protobuf:"bytes,3,opt,name=task,proto3,oneof" json:"task,omitempty"`                                        // The finished task, set for SYNC_JOB_EVENT_TYPE_TASK_DONE
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`                                                  // When the change happened
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncJobEvent) Reset() {
+	*x = SyncJobEvent{}
+	mi := &file_sync_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncJobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncJobEvent) ProtoMessage() {}
+
+func (x *SyncJobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncJobEvent.ProtoReflect.Descriptor instead.
+func (*SyncJobEvent) Descriptor() ([]byte, []int) {
+	return file_sync_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SyncJobEvent) GetType() SyncJobEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SyncJobEventType_SYNC_JOB_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *SyncJobEvent) GetJob() *SyncJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *SyncJobEvent) GetTask() *TaskResult {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SyncJobEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
 var File_sync_proto protoreflect.FileDescriptor
 
 const file_sync_proto_rawDesc = "" +
@@ -1000,7 +1372,7 @@ const file_sync_proto_rawDesc = "" +
 	"\x04item\x18\x01 \x01(\tR\x04item\x12F\n" +
 	"\titem_type\x18\x02 \x01(\x0e2).photos_ng.api.v1.grpc.TaskResultItemTypeR\bitemType\x12\x1a\n" +
 	"\bduration\x18\x03 \x01(\x05R\bduration\x12?\n" +
-	"\x06result\x18\x04 \x01(\v2'.photos_ng.api.v1.grpc.TaskResultStatusR\x06result\"\xef\x04\n" +
+	"\x06result\x18\x04 \x01(\v2'.photos_ng.api.v1.grpc.TaskResultStatusR\x06result\"\x9f\x05\n" +
 	"\aSyncJob\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12<\n" +
 	"\x06status\x18\x02 \x01(\x0e2$.photos_ng.api.v1.grpc.SyncJobStatusR\x06status\x12'\n" +
@@ -1018,17 +1390,43 @@ const file_sync_proto_rawDesc = "" +
 	"\x04path\x18\n" +
 	" \x01(\tR\x04path\x12\x1f\n" +
 	"\bduration\x18\v \x01(\x05H\x03R\bduration\x88\x01\x01\x12\x19\n" +
-	"\x05error\x18\f \x01(\tH\x04R\x05error\x88\x01\x01B\r\n" +
+	"\x05error\x18\f \x01(\tH\x04R\x05error\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\r \x01(\tH\x05R\bparentId\x88\x01\x01B\r\n" +
 	"\v_started_atB\x0e\n" +
 	"\f_finished_atB\x11\n" +
 	"\x0f_remaining_timeB\v\n" +
 	"\t_durationB\b\n" +
-	"\x06_error\"&\n" +
+	"\x06_errorB\f\n" +
+	"\n" +
+	"_parent_id\"`\n" +
 	"\x10StartSyncRequest\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\"#\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1f\n" +
+	"\vfull_rescan\x18\x02 \x01(\bR\n" +
+	"fullRescan\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"n\n" +
 	"\x11StartSyncResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
-	"\x13ListSyncJobsRequest\"J\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12>\n" +
+	"\x06report\x18\x02 \x01(\v2!.photos_ng.api.v1.grpc.SyncReportH\x00R\x06report\x88\x01\x01B\t\n" +
+	"\a_report\".\n" +
+	"\bSyncMove\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\x83\x03\n" +
+	"\n" +
+	"SyncReport\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12(\n" +
+	"\x10albums_to_create\x18\x02 \x03(\tR\x0ealbumsToCreate\x12(\n" +
+	"\x10albums_to_remove\x18\x03 \x03(\tR\x0ealbumsToRemove\x12 \n" +
+	"\fmedia_to_add\x18\x04 \x03(\tR\n" +
+	"mediaToAdd\x12&\n" +
+	"\x0fmedia_to_update\x18\x05 \x03(\tR\rmediaToUpdate\x12C\n" +
+	"\rmedia_to_move\x18\x06 \x03(\v2\x1f.photos_ng.api.v1.grpc.SyncMoveR\vmediaToMove\x12&\n" +
+	"\x0fmedia_to_remove\x18\a \x03(\tR\rmediaToRemove\x12)\n" +
+	"\x10unreadable_items\x18\b \x03(\tR\x0funreadableItems\x12+\n" +
+	"\x11unsupported_files\x18\t \x03(\tR\x10unsupportedFiles\"_\n" +
+	"\x13ListSyncJobsRequest\x12H\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2(.photos_ng.api.v1.grpc.PaginationRequestR\n" +
+	"pagination\"J\n" +
 	"\x14ListSyncJobsResponse\x122\n" +
 	"\x04jobs\x18\x01 \x03(\v2\x1e.photos_ng.api.v1.grpc.SyncJobR\x04jobs\"#\n" +
 	"\x11GetSyncJobRequest\x12\x0e\n" +
@@ -1058,11 +1456,26 @@ const file_sync_proto_rawDesc = "" +
 	"\x1cClearFinishedSyncJobsRequest\"^\n" +
 	"\x1dClearFinishedSyncJobsResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12#\n" +
-	"\rcleared_count\x18\x02 \x01(\x05R\fclearedCount*f\n" +
+	"\rcleared_count\x18\x02 \x01(\x05R\fclearedCount\"2\n" +
+	"\x14WatchSyncJobsRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01B\x05\n" +
+	"\x03_id\"\xee\x01\n" +
+	"\fSyncJobEvent\x12;\n" +
+	"\x04type\x18\x01 \x01(\x0e2'.photos_ng.api.v1.grpc.SyncJobEventTypeR\x04type\x120\n" +
+	"\x03job\x18\x02 \x01(\v2\x1e.photos_ng.api.v1.grpc.SyncJobR\x03job\x12:\n" +
+	"\x04task\x18\x03 \x01(\v2!.photos_ng.api.v1.grpc.TaskResultH\x00R\x04task\x88\x01\x01\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02atB\a\n" +
+	"\x05_task*\xbb\x01\n" +
+	"\x10SyncJobEventType\x12#\n" +
+	"\x1fSYNC_JOB_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SYNC_JOB_EVENT_TYPE_ADDED\x10\x01\x12\x1f\n" +
+	"\x1bSYNC_JOB_EVENT_TYPE_CHANGED\x10\x02\x12!\n" +
+	"\x1dSYNC_JOB_EVENT_TYPE_TASK_DONE\x10\x03\x12\x1f\n" +
+	"\x1bSYNC_JOB_EVENT_TYPE_REMOVED\x10\x04*f\n" +
 	"\rSyncJobAction\x12\x1f\n" +
 	"\x1bSYNC_JOB_ACTION_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14SYNC_JOB_ACTION_STOP\x10\x01\x12\x1a\n" +
-	"\x16SYNC_JOB_ACTION_RESUME\x10\x022\xf2\x06\n" +
+	"\x16SYNC_JOB_ACTION_RESUME\x10\x022\xd7\a\n" +
 	"\vSyncService\x12a\n" +
 	"\fStartSyncJob\x12'.photos_ng.api.v1.grpc.StartSyncRequest\x1a(.photos_ng.api.v1.grpc.StartSyncResponse\x12g\n" +
 	"\fListSyncJobs\x12*.photos_ng.api.v1.grpc.ListSyncJobsRequest\x1a+.photos_ng.api.v1.grpc.ListSyncJobsResponse\x12V\n" +
@@ -1072,7 +1485,8 @@ const file_sync_proto_rawDesc = "" +
 	"\rActionSyncJob\x12+.photos_ng.api.v1.grpc.ActionSyncJobRequest\x1a,.photos_ng.api.v1.grpc.ActionSyncJobResponse\x12\x82\x01\n" +
 	"\x15ClearFinishedSyncJobs\x123.photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest\x1a4.photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse\x12d\n" +
 	"\vStopSyncJob\x12).photos_ng.api.v1.grpc.StopSyncJobRequest\x1a*.photos_ng.api.v1.grpc.StopSyncJobResponse\x12p\n" +
-	"\x0fStopAllSyncJobs\x12-.photos_ng.api.v1.grpc.StopAllSyncJobsRequest\x1a..photos_ng.api.v1.grpc.StopAllSyncJobsResponseB\xcc\x01\n" +
+	"\x0fStopAllSyncJobs\x12-.photos_ng.api.v1.grpc.StopAllSyncJobsRequest\x1a..photos_ng.api.v1.grpc.StopAllSyncJobsResponse\x12c\n" +
+	"\rWatchSyncJobs\x12+.photos_ng.api.v1.grpc.WatchSyncJobsRequest\x1a#.photos_ng.api.v1.grpc.SyncJobEvent0\x01B\xcc\x01\n" +
 	"\x19com.photos_ng.api.v1.grpcB\tSyncProtoP\x01Z0git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/grpc\xa2\x02\x04PAVG\xaa\x02\x14PhotosNg.Api.V1.Grpc\xca\x02\x14PhotosNg\\Api\\V1\\Grpc\xe2\x02 PhotosNg\\Api\\V1\\Grpc\\GPBMetadata\xea\x02\x17PhotosNg::Api::V1::Grpcb\x06proto3"
 
 var (
@@ -1087,66 +1501,81 @@ func file_sync_proto_rawDescGZIP() []byte {
 	return file_sync_proto_rawDescData
 }
 
-var file_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_sync_proto_goTypes = []any{
-	(SyncJobAction)(0),                    // 0: photos_ng.api.v1.grpc.SyncJobAction
-	(*TaskResult)(nil),                    // 1: photos_ng.api.v1.grpc.TaskResult
-	(*SyncJob)(nil),                       // 2: photos_ng.api.v1.grpc.SyncJob
-	(*StartSyncRequest)(nil),              // 3: photos_ng.api.v1.grpc.StartSyncRequest
-	(*StartSyncResponse)(nil),             // 4: photos_ng.api.v1.grpc.StartSyncResponse
-	(*ListSyncJobsRequest)(nil),           // 5: photos_ng.api.v1.grpc.ListSyncJobsRequest
-	(*ListSyncJobsResponse)(nil),          // 6: photos_ng.api.v1.grpc.ListSyncJobsResponse
-	(*GetSyncJobRequest)(nil),             // 7: photos_ng.api.v1.grpc.GetSyncJobRequest
-	(*StopSyncJobRequest)(nil),            // 8: photos_ng.api.v1.grpc.StopSyncJobRequest
-	(*StopSyncJobResponse)(nil),           // 9: photos_ng.api.v1.grpc.StopSyncJobResponse
-	(*StopAllSyncJobsRequest)(nil),        // 10: photos_ng.api.v1.grpc.StopAllSyncJobsRequest
-	(*StopAllSyncJobsResponse)(nil),       // 11: photos_ng.api.v1.grpc.StopAllSyncJobsResponse
-	(*ActionAllSyncJobsRequest)(nil),      // 12: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
-	(*ActionAllSyncJobsResponse)(nil),     // 13: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
-	(*ActionSyncJobRequest)(nil),          // 14: photos_ng.api.v1.grpc.ActionSyncJobRequest
-	(*ActionSyncJobResponse)(nil),         // 15: photos_ng.api.v1.grpc.ActionSyncJobResponse
-	(*ClearFinishedSyncJobsRequest)(nil),  // 16: photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
-	(*ClearFinishedSyncJobsResponse)(nil), // 17: photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
-	(TaskResultItemType)(0),               // 18: photos_ng.api.v1.grpc.TaskResultItemType
-	(*TaskResultStatus)(nil),              // 19: photos_ng.api.v1.grpc.TaskResultStatus
-	(SyncJobStatus)(0),                    // 20: photos_ng.api.v1.grpc.SyncJobStatus
-	(*timestamppb.Timestamp)(nil),         // 21: google.protobuf.Timestamp
+	(SyncJobEventType)(0),                 // 0: photos_ng.api.v1.grpc.SyncJobEventType
+	(SyncJobAction)(0),                    // 1: photos_ng.api.v1.grpc.SyncJobAction
+	(*TaskResult)(nil),                    // 2: photos_ng.api.v1.grpc.TaskResult
+	(*SyncJob)(nil),                       // 3: photos_ng.api.v1.grpc.SyncJob
+	(*StartSyncRequest)(nil),              // 4: photos_ng.api.v1.grpc.StartSyncRequest
+	(*StartSyncResponse)(nil),             // 5: photos_ng.api.v1.grpc.StartSyncResponse
+	(*SyncMove)(nil),                      // 6: photos_ng.api.v1.grpc.SyncMove
+	(*SyncReport)(nil),                    // 7: photos_ng.api.v1.grpc.SyncReport
+	(*ListSyncJobsRequest)(nil),           // 8: photos_ng.api.v1.grpc.ListSyncJobsRequest
+	(*ListSyncJobsResponse)(nil),          // 9: photos_ng.api.v1.grpc.ListSyncJobsResponse
+	(*GetSyncJobRequest)(nil),             // 10: photos_ng.api.v1.grpc.GetSyncJobRequest
+	(*StopSyncJobRequest)(nil),            // 11: photos_ng.api.v1.grpc.StopSyncJobRequest
+	(*StopSyncJobResponse)(nil),           // 12: photos_ng.api.v1.grpc.StopSyncJobResponse
+	(*StopAllSyncJobsRequest)(nil),        // 13: photos_ng.api.v1.grpc.StopAllSyncJobsRequest
+	(*StopAllSyncJobsResponse)(nil),       // 14: photos_ng.api.v1.grpc.StopAllSyncJobsResponse
+	(*ActionAllSyncJobsRequest)(nil),      // 15: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
+	(*ActionAllSyncJobsResponse)(nil),     // 16: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
+	(*ActionSyncJobRequest)(nil),          // 17: photos_ng.api.v1.grpc.ActionSyncJobRequest
+	(*ActionSyncJobResponse)(nil),         // 18: photos_ng.api.v1.grpc.ActionSyncJobResponse
+	(*ClearFinishedSyncJobsRequest)(nil),  // 19: photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
+	(*ClearFinishedSyncJobsResponse)(nil), // 20: photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
+	(*WatchSyncJobsRequest)(nil),          // 21: photos_ng.api.v1.grpc.WatchSyncJobsRequest
+	(*SyncJobEvent)(nil),                  // 22: photos_ng.api.v1.grpc.SyncJobEvent
+	(TaskResultItemType)(0),               // 23: photos_ng.api.v1.grpc.TaskResultItemType
+	(*TaskResultStatus)(nil),              // 24: photos_ng.api.v1.grpc.TaskResultStatus
+	(SyncJobStatus)(0),                    // 25: photos_ng.api.v1.grpc.SyncJobStatus
+	(*timestamppb.Timestamp)(nil),         // 26: google.protobuf.Timestamp
+	(*PaginationRequest)(nil),             // 27: photos_ng.api.v1.grpc.PaginationRequest
 }
 var file_sync_proto_depIdxs = []int32{
-	18, // 0: photos_ng.api.v1.grpc.TaskResult.item_type:type_name -> photos_ng.api.v1.grpc.TaskResultItemType
-	19, // 1: photos_ng.api.v1.grpc.TaskResult.result:type_name -> photos_ng.api.v1.grpc.TaskResultStatus
-	20, // 2: photos_ng.api.v1.grpc.SyncJob.status:type_name -> photos_ng.api.v1.grpc.SyncJobStatus
-	1,  // 3: photos_ng.api.v1.grpc.SyncJob.completed_tasks:type_name -> photos_ng.api.v1.grpc.TaskResult
-	21, // 4: photos_ng.api.v1.grpc.SyncJob.created_at:type_name -> google.protobuf.Timestamp
-	21, // 5: photos_ng.api.v1.grpc.SyncJob.started_at:type_name -> google.protobuf.Timestamp
-	21, // 6: photos_ng.api.v1.grpc.SyncJob.finished_at:type_name -> google.protobuf.Timestamp
-	2,  // 7: photos_ng.api.v1.grpc.ListSyncJobsResponse.jobs:type_name -> photos_ng.api.v1.grpc.SyncJob
-	0,  // 8: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
-	0,  // 9: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
-	0,  // 10: photos_ng.api.v1.grpc.ActionSyncJobRequest.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
-	0,  // 11: photos_ng.api.v1.grpc.ActionSyncJobResponse.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
-	3,  // 12: photos_ng.api.v1.grpc.SyncService.StartSyncJob:input_type -> photos_ng.api.v1.grpc.StartSyncRequest
-	5,  // 13: photos_ng.api.v1.grpc.SyncService.ListSyncJobs:input_type -> photos_ng.api.v1.grpc.ListSyncJobsRequest
-	7,  // 14: photos_ng.api.v1.grpc.SyncService.GetSyncJob:input_type -> photos_ng.api.v1.grpc.GetSyncJobRequest
-	12, // 15: photos_ng.api.v1.grpc.SyncService.ActionAllSyncJobs:input_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
-	14, // 16: photos_ng.api.v1.grpc.SyncService.ActionSyncJob:input_type -> photos_ng.api.v1.grpc.ActionSyncJobRequest
-	16, // 17: photos_ng.api.v1.grpc.SyncService.ClearFinishedSyncJobs:input_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
-	8,  // 18: photos_ng.api.v1.grpc.SyncService.StopSyncJob:input_type -> photos_ng.api.v1.grpc.StopSyncJobRequest
-	10, // 19: photos_ng.api.v1.grpc.SyncService.StopAllSyncJobs:input_type -> photos_ng.api.v1.grpc.StopAllSyncJobsRequest
-	4,  // 20: photos_ng.api.v1.grpc.SyncService.StartSyncJob:output_type -> photos_ng.api.v1.grpc.StartSyncResponse
-	6,  // 21: photos_ng.api.v1.grpc.SyncService.ListSyncJobs:output_type -> photos_ng.api.v1.grpc.ListSyncJobsResponse
-	2,  // 22: photos_ng.api.v1.grpc.SyncService.GetSyncJob:output_type -> photos_ng.api.v1.grpc.SyncJob
-	13, // 23: photos_ng.api.v1.grpc.SyncService.ActionAllSyncJobs:output_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
-	15, // 24: photos_ng.api.v1.grpc.SyncService.ActionSyncJob:output_type -> photos_ng.api.v1.grpc.ActionSyncJobResponse
-	17, // 25: photos_ng.api.v1.grpc.SyncService.ClearFinishedSyncJobs:output_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
-	9,  // 26: photos_ng.api.v1.grpc.SyncService.StopSyncJob:output_type -> photos_ng.api.v1.grpc.StopSyncJobResponse
-	11, // 27: photos_ng.api.v1.grpc.SyncService.StopAllSyncJobs:output_type -> photos_ng.api.v1.grpc.StopAllSyncJobsResponse
-	20, // [20:28] is the sub-list for method output_type
-	12, // [12:20] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	23, // 0: photos_ng.api.v1.grpc.TaskResult.item_type:type_name -> photos_ng.api.v1.grpc.TaskResultItemType
+	24, // 1: photos_ng.api.v1.grpc.TaskResult.result:type_name -> photos_ng.api.v1.grpc.TaskResultStatus
+	25, // 2: photos_ng.api.v1.grpc.SyncJob.status:type_name -> photos_ng.api.v1.grpc.SyncJobStatus
+	2,  // 3: photos_ng.api.v1.grpc.SyncJob.completed_tasks:type_name -> photos_ng.api.v1.grpc.TaskResult
+	26, // 4: photos_ng.api.v1.grpc.SyncJob.created_at:type_name -> google.protobuf.Timestamp
+	26, // 5: photos_ng.api.v1.grpc.SyncJob.started_at:type_name -> google.protobuf.Timestamp
+	26, // 6: photos_ng.api.v1.grpc.SyncJob.finished_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: photos_ng.api.v1.grpc.StartSyncResponse.report:type_name -> photos_ng.api.v1.grpc.SyncReport
+	6,  // 8: photos_ng.api.v1.grpc.SyncReport.media_to_move:type_name -> photos_ng.api.v1.grpc.SyncMove
+	27, // 9: photos_ng.api.v1.grpc.ListSyncJobsRequest.pagination:type_name -> photos_ng.api.v1.grpc.PaginationRequest
+	3,  // 10: photos_ng.api.v1.grpc.ListSyncJobsResponse.jobs:type_name -> photos_ng.api.v1.grpc.SyncJob
+	1,  // 11: photos_ng.api.v1.grpc.ActionAllSyncJobsRequest.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
+	1,  // 12: photos_ng.api.v1.grpc.ActionAllSyncJobsResponse.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
+	1,  // 13: photos_ng.api.v1.grpc.ActionSyncJobRequest.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
+	1,  // 14: photos_ng.api.v1.grpc.ActionSyncJobResponse.action:type_name -> photos_ng.api.v1.grpc.SyncJobAction
+	0,  // 15: photos_ng.api.v1.grpc.SyncJobEvent.type:type_name -> photos_ng.api.v1.grpc.SyncJobEventType
+	3,  // 16: photos_ng.api.v1.grpc.SyncJobEvent.job:type_name -> photos_ng.api.v1.grpc.SyncJob
+	2,  // 17: photos_ng.api.v1.grpc.SyncJobEvent.task:type_name -> photos_ng.api.v1.grpc.TaskResult
+	26, // 18: photos_ng.api.v1.grpc.SyncJobEvent.at:type_name -> google.protobuf.Timestamp
+	4,  // 19: photos_ng.api.v1.grpc.SyncService.StartSyncJob:input_type -> photos_ng.api.v1.grpc.StartSyncRequest
+	8,  // 20: photos_ng.api.v1.grpc.SyncService.ListSyncJobs:input_type -> photos_ng.api.v1.grpc.ListSyncJobsRequest
+	10, // 21: photos_ng.api.v1.grpc.SyncService.GetSyncJob:input_type -> photos_ng.api.v1.grpc.GetSyncJobRequest
+	15, // 22: photos_ng.api.v1.grpc.SyncService.ActionAllSyncJobs:input_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsRequest
+	17, // 23: photos_ng.api.v1.grpc.SyncService.ActionSyncJob:input_type -> photos_ng.api.v1.grpc.ActionSyncJobRequest
+	19, // 24: photos_ng.api.v1.grpc.SyncService.ClearFinishedSyncJobs:input_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsRequest
+	11, // 25: photos_ng.api.v1.grpc.SyncService.StopSyncJob:input_type -> photos_ng.api.v1.grpc.StopSyncJobRequest
+	13, // 26: photos_ng.api.v1.grpc.SyncService.StopAllSyncJobs:input_type -> photos_ng.api.v1.grpc.StopAllSyncJobsRequest
+	21, // 27: photos_ng.api.v1.grpc.SyncService.WatchSyncJobs:input_type -> photos_ng.api.v1.grpc.WatchSyncJobsRequest
+	5,  // 28: photos_ng.api.v1.grpc.SyncService.StartSyncJob:output_type -> photos_ng.api.v1.grpc.StartSyncResponse
+	9,  // 29: photos_ng.api.v1.grpc.SyncService.ListSyncJobs:output_type -> photos_ng.api.v1.grpc.ListSyncJobsResponse
+	3,  // 30: photos_ng.api.v1.grpc.SyncService.GetSyncJob:output_type -> photos_ng.api.v1.grpc.SyncJob
+	16, // 31: photos_ng.api.v1.grpc.SyncService.ActionAllSyncJobs:output_type -> photos_ng.api.v1.grpc.ActionAllSyncJobsResponse
+	18, // 32: photos_ng.api.v1.grpc.SyncService.ActionSyncJob:output_type -> photos_ng.api.v1.grpc.ActionSyncJobResponse
+	20, // 33: photos_ng.api.v1.grpc.SyncService.ClearFinishedSyncJobs:output_type -> photos_ng.api.v1.grpc.ClearFinishedSyncJobsResponse
+	12, // 34: photos_ng.api.v1.grpc.SyncService.StopSyncJob:output_type -> photos_ng.api.v1.grpc.StopSyncJobResponse
+	14, // 35: photos_ng.api.v1.grpc.SyncService.StopAllSyncJobs:output_type -> photos_ng.api.v1.grpc.StopAllSyncJobsResponse
+	22, // 36: photos_ng.api.v1.grpc.SyncService.WatchSyncJobs:output_type -> photos_ng.api.v1.grpc.SyncJobEvent
+	28, // [28:37] is the sub-list for method output_type
+	19, // [19:28] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_sync_proto_init() }
@@ -1156,13 +1585,16 @@ func file_sync_proto_init() {
 	}
 	file_common_proto_init()
 	file_sync_proto_msgTypes[1].OneofWrappers = []any{}
+	file_sync_proto_msgTypes[3].OneofWrappers = []any{}
+	file_sync_proto_msgTypes[19].OneofWrappers = []any{}
+	file_sync_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sync_proto_rawDesc), len(file_sync_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
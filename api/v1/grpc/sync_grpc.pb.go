@@ -27,6 +27,7 @@ const (
 	SyncService_ClearFinishedSyncJobs_FullMethodName = "/photos_ng.api.v1.grpc.SyncService/ClearFinishedSyncJobs"
 	SyncService_StopSyncJob_FullMethodName           = "/photos_ng.api.v1.grpc.SyncService/StopSyncJob"
 	SyncService_StopAllSyncJobs_FullMethodName       = "/photos_ng.api.v1.grpc.SyncService/StopAllSyncJobs"
+	SyncService_WatchSyncJobs_FullMethodName         = "/photos_ng.api.v1.grpc.SyncService/WatchSyncJobs"
 )
 
 // SyncServiceClient is the client API for SyncService service.
@@ -51,6 +52,8 @@ type SyncServiceClient interface {
 	StopSyncJob(ctx context.Context, in *StopSyncJobRequest, opts ...grpc.CallOption) (*StopSyncJobResponse, error)
 	// Stop all running sync jobs (deprecated - use ActionAllSyncJobs instead)
 	StopAllSyncJobs(ctx context.Context, in *StopAllSyncJobsRequest, opts ...grpc.CallOption) (*StopAllSyncJobsResponse, error)
+	// Watch the progress of the sync jobs: the current status of the jobs first, then their changes as they happen
+	WatchSyncJobs(ctx context.Context, in *WatchSyncJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncJobEvent], error)
 }
 
 type syncServiceClient struct {
@@ -141,6 +144,25 @@ func (c *syncServiceClient) StopAllSyncJobs(ctx context.Context, in *StopAllSync
 	return out, nil
 }
 
+func (c *syncServiceClient) WatchSyncJobs(ctx context.Context, in *WatchSyncJobsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SyncJobEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SyncService_ServiceDesc.Streams[0], SyncService_WatchSyncJobs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSyncJobsRequest, SyncJobEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SyncService_WatchSyncJobsClient = grpc.ServerStreamingClient[SyncJobEvent]
+
 // SyncServiceServer is the server API for SyncService service.
 // All implementations must embed UnimplementedSyncServiceServer
 // for forward compatibility.
@@ -163,6 +185,8 @@ type SyncServiceServer interface {
 	StopSyncJob(context.Context, *StopSyncJobRequest) (*StopSyncJobResponse, error)
 	// Stop all running sync jobs (deprecated - use ActionAllSyncJobs instead)
 	StopAllSyncJobs(context.Context, *StopAllSyncJobsRequest) (*StopAllSyncJobsResponse, error)
+	// Watch the progress of the sync jobs: the current status of the jobs first, then their changes as they happen
+	WatchSyncJobs(*WatchSyncJobsRequest, grpc.ServerStreamingServer[SyncJobEvent]) error
 	mustEmbedUnimplementedSyncServiceServer()
 }
 
@@ -197,6 +221,9 @@ func (UnimplementedSyncServiceServer) StopSyncJob(context.Context, *StopSyncJobR
 func (UnimplementedSyncServiceServer) StopAllSyncJobs(context.Context, *StopAllSyncJobsRequest) (*StopAllSyncJobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopAllSyncJobs not implemented")
 }
+func (UnimplementedSyncServiceServer) WatchSyncJobs(*WatchSyncJobsRequest, grpc.ServerStreamingServer[SyncJobEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSyncJobs not implemented")
+}
 func (UnimplementedSyncServiceServer) mustEmbedUnimplementedSyncServiceServer() {}
 func (UnimplementedSyncServiceServer) testEmbeddedByValue()                     {}
 
@@ -362,6 +389,17 @@ func _SyncService_StopAllSyncJobs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SyncService_WatchSyncJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSyncJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncServiceServer).WatchSyncJobs(m, &grpc.GenericServerStream[WatchSyncJobsRequest, SyncJobEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SyncService_WatchSyncJobsServer = grpc.ServerStreamingServer[SyncJobEvent]
+
 // SyncService_ServiceDesc is the grpc.ServiceDesc for SyncService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -402,6 +440,12 @@ var SyncService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _SyncService_StopAllSyncJobs_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSyncJobs",
+			Handler:       _SyncService_WatchSyncJobs_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "sync.proto",
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"strings"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
@@ -18,6 +19,8 @@ func NewAlbum(album entity.Album, syncInProgress bool) Album {
 		Href:           "/api/v1/albums/" + album.ID,
 		MediaCount:     album.MediaCount,
 		SyncInProgress: &syncInProgress,
+		Hidden:         album.Hidden,
+		TrashedAt:      album.TrashedAt,
 	}
 
 	_, name := path.Split(album.Path)
@@ -65,6 +68,36 @@ func NewAlbum(album entity.Album, syncInProgress bool) Album {
 	return apiAlbum
 }
 
+// NewAlbumTreeNode converts an entity.AlbumTreeNode and its children to a v1.AlbumTreeNode for API responses
+func NewAlbumTreeNode(node entity.AlbumTreeNode) AlbumTreeNode {
+	_, name := path.Split(node.Album.Path)
+
+	apiNode := AlbumTreeNode{
+		Id:                 node.Album.ID,
+		Href:               "/api/v1/albums/" + node.Album.ID,
+		Name:               name,
+		Path:               node.Album.Path,
+		Description:        node.Album.Description,
+		MediaCount:         node.MediaCount,
+		SubtreeMediaCount:  node.SubtreeMediaCount,
+		EarliestCapturedAt: node.EarliestCapturedAt,
+		LatestCapturedAt:   node.LatestCapturedAt,
+		CoverId:            node.CoverID,
+		Children:           make([]AlbumTreeNode, 0, len(node.Children)),
+	}
+
+	if node.CoverID != nil {
+		thumbnail := fmt.Sprintf("/api/v1/media/%s/thumbnail", *node.CoverID)
+		apiNode.Thumbnail = &thumbnail
+	}
+
+	for _, child := range node.Children {
+		apiNode.Children = append(apiNode.Children, NewAlbumTreeNode(child))
+	}
+
+	return apiNode
+}
+
 // NewMedia converts an entity.Media to a v1.Media for API responses
 func NewMedia(media entity.Media) Media {
 	// Convert EXIF data
@@ -76,7 +109,7 @@ func NewMedia(media entity.Media) Media {
 		})
 	}
 
-	return Media{
+	apiMedia := Media{
 		Id:         media.ID,
 		Filename:   media.Filename,
 		AlbumHref:  "/api/v1/albums/" + media.Album.ID,
@@ -86,14 +119,366 @@ func NewMedia(media entity.Media) Media {
 		Thumbnail:  "/api/v1/media/" + media.ID + "/thumbnail",
 		Href:       "/api/v1/media/" + media.ID,
 		Exif:       exifHeaders,
+		Caption:    media.Caption,
+		Camera:     media.Camera,
+		Rating:     media.Rating,
+		Favorite:   media.Favorite,
+		TrashedAt:  media.TrashedAt,
+	}
+
+	commentsHref := "/api/v1/media/" + media.ID + "/comments"
+	apiMedia.CommentsHref = &commentsHref
+
+	if len(media.Tags) > 0 {
+		tags := media.Tags
+		apiMedia.Tags = &tags
+	}
+
+	return apiMedia
+}
+
+// NewRelocateMediaResponse converts moved or copied media to a v1.RelocateMediaResponse
+func NewRelocateMediaResponse(media []entity.Media) RelocateMediaResponse {
+	apiMedia := make([]Media, 0, len(media))
+	for _, m := range media {
+		apiMedia = append(apiMedia, NewMedia(m))
+	}
+	return RelocateMediaResponse{Media: apiMedia}
+}
+
+// NewTrashResponse converts the albums and media in the trash to a v1.TrashResponse
+func NewTrashResponse(albums []entity.Album, media []entity.Media) TrashResponse {
+	apiAlbums := make([]Album, 0, len(albums))
+	for _, a := range albums {
+		apiAlbums = append(apiAlbums, NewAlbum(a, false))
+	}
+
+	apiMedia := make([]Media, 0, len(media))
+	for _, m := range media {
+		apiMedia = append(apiMedia, NewMedia(m))
+	}
+
+	return TrashResponse{Albums: apiAlbums, Media: apiMedia}
+}
+
+// Entity converts a v1.ListAlbumsParamsSortBy to the entity.AlbumSortBy of the album listing.
+func (s ListAlbumsParamsSortBy) Entity() entity.AlbumSortBy {
+	switch s {
+	case CreatedAt:
+		return entity.AlbumSortByCreatedAt
+	case LatestCapture:
+		return entity.AlbumSortByLatestCapture
+	case MediaCount:
+		return entity.AlbumSortByMediaCount
+	case Name:
+		return entity.AlbumSortByName
+	}
+	return entity.AlbumSortBy(s)
+}
+
+// NewEventSuggestion converts an entity.EventSuggestion to a v1.EventSuggestion for API responses
+func NewEventSuggestion(event entity.EventSuggestion) EventSuggestion {
+	media := make([]string, 0, len(event.MediaIDs))
+	for _, id := range event.MediaIDs {
+		media = append(media, "/api/v1/media/"+id)
+	}
+
+	apiEvent := EventSuggestion{
+		Id:    event.ID,
+		Name:  event.Name(),
+		Start: event.Start,
+		End:   event.End,
+		Count: len(event.MediaIDs),
+		Media: media,
+	}
+
+	if event.Location != nil {
+		latitude := float32(event.Location.Latitude)
+		longitude := float32(event.Location.Longitude)
+		apiEvent.Latitude = &latitude
+		apiEvent.Longitude = &longitude
+	}
+
+	return apiEvent
+}
+
+// NewAcceptEventResponse converts the album or the collection created from an event to a v1.AcceptEventResponse
+func NewAcceptEventResponse(accepted entity.AcceptedEvent) AcceptEventResponse {
+	response := AcceptEventResponse{Target: AcceptEventResponseTarget(accepted.Target)}
+
+	if accepted.Album != nil {
+		album := NewAlbum(*accepted.Album, false)
+		response.Album = &album
+	}
+
+	if accepted.Collection != nil {
+		collection := NewCollection(*accepted.Collection)
+		response.Collection = &collection
+	}
+
+	return response
+}
+
+// Entity converts the v1.ListEventSuggestionsParams to the clustering options, the defaults fill the unset ones.
+func (p ListEventSuggestionsParams) Entity() entity.EventClusterOptions {
+	return newEventClusterOptions(p.GapMinutes, p.MaxDistanceKm, p.MinMedia)
+}
+
+// ClusterOptions returns the clustering options the suggestion was listed with, the defaults fill the unset ones.
+func (r AcceptEventRequest) ClusterOptions() entity.EventClusterOptions {
+	return newEventClusterOptions(r.GapMinutes, r.MaxDistanceKm, r.MinMedia)
+}
+
+func newEventClusterOptions(gapMinutes *int, maxDistanceKm *float32, minMedia *int) entity.EventClusterOptions {
+	opts := entity.DefaultEventClusterOptions()
+	if gapMinutes != nil {
+		opts.Gap = time.Duration(*gapMinutes) * time.Minute
+	}
+	if maxDistanceKm != nil {
+		opts.MaxDistance = float64(*maxDistanceKm)
+	}
+	if minMedia != nil {
+		opts.MinMedia = *minMedia
+	}
+	return opts
+}
+
+// NewMemoriesResponse converts the memories to a v1.MemoriesResponse
+func NewMemoriesResponse(memories []entity.Memory) MemoriesResponse {
+	apiMemories := make([]Memory, 0, len(memories))
+	for _, memory := range memories {
+		media := make([]Media, 0, len(memory.Media))
+		for _, m := range memory.Media {
+			media = append(media, NewMedia(m))
+		}
+		apiMemories = append(apiMemories, Memory{Year: memory.Year, Media: media})
+	}
+	return MemoriesResponse{Memories: apiMemories}
+}
+
+// NewBucket converts an entity.Bucket to a v1.Bucket for API responses.
+// The cursor is computed by the caller because it depends on the media pagination.
+func NewBucket(bucket entity.Bucket, cursor string) Bucket {
+	apiBucket := Bucket{
+		Year:   bucket.Year,
+		Month:  bucket.Month,
+		Count:  bucket.Count,
+		Start:  bucket.Start(),
+		Cursor: cursor,
+	}
+
+	if bucket.Day != 0 {
+		day := bucket.Day
+		apiBucket.Day = &day
+	}
+
+	return apiBucket
+}
+
+// Entity converts a v1.BulkMediaRequest to an entity.BulkRequest for business logic processing.
+func (r BulkMediaRequest) Entity() entity.BulkRequest {
+	req := entity.BulkRequest{
+		Operation: entity.BulkOperation(r.Operation),
+		MediaIDs:  r.MediaIds,
+	}
+
+	if r.AlbumId != nil {
+		req.AlbumID = *r.AlbumId
+	}
+	if r.AddTags != nil {
+		req.AddTags = *r.AddTags
+	}
+	if r.RemoveTags != nil {
+		req.RemoveTags = *r.RemoveTags
+	}
+	if r.Favorite != nil {
+		req.Favorite = *r.Favorite
+	}
+	if r.ShiftSeconds != nil {
+		req.Shift = time.Duration(*r.ShiftSeconds) * time.Second
+	}
+	if r.Permission != nil {
+		req.Subject = entity.NewUserSubject(r.Permission.User)
+		req.Grant = r.Permission.Grant
+		switch r.Permission.Role {
+		case Viewer:
+			req.Relationship = entity.ViewerRelationship
+		case Editor:
+			req.Relationship = entity.EditorRelationship
+		}
+	}
+
+	return req
+}
+
+// NewBulkJob converts an entity.BulkJob to a v1.BulkJob for API responses
+func NewBulkJob(job entity.BulkJob) BulkJob {
+	results := make([]BulkItemResult, 0, len(job.Items))
+	for _, item := range job.Items {
+		result := BulkItemResult{
+			MediaId:   item.MediaID,
+			MediaHref: "/api/v1/media/" + item.MediaID,
+		}
+		if item.Err != nil {
+			errMsg := item.Err.Error()
+			result.Error = &errMsg
+		}
+		results = append(results, result)
+	}
+
+	return BulkJob{
+		Id:          job.Id.String(),
+		Href:        "/api/v1/media/bulk/" + job.Id.String(),
+		Operation:   BulkJobOperation(job.Operation),
+		Status:      BulkJobStatus(job.Status),
+		Total:       job.Total,
+		Remaining:   job.Remaining,
+		Succeeded:   job.Succeeded(),
+		Failed:      job.Failed(),
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+		Results:     results,
+	}
+}
+
+// NewComment converts an entity.Comment to a v1.Comment for API responses
+func NewComment(comment entity.Comment) Comment {
+	return Comment{
+		Id:        comment.ID,
+		Href:      "/api/v1/media/" + comment.MediaID + "/comments/" + comment.ID,
+		MediaHref: "/api/v1/media/" + comment.MediaID,
+		ParentId:  comment.ParentID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+// NewCollection converts an entity.Collection to a v1.Collection for API responses
+func NewCollection(collection entity.Collection) Collection {
+	apiCollection := Collection{
+		Id:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Href:        "/api/v1/collections/" + collection.ID,
+		MediaHref:   "/api/v1/collections/" + collection.ID + "/media",
+		MediaCount:  collection.MediaCount,
+		CreatedAt:   collection.CreatedAt,
+	}
+
+	if collection.Thumbnail != nil {
+		thumbnail := fmt.Sprintf("/api/v1/media/%s/thumbnail", *collection.Thumbnail)
+		apiCollection.Thumbnail = &thumbnail
+	}
+
+	return apiCollection
+}
+
+// NewSmartAlbum converts an entity.SmartAlbum to a v1.SmartAlbum for API responses
+func NewSmartAlbum(smartAlbum entity.SmartAlbum) SmartAlbum {
+	return SmartAlbum{
+		Id:          smartAlbum.ID,
+		Name:        smartAlbum.Name,
+		Description: smartAlbum.Description,
+		Href:        "/api/v1/smart-albums/" + smartAlbum.ID,
+		MediaHref:   "/api/v1/smart-albums/" + smartAlbum.ID + "/media",
+		Filter:      NewSmartAlbumFilter(smartAlbum.Filter),
+		CreatedAt:   smartAlbum.CreatedAt,
+	}
+}
+
+// NewSmartAlbumFilter converts an entity.SmartAlbumFilter to a v1.SmartAlbumFilter for API responses
+func NewSmartAlbumFilter(filter entity.SmartAlbumFilter) SmartAlbumFilter {
+	apiFilter := SmartAlbumFilter{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		AlbumId:   filter.AlbumID,
+		Camera:    filter.Camera,
+		MinRating: filter.MinRating,
+	}
+
+	if filter.MediaType != nil {
+		mediaType := SmartAlbumFilterType(*filter.MediaType)
+		apiFilter.Type = &mediaType
+	}
+
+	if len(filter.Tags) > 0 {
+		tags := filter.Tags
+		apiFilter.Tags = &tags
+	}
+
+	if filter.Area != nil {
+		apiFilter.Area = &BoundingBox{
+			MinLatitude:  filter.Area.MinLatitude,
+			MinLongitude: filter.Area.MinLongitude,
+			MaxLatitude:  filter.Area.MaxLatitude,
+			MaxLongitude: filter.Area.MaxLongitude,
+		}
+	}
+
+	return apiFilter
+}
+
+// Entity converts a v1.SmartAlbumFilter to an entity.SmartAlbumFilter for business logic processing.
+func (f SmartAlbumFilter) Entity() entity.SmartAlbumFilter {
+	filter := entity.SmartAlbumFilter{
+		StartDate: f.StartDate,
+		EndDate:   f.EndDate,
+		AlbumID:   f.AlbumId,
+		Camera:    f.Camera,
+		MinRating: f.MinRating,
 	}
+
+	if f.Type != nil {
+		mediaType := string(*f.Type)
+		filter.MediaType = &mediaType
+	}
+
+	if f.Tags != nil {
+		filter.Tags = entity.NormalizeTags(*f.Tags)
+	}
+
+	if f.Area != nil {
+		filter.Area = &entity.BoundingBox{
+			MinLatitude:  f.Area.MinLatitude,
+			MinLongitude: f.Area.MinLongitude,
+			MaxLatitude:  f.Area.MaxLatitude,
+			MaxLongitude: f.Area.MaxLongitude,
+		}
+	}
+
+	return filter
+}
+
+// NewSearchResult converts an entity.SearchResult to a v1.SearchResult for API responses.
+// The sync status of the albums is not reported in search results.
+func NewSearchResult(result entity.SearchResult) SearchResult {
+	apiResult := SearchResult{
+		Kind: SearchResultKind(result.Kind),
+		Rank: result.Rank,
+	}
+
+	if result.Album != nil {
+		album := NewAlbum(*result.Album, false)
+		album.SyncInProgress = nil
+		apiResult.Album = &album
+	}
+
+	if result.Media != nil {
+		media := NewMedia(*result.Media)
+		apiResult.Media = &media
+	}
+
+	return apiResult
 }
 
 // Entity converts a v1.CreateAlbumRequest to an entity.Album for business logic processing.
 // This method transforms the HTTP request data into the internal domain model representation.
 func (r CreateAlbumRequest) Entity() entity.Album {
 	album := entity.Album{
-		ID:          entity.GenerateId(r.Name),
+		ID:          entity.NewId(),
 		Path:        r.Name,
 		ParentId:    r.ParentId,
 		Description: r.Description,
@@ -110,6 +495,42 @@ func (r CreateAlbumRequest) Entity() entity.Album {
 func (r UpdateAlbumRequest) ApplyTo(album *entity.Album) {
 	album.Description = r.Description
 	album.Thumbnail = r.Thumbnail
+	if r.Hidden != nil {
+		album.Hidden = *r.Hidden
+	}
+}
+
+// Entity converts a v1.CreateCollectionRequest to an entity.Collection for business logic processing.
+func (r CreateCollectionRequest) Entity() entity.Collection {
+	collection := entity.NewCollection(r.Name)
+	collection.Description = r.Description
+	return collection
+}
+
+// ApplyTo applies the updates of a v1.UpdateCollectionRequest to an existing collection entity.
+// A missing thumbnail keeps the cover of the collection, a null thumbnail removes it.
+func (r UpdateCollectionRequest) ApplyTo(collection *entity.Collection) {
+	collection.Name = r.Name
+	collection.Description = r.Description
+	if r.Thumbnail.IsNull() {
+		collection.Thumbnail = nil
+	} else if thumbnail, err := r.Thumbnail.Get(); err == nil {
+		collection.Thumbnail = &thumbnail
+	}
+}
+
+// Entity converts a v1.CreateSmartAlbumRequest to an entity.SmartAlbum for business logic processing.
+func (r CreateSmartAlbumRequest) Entity() entity.SmartAlbum {
+	smartAlbum := entity.NewSmartAlbum(r.Name, r.Filter.Entity())
+	smartAlbum.Description = r.Description
+	return smartAlbum
+}
+
+// ApplyTo applies the updates of a v1.UpdateSmartAlbumRequest to an existing smart album entity.
+func (r UpdateSmartAlbumRequest) ApplyTo(smartAlbum *entity.SmartAlbum) {
+	smartAlbum.Name = r.Name
+	smartAlbum.Description = r.Description
+	smartAlbum.Filter = r.Filter.Entity()
 }
 
 // Entity converts a v1.UpdateMediaRequest to updates for an entity.Media.
@@ -126,6 +547,34 @@ func (r UpdateMediaRequest) ApplyTo(media *entity.Media) {
 			media.Exif[exif.Key] = exif.Value
 		}
 	}
+	if r.Tags != nil {
+		media.Tags = entity.NormalizeTags(*r.Tags)
+	}
+	if r.Rating != nil {
+		media.Rating = r.Rating
+	}
+	if r.Favorite != nil {
+		media.Favorite = *r.Favorite
+	}
+	if r.Caption != nil {
+		media.Caption = nil
+		if caption := strings.TrimSpace(*r.Caption); caption != "" {
+			media.Caption = &caption
+		}
+	}
+}
+
+// Entity converts a v1.CreateCommentRequest to an entity.Comment on the media.
+// The author is set by the service from the authenticated user.
+func (r CreateCommentRequest) Entity(mediaID string) entity.Comment {
+	comment := entity.NewComment(mediaID, "", r.Body)
+	comment.ParentID = r.ParentId
+	return comment
+}
+
+// ApplyTo applies the updates of a v1.UpdateCommentRequest to an existing comment entity.
+func (r UpdateCommentRequest) ApplyTo(comment *entity.Comment) {
+	comment.Body = r.Body
 }
 
 // ToMediaEntity converts upload request data to an entity.Media for business logic processing.
@@ -144,3 +593,85 @@ func ToMediaEntity(filename, albumId string, fileContent io.Reader, album entity
 
 	return media
 }
+
+// NewSyncReport converts an entity.SyncReport to a v1.SyncReport for API responses
+func NewSyncReport(report entity.SyncReport) SyncReport {
+	moves := make([]SyncMove, 0, len(report.MediaToMove))
+	for _, move := range report.MediaToMove {
+		moves = append(moves, SyncMove{From: move.From, To: move.To})
+	}
+
+	return SyncReport{
+		Path:             report.Path,
+		AlbumsToCreate:   report.AlbumsToCreate,
+		AlbumsToRemove:   report.AlbumsToRemove,
+		MediaToAdd:       report.MediaToAdd,
+		MediaToUpdate:    report.MediaToUpdate,
+		MediaToMove:      moves,
+		MediaToRemove:    report.MediaToRemove,
+		UnreadableItems:  report.UnreadableItems,
+		UnsupportedFiles: report.UnsupportedFiles,
+	}
+}
+
+// NewSyncJob converts an entity.JobProgress to a v1.SyncJob for API responses
+func NewSyncJob(job entity.JobProgress) SyncJob {
+	results := make([]SyncTaskResult, 0, len(job.Results))
+	for _, result := range job.Results {
+		results = append(results, NewSyncTaskResult(result))
+	}
+
+	syncJob := SyncJob{
+		Id:          job.Id.String(),
+		Path:        job.Path,
+		Status:      SyncJobStatus(job.Status),
+		Total:       job.Total,
+		Remaining:   job.Remaining,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+		Results:     results,
+	}
+
+	if job.Reason != "" {
+		syncJob.Reason = &job.Reason
+	}
+
+	if parentID, ok := job.Metadata["parentId"]; ok {
+		syncJob.ParentId = &parentID
+	}
+
+	return syncJob
+}
+
+// NewSyncTaskResult converts an entity.JobResult to a v1.SyncTaskResult for API responses
+func NewSyncTaskResult(result entity.JobResult) SyncTaskResult {
+	taskResult := SyncTaskResult{
+		Result:      result.Result,
+		StartedAt:   result.StartedAt,
+		CompletedAt: result.CompletedAt,
+	}
+
+	if result.Err != nil {
+		errMsg := result.Err.Error()
+		taskResult.Error = &errMsg
+	}
+
+	return taskResult
+}
+
+// NewSyncJobEvent converts an entity.JobEvent to a v1.SyncJobEvent for API responses
+func NewSyncJobEvent(event entity.JobEvent) SyncJobEvent {
+	syncJobEvent := SyncJobEvent{
+		Type: SyncJobEventType(event.Type),
+		Job:  NewSyncJob(event.Job),
+		At:   event.At,
+	}
+
+	if event.Result != nil {
+		task := NewSyncTaskResult(*event.Result)
+		syncJobEvent.Task = &task
+	}
+
+	return syncJobEvent
+}
@@ -19,6 +19,9 @@ type ServerInterface interface {
 	// Create a new album
 	// (POST /albums)
 	CreateAlbum(c *gin.Context)
+	// Get the album tree
+	// (GET /albums/tree)
+	GetAlbumTree(c *gin.Context, params GetAlbumTreeParams)
 	// Delete album by ID
 	// (DELETE /albums/{id})
 	DeleteAlbum(c *gin.Context, id string)
@@ -28,12 +31,63 @@ type ServerInterface interface {
 	// Update album by ID
 	// (PUT /albums/{id})
 	UpdateAlbum(c *gin.Context, id string)
+	// Suggest events
+	// (GET /albums/{id}/events)
+	ListEventSuggestions(c *gin.Context, id string, params ListEventSuggestionsParams)
+	// Accept an event suggestion
+	// (POST /albums/{id}/events/{eventId}/accept)
+	AcceptEventSuggestion(c *gin.Context, id string, eventId string)
+	// Move album
+	// (POST /albums/{id}/move)
+	MoveAlbum(c *gin.Context, id string)
+	// Rename album
+	// (POST /albums/{id}/rename)
+	RenameAlbum(c *gin.Context, id string)
+	// List all collections
+	// (GET /collections)
+	ListCollections(c *gin.Context, params ListCollectionsParams)
+	// Create a new collection
+	// (POST /collections)
+	CreateCollection(c *gin.Context)
+	// Delete collection by ID
+	// (DELETE /collections/{id})
+	DeleteCollection(c *gin.Context, id string)
+	// Get collection by ID
+	// (GET /collections/{id})
+	GetCollection(c *gin.Context, id string)
+	// Update collection by ID
+	// (PUT /collections/{id})
+	UpdateCollection(c *gin.Context, id string)
+	// List collection media
+	// (GET /collections/{id}/media)
+	ListCollectionMedia(c *gin.Context, id string, params ListCollectionMediaParams)
+	// Add media to collection
+	// (POST /collections/{id}/media)
+	AddCollectionMedia(c *gin.Context, id string)
+	// Reorder collection media
+	// (PUT /collections/{id}/media)
+	ReorderCollectionMedia(c *gin.Context, id string)
+	// Remove media from collection
+	// (DELETE /collections/{id}/media/{mediaId})
+	RemoveCollectionMedia(c *gin.Context, id string, mediaId string)
 	// List all media
 	// (GET /media)
 	ListMedia(c *gin.Context, params ListMediaParams)
 	// Upload new media
 	// (POST /media)
 	UploadMedia(c *gin.Context)
+	// Run an operation over many media
+	// (POST /media/bulk)
+	StartBulkMediaJob(c *gin.Context)
+	// Get a bulk job
+	// (GET /media/bulk/{jobId})
+	GetBulkMediaJob(c *gin.Context, jobId string)
+	// Copy media
+	// (POST /media/copy)
+	CopyMedia(c *gin.Context)
+	// Move media
+	// (POST /media/move)
+	MoveMedia(c *gin.Context)
 	// Delete media by ID
 	// (DELETE /media/{id})
 	DeleteMedia(c *gin.Context, id string)
@@ -43,15 +97,87 @@ type ServerInterface interface {
 	// Update media by ID
 	// (PUT /media/{id})
 	UpdateMedia(c *gin.Context, id string)
+	// List media comments
+	// (GET /media/{id}/comments)
+	ListMediaComments(c *gin.Context, id string)
+	// Comment a media
+	// (POST /media/{id}/comments)
+	CreateMediaComment(c *gin.Context, id string)
+	// Delete a comment
+	// (DELETE /media/{id}/comments/{commentId})
+	DeleteMediaComment(c *gin.Context, id string, commentId string)
+	// Edit a comment
+	// (PUT /media/{id}/comments/{commentId})
+	UpdateMediaComment(c *gin.Context, id string, commentId string)
 	// Get media content
 	// (GET /media/{id}/content)
 	GetMediaContent(c *gin.Context, id string)
 	// Get media thumbnail
 	// (GET /media/{id}/thumbnail)
 	GetMediaThumbnail(c *gin.Context, id string)
+	// Get the memories of the day
+	// (GET /memories)
+	GetMemories(c *gin.Context, params GetMemoriesParams)
+	// Search albums and media
+	// (GET /search)
+	Search(c *gin.Context, params SearchParams)
+	// List all smart albums
+	// (GET /smart-albums)
+	ListSmartAlbums(c *gin.Context, params ListSmartAlbumsParams)
+	// Create a new smart album
+	// (POST /smart-albums)
+	CreateSmartAlbum(c *gin.Context)
+	// Delete smart album by ID
+	// (DELETE /smart-albums/{id})
+	DeleteSmartAlbum(c *gin.Context, id string)
+	// Get smart album by ID
+	// (GET /smart-albums/{id})
+	GetSmartAlbum(c *gin.Context, id string)
+	// Update smart album by ID
+	// (PUT /smart-albums/{id})
+	UpdateSmartAlbum(c *gin.Context, id string)
+	// List smart album media
+	// (GET /smart-albums/{id}/media)
+	ListSmartAlbumMedia(c *gin.Context, id string, params ListSmartAlbumMediaParams)
 	// Get application statistics
 	// (GET /stats)
 	GetStats(c *gin.Context)
+	// Start a sync
+	// (POST /sync)
+	StartSync(c *gin.Context)
+	// Preview a sync
+	// (POST /sync/dry-run)
+	DryRunSync(c *gin.Context)
+	// Watch the progress of the sync jobs
+	// (GET /sync/events)
+	WatchSyncJobs(c *gin.Context, params WatchSyncJobsParams)
+	// Clear the finished sync jobs
+	// (DELETE /sync/jobs)
+	ClearFinishedSyncJobs(c *gin.Context)
+	// List the sync jobs
+	// (GET /sync/jobs)
+	ListSyncJobs(c *gin.Context, params ListSyncJobsParams)
+	// Stop all the sync jobs
+	// (POST /sync/jobs/stop)
+	StopAllSyncJobs(c *gin.Context)
+	// Get a sync job
+	// (GET /sync/jobs/{id})
+	GetSyncJob(c *gin.Context, id string)
+	// Stop, pause or resume a sync job
+	// (POST /sync/jobs/{id}/action)
+	ActionSyncJob(c *gin.Context, id string)
+	// Get the media timeline
+	// (GET /timeline)
+	GetTimeline(c *gin.Context, params GetTimelineParams)
+	// List the trash
+	// (GET /trash)
+	ListTrash(c *gin.Context)
+	// Restore an album
+	// (POST /trash/albums/{id}/restore)
+	RestoreAlbum(c *gin.Context, id string)
+	// Restore a media
+	// (POST /trash/media/{id}/restore)
+	RestoreMedia(c *gin.Context, id string)
 	// Get current logged user profile
 	// (GET /user)
 	GetCurrentUser(c *gin.Context)
@@ -90,6 +216,30 @@ func (siw *ServerInterfaceWrapper) ListAlbums(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", c.Request.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortBy: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", c.Request.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortOrder: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "withParent" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "withParent", c.Request.URL.Query(), &params.WithParent)
@@ -98,6 +248,22 @@ func (siw *ServerInterfaceWrapper) ListAlbums(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "smartAlbumsLimit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "smartAlbumsLimit", c.Request.URL.Query(), &params.SmartAlbumsLimit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter smartAlbumsLimit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "smartAlbumsOffset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "smartAlbumsOffset", c.Request.URL.Query(), &params.SmartAlbumsOffset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter smartAlbumsOffset: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -121,6 +287,40 @@ func (siw *ServerInterfaceWrapper) CreateAlbum(c *gin.Context) {
 	siw.Handler.CreateAlbum(c)
 }
 
+// GetAlbumTree operation middleware
+func (siw *ServerInterfaceWrapper) GetAlbumTree(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAlbumTreeParams
+
+	// ------------- Optional query parameter "rootId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rootId", c.Request.URL.Query(), &params.RootId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rootId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "depth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "depth", c.Request.URL.Query(), &params.Depth)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter depth: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAlbumTree(c, params)
+}
+
 // DeleteAlbum operation middleware
 func (siw *ServerInterfaceWrapper) DeleteAlbum(c *gin.Context) {
 
@@ -193,83 +393,159 @@ func (siw *ServerInterfaceWrapper) UpdateAlbum(c *gin.Context) {
 	siw.Handler.UpdateAlbum(c, id)
 }
 
-// ListMedia operation middleware
-func (siw *ServerInterfaceWrapper) ListMedia(c *gin.Context) {
+// ListEventSuggestions operation middleware
+func (siw *ServerInterfaceWrapper) ListEventSuggestions(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListMediaParams
+	var params ListEventSuggestionsParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "gapMinutes" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "gapMinutes", c.Request.URL.Query(), &params.GapMinutes)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter gapMinutes: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "cursor" -------------
+	// ------------- Optional query parameter "maxDistanceKm" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	err = runtime.BindQueryParameter("form", true, false, "maxDistanceKm", c.Request.URL.Query(), &params.MaxDistanceKm)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxDistanceKm: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "direction" -------------
+	// ------------- Optional query parameter "minMedia" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	err = runtime.BindQueryParameter("form", true, false, "minMedia", c.Request.URL.Query(), &params.MinMedia)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minMedia: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "album_id" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "album_id", c.Request.URL.Query(), &params.AlbumId)
+	siw.Handler.ListEventSuggestions(c, id, params)
+}
+
+// AcceptEventSuggestion operation middleware
+func (siw *ServerInterfaceWrapper) AcceptEventSuggestion(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter album_id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "type" -------------
+	// ------------- Path parameter "eventId" -------------
+	var eventId string
 
-	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", c.Param("eventId"), &eventId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter eventId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "startDate" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	siw.Handler.AcceptEventSuggestion(c, id, eventId)
+}
+
+// MoveAlbum operation middleware
+func (siw *ServerInterfaceWrapper) MoveAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "endDate" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	siw.Handler.MoveAlbum(c, id)
+}
+
+// RenameAlbum operation middleware
+func (siw *ServerInterfaceWrapper) RenameAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "sortBy" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "sortBy", c.Request.URL.Query(), &params.SortBy)
+	siw.Handler.RenameAlbum(c, id)
+}
+
+// ListCollections operation middleware
+func (siw *ServerInterfaceWrapper) ListCollections(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCollectionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortBy: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "sortOrder" -------------
+	// ------------- Optional query parameter "offset" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sortOrder", c.Request.URL.Query(), &params.SortOrder)
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortOrder: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -280,11 +556,11 @@ func (siw *ServerInterfaceWrapper) ListMedia(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListMedia(c, params)
+	siw.Handler.ListCollections(c, params)
 }
 
-// UploadMedia operation middleware
-func (siw *ServerInterfaceWrapper) UploadMedia(c *gin.Context) {
+// CreateCollection operation middleware
+func (siw *ServerInterfaceWrapper) CreateCollection(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -293,11 +569,11 @@ func (siw *ServerInterfaceWrapper) UploadMedia(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UploadMedia(c)
+	siw.Handler.CreateCollection(c)
 }
 
-// DeleteMedia operation middleware
-func (siw *ServerInterfaceWrapper) DeleteMedia(c *gin.Context) {
+// DeleteCollection operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCollection(c *gin.Context) {
 
 	var err error
 
@@ -317,11 +593,11 @@ func (siw *ServerInterfaceWrapper) DeleteMedia(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteMedia(c, id)
+	siw.Handler.DeleteCollection(c, id)
 }
 
-// GetMedia operation middleware
-func (siw *ServerInterfaceWrapper) GetMedia(c *gin.Context) {
+// GetCollection operation middleware
+func (siw *ServerInterfaceWrapper) GetCollection(c *gin.Context) {
 
 	var err error
 
@@ -341,11 +617,11 @@ func (siw *ServerInterfaceWrapper) GetMedia(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetMedia(c, id)
+	siw.Handler.GetCollection(c, id)
 }
 
-// UpdateMedia operation middleware
-func (siw *ServerInterfaceWrapper) UpdateMedia(c *gin.Context) {
+// UpdateCollection operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCollection(c *gin.Context) {
 
 	var err error
 
@@ -365,11 +641,54 @@ func (siw *ServerInterfaceWrapper) UpdateMedia(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateMedia(c, id)
+	siw.Handler.UpdateCollection(c, id)
 }
 
-// GetMediaContent operation middleware
-func (siw *ServerInterfaceWrapper) GetMediaContent(c *gin.Context) {
+// ListCollectionMedia operation middleware
+func (siw *ServerInterfaceWrapper) ListCollectionMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCollectionMediaParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCollectionMedia(c, id, params)
+}
+
+// AddCollectionMedia operation middleware
+func (siw *ServerInterfaceWrapper) AddCollectionMedia(c *gin.Context) {
 
 	var err error
 
@@ -389,11 +708,11 @@ func (siw *ServerInterfaceWrapper) GetMediaContent(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetMediaContent(c, id)
+	siw.Handler.AddCollectionMedia(c, id)
 }
 
-// GetMediaThumbnail operation middleware
-func (siw *ServerInterfaceWrapper) GetMediaThumbnail(c *gin.Context) {
+// ReorderCollectionMedia operation middleware
+func (siw *ServerInterfaceWrapper) ReorderCollectionMedia(c *gin.Context) {
 
 	var err error
 
@@ -413,11 +732,31 @@ func (siw *ServerInterfaceWrapper) GetMediaThumbnail(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetMediaThumbnail(c, id)
+	siw.Handler.ReorderCollectionMedia(c, id)
 }
 
-// GetStats operation middleware
-func (siw *ServerInterfaceWrapper) GetStats(c *gin.Context) {
+// RemoveCollectionMedia operation middleware
+func (siw *ServerInterfaceWrapper) RemoveCollectionMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "mediaId" -------------
+	var mediaId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaId", c.Param("mediaId"), &mediaId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mediaId: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -426,7 +765,984 @@ func (siw *ServerInterfaceWrapper) GetStats(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetStats(c)
+	siw.Handler.RemoveCollectionMedia(c, id, mediaId)
+}
+
+// ListMedia operation middleware
+func (siw *ServerInterfaceWrapper) ListMedia(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMediaParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "album_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "album_id", c.Request.URL.Query(), &params.AlbumId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter album_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", c.Request.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortBy: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", c.Request.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sortOrder: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListMedia(c, params)
+}
+
+// UploadMedia operation middleware
+func (siw *ServerInterfaceWrapper) UploadMedia(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UploadMedia(c)
+}
+
+// StartBulkMediaJob operation middleware
+func (siw *ServerInterfaceWrapper) StartBulkMediaJob(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartBulkMediaJob(c)
+}
+
+// GetBulkMediaJob operation middleware
+func (siw *ServerInterfaceWrapper) GetBulkMediaJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetBulkMediaJob(c, jobId)
+}
+
+// CopyMedia operation middleware
+func (siw *ServerInterfaceWrapper) CopyMedia(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CopyMedia(c)
+}
+
+// MoveMedia operation middleware
+func (siw *ServerInterfaceWrapper) MoveMedia(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.MoveMedia(c)
+}
+
+// DeleteMedia operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteMedia(c, id)
+}
+
+// GetMedia operation middleware
+func (siw *ServerInterfaceWrapper) GetMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMedia(c, id)
+}
+
+// UpdateMedia operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateMedia(c, id)
+}
+
+// ListMediaComments operation middleware
+func (siw *ServerInterfaceWrapper) ListMediaComments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListMediaComments(c, id)
+}
+
+// CreateMediaComment operation middleware
+func (siw *ServerInterfaceWrapper) CreateMediaComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateMediaComment(c, id)
+}
+
+// DeleteMediaComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMediaComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", c.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter commentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteMediaComment(c, id, commentId)
+}
+
+// UpdateMediaComment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMediaComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", c.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter commentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateMediaComment(c, id, commentId)
+}
+
+// GetMediaContent operation middleware
+func (siw *ServerInterfaceWrapper) GetMediaContent(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMediaContent(c, id)
+}
+
+// GetMediaThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) GetMediaThumbnail(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMediaThumbnail(c, id)
+}
+
+// GetMemories operation middleware
+func (siw *ServerInterfaceWrapper) GetMemories(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMemoriesParams
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", c.Request.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "favorite" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "favorite", c.Request.URL.Query(), &params.Favorite)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter favorite: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minRating" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minRating", c.Request.URL.Query(), &params.MinRating)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minRating: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMemories(c, params)
+}
+
+// Search operation middleware
+func (siw *ServerInterfaceWrapper) Search(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := c.Query("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument q is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Search(c, params)
+}
+
+// ListSmartAlbums operation middleware
+func (siw *ServerInterfaceWrapper) ListSmartAlbums(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSmartAlbumsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSmartAlbums(c, params)
+}
+
+// CreateSmartAlbum operation middleware
+func (siw *ServerInterfaceWrapper) CreateSmartAlbum(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateSmartAlbum(c)
+}
+
+// DeleteSmartAlbum operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSmartAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSmartAlbum(c, id)
+}
+
+// GetSmartAlbum operation middleware
+func (siw *ServerInterfaceWrapper) GetSmartAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSmartAlbum(c, id)
+}
+
+// UpdateSmartAlbum operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSmartAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateSmartAlbum(c, id)
+}
+
+// ListSmartAlbumMedia operation middleware
+func (siw *ServerInterfaceWrapper) ListSmartAlbumMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSmartAlbumMediaParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSmartAlbumMedia(c, id, params)
+}
+
+// GetStats operation middleware
+func (siw *ServerInterfaceWrapper) GetStats(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetStats(c)
+}
+
+// StartSync operation middleware
+func (siw *ServerInterfaceWrapper) StartSync(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartSync(c)
+}
+
+// DryRunSync operation middleware
+func (siw *ServerInterfaceWrapper) DryRunSync(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DryRunSync(c)
+}
+
+// WatchSyncJobs operation middleware
+func (siw *ServerInterfaceWrapper) WatchSyncJobs(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchSyncJobsParams
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", c.Request.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WatchSyncJobs(c, params)
+}
+
+// ClearFinishedSyncJobs operation middleware
+func (siw *ServerInterfaceWrapper) ClearFinishedSyncJobs(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ClearFinishedSyncJobs(c)
+}
+
+// ListSyncJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListSyncJobs(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSyncJobsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSyncJobs(c, params)
+}
+
+// StopAllSyncJobs operation middleware
+func (siw *ServerInterfaceWrapper) StopAllSyncJobs(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StopAllSyncJobs(c)
+}
+
+// GetSyncJob operation middleware
+func (siw *ServerInterfaceWrapper) GetSyncJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSyncJob(c, id)
+}
+
+// ActionSyncJob operation middleware
+func (siw *ServerInterfaceWrapper) ActionSyncJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ActionSyncJob(c, id)
+}
+
+// GetTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetTimeline(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimelineParams
+
+	// ------------- Optional query parameter "album_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "album_id", c.Request.URL.Query(), &params.AlbumId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter album_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "granularity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "granularity", c.Request.URL.Query(), &params.Granularity)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter granularity: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", c.Request.URL.Query(), &params.StartDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter startDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", c.Request.URL.Query(), &params.EndDate)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter endDate: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimeline(c, params)
+}
+
+// ListTrash operation middleware
+func (siw *ServerInterfaceWrapper) ListTrash(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTrash(c)
+}
+
+// RestoreAlbum operation middleware
+func (siw *ServerInterfaceWrapper) RestoreAlbum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RestoreAlbum(c, id)
+}
+
+// RestoreMedia operation middleware
+func (siw *ServerInterfaceWrapper) RestoreMedia(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RestoreMedia(c, id)
 }
 
 // GetCurrentUser operation middleware
@@ -471,16 +1787,58 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.GET(options.BaseURL+"/albums", wrapper.ListAlbums)
 	router.POST(options.BaseURL+"/albums", wrapper.CreateAlbum)
+	router.GET(options.BaseURL+"/albums/tree", wrapper.GetAlbumTree)
 	router.DELETE(options.BaseURL+"/albums/:id", wrapper.DeleteAlbum)
 	router.GET(options.BaseURL+"/albums/:id", wrapper.GetAlbum)
 	router.PUT(options.BaseURL+"/albums/:id", wrapper.UpdateAlbum)
+	router.GET(options.BaseURL+"/albums/:id/events", wrapper.ListEventSuggestions)
+	router.POST(options.BaseURL+"/albums/:id/events/:eventId/accept", wrapper.AcceptEventSuggestion)
+	router.POST(options.BaseURL+"/albums/:id/move", wrapper.MoveAlbum)
+	router.POST(options.BaseURL+"/albums/:id/rename", wrapper.RenameAlbum)
+	router.GET(options.BaseURL+"/collections", wrapper.ListCollections)
+	router.POST(options.BaseURL+"/collections", wrapper.CreateCollection)
+	router.DELETE(options.BaseURL+"/collections/:id", wrapper.DeleteCollection)
+	router.GET(options.BaseURL+"/collections/:id", wrapper.GetCollection)
+	router.PUT(options.BaseURL+"/collections/:id", wrapper.UpdateCollection)
+	router.GET(options.BaseURL+"/collections/:id/media", wrapper.ListCollectionMedia)
+	router.POST(options.BaseURL+"/collections/:id/media", wrapper.AddCollectionMedia)
+	router.PUT(options.BaseURL+"/collections/:id/media", wrapper.ReorderCollectionMedia)
+	router.DELETE(options.BaseURL+"/collections/:id/media/:mediaId", wrapper.RemoveCollectionMedia)
 	router.GET(options.BaseURL+"/media", wrapper.ListMedia)
 	router.POST(options.BaseURL+"/media", wrapper.UploadMedia)
+	router.POST(options.BaseURL+"/media/bulk", wrapper.StartBulkMediaJob)
+	router.GET(options.BaseURL+"/media/bulk/:jobId", wrapper.GetBulkMediaJob)
+	router.POST(options.BaseURL+"/media/copy", wrapper.CopyMedia)
+	router.POST(options.BaseURL+"/media/move", wrapper.MoveMedia)
 	router.DELETE(options.BaseURL+"/media/:id", wrapper.DeleteMedia)
 	router.GET(options.BaseURL+"/media/:id", wrapper.GetMedia)
 	router.PUT(options.BaseURL+"/media/:id", wrapper.UpdateMedia)
+	router.GET(options.BaseURL+"/media/:id/comments", wrapper.ListMediaComments)
+	router.POST(options.BaseURL+"/media/:id/comments", wrapper.CreateMediaComment)
+	router.DELETE(options.BaseURL+"/media/:id/comments/:commentId", wrapper.DeleteMediaComment)
+	router.PUT(options.BaseURL+"/media/:id/comments/:commentId", wrapper.UpdateMediaComment)
 	router.GET(options.BaseURL+"/media/:id/content", wrapper.GetMediaContent)
 	router.GET(options.BaseURL+"/media/:id/thumbnail", wrapper.GetMediaThumbnail)
+	router.GET(options.BaseURL+"/memories", wrapper.GetMemories)
+	router.GET(options.BaseURL+"/search", wrapper.Search)
+	router.GET(options.BaseURL+"/smart-albums", wrapper.ListSmartAlbums)
+	router.POST(options.BaseURL+"/smart-albums", wrapper.CreateSmartAlbum)
+	router.DELETE(options.BaseURL+"/smart-albums/:id", wrapper.DeleteSmartAlbum)
+	router.GET(options.BaseURL+"/smart-albums/:id", wrapper.GetSmartAlbum)
+	router.PUT(options.BaseURL+"/smart-albums/:id", wrapper.UpdateSmartAlbum)
+	router.GET(options.BaseURL+"/smart-albums/:id/media", wrapper.ListSmartAlbumMedia)
 	router.GET(options.BaseURL+"/stats", wrapper.GetStats)
+	router.POST(options.BaseURL+"/sync", wrapper.StartSync)
+	router.POST(options.BaseURL+"/sync/dry-run", wrapper.DryRunSync)
+	router.GET(options.BaseURL+"/sync/events", wrapper.WatchSyncJobs)
+	router.DELETE(options.BaseURL+"/sync/jobs", wrapper.ClearFinishedSyncJobs)
+	router.GET(options.BaseURL+"/sync/jobs", wrapper.ListSyncJobs)
+	router.POST(options.BaseURL+"/sync/jobs/stop", wrapper.StopAllSyncJobs)
+	router.GET(options.BaseURL+"/sync/jobs/:id", wrapper.GetSyncJob)
+	router.POST(options.BaseURL+"/sync/jobs/:id/action", wrapper.ActionSyncJob)
+	router.GET(options.BaseURL+"/timeline", wrapper.GetTimeline)
+	router.GET(options.BaseURL+"/trash", wrapper.ListTrash)
+	router.POST(options.BaseURL+"/trash/albums/:id/restore", wrapper.RestoreAlbum)
+	router.POST(options.BaseURL+"/trash/media/:id/restore", wrapper.RestoreMedia)
 	router.GET(options.BaseURL+"/user", wrapper.GetCurrentUser)
 }
@@ -6,9 +6,59 @@ package http
 import (
 	"time"
 
+	"github.com/oapi-codegen/nullable"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AcceptEventRequestTarget.
+const (
+	AcceptEventRequestTargetAlbum      AcceptEventRequestTarget = "album"
+	AcceptEventRequestTargetCollection AcceptEventRequestTarget = "collection"
+)
+
+// Defines values for AcceptEventResponseTarget.
+const (
+	AcceptEventResponseTargetAlbum      AcceptEventResponseTarget = "album"
+	AcceptEventResponseTargetCollection AcceptEventResponseTarget = "collection"
+)
+
+// Defines values for BulkJobOperation.
+const (
+	BulkJobOperationDateShift  BulkJobOperation = "date_shift"
+	BulkJobOperationDelete     BulkJobOperation = "delete"
+	BulkJobOperationFavorite   BulkJobOperation = "favorite"
+	BulkJobOperationMove       BulkJobOperation = "move"
+	BulkJobOperationPermission BulkJobOperation = "permission"
+	BulkJobOperationTag        BulkJobOperation = "tag"
+)
+
+// Defines values for BulkJobStatus.
+const (
+	BulkJobStatusCompleted BulkJobStatus = "completed"
+	BulkJobStatusFailed    BulkJobStatus = "failed"
+	BulkJobStatusPaused    BulkJobStatus = "paused"
+	BulkJobStatusPending   BulkJobStatus = "pending"
+	BulkJobStatusRunning   BulkJobStatus = "running"
+	BulkJobStatusStopped   BulkJobStatus = "stopped"
+	BulkJobStatusStopping  BulkJobStatus = "stopping"
+)
+
+// Defines values for BulkMediaRequestOperation.
+const (
+	BulkMediaRequestOperationDateShift  BulkMediaRequestOperation = "date_shift"
+	BulkMediaRequestOperationDelete     BulkMediaRequestOperation = "delete"
+	BulkMediaRequestOperationFavorite   BulkMediaRequestOperation = "favorite"
+	BulkMediaRequestOperationMove       BulkMediaRequestOperation = "move"
+	BulkMediaRequestOperationPermission BulkMediaRequestOperation = "permission"
+	BulkMediaRequestOperationTag        BulkMediaRequestOperation = "tag"
+)
+
+// Defines values for BulkPermissionRole.
+const (
+	Editor BulkPermissionRole = "editor"
+	Viewer BulkPermissionRole = "viewer"
+)
+
 // Defines values for PermissionsCanCreateAlbums.
 const (
 	PermissionsCanCreateAlbumsAllowed PermissionsCanCreateAlbums = "allowed"
@@ -21,16 +71,69 @@ const (
 	PermissionsCanSyncDenied  PermissionsCanSync = "denied"
 )
 
+// Defines values for SearchResultKind.
+const (
+	SearchResultKindAlbum SearchResultKind = "album"
+	SearchResultKindMedia SearchResultKind = "media"
+)
+
+// Defines values for SmartAlbumFilterType.
+const (
+	SmartAlbumFilterTypePhoto SmartAlbumFilterType = "photo"
+	SmartAlbumFilterTypeVideo SmartAlbumFilterType = "video"
+)
+
+// Defines values for SyncJobStatus.
+const (
+	SyncJobStatusCompleted   SyncJobStatus = "completed"
+	SyncJobStatusFailed      SyncJobStatus = "failed"
+	SyncJobStatusInterrupted SyncJobStatus = "interrupted"
+	SyncJobStatusPaused      SyncJobStatus = "paused"
+	SyncJobStatusPending     SyncJobStatus = "pending"
+	SyncJobStatusRunning     SyncJobStatus = "running"
+	SyncJobStatusStopped     SyncJobStatus = "stopped"
+	SyncJobStatusStopping    SyncJobStatus = "stopping"
+)
+
+// Defines values for SyncJobActionRequestAction.
+const (
+	Pause  SyncJobActionRequestAction = "pause"
+	Resume SyncJobActionRequestAction = "resume"
+	Stop   SyncJobActionRequestAction = "stop"
+)
+
+// Defines values for SyncJobEventType.
+const (
+	JobAdded   SyncJobEventType = "job_added"
+	JobChanged SyncJobEventType = "job_changed"
+	JobRemoved SyncJobEventType = "job_removed"
+	TaskDone   SyncJobEventType = "task_done"
+)
+
+// Defines values for ListAlbumsParamsSortBy.
+const (
+	CreatedAt     ListAlbumsParamsSortBy = "createdAt"
+	LatestCapture ListAlbumsParamsSortBy = "latestCapture"
+	MediaCount    ListAlbumsParamsSortBy = "mediaCount"
+	Name          ListAlbumsParamsSortBy = "name"
+)
+
+// Defines values for ListAlbumsParamsSortOrder.
+const (
+	ListAlbumsParamsSortOrderAsc  ListAlbumsParamsSortOrder = "asc"
+	ListAlbumsParamsSortOrderDesc ListAlbumsParamsSortOrder = "desc"
+)
+
 // Defines values for ListMediaParamsDirection.
 const (
-	Backward ListMediaParamsDirection = "backward"
-	Forward  ListMediaParamsDirection = "forward"
+	ListMediaParamsDirectionBackward ListMediaParamsDirection = "backward"
+	ListMediaParamsDirectionForward  ListMediaParamsDirection = "forward"
 )
 
 // Defines values for ListMediaParamsType.
 const (
-	Photo ListMediaParamsType = "photo"
-	Video ListMediaParamsType = "video"
+	ListMediaParamsTypePhoto ListMediaParamsType = "photo"
+	ListMediaParamsTypeVideo ListMediaParamsType = "video"
 )
 
 // Defines values for ListMediaParamsSortBy.
@@ -42,10 +145,69 @@ const (
 
 // Defines values for ListMediaParamsSortOrder.
 const (
-	Asc  ListMediaParamsSortOrder = "asc"
-	Desc ListMediaParamsSortOrder = "desc"
+	ListMediaParamsSortOrderAsc  ListMediaParamsSortOrder = "asc"
+	ListMediaParamsSortOrderDesc ListMediaParamsSortOrder = "desc"
+)
+
+// Defines values for SearchParamsType.
+const (
+	SearchParamsTypeAlbum SearchParamsType = "album"
+	SearchParamsTypeMedia SearchParamsType = "media"
+)
+
+// Defines values for ListSmartAlbumMediaParamsDirection.
+const (
+	ListSmartAlbumMediaParamsDirectionBackward ListSmartAlbumMediaParamsDirection = "backward"
+	ListSmartAlbumMediaParamsDirectionForward  ListSmartAlbumMediaParamsDirection = "forward"
+)
+
+// Defines values for ListSyncJobsParamsStatus.
+const (
+	ListSyncJobsParamsStatusCompleted   ListSyncJobsParamsStatus = "completed"
+	ListSyncJobsParamsStatusFailed      ListSyncJobsParamsStatus = "failed"
+	ListSyncJobsParamsStatusInterrupted ListSyncJobsParamsStatus = "interrupted"
+	ListSyncJobsParamsStatusPaused      ListSyncJobsParamsStatus = "paused"
+	ListSyncJobsParamsStatusPending     ListSyncJobsParamsStatus = "pending"
+	ListSyncJobsParamsStatusRunning     ListSyncJobsParamsStatus = "running"
+	ListSyncJobsParamsStatusStopped     ListSyncJobsParamsStatus = "stopped"
+	ListSyncJobsParamsStatusStopping    ListSyncJobsParamsStatus = "stopping"
 )
 
+// Defines values for GetTimelineParamsGranularity.
+const (
+	Day   GetTimelineParamsGranularity = "day"
+	Month GetTimelineParamsGranularity = "month"
+)
+
+// AcceptEventRequest defines model for AcceptEventRequest.
+type AcceptEventRequest struct {
+	GapMinutes    *int     `json:"gapMinutes,omitempty"`
+	MaxDistanceKm *float32 `json:"maxDistanceKm,omitempty"`
+	MinMedia      *int     `json:"minMedia,omitempty"`
+
+	// Name Name of the album or the collection, the suggested name if not set
+	Name *string `json:"name,omitempty"`
+
+	// ParentId Parent of the new album, the album is created at the root if not set
+	ParentId *string `json:"parentId,omitempty"`
+
+	// Target Create an album and move the media, or create a collection and link the media
+	Target AcceptEventRequestTarget `json:"target"`
+}
+
+// AcceptEventRequestTarget Create an album and move the media, or create a collection and link the media
+type AcceptEventRequestTarget string
+
+// AcceptEventResponse defines model for AcceptEventResponse.
+type AcceptEventResponse struct {
+	Album      *Album                    `json:"album,omitempty"`
+	Collection *Collection               `json:"collection,omitempty"`
+	Target     AcceptEventResponseTarget `json:"target"`
+}
+
+// AcceptEventResponseTarget defines model for AcceptEventResponse.Target.
+type AcceptEventResponseTarget string
+
 // Album defines model for Album.
 type Album struct {
 	Children *[]struct {
@@ -53,7 +215,10 @@ type Album struct {
 		Name string `json:"name"`
 	} `json:"children,omitempty"`
 	Description *string `json:"description,omitempty"`
-	Href        string  `json:"href"`
+
+	// Hidden hidden albums and their subalbums are left out of the memories
+	Hidden bool   `json:"hidden"`
+	Href   string `json:"href"`
 
 	// Id Unique identifier for the album
 	Id string `json:"id"`
@@ -78,14 +243,195 @@ type Album struct {
 
 	// Thumbnail href of the thumbnail
 	Thumbnail *string `json:"thumbnail,omitempty"`
+
+	// TrashedAt set when the album is in the trash
+	TrashedAt *time.Time `json:"trashedAt,omitempty"`
+}
+
+// AlbumTreeNode defines model for AlbumTreeNode.
+type AlbumTreeNode struct {
+	// Children Subalbums, empty below the depth limit
+	Children []AlbumTreeNode `json:"children"`
+
+	// CoverId Id of the cover media, the thumbnail of the album or else the most recent media of the subtree
+	CoverId     *string `json:"coverId,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// EarliestCapturedAt Capture date of the oldest media of the subtree, not set if the subtree has no media
+	EarliestCapturedAt *time.Time `json:"earliestCapturedAt,omitempty"`
+	Href               string     `json:"href"`
+
+	// Id Unique identifier for the album
+	Id string `json:"id"`
+
+	// LatestCapturedAt Capture date of the most recent media of the subtree, not set if the subtree has no media
+	LatestCapturedAt *time.Time `json:"latestCapturedAt,omitempty"`
+
+	// MediaCount Number of media of the album itself
+	MediaCount int `json:"mediaCount"`
+
+	// Name name of the album
+	Name string `json:"name"`
+
+	// Path path of the folder on disk
+	Path string `json:"path"`
+
+	// SubtreeMediaCount Number of media of the album and of all its descendants
+	SubtreeMediaCount int `json:"subtreeMediaCount"`
+
+	// Thumbnail href of the thumbnail of the cover media
+	Thumbnail *string `json:"thumbnail,omitempty"`
+}
+
+// AlbumTreeResponse defines model for AlbumTreeResponse.
+type AlbumTreeResponse struct {
+	// Albums Roots of the tree
+	Albums []AlbumTreeNode `json:"albums"`
+}
+
+// BoundingBox Geographic area. If minLongitude is greater than maxLongitude the area crosses the antimeridian.
+type BoundingBox struct {
+	MaxLatitude  float64 `json:"maxLatitude"`
+	MaxLongitude float64 `json:"maxLongitude"`
+	MinLatitude  float64 `json:"minLatitude"`
+	MinLongitude float64 `json:"minLongitude"`
 }
 
 // Bucket defines model for Bucket.
 type Bucket struct {
-	// Media list of media hrefs in this bucket
-	Media *[]string `json:"media,omitempty"`
-	Month *int      `json:"month,omitempty"`
-	Year  *int      `json:"year,omitempty"`
+	// Count Number of media captured in this bucket
+	Count int `json:"count"`
+
+	// Cursor Cursor to list the media starting with the most recent media of this bucket
+	Cursor string `json:"cursor"`
+
+	// Day Day of the month, only set with the day granularity
+	Day   *int `json:"day,omitempty"`
+	Month int  `json:"month"`
+
+	// Start Start of the bucket
+	Start time.Time `json:"start"`
+	Year  int       `json:"year"`
+}
+
+// BulkItemResult defines model for BulkItemResult.
+type BulkItemResult struct {
+	// Error set when the operation failed on the media
+	Error     *string `json:"error,omitempty"`
+	MediaHref string  `json:"mediaHref"`
+	MediaId   string  `json:"mediaId"`
+}
+
+// BulkJob defines model for BulkJob.
+type BulkJob struct {
+	CompletedAt *time.Time       `json:"completedAt,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Failed      int              `json:"failed"`
+	Href        string           `json:"href"`
+	Id          string           `json:"id"`
+	Operation   BulkJobOperation `json:"operation"`
+
+	// Remaining Number of media not processed yet
+	Remaining int `json:"remaining"`
+
+	// Results Result of every media processed so far
+	Results   []BulkItemResult `json:"results"`
+	StartedAt *time.Time       `json:"startedAt,omitempty"`
+	Status    BulkJobStatus    `json:"status"`
+	Succeeded int              `json:"succeeded"`
+
+	// Total Number of media of the job
+	Total int `json:"total"`
+}
+
+// BulkJobOperation defines model for BulkJob.Operation.
+type BulkJobOperation string
+
+// BulkJobStatus defines model for BulkJob.Status.
+type BulkJobStatus string
+
+// BulkMediaRequest defines model for BulkMediaRequest.
+type BulkMediaRequest struct {
+	// AddTags Tags added to the media by tag
+	AddTags *[]string `json:"addTags,omitempty"`
+
+	// AlbumId Album the media are moved into. Required by move.
+	AlbumId *string `json:"albumId,omitempty"`
+
+	// Favorite Mark, or unmark when false, the media as favorite. Used by favorite.
+	Favorite *bool    `json:"favorite,omitempty"`
+	MediaIds []string `json:"mediaIds"`
+
+	// Operation Operation applied to every media
+	Operation BulkMediaRequestOperation `json:"operation"`
+
+	// Permission Permission granted or revoked by permission
+	Permission *BulkPermission `json:"permission,omitempty"`
+
+	// RemoveTags Tags removed from the media by tag
+	RemoveTags *[]string `json:"removeTags,omitempty"`
+
+	// ShiftSeconds Seconds added to the capture date of the media, negative to move it back. Required by date_shift.
+	ShiftSeconds *int64 `json:"shiftSeconds,omitempty"`
+}
+
+// BulkMediaRequestOperation Operation applied to every media
+type BulkMediaRequestOperation string
+
+// BulkPermission Permission granted or revoked by permission
+type BulkPermission struct {
+	// Grant Grant the role when true, revoke it when false
+	Grant bool               `json:"grant"`
+	Role  BulkPermissionRole `json:"role"`
+
+	// User User the permission is granted to or revoked from
+	User string `json:"user"`
+}
+
+// BulkPermissionRole defines model for BulkPermission.Role.
+type BulkPermissionRole string
+
+// Collection defines model for Collection.
+type Collection struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	Description *string   `json:"description,omitempty"`
+	Href        string    `json:"href"`
+
+	// Id Unique identifier for the collection
+	Id string `json:"id"`
+
+	// MediaCount Number of media in the collection
+	MediaCount int `json:"mediaCount"`
+
+	// MediaHref href of the endpoint listing the media of the collection
+	MediaHref string `json:"mediaHref"`
+
+	// Name name of the collection
+	Name string `json:"name"`
+
+	// Thumbnail href of the cover thumbnail
+	Thumbnail *string `json:"thumbnail,omitempty"`
+}
+
+// CollectionMediaRequest defines model for CollectionMediaRequest.
+type CollectionMediaRequest struct {
+	// MediaIds list of media ids
+	MediaIds []string `json:"mediaIds"`
+}
+
+// Comment defines model for Comment.
+type Comment struct {
+	// Author username of the author
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	Href      string    `json:"href"`
+	Id        string    `json:"id"`
+	MediaHref string    `json:"mediaHref"`
+
+	// ParentId id of the comment answered by this comment
+	ParentId  *string   `json:"parentId,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 // CreateAlbumRequest defines model for CreateAlbumRequest.
@@ -100,6 +446,35 @@ type CreateAlbumRequest struct {
 	ParentId *string `json:"parentId,omitempty"`
 }
 
+// CreateCollectionRequest defines model for CreateCollectionRequest.
+type CreateCollectionRequest struct {
+	// Description Info about the collection
+	Description *string `json:"description,omitempty"`
+
+	// Name Name of the collection
+	Name string `json:"name"`
+}
+
+// CreateCommentRequest defines model for CreateCommentRequest.
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+
+	// ParentId id of the comment to reply to. It must belong to the same media.
+	ParentId *string `json:"parentId,omitempty"`
+}
+
+// CreateSmartAlbumRequest defines model for CreateSmartAlbumRequest.
+type CreateSmartAlbumRequest struct {
+	// Description Info about the smart album
+	Description *string `json:"description,omitempty"`
+
+	// Filter Criteria selecting the media of a smart album. All criteria must match.
+	Filter SmartAlbumFilter `json:"filter"`
+
+	// Name Name of the smart album
+	Name string `json:"name"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Code Error code
@@ -112,6 +487,30 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// EventSuggestion defines model for EventSuggestion.
+type EventSuggestion struct {
+	Count int `json:"count"`
+
+	// End Capture date of the last media
+	End time.Time `json:"end"`
+	Id  string    `json:"id"`
+
+	// Latitude Center of the located media of the event
+	Latitude *float32 `json:"latitude,omitempty"`
+
+	// Longitude Center of the located media of the event
+	Longitude *float32 `json:"longitude,omitempty"`
+
+	// Media list of media href
+	Media []string `json:"media"`
+
+	// Name Name suggested for the album or the collection
+	Name string `json:"name"`
+
+	// Start Capture date of the first media
+	Start time.Time `json:"start"`
+}
+
 // ExifHeader defines model for ExifHeader.
 type ExifHeader struct {
 	Key   string `json:"key"`
@@ -125,13 +524,56 @@ type ListAlbumsResponse struct {
 	// Limit Number of albums returned
 	Limit int `json:"limit"`
 
+	// NextCursor Cursor of the next page, not set on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
 	// Offset Number of albums skipped
 	Offset int `json:"offset"`
 
+	// SmartAlbums Smart albums visible to the user, paged with smartAlbumsLimit and smartAlbumsOffset. Not returned if smartAlbumsLimit is 0.
+	SmartAlbums *[]SmartAlbum `json:"smartAlbums,omitempty"`
+
+	// SmartAlbumsTotal Total number of smart albums visible to the user
+	SmartAlbumsTotal *int `json:"smartAlbumsTotal,omitempty"`
+
 	// Total Total number of albums
 	Total int `json:"total"`
 }
 
+// ListCollectionMediaResponse defines model for ListCollectionMediaResponse.
+type ListCollectionMediaResponse struct {
+	// Limit Number of media items returned
+	Limit int     `json:"limit"`
+	Media []Media `json:"media"`
+
+	// Offset Number of media items skipped
+	Offset int `json:"offset"`
+}
+
+// ListCollectionsResponse defines model for ListCollectionsResponse.
+type ListCollectionsResponse struct {
+	Collections []Collection `json:"collections"`
+
+	// Limit Number of collections returned
+	Limit int `json:"limit"`
+
+	// Offset Number of collections skipped
+	Offset int `json:"offset"`
+
+	// Total Total number of collections
+	Total int `json:"total"`
+}
+
+// ListCommentsResponse defines model for ListCommentsResponse.
+type ListCommentsResponse struct {
+	Comments []Comment `json:"comments"`
+}
+
+// ListEventSuggestionsResponse defines model for ListEventSuggestionsResponse.
+type ListEventSuggestionsResponse struct {
+	Events []EventSuggestion `json:"events"`
+}
+
 // ListMediaResponse defines model for ListMediaResponse.
 type ListMediaResponse struct {
 	// Limit Number of media items returned
@@ -142,23 +584,75 @@ type ListMediaResponse struct {
 	NextCursor *string `json:"nextCursor"`
 }
 
+// ListSmartAlbumsResponse defines model for ListSmartAlbumsResponse.
+type ListSmartAlbumsResponse struct {
+	// Limit Number of smart albums returned
+	Limit int `json:"limit"`
+
+	// Offset Number of smart albums skipped
+	Offset      int          `json:"offset"`
+	SmartAlbums []SmartAlbum `json:"smartAlbums"`
+
+	// Total Total number of smart albums
+	Total int `json:"total"`
+}
+
+// ListSyncJobsResponse defines model for ListSyncJobsResponse.
+type ListSyncJobsResponse struct {
+	Jobs []SyncJob `json:"jobs"`
+}
+
 // Media defines model for Media.
 type Media struct {
-	AlbumHref  string    `json:"albumHref"`
+	AlbumHref string `json:"albumHref"`
+
+	// Camera Camera model
+	Camera *string `json:"camera,omitempty"`
+
+	// Caption Caption of the media. Imported from the description embedded in the file.
+	Caption    *string   `json:"caption,omitempty"`
 	CapturedAt time.Time `json:"capturedAt"`
 
+	// CommentsHref href of the endpoint listing the comments of the media
+	CommentsHref *string `json:"commentsHref,omitempty"`
+
 	// Content href of the endpoint serving the content of the media
 	Content string       `json:"content"`
 	Exif    []ExifHeader `json:"exif"`
 
+	// Favorite set true if the media is marked as favorite
+	Favorite bool `json:"favorite"`
+
 	// Filename full path of the media file on the disk
-	Filename string `json:"filename"`
-	Href     string `json:"href"`
-	Id       string `json:"id"`
+	Filename string    `json:"filename"`
+	Href     string    `json:"href"`
+	Id       string    `json:"id"`
+	Rating   *int      `json:"rating,omitempty"`
+	Tags     *[]string `json:"tags,omitempty"`
 
 	// Thumbnail href to thumbnail
 	Thumbnail string `json:"thumbnail"`
-	Type      string `json:"type"`
+
+	// TrashedAt set when the media is in the trash
+	TrashedAt *time.Time `json:"trashedAt,omitempty"`
+	Type      string     `json:"type"`
+}
+
+// MemoriesResponse defines model for MemoriesResponse.
+type MemoriesResponse struct {
+	Memories []Memory `json:"memories"`
+}
+
+// Memory defines model for Memory.
+type Memory struct {
+	Media []Media `json:"media"`
+	Year  int     `json:"year"`
+}
+
+// MoveAlbumRequest Request body for moving an album
+type MoveAlbumRequest struct {
+	// ParentId ID of the new parent album. The album is moved at the top level when omitted.
+	ParentId *string `json:"parentId,omitempty"`
 }
 
 // Permissions Datastore-level permissions for the user
@@ -176,6 +670,110 @@ type PermissionsCanCreateAlbums string
 // PermissionsCanSync Whether the user can perform sync operations
 type PermissionsCanSync string
 
+// RelocateMediaRequest Request body for moving or copying media into an album
+type RelocateMediaRequest struct {
+	// AlbumId ID of the target album
+	AlbumId string `json:"albumId"`
+
+	// MediaIds IDs of the media to move or copy
+	MediaIds []string `json:"mediaIds"`
+}
+
+// RelocateMediaResponse defines model for RelocateMediaResponse.
+type RelocateMediaResponse struct {
+	// Media The moved media or the copies, in the order of the request
+	Media []Media `json:"media"`
+}
+
+// RenameAlbumRequest Request body for renaming an album
+type RenameAlbumRequest struct {
+	// Name New name of the album folder
+	Name string `json:"name"`
+}
+
+// SearchResponse defines model for SearchResponse.
+type SearchResponse struct {
+	// Limit Maximum number of results requested
+	Limit int `json:"limit"`
+
+	// NextCursor Cursor for next page (base64 encoded)
+	NextCursor *string        `json:"nextCursor"`
+	Results    []SearchResult `json:"results"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	Album *Album           `json:"album,omitempty"`
+	Kind  SearchResultKind `json:"kind"`
+	Media *Media           `json:"media,omitempty"`
+
+	// Rank Relevance of the result, higher is better
+	Rank float64 `json:"rank"`
+}
+
+// SearchResultKind defines model for SearchResult.Kind.
+type SearchResultKind string
+
+// SmartAlbum defines model for SmartAlbum.
+type SmartAlbum struct {
+	CreatedAt   time.Time `json:"createdAt"`
+	Description *string   `json:"description,omitempty"`
+
+	// Filter Criteria selecting the media of a smart album. All criteria must match.
+	Filter SmartAlbumFilter `json:"filter"`
+	Href   string           `json:"href"`
+
+	// Id Unique identifier for the smart album
+	Id string `json:"id"`
+
+	// MediaHref href of the endpoint listing the media matched by the filter
+	MediaHref string `json:"mediaHref"`
+
+	// Name name of the smart album
+	Name string `json:"name"`
+}
+
+// SmartAlbumFilter Criteria selecting the media of a smart album. All criteria must match.
+type SmartAlbumFilter struct {
+	// AlbumId Media of this album and of all its descendants
+	AlbumId *string `json:"albumId,omitempty"`
+
+	// Area Geographic area. If minLongitude is greater than maxLongitude the area crosses the antimeridian.
+	Area *BoundingBox `json:"area,omitempty"`
+
+	// Camera Camera model as found in the exif Model tag
+	Camera *string `json:"camera,omitempty"`
+
+	// EndDate Media captured on or before this date
+	EndDate   *time.Time `json:"endDate,omitempty"`
+	MinRating *int       `json:"minRating,omitempty"`
+
+	// StartDate Media captured on or after this date
+	StartDate *time.Time `json:"startDate,omitempty"`
+
+	// Tags Media having all these tags
+	Tags *[]string             `json:"tags,omitempty"`
+	Type *SmartAlbumFilterType `json:"type,omitempty"`
+}
+
+// SmartAlbumFilterType defines model for SmartAlbumFilter.Type.
+type SmartAlbumFilterType string
+
+// StartSyncRequest defines model for StartSyncRequest.
+type StartSyncRequest struct {
+	// FullRescan Process every media file again instead of only the ones changed since they were synced
+	FullRescan *bool `json:"fullRescan,omitempty"`
+
+	// Path Folder to sync, relative to the data folder. Empty for the whole data folder.
+	Path *string `json:"path,omitempty"`
+}
+
+// StartSyncResponse defines model for StartSyncResponse.
+type StartSyncResponse struct {
+	// Id ID of the sync job, or the path of the folder if nothing changed since the last sync
+	Id string `json:"id"`
+}
+
 // StatsResponse defines model for StatsResponse.
 type StatsResponse struct {
 	// CountAlbum Total number of albums
@@ -188,21 +786,187 @@ type StatsResponse struct {
 	Years []int `json:"years"`
 }
 
+// SyncDryRunRequest defines model for SyncDryRunRequest.
+type SyncDryRunRequest struct {
+	// FullRescan Report every media file as updated instead of only the ones changed since they were synced
+	FullRescan *bool `json:"fullRescan,omitempty"`
+
+	// Path Folder to compare, relative to the data folder. Empty for the whole data folder.
+	Path *string `json:"path,omitempty"`
+}
+
+// SyncJob defines model for SyncJob.
+type SyncJob struct {
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Id          string     `json:"id"`
+
+	// ParentId ID of the parent job of a sub-job
+	ParentId *string `json:"parentId,omitempty"`
+
+	// Path The folder synced, relative to the data folder
+	Path string `json:"path"`
+
+	// Reason Why the job failed or was interrupted
+	Reason *string `json:"reason,omitempty"`
+
+	// Remaining Number of tasks not started yet
+	Remaining int `json:"remaining"`
+
+	// Results Result of every task done so far
+	Results   []SyncTaskResult `json:"results"`
+	StartedAt *time.Time       `json:"startedAt,omitempty"`
+	Status    SyncJobStatus    `json:"status"`
+
+	// Total Number of tasks of the job
+	Total int `json:"total"`
+}
+
+// SyncJobStatus defines model for SyncJob.Status.
+type SyncJobStatus string
+
+// SyncJobActionRequest defines model for SyncJobActionRequest.
+type SyncJobActionRequest struct {
+	Action SyncJobActionRequestAction `json:"action"`
+}
+
+// SyncJobActionRequestAction defines model for SyncJobActionRequest.Action.
+type SyncJobActionRequestAction string
+
+// SyncJobActionResponse defines model for SyncJobActionResponse.
+type SyncJobActionResponse struct {
+	// AffectedCount Number of jobs the action applied to
+	AffectedCount int    `json:"affectedCount"`
+	Message       string `json:"message"`
+}
+
+// SyncJobEvent defines model for SyncJobEvent.
+type SyncJobEvent struct {
+	At   time.Time        `json:"at"`
+	Job  SyncJob          `json:"job"`
+	Task *SyncTaskResult  `json:"task,omitempty"`
+	Type SyncJobEventType `json:"type"`
+}
+
+// SyncJobEventType defines model for SyncJobEvent.Type.
+type SyncJobEventType string
+
+// SyncMove defines model for SyncMove.
+type SyncMove struct {
+	// From Former path of the file
+	From string `json:"from"`
+
+	// To New path of the file
+	To string `json:"to"`
+}
+
+// SyncReport What a sync would do. All the items are paths relative to the data folder.
+type SyncReport struct {
+	// AlbumsToCreate Folders which have no album yet
+	AlbumsToCreate []string `json:"albumsToCreate"`
+
+	// AlbumsToRemove Albums whose folder is gone, they are removed with their subalbums and media
+	AlbumsToRemove []string `json:"albumsToRemove"`
+
+	// MediaToAdd Media files which have no media yet
+	MediaToAdd []string `json:"mediaToAdd"`
+
+	// MediaToMove Media whose file was moved to another folder
+	MediaToMove []SyncMove `json:"mediaToMove"`
+
+	// MediaToRemove Media whose file is gone
+	MediaToRemove []string `json:"mediaToRemove"`
+
+	// MediaToUpdate Media files changed since they were synced
+	MediaToUpdate []string `json:"mediaToUpdate"`
+
+	// Path The folder compared
+	Path string `json:"path"`
+
+	// UnreadableItems Folders and files which cannot be read, their media are kept
+	UnreadableItems []string `json:"unreadableItems"`
+
+	// UnsupportedFiles Files which are not media files of a supported format
+	UnsupportedFiles []string `json:"unsupportedFiles"`
+}
+
+// SyncTaskResult defines model for SyncTaskResult.
+type SyncTaskResult struct {
+	CompletedAt time.Time `json:"completedAt"`
+
+	// Error Why the task failed
+	Error *string `json:"error,omitempty"`
+
+	// Result What the task did
+	Result    string    `json:"result"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// TimelineResponse defines model for TimelineResponse.
+type TimelineResponse struct {
+	Buckets []Bucket `json:"buckets"`
+
+	// Total Total number of media of the timeline
+	Total int `json:"total"`
+}
+
+// TrashResponse defines model for TrashResponse.
+type TrashResponse struct {
+	Albums []Album `json:"albums"`
+	Media  []Media `json:"media"`
+}
+
 // UpdateAlbumRequest Request body for updating an album
 type UpdateAlbumRequest struct {
 	Description *string `json:"description,omitempty"`
 
+	// Hidden leave the album and its subalbums out of the memories
+	Hidden *bool `json:"hidden,omitempty"`
+
 	// Thumbnail id of the media used as thumbnail
 	Thumbnail *string `json:"thumbnail,omitempty"`
 }
 
+// UpdateCollectionRequest Request body for updating a collection
+type UpdateCollectionRequest struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+
+	// Thumbnail id of the media used as cover. It must belong to the collection. The cover is kept if the field is missing and removed if it is null.
+	Thumbnail nullable.Nullable[string] `json:"thumbnail,omitempty"`
+}
+
+// UpdateCommentRequest defines model for UpdateCommentRequest.
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
 // UpdateMediaRequest defines model for UpdateMediaRequest.
 type UpdateMediaRequest struct {
+	// Caption Caption of the media. An empty caption removes it.
+	Caption *string `json:"caption,omitempty"`
+
 	// CapturedAt Date when the media was captured
 	CapturedAt *openapi_types.Date `json:"capturedAt,omitempty"`
 
 	// Exif EXIF data for the media
 	Exif *[]ExifHeader `json:"exif,omitempty"`
+
+	// Favorite Mark or unmark the media as favorite
+	Favorite *bool `json:"favorite,omitempty"`
+	Rating   *int  `json:"rating,omitempty"`
+
+	// Tags Tags of the media. Replaces the existing tags.
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// UpdateSmartAlbumRequest Request body for updating a smart album
+type UpdateSmartAlbumRequest struct {
+	Description *string `json:"description,omitempty"`
+
+	// Filter Criteria selecting the media of a smart album. All criteria must match.
+	Filter SmartAlbumFilter `json:"filter"`
+	Name   string           `json:"name"`
 }
 
 // User defines model for User.
@@ -220,6 +984,12 @@ type User struct {
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = Error
 
@@ -231,11 +1001,71 @@ type ListAlbumsParams struct {
 	// Limit Maximum number of albums to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
-	// Offset Number of albums to skip
+	// Offset Number of albums to skip, ignored if cursor is set
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 
+	// Cursor Cursor of the next page returned by the previous page (base64 encoded)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// SortBy Sort albums by field. The media count and the latest capture date include the subalbums.
+	SortBy *ListAlbumsParamsSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Sort order
+	SortOrder *ListAlbumsParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
+
 	// WithParent If true return albums with parents
 	WithParent *bool `form:"withParent,omitempty" json:"withParent,omitempty"`
+
+	// SmartAlbumsLimit Maximum number of smart albums to return, 0 returns none
+	SmartAlbumsLimit *int `form:"smartAlbumsLimit,omitempty" json:"smartAlbumsLimit,omitempty"`
+
+	// SmartAlbumsOffset Number of smart albums to skip. The smart albums are paged independently of the albums.
+	SmartAlbumsOffset *int `form:"smartAlbumsOffset,omitempty" json:"smartAlbumsOffset,omitempty"`
+}
+
+// ListAlbumsParamsSortBy defines parameters for ListAlbums.
+type ListAlbumsParamsSortBy string
+
+// ListAlbumsParamsSortOrder defines parameters for ListAlbums.
+type ListAlbumsParamsSortOrder string
+
+// GetAlbumTreeParams defines parameters for GetAlbumTree.
+type GetAlbumTreeParams struct {
+	// RootId Return the subtree of this album instead of the whole tree
+	RootId *string `form:"rootId,omitempty" json:"rootId,omitempty"`
+
+	// Depth Maximum number of levels of the tree, 0 returns all the levels
+	Depth *int `form:"depth,omitempty" json:"depth,omitempty"`
+}
+
+// ListEventSuggestionsParams defines parameters for ListEventSuggestions.
+type ListEventSuggestionsParams struct {
+	// GapMinutes Longest time between two media of the same event
+	GapMinutes *int `form:"gapMinutes,omitempty" json:"gapMinutes,omitempty"`
+
+	// MaxDistanceKm Longest distance between two consecutive located media of the same event, 0 ignores the location
+	MaxDistanceKm *float32 `form:"maxDistanceKm,omitempty" json:"maxDistanceKm,omitempty"`
+
+	// MinMedia Smallest number of media of an event
+	MinMedia *int `form:"minMedia,omitempty" json:"minMedia,omitempty"`
+}
+
+// ListCollectionsParams defines parameters for ListCollections.
+type ListCollectionsParams struct {
+	// Limit Maximum number of collections to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of collections to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListCollectionMediaParams defines parameters for ListCollectionMedia.
+type ListCollectionMediaParams struct {
+	// Limit Maximum number of media items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of media items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
 // ListMediaParams defines parameters for ListMedia.
@@ -252,6 +1082,11 @@ type ListMediaParams struct {
 	// AlbumId Filter media by album ID
 	AlbumId *string `form:"album_id,omitempty" json:"album_id,omitempty"`
 
+	// Q Filter media with a query like `camera:"X-T4" after:2022-05 before:2022-09 type:video album:Trips/* tag:beach -tag:work`.
+	// Supported fields are camera, after, before, type, album, tag and rating; other words are searched in the media text.
+	// An invalid query returns 400 with the position of the error.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
 	// Type Filter media by type
 	Type *ListMediaParamsType `form:"type,omitempty" json:"type,omitempty"`
 
@@ -292,14 +1127,161 @@ type UploadMediaMultipartBody struct {
 	Filename string `json:"filename"`
 }
 
+// GetMemoriesParams defines parameters for GetMemories.
+type GetMemoriesParams struct {
+	// Date Day of the memories, today if not set
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+
+	// Favorite Keep only the favorite media, or the media rated at least minRating if it is set too
+	Favorite *bool `form:"favorite,omitempty" json:"favorite,omitempty"`
+
+	// MinRating Keep only the media rated at least minRating, or the favorite media if favorite is set too
+	MinRating *int `form:"minRating,omitempty" json:"minRating,omitempty"`
+
+	// Limit Maximum number of media to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// Q Search text
+	Q string `form:"q" json:"q"`
+
+	// Type Restrict the results to albums or media
+	Type *SearchParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Cursor for pagination (base64 encoded)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// SearchParamsType defines parameters for Search.
+type SearchParamsType string
+
+// ListSmartAlbumsParams defines parameters for ListSmartAlbums.
+type ListSmartAlbumsParams struct {
+	// Limit Maximum number of smart albums to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of smart albums to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListSmartAlbumMediaParams defines parameters for ListSmartAlbumMedia.
+type ListSmartAlbumMediaParams struct {
+	// Limit Maximum number of media items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Cursor for pagination (base64 encoded)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Direction Pagination direction
+	Direction *ListSmartAlbumMediaParamsDirection `form:"direction,omitempty" json:"direction,omitempty"`
+}
+
+// ListSmartAlbumMediaParamsDirection defines parameters for ListSmartAlbumMedia.
+type ListSmartAlbumMediaParamsDirection string
+
+// WatchSyncJobsParams defines parameters for WatchSyncJobs.
+type WatchSyncJobsParams struct {
+	// Id Watch this job and its sub-jobs only
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+}
+
+// ListSyncJobsParams defines parameters for ListSyncJobs.
+type ListSyncJobsParams struct {
+	// Status List the jobs with this status only
+	Status *ListSyncJobsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of jobs of the history to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of jobs of the history to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListSyncJobsParamsStatus defines parameters for ListSyncJobs.
+type ListSyncJobsParamsStatus string
+
+// GetTimelineParams defines parameters for GetTimeline.
+type GetTimelineParams struct {
+	// AlbumId Restrict the timeline to the media of the album and of its descendants
+	AlbumId *string `form:"album_id,omitempty" json:"album_id,omitempty"`
+
+	// Granularity Size of the buckets
+	Granularity *GetTimelineParamsGranularity `form:"granularity,omitempty" json:"granularity,omitempty"`
+
+	// StartDate Count media captured on or after this date
+	StartDate *openapi_types.Date `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Count media captured on or before this date
+	EndDate *openapi_types.Date `form:"endDate,omitempty" json:"endDate,omitempty"`
+}
+
+// GetTimelineParamsGranularity defines parameters for GetTimeline.
+type GetTimelineParamsGranularity string
+
 // CreateAlbumJSONRequestBody defines body for CreateAlbum for application/json ContentType.
 type CreateAlbumJSONRequestBody = CreateAlbumRequest
 
 // UpdateAlbumJSONRequestBody defines body for UpdateAlbum for application/json ContentType.
 type UpdateAlbumJSONRequestBody = UpdateAlbumRequest
 
+// AcceptEventSuggestionJSONRequestBody defines body for AcceptEventSuggestion for application/json ContentType.
+type AcceptEventSuggestionJSONRequestBody = AcceptEventRequest
+
+// MoveAlbumJSONRequestBody defines body for MoveAlbum for application/json ContentType.
+type MoveAlbumJSONRequestBody = MoveAlbumRequest
+
+// RenameAlbumJSONRequestBody defines body for RenameAlbum for application/json ContentType.
+type RenameAlbumJSONRequestBody = RenameAlbumRequest
+
+// CreateCollectionJSONRequestBody defines body for CreateCollection for application/json ContentType.
+type CreateCollectionJSONRequestBody = CreateCollectionRequest
+
+// UpdateCollectionJSONRequestBody defines body for UpdateCollection for application/json ContentType.
+type UpdateCollectionJSONRequestBody = UpdateCollectionRequest
+
+// AddCollectionMediaJSONRequestBody defines body for AddCollectionMedia for application/json ContentType.
+type AddCollectionMediaJSONRequestBody = CollectionMediaRequest
+
+// ReorderCollectionMediaJSONRequestBody defines body for ReorderCollectionMedia for application/json ContentType.
+type ReorderCollectionMediaJSONRequestBody = CollectionMediaRequest
+
 // UploadMediaMultipartRequestBody defines body for UploadMedia for multipart/form-data ContentType.
 type UploadMediaMultipartRequestBody UploadMediaMultipartBody
 
+// StartBulkMediaJobJSONRequestBody defines body for StartBulkMediaJob for application/json ContentType.
+type StartBulkMediaJobJSONRequestBody = BulkMediaRequest
+
+// CopyMediaJSONRequestBody defines body for CopyMedia for application/json ContentType.
+type CopyMediaJSONRequestBody = RelocateMediaRequest
+
+// MoveMediaJSONRequestBody defines body for MoveMedia for application/json ContentType.
+type MoveMediaJSONRequestBody = RelocateMediaRequest
+
 // UpdateMediaJSONRequestBody defines body for UpdateMedia for application/json ContentType.
 type UpdateMediaJSONRequestBody = UpdateMediaRequest
+
+// CreateMediaCommentJSONRequestBody defines body for CreateMediaComment for application/json ContentType.
+type CreateMediaCommentJSONRequestBody = CreateCommentRequest
+
+// UpdateMediaCommentJSONRequestBody defines body for UpdateMediaComment for application/json ContentType.
+type UpdateMediaCommentJSONRequestBody = UpdateCommentRequest
+
+// CreateSmartAlbumJSONRequestBody defines body for CreateSmartAlbum for application/json ContentType.
+type CreateSmartAlbumJSONRequestBody = CreateSmartAlbumRequest
+
+// UpdateSmartAlbumJSONRequestBody defines body for UpdateSmartAlbum for application/json ContentType.
+type UpdateSmartAlbumJSONRequestBody = UpdateSmartAlbumRequest
+
+// StartSyncJSONRequestBody defines body for StartSync for application/json ContentType.
+type StartSyncJSONRequestBody = StartSyncRequest
+
+// DryRunSyncJSONRequestBody defines body for DryRunSync for application/json ContentType.
+type DryRunSyncJSONRequestBody = SyncDryRunRequest
+
+// ActionSyncJobJSONRequestBody defines body for ActionSyncJob for application/json ContentType.
+type ActionSyncJobJSONRequestBody = SyncJobActionRequest
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fatih/color"
+	"github.com/jzelinskie/cobrautil/v2"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/config"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/datastore"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// NewRebuildAlbumsCommand creates a new cobra command which recomputes the album hierarchy
+// and the cached media counts of the albums.
+func NewRebuildAlbumsCommand(config *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rebuild-albums",
+		Short:        "Rebuild the album hierarchy and the cached media counts",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if config.Database.URI == "" {
+				return errors.New("--db-conn-uri cannot be empty")
+			}
+
+			logger := logger.SetupLogger(config)
+			defer logger.Sync()
+
+			undo := zap.ReplaceGlobals(logger)
+			defer undo()
+
+			ctx := context.Background()
+
+			pgPool, err := datastore.NewConnPool(ctx, config.Database.URI)
+			if err != nil {
+				return err
+			}
+			defer pgPool.Close()
+
+			zap.S().Info("rebuilding album hierarchy")
+			err = pg.NewPostgresDatastore(pgPool).WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+				return writer.RebuildAlbumHierarchy(ctx)
+			})
+			if err != nil {
+				zap.S().Errorw("failed to rebuild album hierarchy", "error", err)
+				return err
+			}
+
+			zap.S().Info("album hierarchy rebuilt successfully")
+			return nil
+		},
+	}
+
+	nfs := cobrautil.NewNamedFlagSets(cmd)
+	dbFlagSet := nfs.FlagSet(color.New(color.FgCyan, color.Bold).Sprint("database"))
+	registerDatabaseFlags(dbFlagSet, config.Database)
+	nfs.AddFlagSets(cmd)
+
+	return cmd
+}
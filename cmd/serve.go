@@ -17,11 +17,13 @@ import (
 
 	httpv1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/config"
+	authzStore "git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/authz"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	v1grpc "git.tls.tupangiu.ro/cosmin/photos-ng/internal/handlers/v1/grpc"
 	v1http "git.tls.tupangiu.ro/cosmin/photos-ng/internal/handlers/v1/http"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/server"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/datastore"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/spicedb"
@@ -67,10 +69,28 @@ func NewServeCommand(config *config.Config) *cobra.Command {
 			}
 			defer pgDatastore.Close()
 
+			services.GetScheduler().SetConcurrency(config.Jobs.Concurrency)
+			services.SetTaskLimits(services.TaskLimits{
+				Workers: config.Jobs.Workers,
+				CPU:     config.Jobs.CPULimit,
+				IO:      config.Jobs.IOLimit,
+			})
+
+			// keep the job history in the database, the jobs the last stop interrupted are marked as such
+			jobStore := services.NewJobStore(pg.NewPostgresDatastore(pgDatastore))
+			interruptedJobs, err := services.GetScheduler().UseStore(ctx, jobStore)
+			if err != nil {
+				return err
+			}
+
 			// Create v1 handlers for http and grpc
 			httpHandler := v1http.NewHandler(pg.NewPostgresDatastore(pgDatastore), fs.NewFsDatastore(config.DataRootFolder))
 			grpcHandler := v1grpc.NewHandler(pg.NewPostgresDatastore(pgDatastore), fs.NewFsDatastore(config.DataRootFolder))
 
+			trashSrv := services.NewTrashService(pg.NewPostgresDatastore(pgDatastore), fs.NewFsDatastore(config.DataRootFolder))
+			var trashPurger services.TrashPurger = trashSrv
+			var relationships services.Relationships
+
 			if config.Authorization.Enabled {
 				spiceClient, err := spicedb.InitSpiceDBClient(config.Authorization.SpiceDBURL, config.Authorization.PresharedKey)
 				if err != nil {
@@ -78,6 +98,47 @@ func NewServeCommand(config *config.Config) *cobra.Command {
 				}
 				httpHandler = v1http.NewHandlerWithAuthorization(spiceClient, pg.NewPostgresDatastore(pgDatastore), fs.NewFsDatastore(config.DataRootFolder))
 				grpcHandler = v1grpc.NewHandlerWithAuthorization(spiceClient, pg.NewPostgresDatastore(pgDatastore), fs.NewFsDatastore(config.DataRootFolder))
+
+				authzSrv := services.NewAuthzService(authzStore.NewAuthzDatastore(spiceClient), pg.NewPostgresDatastore(pgDatastore))
+				trashPurger = services.NewAuthzTrashService(authzSrv, trashSrv)
+				relationships = authzSrv
+			}
+
+			// purge the trash every hour
+			if config.TrashRetention > 0 {
+				go services.SchedulePurge(ctx, trashPurger, config.TrashRetention, time.Hour)
+			}
+
+			// delete the old jobs of the history every hour
+			if config.Jobs.Retention > 0 {
+				go services.SchedulePurgeJobHistory(ctx, jobStore, config.Jobs.Retention, time.Hour)
+			}
+
+			fsDatastore := fs.NewFsDatastore(config.DataRootFolder)
+			syncSrv := services.NewSyncService(
+				services.NewAlbumService(pg.NewPostgresDatastore(pgDatastore), fsDatastore),
+				services.NewMediaService(pg.NewPostgresDatastore(pgDatastore), fsDatastore),
+				fsDatastore,
+				relationships,
+			)
+
+			// sync again the folders whose sync was interrupted
+			if config.Jobs.Resume && len(interruptedJobs) > 0 {
+				syncSrv.Resume(ctx, interruptedJobs)
+			}
+
+			// sync the changes of the data folder as they happen
+			if config.Watcher.Enabled {
+				watcher := services.NewWatcher(syncSrv, fsDatastore, config.Watcher.Paths, fs.WatchOptions{
+					Polling:      config.Watcher.Polling,
+					PollInterval: config.Watcher.PollInterval,
+				}, config.Watcher.Debounce)
+
+				go func() {
+					if err := watcher.Run(ctx); err != nil {
+						zap.S().Errorw("data folder watcher stopped", "error", err)
+					}
+				}()
 			}
 
 			var wg sync.WaitGroup
@@ -205,6 +266,12 @@ func registerFlags(cmd *cobra.Command, config *config.Config) {
 	authorizationFlatSet := nfs.FlagSet(color.New(color.FgBlue, color.Bold).Sprint("authorization"))
 	registerAuthorizationFlags(authorizationFlatSet, config)
 
+	watcherFlagSet := nfs.FlagSet(color.New(color.FgGreen, color.Bold).Sprint("watcher"))
+	registerWatcherFlags(watcherFlagSet, config)
+
+	jobsFlagSet := nfs.FlagSet(color.New(color.FgMagenta, color.Bold).Sprint("jobs"))
+	registerJobsFlags(jobsFlagSet, config)
+
 	nfs.AddFlagSets(cmd)
 }
 
@@ -220,6 +287,24 @@ func registerServerFlags(flagSet *pflag.FlagSet, config *config.Config) {
 	flagSet.StringVar(&config.Mode, "server-mode", config.Mode, "server mod: dev or prod")
 	flagSet.StringVar(&config.StaticsFolder, "statics-folder", config.StaticsFolder, "path to statics")
 	flagSet.StringVar(&config.DataRootFolder, "data-root-folder", config.DataRootFolder, "path to the root folder container media")
+	flagSet.DurationVar(&config.TrashRetention, "trash-retention", config.TrashRetention, "how long deleted albums and media are kept in the trash before being purged (0 keeps them forever)")
+}
+
+func registerWatcherFlags(flagSet *pflag.FlagSet, config *config.Config) {
+	flagSet.BoolVar(&config.Watcher.Enabled, "watcher-enabled", config.Watcher.Enabled, "sync the changes of the data root folder as they happen (default false)")
+	flagSet.BoolVar(&config.Watcher.Polling, "watcher-polling", config.Watcher.Polling, "poll the folders instead of using inotify. Network filesystems like NFS are always polled")
+	flagSet.DurationVar(&config.Watcher.PollInterval, "watcher-poll-interval", config.Watcher.PollInterval, "how often the polled folders are scanned")
+	flagSet.DurationVar(&config.Watcher.Debounce, "watcher-debounce", config.Watcher.Debounce, "how long the watcher waits without changes before syncing them")
+	flagSet.StringSliceVar(&config.Watcher.Paths, "watcher-paths", config.Watcher.Paths, "folders to watch, relative to the data root folder (default everything)")
+}
+
+func registerJobsFlags(flagSet *pflag.FlagSet, config *config.Config) {
+	flagSet.DurationVar(&config.Jobs.Retention, "jobs-retention", config.Jobs.Retention, "how long finished jobs are kept in the job history (0 keeps them forever)")
+	flagSet.BoolVar(&config.Jobs.Resume, "jobs-resume", config.Jobs.Resume, "sync again the folders whose sync was interrupted by a restart")
+	flagSet.IntVar(&config.Jobs.Concurrency, "jobs-concurrency", config.Jobs.Concurrency, "how many jobs run at the same time")
+	flagSet.IntVar(&config.Jobs.Workers, "jobs-workers", config.Jobs.Workers, "how many tasks of a job run in parallel")
+	flagSet.IntVar(&config.Jobs.CPULimit, "jobs-cpu-limit", config.Jobs.CPULimit, "how many media files are processed at the same time across all jobs (0 uses the number of CPUs)")
+	flagSet.IntVar(&config.Jobs.IOLimit, "jobs-io-limit", config.Jobs.IOLimit, "how many other tasks, like moving or removing media, run at the same time across all jobs")
 }
 
 func registerAuthenticationFlags(flagSet *pflag.FlagSet, config *config.Config) {
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 //go:generate go run github.com/ecordell/optgen -output zz_generated.configuration.go . Config
 type Config struct {
 	Database *Database `debugmap:"visible"`
@@ -11,6 +13,15 @@ type Config struct {
 	Mode           string `debugmap:"visible" default:"dev"`
 	StaticsFolder  string `debugmap:"visible"`
 
+	// Trash
+	TrashRetention time.Duration `debugmap:"visible" default:"720h"`
+
+	// Watcher
+	Watcher Watcher `debugmap:"visible"`
+
+	// Jobs
+	Jobs Jobs `debugmap:"visible"`
+
 	// Log
 	LogFormat      string         `debugmap:"visible"`
 	LogLevel       string         `debugmap:"visible"`
@@ -39,3 +50,36 @@ type Authorization struct {
 	SpiceDBURL   string `debugmap:"visible" default:"localhost:50051"`
 	PresharedKey string `debugmap:"visible" default:"dev-secret-key"`
 }
+
+// Watcher configures the watcher of the data root folder which syncs the changed folders and files
+// without waiting for a manual sync.
+type Watcher struct {
+	Enabled bool `debugmap:"visible"`
+	// Polling scans the folders every PollInterval instead of relying on inotify. It is used anyway
+	// for the folders on network filesystems like NFS where inotify does not fire.
+	Polling      bool          `debugmap:"visible"`
+	PollInterval time.Duration `debugmap:"visible" default:"1m"`
+	// Debounce is how long the watcher waits without changes before syncing what changed
+	Debounce time.Duration `debugmap:"visible" default:"5s"`
+	// Paths restricts the watcher to these folders, relative to the data root folder. Empty watches everything.
+	Paths []string `debugmap:"visible"`
+}
+
+// Jobs configures how the jobs run, the job history kept in the database and what happens to the jobs a
+// restart interrupted.
+type Jobs struct {
+	// Retention is how long the finished jobs are kept in the history. 0 keeps them forever.
+	Retention time.Duration `debugmap:"visible" default:"168h"`
+	// Resume syncs again the folders of the sync jobs interrupted by a restart. The other interrupted
+	// jobs are only marked as interrupted.
+	Resume bool `debugmap:"visible" default:"true"`
+	// Concurrency is how many jobs run at the same time. The job of a folder still waits for the album of
+	// its parent folder.
+	Concurrency int `debugmap:"visible" default:"2"`
+	// Workers is how many tasks of a job run in parallel
+	Workers int `debugmap:"visible" default:"4"`
+	// CPULimit is how many media files are processed at the same time across all the jobs. 0 uses the number of CPUs.
+	CPULimit int `debugmap:"visible"`
+	// IOLimit is how many other tasks, like moving or removing a media, run at the same time across all the jobs
+	IOLimit int `debugmap:"visible" default:"8"`
+}
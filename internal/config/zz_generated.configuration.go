@@ -7,6 +7,7 @@ package config
 import (
 	defaults "github.com/creasty/defaults"
 	helpers "github.com/ecordell/optgen/helpers"
+	"time"
 )
 
 type ConfigOption func(c *Config)
@@ -40,6 +41,9 @@ func (c *Config) ToOption() ConfigOption {
 		to.GinMode = c.GinMode
 		to.Mode = c.Mode
 		to.StaticsFolder = c.StaticsFolder
+		to.TrashRetention = c.TrashRetention
+		to.Watcher = c.Watcher
+		to.Jobs = c.Jobs
 		to.LogFormat = c.LogFormat
 		to.LogLevel = c.LogLevel
 		to.Authentication = c.Authentication
@@ -57,6 +61,9 @@ func (c *Config) DebugMap() map[string]any {
 	debugMap["GinMode"] = helpers.DebugValue(c.GinMode, false)
 	debugMap["Mode"] = helpers.DebugValue(c.Mode, false)
 	debugMap["StaticsFolder"] = helpers.DebugValue(c.StaticsFolder, false)
+	debugMap["TrashRetention"] = helpers.DebugValue(c.TrashRetention, false)
+	debugMap["Watcher"] = helpers.DebugValue(c.Watcher, false)
+	debugMap["Jobs"] = helpers.DebugValue(c.Jobs, false)
 	debugMap["LogFormat"] = helpers.DebugValue(c.LogFormat, false)
 	debugMap["LogLevel"] = helpers.DebugValue(c.LogLevel, false)
 	debugMap["Authentication"] = helpers.DebugValue(c.Authentication, false)
@@ -129,6 +136,27 @@ func WithStaticsFolder(staticsFolder string) ConfigOption {
 	}
 }
 
+// WithTrashRetention returns an option that can set TrashRetention on a Config
+func WithTrashRetention(trashRetention time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.TrashRetention = trashRetention
+	}
+}
+
+// WithWatcher returns an option that can set Watcher on a Config
+func WithWatcher(watcher Watcher) ConfigOption {
+	return func(c *Config) {
+		c.Watcher = watcher
+	}
+}
+
+// WithJobs returns an option that can set Jobs on a Config
+func WithJobs(jobs Jobs) ConfigOption {
+	return func(c *Config) {
+		c.Jobs = jobs
+	}
+}
+
 // WithLogFormat returns an option that can set LogFormat on a Config
 func WithLogFormat(logFormat string) ConfigOption {
 	return func(c *Config) {
@@ -143,6 +143,45 @@ func (d *Datastore) DeleteRelationships(ctx context.Context, resource entity.Res
 	return resp.DeletedAt.Token, nil
 }
 
+// CheckPermission checks if the user has the permission on the resource.
+func (d *Datastore) CheckPermission(ctx context.Context, token string, userID string, resource entity.Resource, permission entity.Permission) (bool, error) {
+	logger := logger.New("authz_store").
+		WithContext(ctx).
+		Operation("check_permission").
+		Build()
+
+	req := &v1pb.CheckPermissionRequest{
+		Resource: &v1pb.ObjectReference{
+			ObjectType: resource.Kind.String(),
+			ObjectId:   resource.ID,
+		},
+		Permission: permission.String(),
+		Subject: &v1pb.SubjectReference{
+			Object: &v1pb.ObjectReference{
+				ObjectType: "user",
+				ObjectId:   userID,
+			},
+		},
+	}
+	req.Consistency = &v1pb.Consistency{
+		Requirement: &v1pb.Consistency_AtLeastAsFresh{
+			AtLeastAsFresh: &v1pb.ZedToken{Token: token},
+		},
+	}
+
+	resp, err := d.client.CheckPermission(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	logger.Step("check_permission").
+		WithString("permission", permission.String()).
+		WithString("permissionship", resp.Permissionship.String()).
+		Log()
+
+	return resp.Permissionship == v1pb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION, nil
+}
+
 func (d *Datastore) GetPermissions(ctx context.Context, token string, userID string, resources []entity.Resource) (map[entity.Resource][]entity.Permission, error) {
 	logger := logger.New("authz_store").
 		WithContext(ctx).
@@ -13,6 +13,10 @@ import (
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
+// TrashFolder is the hidden folder of the data folder holding the deleted albums and media.
+// It is never walked so its content is not synced.
+const TrashFolder = ".trash"
+
 // WalkResult represents an item found during filesystem traversal
 type WalkResult struct {
 	Path        string // Relative path from the root data folder
@@ -142,6 +146,138 @@ func (fs *Datastore) DeleteMedia(ctx context.Context, mediapath string) error {
 	return os.Remove(fullPath)
 }
 
+// Rename moves a file or a folder to a new path. The parent folders of the new path are created if needed.
+// It fails with os.ErrExist if something already exists at the new path, nothing is ever overwritten.
+func (fs *Datastore) Rename(ctx context.Context, oldPath, newPath string) error {
+	oldFullPath := filepath.Join(fs.rootFolder, oldPath)
+	newFullPath := filepath.Join(fs.rootFolder, newPath)
+
+	if _, err := os.Stat(oldFullPath); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(newFullPath); err == nil {
+		return fmt.Errorf("%s: %w", newPath, os.ErrExist)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(newFullPath), 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(oldFullPath, newFullPath)
+}
+
+// Copy copies a file to a new path. The parent folders of the new path are created if needed.
+// Like Rename, it fails with os.ErrExist if something already exists at the new path.
+func (fs *Datastore) Copy(ctx context.Context, srcPath, dstPath string) error {
+	srcFullPath := filepath.Join(fs.rootFolder, srcPath)
+	dstFullPath := filepath.Join(fs.rootFolder, dstPath)
+
+	src, err := os.Open(srcFullPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dstFullPath), 0755); err != nil {
+		return err
+	}
+
+	// O_EXCL makes the existence check and the creation atomic
+	dst, err := os.OpenFile(dstFullPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s: %w", dstPath, os.ErrExist)
+		}
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(dstFullPath)
+		return err
+	}
+
+	return dst.Close()
+}
+
+// Exists returns true if a file or a folder exists at the path.
+func (fs *Datastore) Exists(ctx context.Context, relativePath string) (bool, error) {
+	_, err := os.Lstat(filepath.Join(fs.rootFolder, relativePath))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// CheckReadable returns an error if the file cannot be opened for reading.
+func (fs *Datastore) CheckReadable(ctx context.Context, relativePath string) error {
+	f, err := os.Open(filepath.Join(fs.rootFolder, relativePath))
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// MoveToTrash moves a file or a folder into the trash under the name.
+// The name must be unique in the trash, the id of the album or media is used.
+func (fs *Datastore) MoveToTrash(ctx context.Context, relativePath, name string) error {
+	return fs.Rename(ctx, relativePath, path.Join(TrashFolder, name))
+}
+
+// RestoreFromTrash moves the item saved in the trash under the name back to relativePath.
+func (fs *Datastore) RestoreFromTrash(ctx context.Context, name, relativePath string) error {
+	return fs.Rename(ctx, path.Join(TrashFolder, name), relativePath)
+}
+
+// ListTrash returns the names of the items in the trash.
+func (fs *Datastore) ListTrash(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(fs.rootFolder, TrashFolder))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+// DeleteFromTrash removes for good the item saved in the trash under the name.
+// This operation is idempotent - it will not fail if the item doesn't exist.
+func (fs *Datastore) DeleteFromTrash(ctx context.Context, name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid trash item name: %q", name)
+	}
+	return os.RemoveAll(filepath.Join(fs.rootFolder, TrashFolder, name))
+}
+
+// Stat returns the attributes of a file which tell if it changed since it was synced
+func (fs *Datastore) Stat(ctx context.Context, relativePath string) (entity.FileInfo, error) {
+	info, err := os.Stat(path.Join(fs.rootFolder, relativePath))
+	if err != nil {
+		return entity.FileInfo{}, err
+	}
+	return newFileInfo(info), nil
+}
+
+func newFileInfo(info os.FileInfo) entity.FileInfo {
+	return entity.FileInfo{
+		Size:       info.Size(),
+		ModifiedAt: info.ModTime().UTC(),
+		Inode:      inode(info),
+	}
+}
+
 // Walk recursively traverses the filesystem starting from the given relative path
 // and returns a list of items (directories and files) as WalkResult structs that pass the filter
 // The filter function receives each WalkResult and returns true if the item should be included
@@ -173,6 +309,10 @@ func (fs *Datastore) Walk(ctx context.Context, relativePath string, filter func(
 			return nil
 		}
 
+		if fs.isTrash(path) {
+			return filepath.SkipDir
+		}
+
 		// Create relative path from the root data folder
 		itemRelativePath, err := filepath.Rel(fs.rootFolder, path)
 		if err != nil {
@@ -201,6 +341,7 @@ func (fs *Datastore) Walk(ctx context.Context, relativePath string, filter func(
 
 // WalkTree recursively walks the directory tree and returns a FolderNode tree structure
 // The tree includes all directories and media files organized in a hierarchical structure
+// The other files and the items which cannot be read are recorded in the node of their folder
 func (fs *Datastore) WalkTree(ctx context.Context, relativePath string) (*entity.FolderNode, error) {
 	fullPath := filepath.Join(fs.rootFolder, relativePath)
 
@@ -221,21 +362,42 @@ func (fs *Datastore) WalkTree(ctx context.Context, relativePath string) (*entity
 	folderNodes := make(map[string]*entity.FolderNode)
 	folderNodes[relativePath] = root
 
+	// parentNode returns the node of the folder containing the item
+	parentNode := func(itemRelativePath string) *entity.FolderNode {
+		parentPath := filepath.Dir(itemRelativePath)
+		// Handle the case where parent is the root - filepath.Dir returns "." for top-level items
+		if parentPath == "." {
+			parentPath = relativePath // Use the original relative path (could be "")
+		}
+		return folderNodes[parentPath]
+	}
+
 	// Walk the directory tree
 	err := filepath.WalkDir(fullPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
+		// Skip the root directory itself
+		if path == fullPath {
 			return err
 		}
 
-		// Skip the root directory itself
-		if path == fullPath {
-			return nil
+		if fs.isTrash(path) {
+			return filepath.SkipDir
 		}
 
 		// Get relative path from fs root
-		itemRelativePath, err := filepath.Rel(fs.rootFolder, path)
+		itemRelativePath, relErr := filepath.Rel(fs.rootFolder, path)
+		if relErr != nil {
+			return fmt.Errorf("failed to get relative path for %s: %w", path, relErr)
+		}
+
+		// An unreadable item is recorded instead of failing the whole walk
 		if err != nil {
-			return fmt.Errorf("failed to get relative path for %s: %w", path, err)
+			if node := parentNode(itemRelativePath); node != nil {
+				node.UnreadableItems = append(node.UnreadableItems, itemRelativePath)
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
 
 		if d.IsDir() {
@@ -244,28 +406,30 @@ func (fs *Datastore) WalkTree(ctx context.Context, relativePath string) (*entity
 			folderNodes[itemRelativePath] = folderNode
 
 			// Find the parent folder and add this as a child
-			parentPath := filepath.Dir(itemRelativePath)
-			// Handle the case where parent is the root - filepath.Dir returns "." for top-level items
-			if parentPath == "." {
-				parentPath = relativePath // Use the original relative path (could be "")
-			}
-			if parentNode, exists := folderNodes[parentPath]; exists {
-				parentNode.AddChild(folderNode)
-			}
-		} else {
-			// This is a file - check if it's a media file and add to parent folder
-			if fs.isMediaFile(itemRelativePath) {
-				parentPath := filepath.Dir(itemRelativePath)
-				// Handle the case where parent is the root - filepath.Dir returns "." for top-level items
-				if parentPath == "." {
-					parentPath = relativePath // Use the original relative path (could be "")
-				}
-				if parentNode, exists := folderNodes[parentPath]; exists {
-					parentNode.AddMediaFile(itemRelativePath)
-				}
+			if node := parentNode(itemRelativePath); node != nil {
+				node.AddChild(folderNode)
 			}
+			return nil
 		}
 
+		// This is a file - check if it's a media file and add to parent folder
+		node := parentNode(itemRelativePath)
+		if node == nil {
+			return nil
+		}
+
+		if !fs.isMediaFile(itemRelativePath) {
+			node.UnsupportedFiles = append(node.UnsupportedFiles, itemRelativePath)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			node.UnreadableItems = append(node.UnreadableItems, itemRelativePath)
+			return nil
+		}
+		node.AddMediaFile(itemRelativePath, newFileInfo(info))
+
 		return nil
 	})
 
@@ -276,6 +440,11 @@ func (fs *Datastore) WalkTree(ctx context.Context, relativePath string) (*entity
 	return root, nil
 }
 
+// isTrash checks if the full path is the trash folder
+func (fs *Datastore) isTrash(fullPath string) bool {
+	return fullPath == filepath.Join(fs.rootFolder, TrashFolder)
+}
+
 // isMediaFile checks if a file is a supported media file based on extension
 func (fs *Datastore) isMediaFile(filePath string) bool {
 	ext := strings.ToLower(filepath.Ext(filePath))
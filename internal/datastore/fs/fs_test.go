@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
@@ -284,4 +285,248 @@ var _ = Describe("Filesystem Datastore", func() {
 			})
 		})
 	})
+
+	Describe("Rename", func() {
+		It("moves a folder with its content", func() {
+			createTestStructure(map[string][]string{
+				"trips/2023":       {"1.jpg"},
+				"trips/2023/italy": {"2.jpg"},
+			})
+
+			err := datastore.Rename(ctx, "trips/2023", "archive/2023-summer")
+			Expect(err).To(BeNil())
+
+			Expect(filepath.Join(tmpDir, "trips/2023")).ToNot(BeADirectory())
+			Expect(filepath.Join(tmpDir, "archive/2023-summer/1.jpg")).To(BeARegularFile())
+			Expect(filepath.Join(tmpDir, "archive/2023-summer/italy/2.jpg")).To(BeARegularFile())
+		})
+
+		It("moves a file", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+				"b": {},
+			})
+
+			err := datastore.Rename(ctx, "a/1.jpg", "b/1.jpg")
+			Expect(err).To(BeNil())
+
+			Expect(filepath.Join(tmpDir, "a/1.jpg")).ToNot(BeAnExistingFile())
+			Expect(filepath.Join(tmpDir, "b/1.jpg")).To(BeARegularFile())
+		})
+
+		It("does not overwrite an existing path", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+				"b": {"1.jpg"},
+			})
+
+			err := datastore.Rename(ctx, "a", "b")
+			Expect(err).To(MatchError(os.ErrExist))
+
+			Expect(filepath.Join(tmpDir, "a/1.jpg")).To(BeARegularFile())
+		})
+
+		It("returns an error if the source does not exist", func() {
+			err := datastore.Rename(ctx, "missing", "b")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+	Describe("Copy", func() {
+		It("copies a file into another folder", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+			})
+
+			err := datastore.Copy(ctx, "a/1.jpg", "b/1.jpg")
+			Expect(err).To(BeNil())
+
+			Expect(filepath.Join(tmpDir, "a/1.jpg")).To(BeARegularFile())
+			Expect(filepath.Join(tmpDir, "b/1.jpg")).To(BeARegularFile())
+
+			src, err := os.ReadFile(filepath.Join(tmpDir, "a/1.jpg"))
+			Expect(err).To(BeNil())
+			dst, err := os.ReadFile(filepath.Join(tmpDir, "b/1.jpg"))
+			Expect(err).To(BeNil())
+			Expect(dst).To(Equal(src))
+		})
+
+		It("does not overwrite an existing file", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+				"b": {"1.jpg"},
+			})
+
+			err := datastore.Copy(ctx, "a/1.jpg", "b/1.jpg")
+			Expect(err).To(MatchError(os.ErrExist))
+		})
+
+		It("returns an error if the source does not exist", func() {
+			err := datastore.Copy(ctx, "a/missing.jpg", "b/missing.jpg")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			Expect(filepath.Join(tmpDir, "b")).ToNot(BeADirectory())
+		})
+	})
+
+	Describe("Exists", func() {
+		It("reports files and folders", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+			})
+
+			for _, p := range []string{"a", "a/1.jpg"} {
+				exists, err := datastore.Exists(ctx, p)
+				Expect(err).To(BeNil())
+				Expect(exists).To(BeTrue())
+			}
+
+			exists, err := datastore.Exists(ctx, "a/2.jpg")
+			Expect(err).To(BeNil())
+			Expect(exists).To(BeFalse())
+		})
+	})
+	Describe("Trash", func() {
+		It("moves an album folder to the trash and restores it", func() {
+			createTestStructure(map[string][]string{
+				"trips/2023": {"1.jpg"},
+			})
+
+			err := datastore.MoveToTrash(ctx, "trips/2023", "album-id")
+			Expect(err).To(BeNil())
+			Expect(filepath.Join(tmpDir, "trips/2023")).ToNot(BeADirectory())
+			Expect(filepath.Join(tmpDir, fs.TrashFolder, "album-id/1.jpg")).To(BeARegularFile())
+
+			err = datastore.RestoreFromTrash(ctx, "album-id", "trips/2023")
+			Expect(err).To(BeNil())
+			Expect(filepath.Join(tmpDir, "trips/2023/1.jpg")).To(BeARegularFile())
+			Expect(filepath.Join(tmpDir, fs.TrashFolder, "album-id")).ToNot(BeAnExistingFile())
+		})
+
+		It("deletes an item from the trash", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+			})
+
+			Expect(datastore.MoveToTrash(ctx, "a/1.jpg", "media-id")).To(Succeed())
+			Expect(datastore.ListTrash(ctx)).To(ConsistOf("media-id"))
+
+			Expect(datastore.DeleteFromTrash(ctx, "media-id")).To(Succeed())
+			Expect(datastore.ListTrash(ctx)).To(BeEmpty())
+			Expect(filepath.Join(tmpDir, fs.TrashFolder, "media-id")).ToNot(BeAnExistingFile())
+
+			// idempotent
+			Expect(datastore.DeleteFromTrash(ctx, "media-id")).To(Succeed())
+		})
+
+		It("refuses names outside of the trash", func() {
+			Expect(datastore.DeleteFromTrash(ctx, "")).ToNot(Succeed())
+			Expect(datastore.DeleteFromTrash(ctx, "../a")).ToNot(Succeed())
+		})
+
+		It("is not walked", func() {
+			createTestStructure(map[string][]string{
+				"a":                       {"1.jpg"},
+				fs.TrashFolder + "/old":   {"2.jpg"},
+				fs.TrashFolder + "/old/b": {"3.jpg"},
+			})
+
+			tree, err := datastore.WalkTree(ctx, "")
+			Expect(err).To(BeNil())
+			Expect(tree.Children).To(HaveLen(1))
+			Expect(tree.Children[0].Path).To(Equal("a"))
+
+			results, err := datastore.Walk(ctx, "", fs.FilterAll)
+			Expect(err).To(BeNil())
+			Expect(results).To(ConsistOf(
+				fs.WalkResult{Path: "a", IsDirectory: true},
+				fs.WalkResult{Path: "a/1.jpg", IsDirectory: false},
+			))
+		})
+	})
+
+	Describe("Watch", func() {
+		interval := 10 * time.Millisecond
+
+		// watch collects the events reported by polling until cancel is called, which returns them
+		watch := func(paths ...string) func() []fs.WatchEvent {
+			watchCtx, cancel := context.WithCancel(ctx)
+			events, err := datastore.Watch(watchCtx, paths, fs.WatchOptions{Polling: true, PollInterval: interval})
+			Expect(err).To(BeNil())
+
+			collected := make(chan []fs.WatchEvent)
+			go func() {
+				received := []fs.WatchEvent{}
+				for event := range events {
+					received = append(received, event)
+				}
+				collected <- received
+			}()
+
+			// the first scan is the reference the changes are compared with
+			time.Sleep(5 * interval)
+
+			return func() []fs.WatchEvent {
+				time.Sleep(5 * interval)
+				cancel()
+				return <-collected
+			}
+		}
+
+		It("reports the new, changed and removed folders and media files by polling", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+				"c": {"3.jpg"},
+			})
+
+			stop := watch()
+
+			Expect(os.WriteFile(filepath.Join(tmpDir, "a/1.jpg"), []byte("changed content"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "a/2.jpg"), []byte("new"), 0644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tmpDir, "b"), 0755)).To(Succeed())
+			Expect(os.RemoveAll(filepath.Join(tmpDir, "c"))).To(Succeed())
+
+			Expect(stop()).To(ConsistOf(
+				fs.WatchEvent{Path: "a/1.jpg"},
+				fs.WatchEvent{Path: "a/2.jpg"},
+				fs.WatchEvent{Path: "b", IsDirectory: true},
+				fs.WatchEvent{Path: "c", Removed: true},
+				fs.WatchEvent{Path: "c/3.jpg", Removed: true},
+			))
+		})
+
+		It("ignores the files which are not media files and the trash", func() {
+			createTestStructure(map[string][]string{
+				"a": {"1.jpg"},
+			})
+
+			stop := watch()
+
+			Expect(os.WriteFile(filepath.Join(tmpDir, "a/notes.txt"), []byte("notes"), 0644)).To(Succeed())
+			Expect(datastore.MoveToTrash(ctx, "a/1.jpg", "media-id")).To(Succeed())
+
+			Expect(stop()).To(ConsistOf(
+				fs.WatchEvent{Path: "a/1.jpg", Removed: true},
+			))
+		})
+
+		It("only reports the changes below the watched folders", func() {
+			createTestStructure(map[string][]string{
+				"a": {},
+				"b": {},
+			})
+
+			stop := watch("a")
+
+			Expect(os.WriteFile(filepath.Join(tmpDir, "a/1.jpg"), []byte("new"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "b/2.jpg"), []byte("new"), 0644)).To(Succeed())
+
+			Expect(stop()).To(ConsistOf(
+				fs.WatchEvent{Path: "a/1.jpg"},
+			))
+		})
+
+		It("refuses a folder which does not exist", func() {
+			_, err := datastore.Watch(ctx, []string{"missing"}, fs.WatchOptions{Polling: true})
+			Expect(err).ToNot(BeNil())
+		})
+	})
 })
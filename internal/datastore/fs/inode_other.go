@@ -0,0 +1,10 @@
+//go:build !unix
+
+package fs
+
+import "os"
+
+// inode is not available outside unix, the files are compared by size and modification time only
+func inode(info os.FileInfo) *uint64 {
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package fs
+
+import (
+	"os"
+	"syscall"
+)
+
+// inode returns the inode number of the file
+func inode(info os.FileInfo) *uint64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	ino := uint64(stat.Ino)
+	return &ino
+}
@@ -0,0 +1,259 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// defaultPollInterval is used when WatchOptions does not set a poll interval
+const defaultPollInterval = time.Minute
+
+// WatchEvent is a change of a folder or a media file reported by Watch
+type WatchEvent struct {
+	Path        string // Relative path from the root data folder
+	IsDirectory bool   // True if this is a directory. Unknown for a removed item, it is always false then
+	Removed     bool   // True if the item was removed or renamed away
+}
+
+// WatchOptions configures how Watch detects the changes
+type WatchOptions struct {
+	// Polling scans the folders every PollInterval instead of using inotify
+	Polling      bool
+	PollInterval time.Duration
+}
+
+// Watch reports the changes of the folders and media files below the given relative paths, the whole
+// data folder if there are none, until the context is done. The channel is closed afterwards.
+// inotify is used where it is available. The folders on a network filesystem, where inotify does not
+// fire for changes made by other hosts, and the folders inotify cannot watch are polled instead.
+func (fs *Datastore) Watch(ctx context.Context, relativePaths []string, opts WatchOptions) (<-chan WatchEvent, error) {
+	if len(relativePaths) == 0 {
+		relativePaths = []string{""}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+
+	for _, relativePath := range relativePaths {
+		if info, err := os.Stat(filepath.Join(fs.rootFolder, relativePath)); err != nil {
+			return nil, err
+		} else if !info.IsDir() {
+			return nil, errors.New("path is not a directory: " + relativePath)
+		}
+	}
+
+	events := make(chan WatchEvent)
+	var wg sync.WaitGroup
+
+	polled := []string{}
+	notified := []string{}
+	for _, relativePath := range relativePaths {
+		if opts.Polling || isNetworkFilesystem(filepath.Join(fs.rootFolder, relativePath)) {
+			polled = append(polled, relativePath)
+			continue
+		}
+		notified = append(notified, relativePath)
+	}
+
+	if len(notified) > 0 {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			zap.S().Warnw("inotify is not available, the folders are polled", "error", err)
+			polled = append(polled, notified...)
+		} else {
+			for _, relativePath := range notified {
+				if err := fs.addWatches(watcher, relativePath); err != nil {
+					zap.S().Warnw("failed to watch folder with inotify, it is polled", "path", relativePath, "error", err)
+					polled = append(polled, relativePath)
+				}
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fs.notify(ctx, watcher, events)
+			}()
+		}
+	}
+
+	for _, relativePath := range polled {
+		wg.Add(1)
+		go func(relativePath string) {
+			defer wg.Done()
+			fs.poll(ctx, relativePath, opts.PollInterval, events)
+		}(relativePath)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events, nil
+}
+
+// addWatches adds an inotify watch on the folder and on all its subfolders, inotify is not recursive.
+func (fs *Datastore) addWatches(watcher *fsnotify.Watcher, relativePath string) error {
+	return filepath.WalkDir(filepath.Join(fs.rootFolder, relativePath), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if fs.isTrash(path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// notify forwards the inotify events of the watched folders. The new folders are watched as well.
+func (fs *Datastore) notify(ctx context.Context, watcher *fsnotify.Watcher, events chan<- WatchEvent) {
+	defer watcher.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			zap.S().Warnw("inotify watcher error", "error", err)
+		case e, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if e.Has(fsnotify.Chmod) && !e.Has(fsnotify.Create) && !e.Has(fsnotify.Write) {
+				continue
+			}
+
+			relativePath, err := filepath.Rel(fs.rootFolder, e.Name)
+			if err != nil || fs.isTrash(e.Name) {
+				continue
+			}
+
+			event := WatchEvent{Path: relativePath, Removed: e.Has(fsnotify.Remove) || e.Has(fsnotify.Rename)}
+			if !event.Removed {
+				info, err := os.Stat(e.Name)
+				if err != nil {
+					// the item is already gone, its removal is reported by another event
+					continue
+				}
+				event.IsDirectory = info.IsDir()
+
+				if event.IsDirectory && e.Has(fsnotify.Create) {
+					if err := fs.addWatches(watcher, relativePath); err != nil {
+						zap.S().Warnw("failed to watch new folder", "path", relativePath, "error", err)
+					}
+				}
+			}
+
+			if !event.IsDirectory && !event.Removed && !fs.isMediaFile(relativePath) {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// pollEntry is what poll compares between two scans to find the changed items
+type pollEntry struct {
+	isDirectory bool
+	size        int64
+	modTime     time.Time
+}
+
+// poll scans the folder every interval and reports the differences with the previous scan.
+func (fs *Datastore) poll(ctx context.Context, relativePath string, interval time.Duration, events chan<- WatchEvent) {
+	previous := fs.scan(relativePath)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := fs.scan(relativePath)
+
+		changes := []WatchEvent{}
+		for path, entry := range current {
+			if old, found := previous[path]; !found || (!entry.isDirectory && (old.size != entry.size || !old.modTime.Equal(entry.modTime))) {
+				changes = append(changes, WatchEvent{Path: path, IsDirectory: entry.isDirectory})
+			}
+		}
+		for path := range previous {
+			if _, found := current[path]; !found {
+				changes = append(changes, WatchEvent{Path: path, Removed: true})
+			}
+		}
+
+		previous = current
+
+		for _, event := range changes {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// scan lists the folders and the media files below the folder. The unreadable items are skipped.
+func (fs *Datastore) scan(relativePath string) map[string]pollEntry {
+	entries := make(map[string]pollEntry)
+	fullPath := filepath.Join(fs.rootFolder, relativePath)
+
+	_ = filepath.WalkDir(fullPath, func(path string, d os.DirEntry, err error) error {
+		if path == fullPath {
+			return nil
+		}
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if fs.isTrash(path) {
+			return filepath.SkipDir
+		}
+
+		itemRelativePath, err := filepath.Rel(fs.rootFolder, path)
+		if err != nil || (!d.IsDir() && !fs.isMediaFile(itemRelativePath)) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		entries[itemRelativePath] = pollEntry{
+			isDirectory: d.IsDir(),
+			size:        info.Size(),
+			modTime:     info.ModTime(),
+		}
+		return nil
+	})
+
+	return entries
+}
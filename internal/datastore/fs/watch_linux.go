@@ -0,0 +1,29 @@
+//go:build linux
+
+package fs
+
+import "syscall"
+
+// Magic numbers of the network filesystems, see statfs(2)
+const (
+	nfsSuperMagic  = 0x6969
+	smbSuperMagic  = 0x517b
+	cifsSuperMagic = 0xff534d42
+	smb2SuperMagic = 0xfe534d42
+)
+
+// isNetworkFilesystem checks if the folder is on a network filesystem where inotify does not report
+// the changes made by other hosts.
+func isNetworkFilesystem(fullPath string) bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(fullPath, &stat); err != nil {
+		return false
+	}
+
+	switch uint32(stat.Type) {
+	case nfsSuperMagic, smbSuperMagic, cifsSuperMagic, smb2SuperMagic:
+		return true
+	default:
+		return false
+	}
+}
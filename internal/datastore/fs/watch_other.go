@@ -0,0 +1,9 @@
+//go:build !linux
+
+package fs
+
+// isNetworkFilesystem cannot tell the network filesystems apart outside linux, they must be polled
+// with the Polling option.
+func isNetworkFilesystem(fullPath string) bool {
+	return false
+}
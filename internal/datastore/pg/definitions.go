@@ -4,11 +4,20 @@ import (
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
 const (
-	albumsTable = "albums"
-	mediaTable  = "media"
+	albumsTable          = "albums"
+	mediaTable           = "media"
+	collectionsTable     = "collections"
+	collectionMediaTable = "collection_media"
+	smartAlbumsTable     = "smart_albums"
+	commentsTable        = "media_comments"
+	jobsTable            = "jobs"
+	jobResultsTable      = "job_results"
+	searchDocumentsView  = "search_documents"
 	// Albums table columns
 	albumID          = "id"
 	albumCreatedAt   = "created_at"
@@ -16,6 +25,10 @@ const (
 	albumDescription = "description"
 	albumParentID    = "parent_id"
 	albumThumbnailID = "thumbnail_id"
+	albumTrashedAt   = "trashed_at"
+	albumHidden      = "hidden"
+	// albumSubtreeMediaCount is the cached number of media of the album and its descendants, see refreshAlbumStatsStmts
+	albumSubtreeMediaCount = "subtree_media_count"
 
 	// Albums table columns for join scenarios
 	albumChildID          = "child.id as child_id"
@@ -32,8 +45,20 @@ const (
 	mediaFileName   = "file_name"
 	mediaThumbnail  = "thumbnail"
 	mediaExif       = "exif"
+	mediaCaption    = "caption"
 	mediaMediaType  = "media_type"
 	mediaHash       = "hash"
+	mediaCamera     = "camera"
+	mediaTags       = "tags"
+	mediaRating     = "rating"
+	mediaFavorite   = "favorite"
+	mediaLatitude   = "latitude"
+	mediaLongitude  = "longitude"
+	mediaTrashedAt  = "trashed_at"
+	// attributes of the media file when it was last synced
+	mediaFileSize       = "file_size"
+	mediaFileModifiedAt = "file_modified_at"
+	mediaFileInode      = "file_inode"
 
 	// Album table columns for media select join scenarios
 	albumMediaCreatedAt   = "albums.created_at as album_created_at"
@@ -50,6 +75,62 @@ const (
 	mediaExifJoin       = "media.exif as media_exif"
 	mediaMediaTypeJoin  = "media.media_type as media_media_type"
 
+	// Collections table columns
+	collectionID          = "id"
+	collectionCreatedAt   = "created_at"
+	collectionName        = "name"
+	collectionDescription = "description"
+	collectionThumbnailID = "thumbnail_id"
+
+	// Collection media table columns
+	collectionMediaCollectionID = "collection_id"
+	collectionMediaMediaID      = "media_id"
+	collectionMediaPosition     = "position"
+
+	// Smart albums table columns
+	smartAlbumID          = "id"
+	smartAlbumCreatedAt   = "created_at"
+	smartAlbumName        = "name"
+	smartAlbumDescription = "description"
+	smartAlbumFilter      = "filter"
+
+	// Comments table columns
+	commentID        = "id"
+	commentMediaID   = "media_id"
+	commentParentID  = "parent_id"
+	commentAuthor    = "author"
+	commentBody      = "body"
+	commentCreatedAt = "created_at"
+	commentUpdatedAt = "updated_at"
+
+	// Jobs table columns
+	jobID          = "id"
+	jobType        = "type"
+	jobPath        = "path"
+	jobMetadata    = "metadata"
+	jobStatus      = "status"
+	jobReason      = "reason"
+	jobTotal       = "total"
+	jobRemaining   = "remaining"
+	jobCreatedAt   = "created_at"
+	jobStartedAt   = "started_at"
+	jobCompletedAt = "completed_at"
+
+	// Job results table columns
+	jobResultJobID       = "job_id"
+	jobResultPosition    = "position"
+	jobResultResult      = "result"
+	jobResultError       = "error"
+	jobResultStartedAt   = "started_at"
+	jobResultCompletedAt = "completed_at"
+
+	// Search documents view columns
+	searchDocumentKind     = "kind"
+	searchDocumentID       = "id"
+	searchDocumentDocument = "document"
+	// searchConfig is the text search configuration. "simple" does no stemming which suits file names and tags.
+	searchConfig = "simple"
+
 	// Definition for zed token and lock
 	lockKey        = "zed_token_lock_key"
 	zedTable       = "zed_token"
@@ -58,11 +139,28 @@ const (
 	globalLockStmt = "SELECT pg_advisory_xact_lock(%d);"
 	sharedLockStmt = "SELECT pg_advisory_xact_lock_shared(%d);"
 	writeStmt      = "INSERT INTO zed_token (id, token) VALUES (1, $1) ON CONFLICT (id) DO UPDATE SET token = excluded.token;"
+
+	// insertBatchSize is the maximum number of rows inserted by a single statement
+	insertBatchSize = 1000
 )
 
+// albumSortKey is the SQL expression an album listing is sorted by and the type its value is cast back to
+// when it is read from a cursor.
+type albumSortKey struct {
+	expr    string
+	sqlType string
+}
+
 var (
 	psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+	albumSortKeys = map[entity.AlbumSortBy]albumSortKey{
+		entity.AlbumSortByName:          {expr: "lower(regexp_replace(albums.path, '^.*/', ''))", sqlType: "text"},
+		entity.AlbumSortByCreatedAt:     {expr: "albums.created_at", sqlType: "timestamp"},
+		entity.AlbumSortByLatestCapture: {expr: "coalesce(albums.subtree_latest_captured_at, '-infinity'::timestamp)", sqlType: "timestamp"},
+		entity.AlbumSortByMediaCount:    {expr: "albums.subtree_media_count", sqlType: "integer"},
+	}
+
 	listAlbumStmt = psql.Select(
 		preffix(albumsTable, albumID),
 		preffix(albumsTable, albumCreatedAt),
@@ -70,6 +168,7 @@ var (
 		preffix(albumsTable, albumDescription),
 		preffix(albumsTable, albumParentID),
 		preffix(albumsTable, albumThumbnailID),
+		preffix(albumsTable, albumHidden),
 		albumChildID,
 		albumChildCreatedAt,
 		albumChildPath,
@@ -84,8 +183,9 @@ var (
 		mediaMediaTypeJoin,
 	).
 		From(albumsTable).
-		LeftJoin("albums as child on child.parent_id = albums.id").
-		LeftJoin("media as media on media.album_id = albums.id")
+		LeftJoin("albums as child on child.parent_id = albums.id and child.trashed_at is null").
+		LeftJoin("media as media on media.album_id = albums.id and media.trashed_at is null").
+		Where(sq.Eq{preffix(albumsTable, albumTrashedAt): nil})
 
 	listAlbumsStmt = psql.Select(
 		preffix(albumsTable, albumID),
@@ -94,7 +194,8 @@ var (
 		preffix(albumsTable, albumDescription),
 		preffix(albumsTable, albumParentID),
 		preffix(albumsTable, albumThumbnailID),
-		"count_media_in_album_with_children(albums.id) as media_count",
+		preffix(albumsTable, albumHidden),
+		preffix(albumsTable, albumSubtreeMediaCount),
 		albumChildID,
 		albumChildCreatedAt,
 		albumChildPath,
@@ -102,9 +203,25 @@ var (
 		albumChildThumbnailID,
 	).
 		From(albumsTable).
-		LeftJoin("albums as child on child.parent_id = albums.id")
+		LeftJoin("albums as child on child.parent_id = albums.id and child.trashed_at is null").
+		Where(sq.Eq{preffix(albumsTable, albumTrashedAt): nil})
 
-	listMediaStmt = psql.Select(
+	// listTrashedAlbumsStmt lists the albums which were deleted themselves, not the subalbums trashed with them
+	listTrashedAlbumsStmt = psql.Select(
+		preffix(albumsTable, albumID),
+		preffix(albumsTable, albumCreatedAt),
+		preffix(albumsTable, albumPath),
+		preffix(albumsTable, albumDescription),
+		preffix(albumsTable, albumParentID),
+		preffix(albumsTable, albumThumbnailID),
+		preffix(albumsTable, albumTrashedAt),
+	).
+		From(albumsTable).
+		Where(sq.NotEq{preffix(albumsTable, albumTrashedAt): nil}).
+		Where("not exists (select 1 from albums as parent where parent.id = albums.parent_id and parent.trashed_at = albums.trashed_at)")
+
+	// selectMediaStmt selects the media with their album, trashed or not
+	selectMediaStmt = psql.Select(
 		preffix(mediaTable, mediaID),
 		preffix(mediaTable, mediaCreatedAt),
 		preffix(mediaTable, mediaCapturedAt),
@@ -114,6 +231,17 @@ var (
 		preffix(mediaTable, mediaHash),
 		preffix(mediaTable, mediaExif),
 		preffix(mediaTable, mediaMediaType),
+		preffix(mediaTable, mediaCaption),
+		preffix(mediaTable, mediaCamera),
+		preffix(mediaTable, mediaTags),
+		preffix(mediaTable, mediaRating),
+		preffix(mediaTable, mediaFavorite),
+		preffix(mediaTable, mediaLatitude),
+		preffix(mediaTable, mediaLongitude),
+		preffix(mediaTable, mediaTrashedAt),
+		preffix(mediaTable, mediaFileSize),
+		preffix(mediaTable, mediaFileModifiedAt),
+		preffix(mediaTable, mediaFileInode),
 		albumMediaCreatedAt,
 		albumMediaPath,
 		albumMediaDescription,
@@ -122,9 +250,218 @@ var (
 		From(mediaTable).
 		InnerJoin("albums on albums.id = media.album_id")
 
-	statAlbumMediaStmt = `select (select count(*) from albums) as total_albums, (select count(*) from media) as total_media;`
+	listMediaStmt = selectMediaStmt.Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil})
+
+	// listMediaFilesStmt lists the files of the media, without the thumbnails and exif of listMediaStmt
+	listMediaFilesStmt = psql.Select(
+		preffix(mediaTable, mediaID),
+		preffix(mediaTable, mediaAlbumID),
+		preffix(mediaTable, mediaFileName),
+		preffix(mediaTable, mediaHash),
+		preffix(mediaTable, mediaFileSize),
+		preffix(mediaTable, mediaFileModifiedAt),
+		preffix(mediaTable, mediaFileInode),
+		albumMediaPath,
+	).
+		From(mediaTable).
+		InnerJoin("albums on albums.id = media.album_id").
+		Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil})
+
+	// listTrashedMediaStmt lists the media which were deleted themselves, not the media trashed with their album
+	listTrashedMediaStmt = selectMediaStmt.Where("media.trashed_at is not null and albums.trashed_at is distinct from media.trashed_at")
+
+	listCollectionsStmt = psql.Select(
+		preffix(collectionsTable, collectionID),
+		preffix(collectionsTable, collectionCreatedAt),
+		preffix(collectionsTable, collectionName),
+		preffix(collectionsTable, collectionDescription),
+		preffix(collectionsTable, collectionThumbnailID),
+		"(select count(*) from collection_media inner join media on media.id = collection_media.media_id where collection_media.collection_id = collections.id and media.trashed_at is null) as media_count",
+	).
+		From(collectionsTable)
+
+	listSmartAlbumsStmt = psql.Select(
+		preffix(smartAlbumsTable, smartAlbumID),
+		preffix(smartAlbumsTable, smartAlbumCreatedAt),
+		preffix(smartAlbumsTable, smartAlbumName),
+		preffix(smartAlbumsTable, smartAlbumDescription),
+		preffix(smartAlbumsTable, smartAlbumFilter),
+	).
+		From(smartAlbumsTable)
+
+	listCommentsStmt = psql.Select(
+		preffix(commentsTable, commentID),
+		preffix(commentsTable, commentMediaID),
+		preffix(commentsTable, commentParentID),
+		preffix(commentsTable, commentAuthor),
+		preffix(commentsTable, commentBody),
+		preffix(commentsTable, commentCreatedAt),
+		preffix(commentsTable, commentUpdatedAt),
+	).
+		From(commentsTable)
 
-	statYearsStmt = `SELECT DISTINCT EXTRACT(YEAR FROM captured_at)::INTEGER AS year FROM media WHERE captured_at IS NOT NULL ORDER BY year DESC;`
+	listJobsStmt = psql.Select(
+		preffix(jobsTable, jobID),
+		preffix(jobsTable, jobMetadata),
+		preffix(jobsTable, jobStatus),
+		preffix(jobsTable, jobReason),
+		preffix(jobsTable, jobTotal),
+		preffix(jobsTable, jobRemaining),
+		preffix(jobsTable, jobCreatedAt),
+		preffix(jobsTable, jobStartedAt),
+		preffix(jobsTable, jobCompletedAt),
+	).
+		From(jobsTable)
+
+	listJobResultsStmt = psql.Select(
+		preffix(jobResultsTable, jobResultJobID),
+		preffix(jobResultsTable, jobResultResult),
+		preffix(jobResultsTable, jobResultError),
+		preffix(jobResultsTable, jobResultStartedAt),
+		preffix(jobResultsTable, jobResultCompletedAt),
+	).
+		From(jobResultsTable).
+		OrderBy(preffix(jobResultsTable, jobResultJobID), preffix(jobResultsTable, jobResultPosition))
+
+	statAlbumMediaStmt = `select (select count(*) from albums where trashed_at is null) as total_albums, (select count(*) from media where trashed_at is null) as total_media;`
+
+	statYearsStmt = `SELECT DISTINCT EXTRACT(YEAR FROM captured_at)::INTEGER AS year FROM media WHERE captured_at IS NOT NULL AND trashed_at IS NULL ORDER BY year DESC;`
+
+	// timelineStmt counts the media per capture month. The media of the trashed albums are trashed too.
+	timelineStmt = psql.Select(
+		"extract(year from media.captured_at)::int as year",
+		"extract(month from media.captured_at)::int as month",
+	).
+		From(mediaTable).
+		Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil}).
+		GroupBy("year", "month").
+		OrderBy("year desc", "month desc")
+
+	// albumTreeSelectStmt selects the albums of the tree. The query options restrict it to the albums
+	// the tree is made of: the statistics of albumTreeStmt only count the media of these albums.
+	albumTreeSelectStmt = psql.Select(
+		preffix(albumsTable, albumID),
+		preffix(albumsTable, albumParentID),
+	).
+		From(albumsTable).
+		Where(sq.Eq{preffix(albumsTable, albumTrashedAt): nil})
+
+	// albumTreeStmt computes the statistics of the albums returned by albumTreeSelectStmt in a single query:
+	// album_tree pairs every album with itself and with each of its descendants, so the subtree statistics
+	// are an aggregate over the pairs of the album instead of a recursive query per album.
+	albumTreeStmt = `WITH RECURSIVE tree_albums AS (%s),
+own AS (
+	SELECT media.album_id,
+		count(*)::int AS media_count,
+		min(media.captured_at) AS earliest,
+		max(media.captured_at) AS latest,
+		(array_agg(media.id ORDER BY media.captured_at DESC NULLS LAST))[1] AS latest_media_id
+	FROM media
+	INNER JOIN tree_albums ON tree_albums.id = media.album_id
+	WHERE media.trashed_at IS NULL
+	GROUP BY media.album_id
+),
+album_tree AS (
+	SELECT tree_albums.id AS root_id, tree_albums.id AS album_id FROM tree_albums
+	UNION ALL
+	SELECT album_tree.root_id, tree_albums.id FROM album_tree INNER JOIN tree_albums ON tree_albums.parent_id = album_tree.album_id
+),
+subtree AS (
+	SELECT album_tree.root_id,
+		coalesce(sum(own.media_count), 0)::int AS media_count,
+		min(own.earliest) AS earliest,
+		max(own.latest) AS latest,
+		(array_agg(own.latest_media_id ORDER BY own.latest DESC NULLS LAST) FILTER (WHERE own.album_id IS NOT NULL))[1] AS latest_media_id
+	FROM album_tree
+	LEFT JOIN own ON own.album_id = album_tree.album_id
+	GROUP BY album_tree.root_id
+)
+SELECT albums.id, albums.created_at, albums.path, albums.description, albums.parent_id, albums.thumbnail_id, albums.hidden,
+	coalesce(own.media_count, 0), subtree.media_count, subtree.earliest, subtree.latest,
+	coalesce(albums.thumbnail_id, subtree.latest_media_id)
+FROM albums
+INNER JOIN subtree ON subtree.root_id = albums.id
+LEFT JOIN own ON own.album_id = albums.id
+ORDER BY albums.path`
+
+	// insertAlbumClosureStmt links a new album to itself and to every ancestor of its parent.
+	insertAlbumClosureStmt = `INSERT INTO album_closure (ancestor_id, descendant_id, depth)
+SELECT $1::text, $1::text, 0
+UNION ALL
+SELECT ancestor_id, $1::text, depth + 1 FROM album_closure WHERE descendant_id = $2
+ON CONFLICT DO NOTHING`
+
+	// moveAlbumClosureStmts detach the subtree of an album from its former ancestors
+	// and attach it to every ancestor of the new parent.
+	moveAlbumClosureStmts = []string{
+		`DELETE FROM album_closure
+WHERE descendant_id IN (SELECT descendant_id FROM album_closure WHERE ancestor_id = $1)
+AND ancestor_id NOT IN (SELECT descendant_id FROM album_closure WHERE ancestor_id = $1)`,
+		`INSERT INTO album_closure (ancestor_id, descendant_id, depth)
+SELECT parent.ancestor_id, subtree.descendant_id, parent.depth + subtree.depth + 1
+FROM album_closure parent
+CROSS JOIN album_closure subtree
+WHERE parent.descendant_id = $2 AND subtree.ancestor_id = $1`,
+	}
+
+	// lockAlbumAncestorsStmt locks the albums in $1 and all their ancestors in id order before their statistics
+	// are changed, so the transactions changing the statistics of the same albums wait for each other instead
+	// of deadlocking.
+	lockAlbumAncestorsStmt = `SELECT id FROM albums
+WHERE id IN (SELECT ancestor_id FROM album_closure WHERE descendant_id = ANY($1))
+ORDER BY id
+FOR UPDATE`
+
+	// addAlbumMediaStatsStmts add $2 media captured at $3 to the cached statistics of the album $1 and of all
+	// its ancestors. The latest capture dates only grow, a media captured earlier must refresh the statistics.
+	addAlbumMediaStatsStmts = []string{
+		`UPDATE albums SET
+	media_count = media_count + $2,
+	latest_captured_at = greatest(latest_captured_at, $3::timestamp)
+WHERE id = $1`,
+		`UPDATE albums SET
+	subtree_media_count = subtree_media_count + $2,
+	subtree_latest_captured_at = greatest(subtree_latest_captured_at, $3::timestamp)
+WHERE id IN (SELECT ancestor_id FROM album_closure WHERE descendant_id = $1)
+AND EXISTS (SELECT 1 FROM albums album WHERE album.id = $1 AND album.trashed_at IS NULL)`,
+	}
+
+	// refreshAlbumStatsStmts recompute the cached statistics of the albums in $1 and of all their ancestors.
+	// The album statistics are read from the media of the album using idx_media_album_captured_at,
+	// the subtree statistics are summed from the album statistics of the descendants.
+	refreshAlbumStatsStmts = []string{
+		`UPDATE albums SET
+	media_count = (SELECT count(*) FROM media WHERE media.album_id = albums.id AND media.trashed_at IS NULL),
+	latest_captured_at = (SELECT max(captured_at) FROM media WHERE media.album_id = albums.id AND media.trashed_at IS NULL)
+WHERE albums.id = ANY($1)`,
+		`UPDATE albums SET
+	subtree_media_count = subtree.media_count,
+	subtree_latest_captured_at = subtree.latest
+FROM (
+	SELECT album_closure.ancestor_id,
+		coalesce(sum(descendant.media_count) FILTER (WHERE descendant.trashed_at IS NULL), 0)::int AS media_count,
+		max(descendant.latest_captured_at) FILTER (WHERE descendant.trashed_at IS NULL) AS latest
+	FROM album_closure
+	INNER JOIN albums descendant ON descendant.id = album_closure.descendant_id
+	WHERE album_closure.ancestor_id IN (SELECT ancestor_id FROM album_closure WHERE descendant_id = ANY($1))
+	GROUP BY album_closure.ancestor_id
+) subtree
+WHERE subtree.ancestor_id = albums.id`,
+	}
+
+	// rebuildAlbumHierarchyStmts recompute the closure table and the cached statistics of every album.
+	rebuildAlbumHierarchyStmts = []string{
+		`DELETE FROM album_closure`,
+		`WITH RECURSIVE closure AS (
+	SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth FROM albums
+	UNION ALL
+	SELECT closure.ancestor_id, albums.id, closure.depth + 1
+	FROM closure
+	INNER JOIN albums ON albums.parent_id = closure.descendant_id
+)
+INSERT INTO album_closure (ancestor_id, descendant_id, depth)
+SELECT ancestor_id, descendant_id, depth FROM closure`,
+	}
 
 	tokenWriteStmt  = psql.Insert(zedTable).Columns("id", "token")
 	selectTokenStmt = psql.Select("token").From(zedTable).Limit(1)
@@ -36,6 +36,8 @@ func (aa Albums) Entity() []entity.Album {
 			album.Path = row.Path
 			album.Description = row.Description
 			album.ParentId = row.ParentID
+			album.TrashedAt = row.TrashedAt
+			album.Hidden = row.Hidden
 			if row.ThumbnailID != nil {
 				album.Thumbnail = row.ThumbnailID
 			}
@@ -94,13 +96,15 @@ func (aa Albums) Entity() []entity.Album {
 
 // Album represents the database model for albums table
 type Album struct {
-	ID          string    `db:"id"`
-	CreatedAt   time.Time `db:"created_at"`
-	Path        string    `db:"path"`
-	Description *string   `db:"description"`
-	ParentID    *string   `db:"parent_id"`
-	ThumbnailID *string   `db:"thumbnail_id"`
-	MediaCount  *int      `db:"media_count"`
+	ID          string     `db:"id"`
+	CreatedAt   time.Time  `db:"created_at"`
+	Path        string     `db:"path"`
+	Description *string    `db:"description"`
+	ParentID    *string    `db:"parent_id"`
+	ThumbnailID *string    `db:"thumbnail_id"`
+	Hidden      bool       `db:"hidden"`
+	MediaCount  *int       `db:"media_count"`
+	TrashedAt   *time.Time `db:"trashed_at"`
 
 	// Fields for join scenarios (child albums)
 	ChildID          *string    `db:"child_id"`
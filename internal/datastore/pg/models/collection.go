@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// Collections stores collection rows in the order returned by the query
+type Collections []Collection
+
+// Entity converts the database model collections to entity collections.
+func (cc Collections) Entity() []entity.Collection {
+	collections := make([]entity.Collection, 0, len(cc))
+	for _, c := range cc {
+		collections = append(collections, entity.Collection{
+			ID:          c.ID,
+			CreatedAt:   c.CreatedAt,
+			Name:        c.Name,
+			Description: c.Description,
+			Thumbnail:   c.ThumbnailID,
+			MediaCount:  c.MediaCount,
+		})
+	}
+	return collections
+}
+
+// Collection represents the database model for collections table
+type Collection struct {
+	ID          string    `db:"id"`
+	CreatedAt   time.Time `db:"created_at"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+	ThumbnailID *string   `db:"thumbnail_id"`
+	MediaCount  int       `db:"media_count"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// Comments stores comment rows in the order returned by the query
+type Comments []Comment
+
+// Entity converts the database model comments to entity comments.
+func (cc Comments) Entity() []entity.Comment {
+	comments := make([]entity.Comment, 0, len(cc))
+	for _, c := range cc {
+		comments = append(comments, entity.Comment{
+			ID:        c.ID,
+			MediaID:   c.MediaID,
+			ParentID:  c.ParentID,
+			Author:    c.Author,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	return comments
+}
+
+// Comment represents the database model for media_comments table
+type Comment struct {
+	ID        string    `db:"id"`
+	MediaID   string    `db:"media_id"`
+	ParentID  *string   `db:"parent_id"`
+	Author    string    `db:"author"`
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
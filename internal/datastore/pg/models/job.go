@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// Job represents the database model for the jobs table
+type Job struct {
+	ID          uuid.UUID         `db:"id"`
+	Metadata    map[string]string `db:"metadata"`
+	Status      string            `db:"status"`
+	Reason      string            `db:"reason"`
+	Total       int               `db:"total"`
+	Remaining   int               `db:"remaining"`
+	CreatedAt   time.Time         `db:"created_at"`
+	StartedAt   *time.Time        `db:"started_at"`
+	CompletedAt *time.Time        `db:"completed_at"`
+}
+
+// Entity converts the database model job and the results of its tasks to an entity job progress
+func (j Job) Entity(results []JobResult) entity.JobProgress {
+	progress := entity.JobProgress{
+		Id:          j.ID,
+		Status:      entity.JobStatus(j.Status),
+		Reason:      j.Reason,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.CompletedAt,
+		Total:       j.Total,
+		Remaining:   j.Remaining,
+		Results:     make([]entity.JobResult, 0, len(results)),
+		Path:        j.Metadata["path"],
+		Metadata:    j.Metadata,
+	}
+
+	for _, r := range results {
+		progress.Results = append(progress.Results, r.Entity())
+	}
+
+	return progress
+}
+
+// JobResult represents the database model for the job_results table
+type JobResult struct {
+	JobID       uuid.UUID `db:"job_id"`
+	Result      string    `db:"result"`
+	Error       *string   `db:"error"`
+	StartedAt   time.Time `db:"started_at"`
+	CompletedAt time.Time `db:"completed_at"`
+}
+
+// Entity converts the database model job result to an entity job result.
+// Only the message of the error of the task is kept.
+func (r JobResult) Entity() entity.JobResult {
+	result := entity.JobResult{
+		Result:      r.Result,
+		StartedAt:   r.StartedAt,
+		CompletedAt: r.CompletedAt,
+	}
+	if r.Error != nil {
+		result.Err = errors.New(*r.Error)
+	}
+	return result
+}
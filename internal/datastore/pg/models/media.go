@@ -7,12 +7,12 @@ import (
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
-// MediaList stores media records indexed by ID for efficient grouping
-type MediaList map[string]Media
+// MediaList stores media records in the order returned by the query
+type MediaList []Media
 
-// Add appends a media to the collection.
-func (mm MediaList) Add(m Media) {
-	mm[m.ID] = m
+// Add appends a media to the list.
+func (mm *MediaList) Add(m Media) {
+	*mm = append(*mm, m)
 }
 
 // Entity converts the database model media to entity media.
@@ -49,6 +49,19 @@ func (mm MediaList) Entity() []entity.Media {
 			Exif:       exifMetadata,
 			MediaType:  entity.MediaType(m.MediaType),
 			Album:      album,
+			Caption:    m.Caption,
+			Camera:     m.Camera,
+			Tags:       m.Tags,
+			Rating:     m.Rating,
+			Favorite:   m.Favorite,
+			TrashedAt:  m.TrashedAt,
+		}
+		media.File = m.FileInfo()
+		if m.Latitude != nil && m.Longitude != nil {
+			media.Location = &entity.Location{
+				Latitude:  *m.Latitude,
+				Longitude: *m.Longitude,
+			}
 		}
 		mediaList = append(mediaList, media)
 	}
@@ -66,6 +79,19 @@ type Media struct {
 	Hash       string           `db:"hash"`
 	Exif       *json.RawMessage `db:"exif"`
 	MediaType  string           `db:"media_type"`
+	Caption    *string          `db:"caption"`
+	Camera     *string          `db:"camera"`
+	Tags       []string         `db:"tags"`
+	Rating     *int             `db:"rating"`
+	Favorite   bool             `db:"favorite"`
+	Latitude   *float64         `db:"latitude"`
+	Longitude  *float64         `db:"longitude"`
+	TrashedAt  *time.Time       `db:"trashed_at"`
+
+	// File attributes, null until the media is synced
+	FileSize       *int64     `db:"file_size"`
+	FileModifiedAt *time.Time `db:"file_modified_at"`
+	FileInode      *int64     `db:"file_inode"`
 
 	// Album fields from join
 	AlbumJoinCreatedAt   time.Time `db:"album_created_at"`
@@ -73,3 +99,20 @@ type Media struct {
 	AlbumJoinDescription *string   `db:"album_description"`
 	AlbumJoinThumbnailID *string   `db:"album_thumbnail_id"`
 }
+
+// FileInfo returns the attributes of the file of the media, nil if they are not known.
+func (m Media) FileInfo() *entity.FileInfo {
+	if m.FileSize == nil || m.FileModifiedAt == nil {
+		return nil
+	}
+
+	info := &entity.FileInfo{
+		Size:       *m.FileSize,
+		ModifiedAt: *m.FileModifiedAt,
+	}
+	if m.FileInode != nil {
+		inode := uint64(*m.FileInode)
+		info.Inode = &inode
+	}
+	return info
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// SmartAlbums stores smart album rows in the order returned by the query
+type SmartAlbums []SmartAlbum
+
+// Entity converts the database model smart albums to entity smart albums.
+// Unlike exif, a filter which cannot be decoded is an error: ignoring it would match every media.
+func (ss SmartAlbums) Entity() ([]entity.SmartAlbum, error) {
+	smartAlbums := make([]entity.SmartAlbum, 0, len(ss))
+	for _, s := range ss {
+		var filter entity.SmartAlbumFilter
+		if s.Filter != nil {
+			if err := json.Unmarshal(*s.Filter, &filter); err != nil {
+				return nil, fmt.Errorf("failed to decode filter of smart album %s: %w", s.ID, err)
+			}
+		}
+
+		smartAlbums = append(smartAlbums, entity.SmartAlbum{
+			ID:          s.ID,
+			CreatedAt:   s.CreatedAt,
+			Name:        s.Name,
+			Description: s.Description,
+			Filter:      filter,
+		})
+	}
+	return smartAlbums, nil
+}
+
+// SmartAlbum represents the database model for smart_albums table
+type SmartAlbum struct {
+	ID          string           `db:"id"`
+	CreatedAt   time.Time        `db:"created_at"`
+	Name        string           `db:"name"`
+	Description *string          `db:"description"`
+	Filter      *json.RawMessage `db:"filter"`
+}
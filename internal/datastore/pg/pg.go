@@ -5,8 +5,10 @@ package pg
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg/models"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
@@ -61,6 +63,7 @@ func (d *Datastore) QueryAlbum(ctx context.Context, opts ...QueryOption) (*entit
 			&album.Description,
 			&album.ParentID,
 			&album.ThumbnailID,
+			&album.Hidden,
 			&album.ChildID,
 			&album.ChildCreatedAt,
 			&album.ChildPath,
@@ -125,6 +128,7 @@ func (d *Datastore) QueryAlbums(ctx context.Context, opts ...QueryOption) ([]ent
 			&album.Description,
 			&album.ParentID,
 			&album.ThumbnailID,
+			&album.Hidden,
 			&album.MediaCount,
 			&album.ChildID,
 			&album.ChildCreatedAt,
@@ -146,9 +150,64 @@ func (d *Datastore) QueryAlbums(ctx context.Context, opts ...QueryOption) ([]ent
 	return albums.Entity(), nil
 }
 
+// QueryTrashedAlbums returns the albums in the trash which were deleted on their own.
+// Subalbums trashed together with their parent are not returned.
+func (d *Datastore) QueryTrashedAlbums(ctx context.Context, opts ...QueryOption) ([]entity.Album, error) {
+	query := listTrashedAlbumsStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	albums := []entity.Album{}
+	for rows.Next() {
+		var album models.Album
+		err := rows.Scan(
+			&album.ID,
+			&album.CreatedAt,
+			&album.Path,
+			&album.Description,
+			&album.ParentID,
+			&album.ThumbnailID,
+			&album.TrashedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// albums are not grouped, the statement has no join
+		albums = append(albums, models.Albums{album.ID: {album}}.Entity()...)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return albums, nil
+}
+
 func (d *Datastore) QueryMedia(ctx context.Context, opts ...QueryOption) ([]entity.Media, error) {
-	// Start with the base listMediaStmt and apply any query options
-	query := listMediaStmt
+	return d.queryMedia(ctx, listMediaStmt, opts...)
+}
+
+// QueryTrashedMedia returns the media in the trash which were deleted on their own.
+// Media trashed together with their album are restored and purged with the album.
+func (d *Datastore) QueryTrashedMedia(ctx context.Context, opts ...QueryOption) ([]entity.Media, error) {
+	return d.queryMedia(ctx, listTrashedMediaStmt, opts...)
+}
+
+func (d *Datastore) queryMedia(ctx context.Context, stmt sq.SelectBuilder, opts ...QueryOption) ([]entity.Media, error) {
+	// Start with the base statement and apply any query options
+	query := stmt
 	for _, opt := range opts {
 		query = opt(query)
 	}
@@ -180,6 +239,17 @@ func (d *Datastore) QueryMedia(ctx context.Context, opts ...QueryOption) ([]enti
 			&media.Hash,
 			&media.Exif,
 			&media.MediaType,
+			&media.Caption,
+			&media.Camera,
+			&media.Tags,
+			&media.Rating,
+			&media.Favorite,
+			&media.Latitude,
+			&media.Longitude,
+			&media.TrashedAt,
+			&media.FileSize,
+			&media.FileModifiedAt,
+			&media.FileInode,
 			&media.AlbumJoinCreatedAt,
 			&media.AlbumJoinPath,
 			&media.AlbumJoinDescription,
@@ -199,9 +269,359 @@ func (d *Datastore) QueryMedia(ctx context.Context, opts ...QueryOption) ([]enti
 	return mediaList.Entity(), nil
 }
 
+// OldestCapture returns the capture date of the oldest media, or nil if there are no media.
+func (d *Datastore) OldestCapture(ctx context.Context) (*time.Time, error) {
+	query := psql.Select("min(" + preffix(mediaTable, mediaCapturedAt) + ")").
+		From(mediaTable).
+		Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var oldest *time.Time
+	if err := d.pool.QueryRow(ctx, sql, args...).Scan(&oldest); err != nil {
+		return nil, err
+	}
+
+	return oldest, nil
+}
+
+// QueryMediaLocations returns the media matching the query options sorted by capture date ascending.
+// Only the id, the capture date and the location of the media are loaded.
+func (d *Datastore) QueryMediaLocations(ctx context.Context, opts ...QueryOption) ([]entity.Media, error) {
+	query := psql.Select(
+		preffix(mediaTable, mediaID),
+		preffix(mediaTable, mediaCapturedAt),
+		preffix(mediaTable, mediaLatitude),
+		preffix(mediaTable, mediaLongitude),
+	).
+		From(mediaTable).
+		Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil}).
+		OrderBy(preffix(mediaTable, mediaCapturedAt)+" ASC", preffix(mediaTable, mediaID)+" ASC")
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	media := []entity.Media{}
+	for rows.Next() {
+		var (
+			m                   entity.Media
+			latitude, longitude *float64
+		)
+		if err := rows.Scan(&m.ID, &m.CapturedAt, &latitude, &longitude); err != nil {
+			return nil, err
+		}
+		if latitude != nil && longitude != nil {
+			m.Location = &entity.Location{Latitude: *latitude, Longitude: *longitude}
+		}
+		media = append(media, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// QueryMediaIDs returns only the ids of the media matching the query options.
+// It avoids loading thumbnails and exif when only the identity of the media is needed.
+func (d *Datastore) QueryMediaIDs(ctx context.Context, opts ...QueryOption) ([]string, error) {
+	query := psql.Select(preffix(mediaTable, mediaID)).From(mediaTable).Where(sq.Eq{preffix(mediaTable, mediaTrashedAt): nil})
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// QueryMediaFiles returns the media matching the query options with only their id, file name, hash,
+// album and file attributes. It is used to compare the media with the files of the data folder.
+func (d *Datastore) QueryMediaFiles(ctx context.Context, opts ...QueryOption) ([]entity.Media, error) {
+	query := listMediaFilesStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	media := []entity.Media{}
+	for rows.Next() {
+		var m models.Media
+		if err := rows.Scan(
+			&m.ID,
+			&m.AlbumID,
+			&m.FileName,
+			&m.Hash,
+			&m.FileSize,
+			&m.FileModifiedAt,
+			&m.FileInode,
+			&m.AlbumJoinPath,
+		); err != nil {
+			return nil, err
+		}
+
+		media = append(media, entity.Media{
+			ID:       m.ID,
+			Filename: m.FileName,
+			Hash:     m.Hash,
+			Album:    entity.Album{ID: m.AlbumID, Path: m.AlbumJoinPath},
+			File:     m.FileInfo(),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// QueryJobs returns the jobs of the history matching the query options, the oldest first. The results of
+// their tasks are not loaded, see QueryJobResults.
+func (d *Datastore) QueryJobs(ctx context.Context, opts ...QueryOption) ([]entity.JobProgress, error) {
+	query := listJobsStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+	query = query.OrderBy(preffix(jobsTable, jobCreatedAt)+" ASC", preffix(jobsTable, jobID)+" ASC")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []entity.JobProgress{}
+	for rows.Next() {
+		var job models.Job
+		if err := rows.Scan(
+			&job.ID,
+			&job.Metadata,
+			&job.Status,
+			&job.Reason,
+			&job.Total,
+			&job.Remaining,
+			&job.CreatedAt,
+			&job.StartedAt,
+			&job.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job.Entity(nil))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CountJobs returns the number of jobs of the history matching the query options.
+func (d *Datastore) CountJobs(ctx context.Context, opts ...QueryOption) (int, error) {
+	query := psql.Select("COUNT(*)").From(jobsTable)
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := d.pool.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// QueryJobResults returns the results of the tasks of a job of the history in the order they finished.
+func (d *Datastore) QueryJobResults(ctx context.Context, id uuid.UUID) ([]entity.JobResult, error) {
+	sql, args, err := listJobResultsStmt.Where(sq.Eq{preffix(jobResultsTable, jobResultJobID): id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []entity.JobResult{}
+	for rows.Next() {
+		var result models.JobResult
+		if err := rows.Scan(
+			&result.JobID,
+			&result.Result,
+			&result.Error,
+			&result.StartedAt,
+			&result.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, result.Entity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// AlbumKey is the position of an album in a sorted album listing: its id and the text form of its sort key.
+type AlbumKey struct {
+	ID    string
+	Value string
+}
+
+// QueryAlbumKeys returns the keys of the albums matching the query options, without loading the albums.
+// Unlike QueryAlbums, the query has no join so the albums can be sorted and paginated in SQL;
+// the options are expected to include SortAlbums with the same sort key.
+func (d *Datastore) QueryAlbumKeys(ctx context.Context, sortBy entity.AlbumSortBy, opts ...QueryOption) ([]AlbumKey, error) {
+	query := psql.Select(preffix(albumsTable, albumID), "("+albumSortKeyOf(sortBy).expr+")::text").
+		From(albumsTable).
+		Where(sq.Eq{preffix(albumsTable, albumTrashedAt): nil})
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []AlbumKey{}
+	for rows.Next() {
+		var key AlbumKey
+		if err := rows.Scan(&key.ID, &key.Value); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// QueryAlbumTree returns the albums matching the query options with the statistics of their media
+// and of the media of their descendants, ordered by path. Only the media of the matching albums are counted.
+// The nodes are not linked to their children, see entity.NewAlbumTree.
+func (d *Datastore) QueryAlbumTree(ctx context.Context, opts ...QueryOption) ([]entity.AlbumTreeNode, error) {
+	query := albumTreeSelectStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, fmt.Sprintf(albumTreeStmt, sql), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := []entity.AlbumTreeNode{}
+	for rows.Next() {
+		var node entity.AlbumTreeNode
+		err := rows.Scan(
+			&node.Album.ID,
+			&node.Album.CreatedAt,
+			&node.Album.Path,
+			&node.Album.Description,
+			&node.Album.ParentId,
+			&node.Album.Thumbnail,
+			&node.Album.Hidden,
+			&node.MediaCount,
+			&node.SubtreeMediaCount,
+			&node.EarliestCapturedAt,
+			&node.LatestCapturedAt,
+			&node.CoverID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		node.Album.MediaCount = node.SubtreeMediaCount
+		nodes = append(nodes, node)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
 func (d *Datastore) CountAlbums(ctx context.Context, opts ...QueryOption) (int, error) {
 	// Start with base count query
-	query := psql.Select("COUNT(*)").From(albumsTable)
+	query := psql.Select("COUNT(*)").From(albumsTable).Where(sq.Eq{preffix(albumsTable, albumTrashedAt): nil})
 
 	// Apply query options (filters)
 	for _, opt := range opts {
@@ -223,6 +643,262 @@ func (d *Datastore) CountAlbums(ctx context.Context, opts ...QueryOption) (int,
 	return count, nil
 }
 
+func (d *Datastore) QueryCollections(ctx context.Context, opts ...QueryOption) ([]entity.Collection, error) {
+	query := listCollectionsStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	// Build the SQL query
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Scan results into collection models
+	collections := models.Collections{}
+	for rows.Next() {
+		var collection models.Collection
+		err := rows.Scan(
+			&collection.ID,
+			&collection.CreatedAt,
+			&collection.Name,
+			&collection.Description,
+			&collection.ThumbnailID,
+			&collection.MediaCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collections.Entity(), nil
+}
+
+func (d *Datastore) CountCollections(ctx context.Context, opts ...QueryOption) (int, error) {
+	query := psql.Select("COUNT(*)").From(collectionsTable)
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = d.pool.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// QueryCollectionMediaIDs returns the ids of all the media of a collection in the collection order,
+// the media in the trash included.
+func (d *Datastore) QueryCollectionMediaIDs(ctx context.Context, id string) ([]string, error) {
+	query := psql.Select(collectionMediaMediaID).
+		From(collectionMediaTable).
+		Where(sq.Eq{collectionMediaCollectionID: id}).
+		OrderBy(collectionMediaPosition + " ASC")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var mediaID string
+		if err := rows.Scan(&mediaID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, mediaID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (d *Datastore) QuerySmartAlbums(ctx context.Context, opts ...QueryOption) ([]entity.SmartAlbum, error) {
+	query := listSmartAlbumsStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	// Build the SQL query
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the query
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Scan results into smart album models
+	smartAlbums := models.SmartAlbums{}
+	for rows.Next() {
+		var smartAlbum models.SmartAlbum
+		err := rows.Scan(
+			&smartAlbum.ID,
+			&smartAlbum.CreatedAt,
+			&smartAlbum.Name,
+			&smartAlbum.Description,
+			&smartAlbum.Filter,
+		)
+		if err != nil {
+			return nil, err
+		}
+		smartAlbums = append(smartAlbums, smartAlbum)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return smartAlbums.Entity()
+}
+
+func (d *Datastore) CountSmartAlbums(ctx context.Context, opts ...QueryOption) (int, error) {
+	query := psql.Select("COUNT(*)").From(smartAlbumsTable)
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = d.pool.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// QueryComments returns the comments matching the query options, the oldest first.
+func (d *Datastore) QueryComments(ctx context.Context, opts ...QueryOption) ([]entity.Comment, error) {
+	query := listCommentsStmt
+	for _, opt := range opts {
+		query = opt(query)
+	}
+	query = query.OrderBy(preffix(commentsTable, commentCreatedAt)+" ASC", preffix(commentsTable, commentID)+" ASC")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := models.Comments{}
+	for rows.Next() {
+		var comment models.Comment
+		err := rows.Scan(
+			&comment.ID,
+			&comment.MediaID,
+			&comment.ParentID,
+			&comment.Author,
+			&comment.Body,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments.Entity(), nil
+}
+
+// Search runs a full-text search over albums and media and returns the matches ordered by relevance.
+// The text is parsed with websearch_to_tsquery so quoted phrases, "or" and "-word" are supported.
+// Only the kind, id and rank of the matches are returned; query options apply to the ranked matches.
+func (d *Datastore) Search(ctx context.Context, text string, opts ...QueryOption) ([]entity.SearchResult, error) {
+	tsQuery := fmt.Sprintf("websearch_to_tsquery('%s', ?)", searchConfig)
+
+	matches := sq.Select(
+		preffix(searchDocumentsView, searchDocumentKind),
+		preffix(searchDocumentsView, searchDocumentID),
+	).
+		Column(sq.Expr(fmt.Sprintf("ts_rank(%s, %s)::float8 AS rank", preffix(searchDocumentsView, searchDocumentDocument), tsQuery), text)).
+		From(searchDocumentsView).
+		Where(fmt.Sprintf("%s @@ %s", preffix(searchDocumentsView, searchDocumentDocument), tsQuery), text)
+
+	query := psql.Select("hits.kind", "hits.id", "hits.rank").
+		FromSelect(matches, "hits").
+		OrderBy("hits.rank DESC", "hits.kind ASC", "hits.id ASC")
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []entity.SearchResult{}
+	for rows.Next() {
+		var result entity.SearchResult
+		if err := rows.Scan(&result.Kind, &result.ID, &result.Rank); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (d *Datastore) Stats(ctx context.Context) (entity.Stats, error) {
 	var stats entity.Stats
 
@@ -257,6 +933,50 @@ func (d *Datastore) Stats(ctx context.Context) (entity.Stats, error) {
 	return stats, nil
 }
 
+// Timeline counts the media per capture month, or per capture day when daily is true.
+// Buckets are ordered from the most recent.
+func (d *Datastore) Timeline(ctx context.Context, daily bool, opts ...QueryOption) ([]entity.Bucket, error) {
+	query := timelineStmt
+	if daily {
+		query = query.Column("extract(day from media.captured_at)::int as day").
+			GroupBy("day").
+			OrderBy("day desc")
+	} else {
+		query = query.Column("0 as day")
+	}
+	query = query.Column("count(*)")
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []entity.Bucket{}
+	for rows.Next() {
+		var bucket entity.Bucket
+		if err := rows.Scan(&bucket.Year, &bucket.Month, &bucket.Day, &bucket.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
 func (d *Datastore) ReadToken(ctx context.Context) (string, error) {
 	sql, args, err := selectTokenStmt.ToSql()
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/datastore"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5/pgxpool"
 	. "github.com/onsi/ginkgo/v2"
@@ -24,6 +25,15 @@ var (
 	insertMediaStmt = psql.Insert("media").Columns("id", "created_at", "captured_at", "album_id", "file_name", "thumbnail", "exif", "media_type")
 )
 
+// newDatastore connects a datastore to the test database
+func newDatastore(ctx context.Context, uri string) (*pg.Datastore, error) {
+	pool, err := datastore.NewConnPool(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewPostgresDatastore(pool), nil
+}
+
 var _ = Describe("Query", Ordered, func() {
 	var (
 		dt     *pg.Datastore
@@ -31,7 +41,7 @@ var _ = Describe("Query", Ordered, func() {
 	)
 
 	BeforeAll(func() {
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
 		Expect(pgDt).ToNot(BeNil())
 
@@ -6,9 +6,12 @@
 package pg
 
 import (
+	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
 // FilterByAlbumId creates a filter that adds a WHERE clause to match a specific ID.
@@ -23,10 +26,36 @@ func FilterByAlbumId(id string) QueryOption {
 	return FilterByColumnName("albums.id", id)
 }
 
+// FilterAlbumByPath matches the album whose path is exactly path.
+// Use FilterByAlbumPath to match a LIKE pattern.
+func FilterAlbumByPath(path string) QueryOption {
+	return FilterByColumnName("albums.path", path)
+}
+
+// FilterMediaByFilename matches the media of the album having the file name.
+func FilterMediaByFilename(albumID, filename string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"media.album_id": albumID, "media.file_name": filename})
+	}
+}
+
 func FilterByMediaId(id string) QueryOption {
 	return FilterByColumnName("media.id", id)
 }
 
+func FilterMediaByIDs(ids []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"media.id": ids})
+	}
+}
+
+// FilterMediaByAlbumIDs restricts a media query to the media of the albums.
+func FilterMediaByAlbumIDs(ids []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"media.album_id": ids})
+	}
+}
+
 func FilterAlbumByParentId(parentId string) QueryOption {
 	return func(orig sq.SelectBuilder) sq.SelectBuilder {
 		if parentId == "" {
@@ -51,6 +80,378 @@ func FilterAlbumsByIDs(ids []string) QueryOption {
 	}
 }
 
+// FilterAlbumsBySubtree restricts an album query to the album and all its descendants.
+func FilterAlbumsBySubtree(albumID string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Expr("albums.id IN (SELECT descendant_id FROM album_closure WHERE ancestor_id = ?)", albumID))
+	}
+}
+
+// FilterByCollectionId restricts a media query to the media of a collection.
+// The result is ordered by the position of the media inside the collection.
+func FilterByCollectionId(id string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.
+			InnerJoin("collection_media on collection_media.media_id = media.id").
+			Where(sq.Eq{"collection_media.collection_id": id}).
+			OrderBy("collection_media.position ASC")
+	}
+}
+
+func FilterCollectionById(id string) QueryOption {
+	return FilterByColumnName("collections.id", id)
+}
+
+func FilterCollectionsByIDs(ids []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"collections.id": ids})
+	}
+}
+
+func FilterSmartAlbumById(id string) QueryOption {
+	return FilterByColumnName("smart_albums.id", id)
+}
+
+func FilterSmartAlbumsByIDs(ids []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"smart_albums.id": ids})
+	}
+}
+
+func FilterCommentById(id string) QueryOption {
+	return FilterByColumnName("media_comments.id", id)
+}
+
+// FilterCommentsByMediaId restricts a comment query to the comments of a media.
+func FilterCommentsByMediaId(mediaID string) QueryOption {
+	return FilterByColumnName("media_comments.media_id", mediaID)
+}
+
+// FilterJobByID restricts a job query to a job.
+func FilterJobByID(id string) QueryOption {
+	return FilterByColumnName("jobs.id", id)
+}
+
+// ExcludeJobs leaves the jobs out of a job query. The filter is a no-op without ids.
+func ExcludeJobs(ids ...string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if len(ids) == 0 {
+			return orig
+		}
+		return orig.Where(sq.NotEq{"jobs.id": ids})
+	}
+}
+
+// ExcludeJobTypes leaves the jobs of the types out of a job query. The filter is a no-op without types.
+func ExcludeJobTypes(types ...string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if len(types) == 0 {
+			return orig
+		}
+		return orig.Where(sq.NotEq{"jobs.type": types})
+	}
+}
+
+// FilterJobsByStatus restricts a job query to the jobs having one of the statuses.
+func FilterJobsByStatus(statuses ...entity.JobStatus) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		values := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, string(status))
+		}
+		return orig.Where(sq.Eq{"jobs.status": values})
+	}
+}
+
+// FilterByAlbumSubtree restricts a media query to the media of an album and of all its descendants.
+func FilterByAlbumSubtree(albumID string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Expr(`media.album_id IN (
+			WITH RECURSIVE album_tree AS (
+				SELECT id FROM albums WHERE id = ?
+				UNION ALL
+				SELECT a.id FROM albums a INNER JOIN album_tree t ON a.parent_id = t.id
+			)
+			SELECT id FROM album_tree)`, albumID))
+	}
+}
+
+// FilterByCamera matches the camera model case insensitively.
+func FilterByCamera(camera string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if camera == "" {
+			return orig
+		}
+		return orig.Where(sq.Expr("lower(media.camera) = lower(?)", camera))
+	}
+}
+
+// FilterByTags matches the media having all the tags.
+func FilterByTags(tags []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if len(tags) == 0 {
+			return orig
+		}
+		return orig.Where(sq.Expr("media.tags @> ?", tags))
+	}
+}
+
+// ExcludeCamera excludes the media taken with the camera model. Media without camera are kept.
+func ExcludeCamera(camera string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if camera == "" {
+			return orig
+		}
+		return orig.Where(sq.Expr("(media.camera IS NULL OR lower(media.camera) <> lower(?))", camera))
+	}
+}
+
+// ExcludeTags excludes the media having any of the tags.
+func ExcludeTags(tags []string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if len(tags) == 0 {
+			return orig
+		}
+		return orig.Where(sq.Expr("NOT (media.tags && ?)", tags))
+	}
+}
+
+// FilterByMediaType matches the media type case insensitively.
+func FilterByMediaType(mediaType string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Expr("lower(media.media_type) = lower(?)", mediaType))
+	}
+}
+
+// ExcludeMediaType excludes the media of the type, compared case insensitively.
+func ExcludeMediaType(mediaType string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Expr("lower(media.media_type) <> lower(?)", mediaType))
+	}
+}
+
+// FilterByAlbumPath matches the path of the album of the media against a LIKE pattern.
+// The query must join the albums table, like listMediaStmt does.
+func FilterByAlbumPath(pattern string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Like{"albums.path": pattern})
+	}
+}
+
+// FilterByAlbumFolder matches the media of the album at path and of all its descendants, by path.
+// An empty path matches every media. The query must join the albums table, like listMediaStmt does.
+// On an album query, it matches the album at path and its descendants.
+func FilterByAlbumFolder(path string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if path == "" {
+			return orig
+		}
+		return orig.Where(sq.Or{
+			sq.Eq{"albums.path": path},
+			sq.Expr("starts_with(albums.path, ?)", path+"/"),
+		})
+	}
+}
+
+// ExcludeAlbumPath excludes the media whose album path matches a LIKE pattern.
+// The query must join the albums table, like listMediaStmt does.
+func ExcludeAlbumPath(pattern string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.NotLike{"albums.path": pattern})
+	}
+}
+
+// FilterCapturedBefore matches the media captured strictly before t.
+func FilterCapturedBefore(t time.Time) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Lt{"media.captured_at": t})
+	}
+}
+
+// FilterAlbumsTrashedBefore matches the albums put in the trash strictly before t.
+func FilterAlbumsTrashedBefore(t time.Time) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Lt{"albums.trashed_at": t})
+	}
+}
+
+// FilterMediaTrashedBefore matches the media put in the trash strictly before t.
+func FilterMediaTrashedBefore(t time.Time) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Lt{"media.trashed_at": t})
+	}
+}
+
+// FilterByText matches the media whose search document matches the text.
+// The text is parsed with websearch_to_tsquery, like for Search.
+func FilterByText(text string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		if text == "" {
+			return orig
+		}
+		return orig.Where(sq.Expr(fmt.Sprintf("media.search_vector @@ websearch_to_tsquery('%s', ?)", searchConfig), text))
+	}
+}
+
+// FilterByMinRating matches the media rated at least rating. Media without rating are excluded.
+func FilterByMinRating(rating int) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.GtOrEq{"media.rating": rating})
+	}
+}
+
+// FilterByHighlights matches the favorite media or the media rated at least minRating.
+// If favorite is false and minRating is nil, the filter is a no-op.
+func FilterByHighlights(favorite bool, minRating *int) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		highlights := sq.Or{}
+		if favorite {
+			highlights = append(highlights, sq.Eq{"media.favorite": true})
+		}
+		if minRating != nil {
+			highlights = append(highlights, sq.GtOrEq{"media.rating": *minRating})
+		}
+
+		if len(highlights) == 0 {
+			return orig
+		}
+		return orig.Where(highlights)
+	}
+}
+
+// FilterByOnThisDay matches the media captured on the same day as day in the years from fromYear to the year before day.
+// The filter is a union of one day ranges on captured_at so the captured_at indexes are used instead of
+// extracting the month and day of every media. February 29 only matches leap years.
+func FilterByOnThisDay(day time.Time, fromYear int) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		ranges := sq.Or{}
+		for year := day.Year() - 1; year >= fromYear; year-- {
+			start := time.Date(year, day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+			if start.Day() != day.Day() {
+				// the day does not exist this year
+				continue
+			}
+
+			ranges = append(ranges, sq.And{
+				sq.GtOrEq{"media.captured_at": start},
+				sq.Lt{"media.captured_at": start.AddDate(0, 0, 1)},
+			})
+		}
+
+		// an empty sq.Or matches nothing
+		return orig.Where(ranges)
+	}
+}
+
+// ExcludeHiddenAlbums removes the media of the hidden albums and of their subalbums.
+func ExcludeHiddenAlbums() QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(`media.album_id NOT IN (
+			WITH RECURSIVE hidden_tree AS (
+				SELECT id FROM albums WHERE hidden
+				UNION ALL
+				SELECT a.id FROM albums a INNER JOIN hidden_tree t ON a.parent_id = t.id
+			)
+			SELECT id FROM hidden_tree)`)
+	}
+}
+
+// FilterByArea matches the media captured inside the bounding box.
+// Media without location are excluded.
+func FilterByArea(area entity.BoundingBox) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		latitude := sq.And{
+			sq.GtOrEq{"media.latitude": area.MinLatitude},
+			sq.LtOrEq{"media.latitude": area.MaxLatitude},
+		}
+
+		if area.MinLongitude > area.MaxLongitude {
+			// the box crosses the antimeridian
+			return orig.Where(sq.And{
+				latitude,
+				sq.Or{
+					sq.GtOrEq{"media.longitude": area.MinLongitude},
+					sq.LtOrEq{"media.longitude": area.MaxLongitude},
+				},
+			})
+		}
+
+		return orig.Where(sq.And{
+			latitude,
+			sq.GtOrEq{"media.longitude": area.MinLongitude},
+			sq.LtOrEq{"media.longitude": area.MaxLongitude},
+		})
+	}
+}
+
+// FilterSearchByKind restricts the matches of Search to one kind of resource.
+func FilterSearchByKind(kind entity.SearchResultKind) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(sq.Eq{"hits.kind": string(kind)})
+	}
+}
+
+// FilterBySearchCursor creates a filter for cursor-based pagination of Search.
+// Matches are ordered by rank DESC, kind ASC and id ASC so the next page starts
+// after the match identified by the cursor in that order.
+//
+// Parameters:
+//   - rank: The rank of the last match of the previous page
+//   - kind: The kind of the last match, used for tie-breaking when ranks are equal
+//   - id: The id of the last match, used for tie-breaking when ranks and kinds are equal
+//
+// Returns: A QueryOption function that can be applied to a SelectBuilder.
+func FilterBySearchCursor(rank float64, kind entity.SearchResultKind, id string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		return orig.Where(
+			sq.Or{
+				sq.Lt{"hits.rank": rank},
+				sq.And{
+					sq.Eq{"hits.rank": rank},
+					sq.Expr("(hits.kind, hits.id) > (?, ?)", string(kind), id),
+				},
+			},
+		)
+	}
+}
+
+// SortAlbums orders an album query by the sort key, the album id breaking the ties.
+// An unknown sort key sorts by name.
+func SortAlbums(sortBy entity.AlbumSortBy, descending bool) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		key := albumSortKeyOf(sortBy)
+		order := " ASC"
+		if descending {
+			order = " DESC"
+		}
+		return orig.OrderBy(key.expr+order, preffix(albumsTable, albumID)+order)
+	}
+}
+
+// FilterByAlbumCursor matches the albums coming after the album (value, id) in the order of SortAlbums.
+// The value is the text form of the sort key, as returned by QueryAlbumKeys.
+func FilterByAlbumCursor(sortBy entity.AlbumSortBy, descending bool, value, id string) QueryOption {
+	return func(orig sq.SelectBuilder) sq.SelectBuilder {
+		key := albumSortKeyOf(sortBy)
+		op := ">"
+		if descending {
+			op = "<"
+		}
+		return orig.Where(sq.Expr(
+			fmt.Sprintf("(%s, %s) %s (?::%s, ?)", key.expr, preffix(albumsTable, albumID), op, key.sqlType),
+			value, id,
+		))
+	}
+}
+
+func albumSortKeyOf(sortBy entity.AlbumSortBy) albumSortKey {
+	key, ok := albumSortKeys[sortBy]
+	if !ok {
+		return albumSortKeys[entity.AlbumSortByName]
+	}
+	return key
+}
+
 // Limit creates a filter that adds a LIMIT clause to restrict the number of results.
 // If the limit is 0 or negative, no LIMIT clause is added to the query.
 //
@@ -157,18 +558,18 @@ func FilterByMediaDate(start, end *time.Time) func(orig sq.SelectBuilder) sq.Sel
 		if start != nil && end != nil {
 			return orig.Where(
 				sq.And{
-					sq.GtOrEq{"captured_at": start},
-					sq.LtOrEq{"captured_at": end},
+					sq.GtOrEq{"media.captured_at": start},
+					sq.LtOrEq{"media.captured_at": end},
 				},
 			)
 		}
 
 		if start != nil {
-			return orig.Where(sq.GtOrEq{"captured_at": start})
+			return orig.Where(sq.GtOrEq{"media.captured_at": start})
 		}
 
 		if end != nil {
-			return orig.Where(sq.LtOrEq{"captured_at": end})
+			return orig.Where(sq.LtOrEq{"media.captured_at": end})
 		}
 
 		return orig
@@ -18,7 +18,7 @@ var _ = Describe("QueryOptions", Ordered, func() {
 	)
 
 	BeforeAll(func() {
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
 		Expect(pgDt).ToNot(BeNil())
 
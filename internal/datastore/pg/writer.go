@@ -3,12 +3,14 @@ package pg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -27,6 +29,7 @@ func (w *Writer) WriteAlbum(ctx context.Context, album entity.Album) error {
 			albumDescription,
 			albumParentID,
 			albumThumbnailID,
+			albumHidden,
 		).
 		Values(
 			album.ID,
@@ -35,10 +38,12 @@ func (w *Writer) WriteAlbum(ctx context.Context, album entity.Album) error {
 			album.Description,
 			album.ParentId,
 			album.Thumbnail,
+			album.Hidden,
 		).
 		Suffix("ON CONFLICT ( id ) DO UPDATE SET " +
 			albumDescription + " = EXCLUDED." + albumDescription + ", " +
-			albumThumbnailID + " = EXCLUDED." + albumThumbnailID)
+			albumThumbnailID + " = EXCLUDED." + albumThumbnailID + ", " +
+			albumHidden + " = EXCLUDED." + albumHidden)
 
 	// Convert to SQL
 	sql, args, err := stmt.ToSql()
@@ -47,7 +52,12 @@ func (w *Writer) WriteAlbum(ctx context.Context, album entity.Album) error {
 	}
 
 	// Execute the query
-	_, err = w.tx.Exec(ctx, sql, args...)
+	if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	// The parent is never changed by the upsert so an existing album keeps its closure rows
+	_, err = w.tx.Exec(ctx, insertAlbumClosureStmt, album.ID, album.ParentId)
 	return err
 }
 
@@ -59,6 +69,20 @@ func (w *Writer) WriteMedia(ctx context.Context, media entity.Media) error {
 		return err
 	}
 
+	// tags column is not nullable
+	tags := media.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var latitude, longitude *float64
+	if media.Location != nil {
+		latitude = &media.Location.Latitude
+		longitude = &media.Location.Longitude
+	}
+
+	fileSize, fileModifiedAt, fileInode := fileInfoColumns(media.File)
+
 	// Build the upsert statement
 	stmt := psql.Insert(mediaTable).
 		Columns(
@@ -71,6 +95,16 @@ func (w *Writer) WriteMedia(ctx context.Context, media entity.Media) error {
 			mediaExif,
 			mediaMediaType,
 			mediaHash,
+			mediaCaption,
+			mediaCamera,
+			mediaTags,
+			mediaRating,
+			mediaFavorite,
+			mediaLatitude,
+			mediaLongitude,
+			mediaFileSize,
+			mediaFileModifiedAt,
+			mediaFileInode,
 		).
 		Values(
 			media.ID,
@@ -82,11 +116,38 @@ func (w *Writer) WriteMedia(ctx context.Context, media entity.Media) error {
 			exifData,
 			string(media.MediaType),
 			media.Hash,
+			media.Caption,
+			media.Camera,
+			tags,
+			media.Rating,
+			media.Favorite,
+			latitude,
+			longitude,
+			fileSize,
+			fileModifiedAt,
+			fileInode,
 		).
 		Suffix("ON CONFLICT (" + mediaID + ") DO UPDATE SET " +
 			mediaCapturedAt + " = EXCLUDED." + mediaCapturedAt + ", " +
 			mediaThumbnail + " = EXCLUDED." + mediaThumbnail + ", " +
-			mediaExif + " = EXCLUDED." + mediaExif)
+			mediaExif + " = EXCLUDED." + mediaExif + ", " +
+			mediaCaption + " = EXCLUDED." + mediaCaption + ", " +
+			mediaCamera + " = EXCLUDED." + mediaCamera + ", " +
+			mediaTags + " = EXCLUDED." + mediaTags + ", " +
+			mediaRating + " = EXCLUDED." + mediaRating + ", " +
+			mediaFavorite + " = EXCLUDED." + mediaFavorite + ", " +
+			mediaLatitude + " = EXCLUDED." + mediaLatitude + ", " +
+			mediaLongitude + " = EXCLUDED." + mediaLongitude + ", " +
+			mediaFileSize + " = EXCLUDED." + mediaFileSize + ", " +
+			mediaFileModifiedAt + " = EXCLUDED." + mediaFileModifiedAt + ", " +
+			mediaFileInode + " = EXCLUDED." + mediaFileInode + " " +
+			"RETURNING " + mediaCapturedAt)
+
+	// The statistics only change with a new media or a new capture date, they are read before the upsert
+	previous, err := w.mediaCapture(ctx, media.ID)
+	if err != nil {
+		return err
+	}
 
 	// Convert to SQL
 	sql, args, err := stmt.ToSql()
@@ -95,44 +156,744 @@ func (w *Writer) WriteMedia(ctx context.Context, media entity.Media) error {
 	}
 
 	// Execute the query
+	var capturedAt time.Time
+	if err := w.tx.QueryRow(ctx, sql, args...).Scan(&capturedAt); err != nil {
+		return err
+	}
+
+	switch {
+	case previous == nil:
+		return w.addAlbumMediaStats(ctx, media.Album.ID, 1, capturedAt)
+	case previous.trashed || previous.capturedAt.Equal(capturedAt):
+		return nil
+	case previous.capturedAt.Before(capturedAt):
+		return w.addAlbumMediaStats(ctx, media.Album.ID, 0, capturedAt)
+	default:
+		// the media may have been the latest one, the latest capture date is computed again
+		return w.refreshAlbumStats(ctx, media.Album.ID)
+	}
+}
+
+// mediaCaptureState is what the album statistics count of a media
+type mediaCaptureState struct {
+	capturedAt time.Time
+	trashed    bool
+}
+
+// mediaCapture returns the capture date of a media and whether it is trashed, nil for a missing media.
+func (w *Writer) mediaCapture(ctx context.Context, id string) (*mediaCaptureState, error) {
+	sql, args, err := psql.Select(mediaCapturedAt, mediaTrashedAt+" IS NOT NULL").
+		From(mediaTable).
+		Where(sq.Eq{mediaID: id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var state mediaCaptureState
+	if err := w.tx.QueryRow(ctx, sql, args...).Scan(&state.capturedAt, &state.trashed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+// WriteMediaFile records the attributes of the file of a media whose content did not change.
+func (w *Writer) WriteMediaFile(ctx context.Context, id string, file *entity.FileInfo) error {
+	fileSize, fileModifiedAt, fileInode := fileInfoColumns(file)
+
+	stmt := psql.Update(mediaTable).
+		Set(mediaFileSize, fileSize).
+		Set(mediaFileModifiedAt, fileModifiedAt).
+		Set(mediaFileInode, fileInode).
+		Where(sq.Eq{mediaID: id})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
 	_, err = w.tx.Exec(ctx, sql, args...)
 	return err
 }
 
+// fileInfoColumns returns the values of the file attribute columns, all null if the attributes are unknown.
+// The inode is stored in a signed column, it is only ever compared for equality.
+func fileInfoColumns(file *entity.FileInfo) (*int64, *time.Time, *int64) {
+	if file == nil {
+		return nil, nil, nil
+	}
+
+	var inode *int64
+	if file.Inode != nil {
+		i := int64(*file.Inode)
+		inode = &i
+	}
+	return &file.Size, &file.ModifiedAt, inode
+}
+
+// MoveAlbum changes the path and the parent of an album.
+// The paths of all the descendants of the album are rewritten with the new path prefix.
+// Ids are untouched so media, relationships and links follow the album.
+func (w *Writer) MoveAlbum(ctx context.Context, id string, parentID *string, oldPath, newPath string) error {
+	oldParentID, err := w.albumParentID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	parentStmt := psql.Update(albumsTable).
+		Set(albumParentID, parentID).
+		Where(sq.Eq{albumID: id})
+
+	sql, args, err := parentStmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	// the subtree is read from the closure, a trashed album whose path starts with oldPath is not part of it
+	pathStmt := psql.Update(albumsTable).
+		Set(albumPath, sq.Expr("? || substr("+albumPath+", ?)", newPath, len([]rune(oldPath))+1)).
+		Where(albumDescendants(id))
+
+	sql, args, err = pathStmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	for _, stmt := range moveAlbumClosureStmts {
+		if _, err := w.tx.Exec(ctx, stmt, id, parentID); err != nil {
+			return err
+		}
+	}
+
+	// the former ancestors lose the media of the subtree and the new ones gain them
+	return w.refreshAlbumStats(ctx, append(oldParentID, id)...)
+}
+
+// MoveMedia changes the album and the file name of a media.
+// Everything else, including the id, the hash and the thumbnail, is kept.
+func (w *Writer) MoveMedia(ctx context.Context, id, albumID, filename string) error {
+	oldAlbumID, err := w.mediaAlbumID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stmt := psql.Update(mediaTable).
+		Set(mediaAlbumID, albumID).
+		Set(mediaFileName, filename).
+		Where(sq.Eq{mediaID: id})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	return w.refreshAlbumStats(ctx, append(oldAlbumID, albumID)...)
+}
+
+// TrashAlbum puts an album, its subalbums and their media in the trash.
+// Rows already in the trash keep their own trashed_at so they are not restored with the album.
+func (w *Writer) TrashAlbum(ctx context.Context, path string, trashedAt time.Time) error {
+	return w.setAlbumTrashedAt(ctx, path, nil, &trashedAt)
+}
+
+// RestoreAlbum takes an album out of the trash with the subalbums and media which were trashed with it.
+func (w *Writer) RestoreAlbum(ctx context.Context, path string, trashedAt time.Time) error {
+	return w.setAlbumTrashedAt(ctx, path, &trashedAt, nil)
+}
+
+// setAlbumTrashedAt changes trashed_at from `from` to `to` for the album subtree rooted at path.
+func (w *Writer) setAlbumTrashedAt(ctx context.Context, path string, from, to *time.Time) error {
+	subtree := psql.Select(albumID).From(albumsTable).Where(albumSubtree(path))
+
+	mediaStmt := psql.Update(mediaTable).
+		Set(mediaTrashedAt, to).
+		Where(sq.Eq{mediaTrashedAt: from}).
+		Where(subtree.Prefix(mediaAlbumID + " IN (").Suffix(")"))
+
+	albumsStmt := psql.Update(albumsTable).
+		Set(albumTrashedAt, to).
+		Where(sq.Eq{albumTrashedAt: from}).
+		Where(albumSubtree(path))
+
+	for _, stmt := range []sq.UpdateBuilder{mediaStmt, albumsStmt} {
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	sql, args, err := subtree.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := w.tx.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	return w.refreshAlbumStats(ctx, ids...)
+}
+
+// TrashMedia puts a media in the trash.
+func (w *Writer) TrashMedia(ctx context.Context, id string, trashedAt time.Time) error {
+	return w.setMediaTrashedAt(ctx, id, &trashedAt)
+}
+
+// RestoreMedia takes a media out of the trash.
+func (w *Writer) RestoreMedia(ctx context.Context, id string) error {
+	return w.setMediaTrashedAt(ctx, id, nil)
+}
+
+func (w *Writer) setMediaTrashedAt(ctx context.Context, id string, trashedAt *time.Time) error {
+	stmt := psql.Update(mediaTable).
+		Set(mediaTrashedAt, trashedAt).
+		Where(sq.Eq{mediaID: id}).
+		Suffix("RETURNING " + mediaAlbumID)
+
+	return w.execAndRefreshAlbumStats(ctx, stmt)
+}
+
+// PurgeAlbum deletes for good an album, all its subalbums and their media, trashed or not.
+// It returns the deleted albums and media so their relationships can be removed as well.
+func (w *Writer) PurgeAlbum(ctx context.Context, id string) ([]entity.Resource, error) {
+	const subtree = `WITH RECURSIVE album_tree AS (
+		SELECT id FROM albums WHERE id = $1
+		UNION ALL
+		SELECT a.id FROM albums a INNER JOIN album_tree t ON a.parent_id = t.id
+	) `
+
+	parentID, err := w.albumParentID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []entity.Resource{}
+	statements := []struct {
+		sql      string
+		resource func(string) entity.Resource
+	}{
+		{subtree + "DELETE FROM media WHERE album_id IN (SELECT id FROM album_tree) RETURNING id", entity.NewMediaResource},
+		{subtree + "DELETE FROM albums WHERE id IN (SELECT id FROM album_tree) RETURNING id", entity.NewAlbumResource},
+	}
+
+	for _, stmt := range statements {
+		rows, err := w.tx.Query(ctx, stmt.sql, id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			resources = append(resources, stmt.resource(id))
+		}
+	}
+
+	if err := w.refreshAlbumStats(ctx, parentID...); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// albumDescendants matches the album and all its descendants, the trashed ones included.
+func albumDescendants(id string) sq.Sqlizer {
+	return sq.Expr(albumID+" IN (SELECT descendant_id FROM album_closure WHERE ancestor_id = ?)", id)
+}
+
+// albumSubtree matches the album at path and all its descendants.
+func albumSubtree(path string) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{albumPath: path},
+		sq.Expr("starts_with("+albumPath+", ?)", path+"/"),
+	}
+}
+
+// RebuildAlbumHierarchy recomputes the album closure table and the cached statistics of every album.
+// The writes keep them up to date, a rebuild is only needed to repair them.
+func (w *Writer) RebuildAlbumHierarchy(ctx context.Context) error {
+	for _, stmt := range rebuildAlbumHierarchyStmts {
+		if _, err := w.tx.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	sql, args, err := psql.Select(albumID).From(albumsTable).ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := w.tx.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	return w.refreshAlbumStats(ctx, ids...)
+}
+
+// refreshAlbumStats recomputes the cached media statistics of the albums and of all their ancestors.
+func (w *Writer) refreshAlbumStats(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := w.tx.Exec(ctx, lockAlbumAncestorsStmt, ids); err != nil {
+		return err
+	}
+
+	for _, stmt := range refreshAlbumStatsStmts {
+		if _, err := w.tx.Exec(ctx, stmt, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addAlbumMediaStats adds count media captured at capturedAt to the cached statistics of the album and of all
+// its ancestors without recomputing them. The media of a trashed album are not counted by its ancestors.
+func (w *Writer) addAlbumMediaStats(ctx context.Context, id string, count int, capturedAt time.Time) error {
+	if _, err := w.tx.Exec(ctx, lockAlbumAncestorsStmt, []string{id}); err != nil {
+		return err
+	}
+
+	for _, stmt := range addAlbumMediaStatsStmts {
+		if _, err := w.tx.Exec(ctx, stmt, id, count, capturedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execAndRefreshAlbumStats executes a statement returning the id of the album whose statistics it changed.
+// Nothing is refreshed when no row is changed or the returned id is null.
+func (w *Writer) execAndRefreshAlbumStats(ctx context.Context, stmt sq.Sqlizer) error {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	var id *string
+	if err := w.tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if id == nil {
+		return nil
+	}
+
+	return w.refreshAlbumStats(ctx, *id)
+}
+
+// albumParentID returns the parent of an album as a slice which is empty for a root album or a missing one.
+func (w *Writer) albumParentID(ctx context.Context, id string) ([]string, error) {
+	return w.queryID(ctx, psql.Select(albumParentID).From(albumsTable).Where(sq.Eq{albumID: id}))
+}
+
+// mediaAlbumID returns the album of a media as a slice which is empty for a missing media.
+func (w *Writer) mediaAlbumID(ctx context.Context, id string) ([]string, error) {
+	return w.queryID(ctx, psql.Select(mediaAlbumID).From(mediaTable).Where(sq.Eq{mediaID: id}))
+}
+
+func (w *Writer) queryID(ctx context.Context, stmt sq.SelectBuilder) ([]string, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var id *string
+	if err := w.tx.QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	if id == nil {
+		return []string{}, nil
+	}
+
+	return []string{*id}, nil
+}
+
 // DeleteAlbum deletes an album from the database
 func (w *Writer) DeleteAlbum(ctx context.Context, id string) error {
 	// Build the delete statement
 	stmt := psql.Delete(albumsTable).
-		Where(sq.Eq{albumID: id})
+		Where(sq.Eq{albumID: id}).
+		Suffix("RETURNING " + albumParentID)
+
+	// The closure rows are deleted by cascade, the ancestors lose the media of the album
+	return w.execAndRefreshAlbumStats(ctx, stmt)
+}
+
+// DeleteMedia deletes a media item from the database
+func (w *Writer) DeleteMedia(ctx context.Context, id string) error {
+	// Build the delete statement
+	stmt := psql.Delete(mediaTable).
+		Where(sq.Eq{mediaID: id}).
+		Suffix("RETURNING " + mediaAlbumID)
+
+	return w.execAndRefreshAlbumStats(ctx, stmt)
+}
+
+// WriteCollection creates or updates a collection using PostgreSQL upsert (ON CONFLICT)
+func (w *Writer) WriteCollection(ctx context.Context, collection entity.Collection) error {
+	stmt := psql.Insert(collectionsTable).
+		Columns(
+			collectionID,
+			collectionCreatedAt,
+			collectionName,
+			collectionDescription,
+			collectionThumbnailID,
+		).
+		Values(
+			collection.ID,
+			collection.CreatedAt,
+			collection.Name,
+			collection.Description,
+			collection.Thumbnail,
+		).
+		Suffix("ON CONFLICT ( id ) DO UPDATE SET " +
+			collectionName + " = EXCLUDED." + collectionName + ", " +
+			collectionDescription + " = EXCLUDED." + collectionDescription + ", " +
+			collectionThumbnailID + " = EXCLUDED." + collectionThumbnailID)
 
-	// Convert to SQL
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return err
 	}
 
-	// Execute the query
 	_, err = w.tx.Exec(ctx, sql, args...)
 	return err
 }
 
-// DeleteMedia deletes a media item from the database
-func (w *Writer) DeleteMedia(ctx context.Context, id string) error {
-	// Build the delete statement
-	stmt := psql.Delete(mediaTable).
-		Where(sq.Eq{mediaID: id})
+// DeleteCollection deletes a collection from the database.
+// The media themselves are untouched, only their membership is removed.
+func (w *Writer) DeleteCollection(ctx context.Context, id string) error {
+	stmt := psql.Delete(collectionsTable).
+		Where(sq.Eq{collectionID: id})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// WriteCollectionMedia replaces the media of a collection with mediaIDs.
+// The position of each media in the collection is given by its index in mediaIDs.
+func (w *Writer) WriteCollectionMedia(ctx context.Context, id string, mediaIDs []string) error {
+	deleteStmt := psql.Delete(collectionMediaTable).
+		Where(sq.Eq{collectionMediaCollectionID: id})
+
+	sql, args, err := deleteStmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	// insert in batches to stay well below the limit of bind parameters of a statement
+	for start := 0; start < len(mediaIDs); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(mediaIDs))
+
+		insertStmt := psql.Insert(collectionMediaTable).
+			Columns(
+				collectionMediaCollectionID,
+				collectionMediaMediaID,
+				collectionMediaPosition,
+			)
+		for position := start; position < end; position++ {
+			insertStmt = insertStmt.Values(id, mediaIDs[position], position)
+		}
+
+		sql, args, err := insertStmt.ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteSmartAlbum creates or updates a smart album using PostgreSQL upsert (ON CONFLICT)
+func (w *Writer) WriteSmartAlbum(ctx context.Context, smartAlbum entity.SmartAlbum) error {
+	filter, err := json.Marshal(smartAlbum.Filter)
+	if err != nil {
+		return err
+	}
+
+	stmt := psql.Insert(smartAlbumsTable).
+		Columns(
+			smartAlbumID,
+			smartAlbumCreatedAt,
+			smartAlbumName,
+			smartAlbumDescription,
+			smartAlbumFilter,
+		).
+		Values(
+			smartAlbum.ID,
+			smartAlbum.CreatedAt,
+			smartAlbum.Name,
+			smartAlbum.Description,
+			filter,
+		).
+		Suffix("ON CONFLICT ( id ) DO UPDATE SET " +
+			smartAlbumName + " = EXCLUDED." + smartAlbumName + ", " +
+			smartAlbumDescription + " = EXCLUDED." + smartAlbumDescription + ", " +
+			smartAlbumFilter + " = EXCLUDED." + smartAlbumFilter)
 
-	// Convert to SQL
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return err
 	}
 
-	// Execute the query
 	_, err = w.tx.Exec(ctx, sql, args...)
 	return err
 }
 
+// DeleteSmartAlbum deletes a smart album from the database.
+// The media matched by its filter are untouched.
+func (w *Writer) DeleteSmartAlbum(ctx context.Context, id string) error {
+	stmt := psql.Delete(smartAlbumsTable).
+		Where(sq.Eq{smartAlbumID: id})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// WriteComment creates or updates a comment using PostgreSQL upsert (ON CONFLICT).
+// Only the body and the update time of an existing comment can change.
+func (w *Writer) WriteComment(ctx context.Context, comment entity.Comment) error {
+	stmt := psql.Insert(commentsTable).
+		Columns(
+			commentID,
+			commentMediaID,
+			commentParentID,
+			commentAuthor,
+			commentBody,
+			commentCreatedAt,
+			commentUpdatedAt,
+		).
+		Values(
+			comment.ID,
+			comment.MediaID,
+			comment.ParentID,
+			comment.Author,
+			comment.Body,
+			comment.CreatedAt,
+			comment.UpdatedAt,
+		).
+		Suffix("ON CONFLICT ( id ) DO UPDATE SET " +
+			commentBody + " = EXCLUDED." + commentBody + ", " +
+			commentUpdatedAt + " = EXCLUDED." + commentUpdatedAt)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// DeleteComment deletes a comment from the database together with its replies.
+func (w *Writer) DeleteComment(ctx context.Context, id string) error {
+	stmt := psql.Delete(commentsTable).
+		Where(sq.Eq{commentID: id})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// WriteJob creates or updates a job of the history. The results of its tasks are written by WriteJobResult.
+func (w *Writer) WriteJob(ctx context.Context, job entity.JobProgress) error {
+	metadata := job.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	stmt := psql.Insert(jobsTable).
+		Columns(
+			jobID,
+			jobType,
+			jobPath,
+			jobMetadata,
+			jobStatus,
+			jobReason,
+			jobTotal,
+			jobRemaining,
+			jobCreatedAt,
+			jobStartedAt,
+			jobCompletedAt,
+		).
+		Values(
+			job.Id,
+			metadata["type"],
+			job.Path,
+			metadata,
+			string(job.Status),
+			job.Reason,
+			job.Total,
+			job.Remaining,
+			job.CreatedAt,
+			job.StartedAt,
+			job.CompletedAt,
+		).
+		Suffix("ON CONFLICT ( id ) DO UPDATE SET " +
+			jobStatus + " = EXCLUDED." + jobStatus + ", " +
+			jobReason + " = EXCLUDED." + jobReason + ", " +
+			jobTotal + " = EXCLUDED." + jobTotal + ", " +
+			jobRemaining + " = EXCLUDED." + jobRemaining + ", " +
+			jobStartedAt + " = EXCLUDED." + jobStartedAt + ", " +
+			jobCompletedAt + " = EXCLUDED." + jobCompletedAt)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// WriteJobResult writes the result of the task of a job at position, the tasks are numbered from 0.
+func (w *Writer) WriteJobResult(ctx context.Context, id uuid.UUID, position int, result entity.JobResult) error {
+	var errMessage *string
+	if result.Err != nil {
+		message := result.Err.Error()
+		errMessage = &message
+	}
+
+	stmt := psql.Insert(jobResultsTable).
+		Columns(
+			jobResultJobID,
+			jobResultPosition,
+			jobResultResult,
+			jobResultError,
+			jobResultStartedAt,
+			jobResultCompletedAt,
+		).
+		Values(id, position, result.Result, errMessage, result.StartedAt, result.CompletedAt).
+		Suffix("ON CONFLICT ( job_id, position ) DO NOTHING")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// InterruptJobs marks the jobs which did not reach a final status as interrupted at the given time
+// and returns their ids.
+func (w *Writer) InterruptJobs(ctx context.Context, reason string, at time.Time) ([]string, error) {
+	stmt := psql.Update(jobsTable).
+		Set(jobStatus, string(entity.StatusInterrupted)).
+		Set(jobReason, reason).
+		Set(jobCompletedAt, at).
+		Where(sq.NotEq{jobStatus: finishedJobStatuses()}).
+		Suffix("RETURNING " + jobID + "::text")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// DeleteFinishedJobs deletes the jobs of the history which reached a final status before the given time,
+// with the results of their tasks. It returns the number of deleted jobs.
+func (w *Writer) DeleteFinishedJobs(ctx context.Context, before time.Time) (int64, error) {
+	stmt := psql.Delete(jobsTable).
+		Where(sq.Eq{jobStatus: finishedJobStatuses()}).
+		Where(sq.Lt{jobCompletedAt: before})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := w.tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// finishedJobStatuses lists the final job statuses as stored in the jobs table
+func finishedJobStatuses() []string {
+	return []string{
+		string(entity.StatusCompleted),
+		string(entity.StatusFailed),
+		string(entity.StatusStopped),
+		string(entity.StatusInterrupted),
+	}
+}
+
 func (w *Writer) WriteToken(ctx context.Context, token string) error {
 	stmt := tokenWriteStmt.
 		Values(1, token).
@@ -3,10 +3,12 @@ package pg_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -19,7 +21,7 @@ var _ = Describe("Writer", Ordered, func() {
 	)
 
 	BeforeAll(func() {
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
 		Expect(pgDt).ToNot(BeNil())
 
@@ -145,6 +147,186 @@ var _ = Describe("Writer", Ordered, func() {
 		})
 	})
 
+	Context("MoveAlbum", func() {
+		// writeAlbum writes an album below the parent, a root album if parent is nil
+		writeAlbum := func(path string, parent *entity.Album) entity.Album {
+			album := entity.NewAlbum(path)
+			if parent != nil {
+				album.ParentId = &parent.ID
+			}
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.WriteAlbum(ctx, album)
+			})
+			Expect(err).To(BeNil())
+			return album
+		}
+
+		albumPath := func(id string) string {
+			var path string
+			err := pgPool.QueryRow(context.TODO(), "SELECT path FROM albums WHERE id = $1", id).Scan(&path)
+			Expect(err).To(BeNil())
+			return path
+		}
+
+		It("moves the subtree of the album and leaves the trashed albums sharing its path", func() {
+			// a former album at the same path, in the trash with its subalbum
+			trashed := writeAlbum("/move/album", nil)
+			trashedChild := writeAlbum("/move/album/old", &trashed)
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.TrashAlbum(ctx, "/move/album", time.Now())
+			})
+			Expect(err).To(BeNil())
+
+			album := writeAlbum("/move/album", nil)
+			child := writeAlbum("/move/album/child", &album)
+			target := writeAlbum("/target", nil)
+
+			err = dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.MoveAlbum(ctx, album.ID, &target.ID, "/move/album", "/target/album")
+			})
+			Expect(err).To(BeNil())
+
+			Expect(albumPath(album.ID)).To(Equal("/target/album"))
+			Expect(albumPath(child.ID)).To(Equal("/target/album/child"))
+			Expect(albumPath(trashed.ID)).To(Equal("/move/album"))
+			Expect(albumPath(trashedChild.ID)).To(Equal("/move/album/old"))
+		})
+
+		AfterEach(func() {
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Context("album closure", func() {
+		// writeAlbum writes an album below the parent, a root album if parent is nil
+		writeAlbum := func(path string, parent *entity.Album) entity.Album {
+			album := entity.NewAlbum(path)
+			if parent != nil {
+				album.ParentId = &parent.ID
+			}
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.WriteAlbum(ctx, album)
+			})
+			Expect(err).To(BeNil())
+			return album
+		}
+
+		// ancestors returns the depth of every ancestor of the album, the album itself included
+		ancestors := func(id string) map[string]int {
+			rows, err := pgPool.Query(context.TODO(),
+				"SELECT ancestor_id, depth FROM album_closure WHERE descendant_id = $1", id)
+			Expect(err).To(BeNil())
+			defer rows.Close()
+
+			depths := map[string]int{}
+			for rows.Next() {
+				var (
+					ancestorID string
+					depth      int
+				)
+				Expect(rows.Scan(&ancestorID, &depth)).To(Succeed())
+				depths[ancestorID] = depth
+			}
+			Expect(rows.Err()).To(BeNil())
+			return depths
+		}
+
+		It("links a new album to itself and to every ancestor", func() {
+			root := writeAlbum("/closure", nil)
+			parent := writeAlbum("/closure/2023", &root)
+			album := writeAlbum("/closure/2023/summer", &parent)
+
+			Expect(ancestors(root.ID)).To(Equal(map[string]int{root.ID: 0}))
+			Expect(ancestors(album.ID)).To(Equal(map[string]int{album.ID: 0, parent.ID: 1, root.ID: 2}))
+		})
+
+		It("keeps the links of an existing album written again", func() {
+			root := writeAlbum("/closure", nil)
+			album := writeAlbum("/closure/2023", &root)
+
+			album.Description = stringPtr("Updated")
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.WriteAlbum(ctx, album)
+			})
+			Expect(err).To(BeNil())
+
+			Expect(ancestors(album.ID)).To(Equal(map[string]int{album.ID: 0, root.ID: 1}))
+		})
+
+		It("moves the links of the subtree to the ancestors of the new parent", func() {
+			root := writeAlbum("/closure", nil)
+			album := writeAlbum("/closure/album", &root)
+			child := writeAlbum("/closure/album/child", &album)
+			other := writeAlbum("/other", nil)
+			target := writeAlbum("/other/target", &other)
+
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.MoveAlbum(ctx, album.ID, &target.ID, "/closure/album", "/other/target/album")
+			})
+			Expect(err).To(BeNil())
+
+			Expect(ancestors(album.ID)).To(Equal(map[string]int{album.ID: 0, target.ID: 1, other.ID: 2}))
+			Expect(ancestors(child.ID)).To(Equal(map[string]int{child.ID: 0, album.ID: 1, target.ID: 2, other.ID: 3}))
+			Expect(ancestors(root.ID)).To(Equal(map[string]int{root.ID: 0}))
+		})
+
+		It("moves the subtree to the root", func() {
+			root := writeAlbum("/closure", nil)
+			album := writeAlbum("/closure/album", &root)
+			child := writeAlbum("/closure/album/child", &album)
+
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.MoveAlbum(ctx, album.ID, nil, "/closure/album", "/album")
+			})
+			Expect(err).To(BeNil())
+
+			Expect(ancestors(album.ID)).To(Equal(map[string]int{album.ID: 0}))
+			Expect(ancestors(child.ID)).To(Equal(map[string]int{child.ID: 0, album.ID: 1}))
+		})
+
+		It("removes the links of the deleted albums", func() {
+			root := writeAlbum("/closure", nil)
+			album := writeAlbum("/closure/album", &root)
+			child := writeAlbum("/closure/album/child", &album)
+
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.DeleteAlbum(ctx, album.ID)
+			})
+			Expect(err).To(BeNil())
+
+			Expect(ancestors(album.ID)).To(BeEmpty())
+			Expect(ancestors(child.ID)).To(BeEmpty())
+			Expect(ancestors(root.ID)).To(Equal(map[string]int{root.ID: 0}))
+		})
+
+		It("rebuilds the links from the parents", func() {
+			root := writeAlbum("/closure", nil)
+			album := writeAlbum("/closure/album", &root)
+			child := writeAlbum("/closure/album/child", &album)
+
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM album_closure;")
+			Expect(err).To(BeNil())
+
+			err = dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.RebuildAlbumHierarchy(ctx)
+			})
+			Expect(err).To(BeNil())
+
+			Expect(ancestors(root.ID)).To(Equal(map[string]int{root.ID: 0}))
+			Expect(ancestors(child.ID)).To(Equal(map[string]int{child.ID: 0, album.ID: 1, root.ID: 2}))
+		})
+
+		AfterEach(func() {
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("WriteMedia", func() {
 		var testAlbum entity.Album
 
@@ -421,6 +603,235 @@ var _ = Describe("Writer", Ordered, func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Context("job history", func() {
+		// at returns a time of the history, the timestamps are stored to the microsecond without time zone
+		at := func(ago time.Duration) time.Time {
+			return time.Now().UTC().Add(-ago).Truncate(time.Microsecond)
+		}
+
+		// newJob returns a job of the type syncing photos created at the time
+		newJob := func(jobType string, status entity.JobStatus, createdAt time.Time) entity.JobProgress {
+			return entity.JobProgress{
+				Id:        uuid.New(),
+				Status:    status,
+				CreatedAt: createdAt,
+				Total:     2,
+				Remaining: 2,
+				Path:      "photos",
+				Metadata:  map[string]string{"path": "photos", "type": jobType},
+			}
+		}
+
+		// finished returns the job with a final status reached at the time
+		finished := func(job entity.JobProgress, status entity.JobStatus, completedAt time.Time) entity.JobProgress {
+			job.Status = status
+			job.StartedAt = &job.CreatedAt
+			job.CompletedAt = &completedAt
+			job.Remaining = 0
+			return job
+		}
+
+		writeJobs := func(jobs ...entity.JobProgress) {
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				for _, job := range jobs {
+					if err := w.WriteJob(ctx, job); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			Expect(err).To(BeNil())
+		}
+
+		writeResult := func(id uuid.UUID, position int, result entity.JobResult) {
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.WriteJobResult(ctx, id, position, result)
+			})
+			Expect(err).To(BeNil())
+		}
+
+		queryJob := func(id uuid.UUID) entity.JobProgress {
+			jobs, err := dt.QueryJobs(context.TODO(), pg.FilterJobByID(id.String()))
+			Expect(err).To(BeNil())
+			Expect(jobs).To(HaveLen(1))
+			return jobs[0]
+		}
+
+		AfterEach(func() {
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM jobs;")
+			Expect(err).To(BeNil())
+		})
+
+		It("writes a job and then its progress", func() {
+			job := newJob("sync", entity.StatusPending, at(time.Minute))
+			job.Metadata["full_rescan"] = "true"
+			writeJobs(job)
+
+			stored := queryJob(job.Id)
+			Expect(stored.Status).To(Equal(entity.StatusPending))
+			Expect(stored.Path).To(Equal("photos"))
+			Expect(stored.Metadata).To(Equal(map[string]string{"path": "photos", "type": "sync", "full_rescan": "true"}))
+			Expect(stored.CreatedAt).To(BeTemporally("==", job.CreatedAt))
+			Expect(stored.StartedAt).To(BeNil())
+			Expect(stored.Results).To(BeEmpty())
+
+			startedAt := at(0)
+			job.Status = entity.StatusRunning
+			job.StartedAt = &startedAt
+			job.Remaining = 1
+			writeJobs(job)
+
+			stored = queryJob(job.Id)
+			Expect(stored.Status).To(Equal(entity.StatusRunning))
+			Expect(stored.Total).To(Equal(2))
+			Expect(stored.Remaining).To(Equal(1))
+			Expect(stored.StartedAt).To(HaveValue(BeTemporally("==", startedAt)))
+			Expect(stored.CompletedAt).To(BeNil())
+
+			count, err := dt.CountJobs(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(1))
+		})
+
+		It("writes the result of every task once, in the order of the tasks", func() {
+			job := newJob("sync", entity.StatusRunning, at(time.Minute))
+			writeJobs(job)
+
+			writeResult(job.Id, 1, entity.JobResult{Err: errors.New("unreadable file"), StartedAt: at(time.Second), CompletedAt: at(0)})
+			writeResult(job.Id, 0, entity.JobResult{Result: "album photos created", StartedAt: at(2 * time.Second), CompletedAt: at(time.Second)})
+			// a result written again is kept as it was first written
+			writeResult(job.Id, 0, entity.JobResult{Result: "written again", StartedAt: at(0), CompletedAt: at(0)})
+
+			results, err := dt.QueryJobResults(context.TODO(), job.Id)
+			Expect(err).To(BeNil())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Result).To(Equal("album photos created"))
+			Expect(results[0].Err).To(BeNil())
+			Expect(results[1].Result).To(BeEmpty())
+			Expect(results[1].Err).To(MatchError("unreadable file"))
+
+			// the results of the other jobs are not returned
+			other := newJob("sync", entity.StatusRunning, at(time.Minute))
+			writeJobs(other)
+			results, err = dt.QueryJobResults(context.TODO(), other.Id)
+			Expect(err).To(BeNil())
+			Expect(results).To(BeEmpty())
+		})
+
+		It("lists and counts the history by status and type, the oldest first", func() {
+			completed := finished(newJob("sync", entity.StatusRunning, at(3*time.Hour)), entity.StatusCompleted, at(2*time.Hour))
+			failed := finished(newJob("reconcile", entity.StatusRunning, at(2*time.Hour)), entity.StatusFailed, at(time.Hour))
+			purge := newJob("purge", entity.StatusRunning, at(time.Hour))
+			pending := newJob("sync", entity.StatusPending, at(time.Minute))
+			writeJobs(pending, purge, failed, completed)
+
+			ids := func(jobs []entity.JobProgress) []uuid.UUID {
+				jobIDs := []uuid.UUID{}
+				for _, job := range jobs {
+					jobIDs = append(jobIDs, job.Id)
+				}
+				return jobIDs
+			}
+
+			jobs, err := dt.QueryJobs(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(ids(jobs)).To(Equal([]uuid.UUID{completed.Id, failed.Id, purge.Id, pending.Id}))
+
+			jobs, err = dt.QueryJobs(context.TODO(), pg.ExcludeJobTypes("purge"), pg.Limit(2), pg.Offset(1))
+			Expect(err).To(BeNil())
+			Expect(ids(jobs)).To(Equal([]uuid.UUID{failed.Id, pending.Id}))
+
+			jobs, err = dt.QueryJobs(context.TODO(), pg.FilterJobsByStatus(entity.StatusCompleted, entity.StatusFailed), pg.ExcludeJobs(failed.Id.String()))
+			Expect(err).To(BeNil())
+			Expect(ids(jobs)).To(Equal([]uuid.UUID{completed.Id}))
+
+			count, err := dt.CountJobs(context.TODO(), pg.ExcludeJobTypes("purge"), pg.FilterJobsByStatus(entity.StatusRunning, entity.StatusPending))
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(1))
+		})
+
+		It("interrupts the jobs which did not finish and keeps their progress", func() {
+			running := newJob("sync", entity.StatusRunning, at(time.Hour))
+			running.Remaining = 1
+			pending := newJob("sync", entity.StatusPending, at(time.Hour))
+			paused := newJob("sync", entity.StatusPause, at(time.Hour))
+			completed := finished(newJob("sync", entity.StatusRunning, at(time.Hour)), entity.StatusCompleted, at(time.Minute))
+			writeJobs(running, pending, paused, completed)
+			writeResult(running.Id, 0, entity.JobResult{Result: "album photos created", StartedAt: at(time.Minute), CompletedAt: at(time.Minute)})
+
+			interruptedAt := at(0)
+			var interrupted []string
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				var err error
+				interrupted, err = w.InterruptJobs(ctx, "interrupted by a server restart", interruptedAt)
+				return err
+			})
+			Expect(err).To(BeNil())
+			Expect(interrupted).To(ConsistOf(running.Id.String(), pending.Id.String(), paused.Id.String()))
+
+			stored := queryJob(running.Id)
+			Expect(stored.Status).To(Equal(entity.StatusInterrupted))
+			Expect(stored.Reason).To(Equal("interrupted by a server restart"))
+			Expect(stored.CompletedAt).To(HaveValue(BeTemporally("==", interruptedAt)))
+			Expect(stored.Remaining).To(Equal(1))
+
+			results, err := dt.QueryJobResults(context.TODO(), running.Id)
+			Expect(err).To(BeNil())
+			Expect(results).To(HaveLen(1))
+
+			stored = queryJob(completed.Id)
+			Expect(stored.Status).To(Equal(entity.StatusCompleted))
+			Expect(stored.Reason).To(BeEmpty())
+
+			// the interrupted jobs are finished, they are not interrupted again
+			err = dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				var err error
+				interrupted, err = w.InterruptJobs(ctx, "interrupted by a server restart", at(0))
+				return err
+			})
+			Expect(err).To(BeNil())
+			Expect(interrupted).To(BeEmpty())
+		})
+
+		It("deletes the jobs which finished before the time with their results", func() {
+			old := finished(newJob("sync", entity.StatusRunning, at(72*time.Hour)), entity.StatusCompleted, at(48*time.Hour))
+			oldFailed := finished(newJob("sync", entity.StatusRunning, at(72*time.Hour)), entity.StatusFailed, at(48*time.Hour))
+			recent := finished(newJob("sync", entity.StatusRunning, at(2*time.Hour)), entity.StatusCompleted, at(time.Hour))
+			// a job running for long has no completion time, it is kept
+			running := newJob("sync", entity.StatusRunning, at(72*time.Hour))
+			writeJobs(old, oldFailed, recent, running)
+			writeResult(old.Id, 0, entity.JobResult{Result: "album photos created", StartedAt: at(48 * time.Hour), CompletedAt: at(48 * time.Hour)})
+
+			var deleted int64
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				var err error
+				deleted, err = w.DeleteFinishedJobs(ctx, at(24*time.Hour))
+				return err
+			})
+			Expect(err).To(BeNil())
+			Expect(deleted).To(Equal(int64(2)))
+
+			jobs, err := dt.QueryJobs(context.TODO())
+			Expect(err).To(BeNil())
+			Expect(jobs).To(HaveLen(2))
+			Expect([]uuid.UUID{jobs[0].Id, jobs[1].Id}).To(ConsistOf(recent.Id, running.Id))
+
+			var results int
+			Expect(pgPool.QueryRow(context.TODO(), "SELECT count(*) FROM job_results WHERE job_id = $1", old.Id).Scan(&results)).To(Succeed())
+			Expect(results).To(Equal(0))
+
+			// clearing every finished job keeps the running one
+			err = dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				var err error
+				deleted, err = w.DeleteFinishedJobs(ctx, at(0).Add(time.Second))
+				return err
+			})
+			Expect(err).To(BeNil())
+			Expect(deleted).To(Equal(int64(1)))
+			Expect(queryJob(running.Id).Status).To(Equal(entity.StatusRunning))
+		})
+	})
 })
 
 // Helper function to create string pointers
@@ -12,11 +12,32 @@ type Album struct {
 	Children    []Album
 	Media       []Media
 	MediaCount  int
+	// Hidden albums and their subalbums are left out of the memories
+	Hidden bool
+	// TrashedAt is set when the album is in the trash
+	TrashedAt *time.Time
 }
 
+// AlbumSortBy is the order of an album listing.
+type AlbumSortBy string
+
+const (
+	// AlbumSortByName sorts albums by the name of their folder, case insensitively
+	AlbumSortByName AlbumSortBy = "name"
+	// AlbumSortByCreatedAt sorts albums by creation date
+	AlbumSortByCreatedAt AlbumSortBy = "created_at"
+	// AlbumSortByLatestCapture sorts albums by the capture date of their most recent media, subalbums included.
+	// Albums without media come last in descending order.
+	AlbumSortByLatestCapture AlbumSortBy = "latest_capture"
+	// AlbumSortByMediaCount sorts albums by the number of media, subalbums included
+	AlbumSortByMediaCount AlbumSortBy = "media_count"
+)
+
+// NewAlbum returns a new album for the folder. The id is not related to the path,
+// an existing album must be looked up by its path.
 func NewAlbum(folderPath string) Album {
 	return Album{
-		ID:        GenerateId(folderPath),
+		ID:        NewId(),
 		Path:      folderPath,
 		CreatedAt: time.Now(),
 		Children:  make([]Album, 0),
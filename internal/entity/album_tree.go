@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// AlbumTreeNode is an album of the album tree with the statistics of its media.
+// The subtree statistics include the media of the album and of all its descendants.
+type AlbumTreeNode struct {
+	Album Album
+	// MediaCount is the number of media of the album itself
+	MediaCount int
+	// SubtreeMediaCount is the number of media of the album and of its descendants
+	SubtreeMediaCount int
+	// EarliestCapturedAt and LatestCapturedAt bound the capture dates of the subtree media. Nil if the subtree has no media.
+	EarliestCapturedAt *time.Time
+	LatestCapturedAt   *time.Time
+	// CoverID is the id of the media used as cover: the thumbnail of the album or else the most recent media of the subtree
+	CoverID  *string
+	Children []AlbumTreeNode
+}
+
+// NewAlbumTree links the nodes to their parent and returns the roots: the nodes whose parent is not among the nodes.
+// The order of the nodes is kept among siblings. A depth greater than 0 limits the number of levels of the tree,
+// the statistics of the nodes are not changed.
+func NewAlbumTree(nodes []AlbumTreeNode, depth int) []AlbumTreeNode {
+	present := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		present[node.Album.ID] = true
+	}
+
+	children := make(map[string][]int)
+	roots := []int{}
+	for i, node := range nodes {
+		if node.Album.ParentId == nil || !present[*node.Album.ParentId] {
+			roots = append(roots, i)
+			continue
+		}
+		children[*node.Album.ParentId] = append(children[*node.Album.ParentId], i)
+	}
+
+	var build func(idx []int, level int) []AlbumTreeNode
+	build = func(idx []int, level int) []AlbumTreeNode {
+		tree := make([]AlbumTreeNode, 0, len(idx))
+		for _, i := range idx {
+			node := nodes[i]
+			node.Children = []AlbumTreeNode{}
+			if depth <= 0 || level < depth {
+				node.Children = build(children[node.Album.ID], level+1)
+			}
+			tree = append(tree, node)
+		}
+		return tree
+	}
+
+	return build(roots, 1)
+}
@@ -11,7 +11,7 @@ const (
 var (
 	UserPermissions      = []Permission{ViewPermission, EditPermission, DeletePermission}
 	DatastorePermissions = []Permission{CreatePermission, ViewPermission, EditPermission, DeletePermission}
-	AllPermissions       = []Permission{ViewPermission, EditPermission, EditPermission, CreatePermission}
+	AllPermissions       = []Permission{ViewPermission, EditPermission, CreatePermission, DeletePermission}
 )
 
 type Permission int
@@ -28,6 +28,8 @@ func (p Permission) String() string {
 		return "create"
 	case SyncPermission:
 		return "sync"
+	case SetPermissionsPermission:
+		return "can_set_permissions"
 	default:
 		return "unknown"
 	}
@@ -39,6 +41,7 @@ const (
 	CreatePermission
 	DeletePermission
 	SyncPermission
+	SetPermissionsPermission
 )
 
 type ResourceKind int
@@ -53,6 +56,10 @@ func (rk ResourceKind) String() string {
 		return "datastore"
 	case RoleResource:
 		return "role"
+	case CollectionResource:
+		return "collection"
+	case SmartAlbumResource:
+		return "smart_album"
 	default:
 		return "unknown"
 	}
@@ -63,6 +70,8 @@ const (
 	MediaResource
 	DatastoreResource
 	RoleResource
+	CollectionResource
+	SmartAlbumResource
 )
 
 type SubjectKind int
@@ -110,6 +119,14 @@ func NewDatastoreResource(id string) Resource {
 	return Resource{ID: id, Kind: DatastoreResource}
 }
 
+func NewCollectionResource(id string) Resource {
+	return Resource{ID: id, Kind: CollectionResource}
+}
+
+func NewSmartAlbumResource(id string) Resource {
+	return Resource{ID: id, Kind: SmartAlbumResource}
+}
+
 type Subject struct {
 	ID   string
 	Kind SubjectKind
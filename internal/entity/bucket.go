@@ -1,9 +1,39 @@
 package entity
 
+import "time"
+
+// Bucket is a period of the timeline holding Count media: a month, or a day when Day is set.
 type Bucket struct {
 	Year  int
 	Month int
-	Media []Media
+	Day   int
+	Count int
+}
+
+// Start returns the first instant of the bucket.
+func (b Bucket) Start() time.Time {
+	day := b.Day
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(b.Year, time.Month(b.Month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the first instant after the bucket.
+func (b Bucket) End() time.Time {
+	if b.Day == 0 {
+		return b.Start().AddDate(0, 1, 0)
+	}
+	return b.Start().AddDate(0, 0, 1)
 }
 
 type Buckets []Bucket
+
+// Total returns the number of media of all the buckets.
+func (bb Buckets) Total() int {
+	total := 0
+	for _, b := range bb {
+		total += b.Count
+	}
+	return total
+}
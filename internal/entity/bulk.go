@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// BulkOperation is the operation applied to every media of a bulk request.
+type BulkOperation string
+
+const (
+	BulkDelete     BulkOperation = "delete"
+	BulkMove       BulkOperation = "move"
+	BulkTag        BulkOperation = "tag"
+	BulkFavorite   BulkOperation = "favorite"
+	BulkDateShift  BulkOperation = "date_shift"
+	BulkPermission BulkOperation = "permission"
+)
+
+// BulkRequest applies one operation to a list of media.
+// Only the fields of the requested operation are used.
+type BulkRequest struct {
+	Operation BulkOperation
+	MediaIDs  []string
+
+	// AlbumID is the album the media are moved into
+	AlbumID string
+
+	// AddTags and RemoveTags are added to and removed from the tags of the media
+	AddTags    []string
+	RemoveTags []string
+
+	// Favorite marks or unmarks the media as favorite
+	Favorite bool
+
+	// Shift is added to the capture date of the media
+	Shift time.Duration
+
+	// Subject is granted, or revoked when Grant is false, the Relationship on the media.
+	// The relationship is either ViewerRelationship or EditorRelationship.
+	Subject      Subject
+	Relationship RelationshipKind
+	Grant        bool
+}
+
+// BulkItemResult is the outcome of the operation on one media of a bulk request.
+type BulkItemResult struct {
+	MediaID string
+	Err     error
+}
+
+// BulkJob tracks the progress of a bulk request.
+// Items holds the result of every media processed so far.
+type BulkJob struct {
+	JobProgress
+	Operation BulkOperation
+	Items     []BulkItemResult
+}
+
+// Failed returns the number of media the operation failed on.
+func (b BulkJob) Failed() int {
+	failed := 0
+	for _, item := range b.Items {
+		if item.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the number of media the operation succeeded on.
+func (b BulkJob) Succeeded() int {
+	return len(b.Items) - b.Failed()
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Collection is a named, ordered grouping of existing media.
+// Unlike Album, a collection is purely virtual: it has no folder on disk
+// and a media can belong to any number of collections.
+type Collection struct {
+	ID          string
+	CreatedAt   time.Time
+	Name        string
+	Description *string
+	Thumbnail   *string
+	MediaCount  int
+}
+
+func NewCollection(name string) Collection {
+	return Collection{
+		ID:        NewId(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
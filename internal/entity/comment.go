@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Comment is a comment left by a user on a media.
+// A comment with a ParentID is a reply to another comment of the same media.
+type Comment struct {
+	ID        string
+	MediaID   string
+	ParentID  *string
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewComment(mediaID, author, body string) Comment {
+	now := time.Now()
+	return Comment{
+		ID:        NewId(),
+		MediaID:   mediaID,
+		Author:    author,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsReply returns true if the comment answers another comment.
+func (c Comment) IsReply() bool {
+	return c.ParentID != nil
+}
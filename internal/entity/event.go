@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strings"
+	"time"
+)
+
+// EventTarget is what an accepted event suggestion becomes.
+type EventTarget string
+
+const (
+	// EventAlbumTarget moves the media of the event into a new album
+	EventAlbumTarget EventTarget = "album"
+	// EventCollectionTarget links the media of the event into a new collection, the media stay in their album
+	EventCollectionTarget EventTarget = "collection"
+)
+
+const earthRadiusKm = 6371.0
+
+// EventClusterOptions are the thresholds splitting a series of media into events.
+type EventClusterOptions struct {
+	// Gap is the longest time between two media of the same event
+	Gap time.Duration
+	// MaxDistance is the longest distance in km between two consecutive located media of the same event.
+	// Zero ignores the location.
+	MaxDistance float64
+	// MinMedia is the smallest number of media of an event, smaller groups are not suggested
+	MinMedia int
+}
+
+// DefaultEventClusterOptions returns the thresholds used when the user does not set them.
+func DefaultEventClusterOptions() EventClusterOptions {
+	return EventClusterOptions{
+		Gap:         6 * time.Hour,
+		MaxDistance: 50,
+		MinMedia:    5,
+	}
+}
+
+// EventSuggestion is a group of media captured close in time, and in space when they have a location.
+// Suggestions are not stored, the ID is derived from the media so the same group gets the same ID
+// as long as the media do not change.
+type EventSuggestion struct {
+	ID       string
+	Start    time.Time
+	End      time.Time
+	MediaIDs []string
+	// Location is the center of the located media of the event, nil if none has a location
+	Location *Location
+}
+
+// Name returns the name suggested for the album or the collection made from the event.
+func (e EventSuggestion) Name() string {
+	start, end := e.Start.Format(time.DateOnly), e.End.Format(time.DateOnly)
+	if start == end {
+		return start
+	}
+	return start + " - " + end
+}
+
+// AcceptedEvent holds the album or the collection created from an event suggestion.
+type AcceptedEvent struct {
+	Target     EventTarget
+	Album      *Album
+	Collection *Collection
+}
+
+// ClusterEvents splits the media into events. A new event starts when the time since the previous media
+// is longer than opts.Gap or when both media have a location farther than opts.MaxDistance.
+// The media must be sorted by capture date ascending.
+func ClusterEvents(media []Media, opts EventClusterOptions) []EventSuggestion {
+	suggestions := []EventSuggestion{}
+
+	var group []Media
+	flush := func() {
+		if len(group) > 0 && len(group) >= opts.MinMedia {
+			suggestions = append(suggestions, newEventSuggestion(group))
+		}
+		group = nil
+	}
+
+	for i, m := range media {
+		if i > 0 && splitsEvent(media[i-1], m, opts) {
+			flush()
+		}
+		group = append(group, m)
+	}
+	flush()
+
+	return suggestions
+}
+
+func splitsEvent(previous, current Media, opts EventClusterOptions) bool {
+	if current.CapturedAt.Sub(previous.CapturedAt) > opts.Gap {
+		return true
+	}
+
+	if opts.MaxDistance > 0 && previous.Location != nil && current.Location != nil {
+		return previous.Location.Distance(*current.Location) > opts.MaxDistance
+	}
+
+	return false
+}
+
+func newEventSuggestion(group []Media) EventSuggestion {
+	ids := make([]string, 0, len(group))
+	var latitude, longitude float64
+	located := 0
+	for _, m := range group {
+		ids = append(ids, m.ID)
+		if m.Location != nil {
+			latitude += m.Location.Latitude
+			longitude += m.Location.Longitude
+			located++
+		}
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(ids, ",")))
+
+	suggestion := EventSuggestion{
+		ID:       hex.EncodeToString(hash[:8]),
+		Start:    group[0].CapturedAt,
+		End:      group[len(group)-1].CapturedAt,
+		MediaIDs: ids,
+	}
+
+	if located > 0 {
+		suggestion.Location = &Location{
+			Latitude:  latitude / float64(located),
+			Longitude: longitude / float64(located),
+		}
+	}
+
+	return suggestion
+}
+
+// Distance returns the great-circle distance in km between two locations.
+func (l Location) Distance(other Location) float64 {
+	lat1, lat2 := l.Latitude*math.Pi/180, other.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
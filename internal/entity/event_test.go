@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterEvents", func() {
+	start := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	// media returns a media captured minutes after start, at the location if one is given
+	media := func(id string, minutes int, location ...float64) entity.Media {
+		m := entity.Media{ID: id, CapturedAt: start.Add(time.Duration(minutes) * time.Minute)}
+		if len(location) == 2 {
+			m.Location = &entity.Location{Latitude: location[0], Longitude: location[1]}
+		}
+		return m
+	}
+
+	opts := entity.EventClusterOptions{Gap: time.Hour, MaxDistance: 10, MinMedia: 1}
+
+	// ids returns the media ids of each event
+	ids := func(events []entity.EventSuggestion) [][]string {
+		groups := [][]string{}
+		for _, event := range events {
+			groups = append(groups, event.MediaIDs)
+		}
+		return groups
+	}
+
+	DescribeTable("groups the media into events",
+		func(media []entity.Media, opts entity.EventClusterOptions, expected [][]string) {
+			Expect(ids(entity.ClusterEvents(media, opts))).To(Equal(expected))
+		},
+		Entry("no media", []entity.Media{}, opts, [][]string{}),
+		Entry("media within the gap",
+			[]entity.Media{media("a", 0), media("b", 30), media("c", 90)}, opts,
+			[][]string{{"a", "b", "c"}}),
+		Entry("a gap longer than the limit",
+			[]entity.Media{media("a", 0), media("b", 30), media("c", 91)}, opts,
+			[][]string{{"a", "b"}, {"c"}}),
+		Entry("a gap equal to the limit",
+			[]entity.Media{media("a", 0), media("b", 60)}, opts,
+			[][]string{{"a", "b"}}),
+		Entry("located media farther than the distance",
+			[]entity.Media{media("a", 0, 45.0, 25.0), media("b", 5, 45.0, 25.05), media("c", 10, 46.0, 25.0)}, opts,
+			[][]string{{"a", "b"}, {"c"}}),
+		Entry("a media without location between located media",
+			[]entity.Media{media("a", 0, 45.0, 25.0), media("b", 5), media("c", 10, 46.0, 25.0)}, opts,
+			[][]string{{"a", "b", "c"}}),
+		Entry("the location ignored without a distance",
+			[]entity.Media{media("a", 0, 45.0, 25.0), media("b", 5, 46.0, 25.0)},
+			entity.EventClusterOptions{Gap: time.Hour, MinMedia: 1},
+			[][]string{{"a", "b"}}),
+		Entry("groups smaller than the minimum",
+			[]entity.Media{media("a", 0), media("b", 120), media("c", 130), media("d", 300)},
+			entity.EventClusterOptions{Gap: time.Hour, MinMedia: 2},
+			[][]string{{"b", "c"}}),
+	)
+
+	It("gives an event the dates and the center of its media", func() {
+		events := entity.ClusterEvents([]entity.Media{
+			media("a", 0, 45.0, 25.0),
+			media("b", 10),
+			media("c", 20, 45.02, 25.02),
+		}, opts)
+
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Start).To(Equal(start))
+		Expect(events[0].End).To(Equal(start.Add(20 * time.Minute)))
+		Expect(events[0].Location).ToNot(BeNil())
+		Expect(events[0].Location.Latitude).To(BeNumerically("~", 45.01, 1e-9))
+		Expect(events[0].Location.Longitude).To(BeNumerically("~", 25.01, 1e-9))
+	})
+
+	It("gives the same id to the same media and another one when they change", func() {
+		first := entity.ClusterEvents([]entity.Media{media("a", 0), media("b", 10)}, opts)
+		again := entity.ClusterEvents([]entity.Media{media("a", 0), media("b", 10)}, opts)
+		changed := entity.ClusterEvents([]entity.Media{media("a", 0), media("b", 10), media("c", 20)}, opts)
+
+		Expect(first[0].ID).ToNot(BeEmpty())
+		Expect(again[0].ID).To(Equal(first[0].ID))
+		Expect(changed[0].ID).ToNot(Equal(first[0].ID))
+	})
+})
@@ -8,6 +8,16 @@ type FolderNode struct {
 	// MediaFiles contains the list of media file paths directly in this folder
 	MediaFiles []string
 
+	// MediaFileInfos holds the attributes of the media files, by path
+	MediaFileInfos map[string]FileInfo
+
+	// UnsupportedFiles contains the paths of the files directly in this folder which are not media files
+	UnsupportedFiles []string
+
+	// UnreadableItems contains the paths of the folders and files directly in this folder which could not
+	// be read. The content of an unreadable folder is unknown.
+	UnreadableItems []string
+
 	// Children contains the immediate child folders
 	Children []*FolderNode
 
@@ -18,10 +28,11 @@ type FolderNode struct {
 // NewFolderNode creates a new folder node with the given path
 func NewFolderNode(path string) *FolderNode {
 	return &FolderNode{
-		Path:       path,
-		MediaFiles: make([]string, 0),
-		Children:   make([]*FolderNode, 0),
-		Parent:     nil,
+		Path:           path,
+		MediaFiles:     make([]string, 0),
+		MediaFileInfos: make(map[string]FileInfo),
+		Children:       make([]*FolderNode, 0),
+		Parent:         nil,
 	}
 }
 
@@ -32,8 +43,9 @@ func (fn *FolderNode) AddChild(child *FolderNode) {
 }
 
 // AddMediaFile adds a media file to this folder
-func (fn *FolderNode) AddMediaFile(filePath string) {
+func (fn *FolderNode) AddMediaFile(filePath string, info FileInfo) {
 	fn.MediaFiles = append(fn.MediaFiles, filePath)
+	fn.MediaFileInfos[filePath] = info
 }
 
 // FindChild finds a direct child by path, returns nil if not found
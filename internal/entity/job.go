@@ -17,8 +17,20 @@ const (
 	StatusStopped   JobStatus = "stopped"
 	StatusStopping  JobStatus = "stopping"
 	StatusPause     JobStatus = "paused"
+	// StatusInterrupted is the status of the jobs which were not finished when the server stopped
+	StatusInterrupted JobStatus = "interrupted"
 )
 
+// Finished checks if the job reached a final status
+func (s JobStatus) Finished() bool {
+	switch s {
+	case StatusCompleted, StatusFailed, StatusStopped, StatusInterrupted:
+		return true
+	default:
+		return false
+	}
+}
+
 // JobProgress tracks the progress of a job
 type JobProgress struct {
 	Id          uuid.UUID
@@ -30,7 +42,8 @@ type JobProgress struct {
 	Total       int
 	Remaining   int
 	Results     []JobResult
-	Path        string // The folder path being synchronized
+	Path        string            // The folder path being synchronized
+	Metadata    map[string]string // The options the job was created with, like its type
 }
 
 // JobResult represents the result of processing a single task within a job
@@ -40,3 +53,42 @@ type JobResult struct {
 	StartedAt   time.Time
 	CompletedAt time.Time
 }
+
+// JobEventType is the kind of change a job event tells about
+type JobEventType string
+
+const (
+	// JobAddedEvent is published when a job is added to the scheduler
+	JobAddedEvent JobEventType = "job_added"
+	// JobChangedEvent is published when the status of a job changed
+	JobChangedEvent JobEventType = "job_changed"
+	// TaskDoneEvent is published when a task of a job finished
+	TaskDoneEvent JobEventType = "task_done"
+	// JobRemovedEvent is published when a finished job is removed from the scheduler
+	JobRemovedEvent JobEventType = "job_removed"
+)
+
+// JobEvent tells about a change of a job as it happens
+type JobEvent struct {
+	Type JobEventType
+	// Job is the progress of the job after the change, without the results of its tasks
+	Job JobProgress
+	// Result is the result of the task, set for TaskDoneEvent only
+	Result *JobResult
+	At     time.Time
+}
+
+// NewJobEvent creates an event about the job. The results of the job are left out.
+func NewJobEvent(eventType JobEventType, job JobProgress) JobEvent {
+	job.Results = nil
+	return JobEvent{
+		Type: eventType,
+		Job:  job,
+		At:   time.Now(),
+	}
+}
+
+// Concerns tells if the event is about the job or one of its sub-jobs
+func (e JobEvent) Concerns(jobID string) bool {
+	return e.Job.Id.String() == jobID || e.Job.Metadata["parentId"] == jobID
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -26,11 +28,42 @@ var (
 	}
 )
 
+var (
+	// exiftool renders coordinates like 46 deg 46' 12.34" N
+	exifCoordinate = regexp.MustCompile(`^([0-9.]+) deg ([0-9.]+)' ([0-9.]+)"(?: ([NSEW]))?$`)
+)
+
 const (
 	Photo MediaType = "photo"
 	Video MediaType = "Video"
 )
 
+// FileInfo holds the attributes of a media file which tell cheaply if the file changed since it was synced.
+type FileInfo struct {
+	Size       int64
+	ModifiedAt time.Time
+	// Inode is nil where the filesystem does not expose it
+	Inode *uint64
+}
+
+// Same checks if both attributes describe the same unchanged file. The modification times are compared
+// to the microsecond which is the precision they are stored with.
+func (f FileInfo) Same(other FileInfo) bool {
+	if f.Size != other.Size || !f.ModifiedAt.Truncate(time.Microsecond).Equal(other.ModifiedAt.Truncate(time.Microsecond)) {
+		return false
+	}
+	if f.Inode != nil && other.Inode != nil {
+		return *f.Inode == *other.Inode
+	}
+	return true
+}
+
+// Location is the place where the media was captured.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
 type Media struct {
 	ID         string
 	Album      Album
@@ -41,11 +74,23 @@ type Media struct {
 	Thumbnail  []byte
 	Content    MediaContentFn
 	Exif       map[string]string
+	Caption    *string
+	Camera     *string
+	Tags       []string
+	Rating     *int
+	Favorite   bool
+	Location   *Location
+	// File is the attributes of the file when the media was last written. Nil if they are unknown.
+	File *FileInfo
+	// TrashedAt is set when the media is in the trash
+	TrashedAt *time.Time
 }
 
+// NewMedia returns a new media of the album. The id is not related to the file name,
+// an existing media must be looked up by its album and file name.
 func NewMedia(filename string, album Album) Media {
 	return Media{
-		ID:        GenerateId(fmt.Sprintf("%s%s", filename, album.ID)),
+		ID:        NewId(),
 		Album:     album,
 		Filename:  filename,
 		MediaType: Photo,
@@ -78,6 +123,59 @@ func (m Media) GetCapturedTime() (time.Time, error) {
 	return time.Now(), errors.New("failed to find captured at value in exif meta data")
 }
 
+// GetCaption returns the description embedded in the file.
+// The exif ImageDescription is preferred over the IPTC caption.
+func (m Media) GetCaption() *string {
+	for _, key := range []string{"ImageDescription", "Caption-Abstract", "Description"} {
+		caption := strings.TrimSpace(m.Exif[key])
+		if caption != "" {
+			return &caption
+		}
+	}
+	return nil
+}
+
+// GetCamera returns the camera model found in the exif metadata.
+func (m Media) GetCamera() *string {
+	model := strings.TrimSpace(m.Exif["Model"])
+	if model == "" {
+		return nil
+	}
+	return &model
+}
+
+// GetTags returns the keywords found in the exif metadata.
+// Tags are lower case and unique.
+func (m Media) GetTags() []string {
+	keywords, ok := m.Exif["Keywords"]
+	if !ok {
+		keywords = m.Exif["Subject"]
+	}
+	return NormalizeTags(strings.Split(keywords, ","))
+}
+
+// GetRating returns the rating found in the exif metadata.
+func (m Media) GetRating() *int {
+	rating, err := strconv.Atoi(strings.TrimSpace(m.Exif["Rating"]))
+	if err != nil || rating < 0 || rating > 5 {
+		return nil
+	}
+	return &rating
+}
+
+// GetLocation returns the GPS position found in the exif metadata.
+func (m Media) GetLocation() *Location {
+	latitude, err := parseExifCoordinate(m.Exif["GPSLatitude"], m.Exif["GPSLatitudeRef"])
+	if err != nil {
+		return nil
+	}
+	longitude, err := parseExifCoordinate(m.Exif["GPSLongitude"], m.Exif["GPSLongitudeRef"])
+	if err != nil {
+		return nil
+	}
+	return &Location{Latitude: latitude, Longitude: longitude}
+}
+
 func (m Media) Filepath() string {
 	return path.Join(m.Album.Path, m.Filename)
 }
@@ -104,3 +202,56 @@ func (m Media) String() string {
 	data, _ := json.Marshal(mm)
 	return string(data)
 }
+
+// NormalizeTags lower cases and trims tags, dropping empty and duplicated ones.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// parseExifCoordinate parses a coordinate either in exiftool format or as a decimal number.
+// ref is used for the sign when the coordinate does not carry the hemisphere.
+func parseExifCoordinate(value, ref string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("missing coordinate")
+	}
+
+	if decimal, err := strconv.ParseFloat(value, 64); err == nil {
+		return withHemisphere(decimal, ref), nil
+	}
+
+	matches := exifCoordinate.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+
+	degrees, _ := strconv.ParseFloat(matches[1], 64)
+	minutes, _ := strconv.ParseFloat(matches[2], 64)
+	seconds, _ := strconv.ParseFloat(matches[3], 64)
+
+	if matches[4] != "" {
+		ref = matches[4]
+	}
+
+	return withHemisphere(degrees+minutes/60+seconds/3600, ref), nil
+}
+
+func withHemisphere(coordinate float64, ref string) float64 {
+	switch strings.ToUpper(strings.TrimSpace(ref)) {
+	case "S", "W", "SOUTH", "WEST":
+		if coordinate > 0 {
+			return -coordinate
+		}
+	}
+	return coordinate
+}
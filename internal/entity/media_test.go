@@ -0,0 +1,41 @@
+package entity_test
+
+import (
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileInfo", func() {
+	modifiedAt := time.Date(2024, 7, 1, 10, 0, 0, 123456789, time.UTC)
+
+	inode := func(i uint64) *uint64 {
+		return &i
+	}
+
+	DescribeTable("Same",
+		func(other entity.FileInfo, expected bool) {
+			file := entity.FileInfo{Size: 100, ModifiedAt: modifiedAt, Inode: inode(1)}
+			Expect(file.Same(other)).To(Equal(expected))
+			Expect(other.Same(file)).To(Equal(expected))
+		},
+		Entry("is true for the same attributes",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt, Inode: inode(1)}, true),
+		Entry("is false for another size",
+			entity.FileInfo{Size: 101, ModifiedAt: modifiedAt, Inode: inode(1)}, false),
+		Entry("is false for another modification time",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt.Add(time.Second), Inode: inode(1)}, false),
+		Entry("is false for another inode",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt, Inode: inode(2)}, false),
+		Entry("ignores the inode when it is unknown",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt}, true),
+		Entry("compares the modification times to the microsecond they are stored with",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt.Truncate(time.Microsecond), Inode: inode(1)}, true),
+		Entry("is false for modification times a microsecond apart",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt.Add(time.Microsecond), Inode: inode(1)}, false),
+		Entry("compares the modification times across time zones",
+			entity.FileInfo{Size: 100, ModifiedAt: modifiedAt.In(time.FixedZone("UTC+2", 2*60*60)), Inode: inode(1)}, true),
+	)
+})
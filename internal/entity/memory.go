@@ -0,0 +1,20 @@
+package entity
+
+// Memory holds the media captured in Year on the day of the memories.
+type Memory struct {
+	Year  int
+	Media []Media
+}
+
+// NewMemories groups the media by year. The media must be sorted by capture date, the order is kept.
+func NewMemories(media []Media) []Memory {
+	memories := []Memory{}
+	for _, m := range media {
+		year := m.CapturedAt.Year()
+		if len(memories) == 0 || memories[len(memories)-1].Year != year {
+			memories = append(memories, Memory{Year: year, Media: []Media{}})
+		}
+		memories[len(memories)-1].Media = append(memories[len(memories)-1].Media, m)
+	}
+	return memories
+}
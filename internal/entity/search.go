@@ -0,0 +1,20 @@
+package entity
+
+// SearchResultKind is the kind of resource matched by a search.
+type SearchResultKind string
+
+const (
+	AlbumSearchResult SearchResultKind = "album"
+	MediaSearchResult SearchResultKind = "media"
+)
+
+// SearchResult is an album or a media matched by a full-text search.
+// Rank is the relevance of the match, higher is better.
+// Depending on Kind, either Album or Media is set.
+type SearchResult struct {
+	Kind  SearchResultKind
+	ID    string
+	Rank  float64
+	Album *Album
+	Media *Media
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// SmartAlbum is an album whose media are not stored in a folder but selected by a saved filter.
+// The filter is evaluated every time the album is listed so the album is always up to date.
+type SmartAlbum struct {
+	ID          string
+	CreatedAt   time.Time
+	Name        string
+	Description *string
+	Filter      SmartAlbumFilter
+}
+
+// SmartAlbumFilter is the definition of the media belonging to a smart album.
+// Criteria are combined with AND; an empty filter matches every media.
+type SmartAlbumFilter struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	// AlbumID restricts the media to this album and all its descendants
+	AlbumID   *string      `json:"album_id,omitempty"`
+	MediaType *string      `json:"media_type,omitempty"`
+	Camera    *string      `json:"camera,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+	MinRating *int         `json:"min_rating,omitempty"`
+	Area      *BoundingBox `json:"area,omitempty"`
+}
+
+// BoundingBox is a geographic area delimited by two corners.
+// If MinLongitude is greater than MaxLongitude the box crosses the antimeridian.
+type BoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+func NewSmartAlbum(name string, filter SmartAlbumFilter) SmartAlbum {
+	return SmartAlbum{
+		ID:        NewId(),
+		Name:      name,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+// SyncReport describes what a sync of a folder would do, without doing it. Every item is a path relative
+// to the data folder.
+type SyncReport struct {
+	// Path is the folder the report is about, empty for the whole data folder
+	Path string
+	// AlbumsToCreate are the folders which have no album yet
+	AlbumsToCreate []string
+	// AlbumsToRemove are the albums whose folder is gone. Their subalbums and media are removed with them.
+	AlbumsToRemove []string
+	// MediaToAdd are the media files which have no media yet
+	MediaToAdd []string
+	// MediaToUpdate are the media files which changed since they were synced, or all of them on a full rescan
+	MediaToUpdate []string
+	// MediaToMove are the media whose file was moved to another folder
+	MediaToMove []SyncMove
+	// MediaToRemove are the media whose file is gone
+	MediaToRemove []string
+	// UnreadableItems are the folders and files which cannot be read. Their media are kept.
+	UnreadableItems []string
+	// UnsupportedFiles are the files which are not media files of a supported format
+	UnsupportedFiles []string
+}
+
+// SyncMove is a media whose file was moved, found by the hash of the file
+type SyncMove struct {
+	From string
+	To   string
+}
+
+// NewSyncReport creates an empty report of the folder
+func NewSyncReport(path string) SyncReport {
+	return SyncReport{
+		Path:             path,
+		AlbumsToCreate:   []string{},
+		AlbumsToRemove:   []string{},
+		MediaToAdd:       []string{},
+		MediaToUpdate:    []string{},
+		MediaToMove:      []SyncMove{},
+		MediaToRemove:    []string{},
+		UnreadableItems:  []string{},
+		UnsupportedFiles: []string{},
+	}
+}
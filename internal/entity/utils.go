@@ -5,8 +5,16 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
+// NewId returns a new random identifier.
+// Identifiers are never derived from paths so they survive renames and moves.
+func NewId() string {
+	return GenerateId(uuid.New().String())
+}
+
 func GenerateId(path string) string {
 	cleanPath := strings.TrimRight(path, string(os.PathSeparator))
 	h := sha256.New()
@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	v1grpc "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/grpc"
+	authzStore "git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/authz"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/internal/handlers/v1"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
 	"github.com/authzed/authzed-go/v1"
 	"google.golang.org/grpc/metadata"
@@ -20,37 +22,50 @@ import (
 // Handler implements the gRPC PhotosNGService
 type Handler struct {
 	v1grpc.UnimplementedPhotosNGServiceServer
-	albumSrv *services.AlbumService
-	mediaSrv *services.MediaService
-	statsSrv *services.StatsService
-	syncSrv  *services.SyncService
+	albumSrv      *services.AlbumService
+	mediaSrv      *services.MediaService
+	smartAlbumSrv *services.SmartAlbumService
+	searchSrv     *services.SearchService
+	statsSrv      *services.StatsService
+	syncSrv       v1.SyncService
+	bulkSrv       *services.BulkService
 }
 
 // NewHandler creates a new gRPC server implementation
 func NewHandler(dt *pg.Datastore, fsDatastore *fs.Datastore) *Handler {
 	albumSrv := services.NewAlbumService(dt, fsDatastore)
 	mediaSrv := services.NewMediaService(dt, fsDatastore)
-	syncSrv := services.NewSyncService(albumSrv, mediaSrv, fsDatastore)
+	syncSrv := services.NewSyncService(albumSrv, mediaSrv, fsDatastore, nil)
 
 	return &Handler{
-		albumSrv: albumSrv,
-		mediaSrv: mediaSrv,
-		statsSrv: services.NewStatsService(dt),
-		syncSrv:  syncSrv,
+		albumSrv:      albumSrv,
+		mediaSrv:      mediaSrv,
+		smartAlbumSrv: services.NewSmartAlbumService(dt, mediaSrv),
+		searchSrv:     services.NewSearchService(dt),
+		statsSrv:      services.NewStatsService(dt),
+		syncSrv:       syncSrv,
+		bulkSrv:       services.NewBulkService(mediaSrv, nil),
 	}
 }
 
 func NewHandlerWithAuthorization(spiceDBClient *authzed.Client, dt *pg.Datastore, fs *fs.Datastore) *Handler {
 	albumSrv := services.NewAlbumService(dt, fs)
 	mediaSrv := services.NewMediaService(dt, fs)
-	syncSrv := services.NewSyncService(albumSrv, mediaSrv, fs)
+	// the relationships of the albums and media created and moved by a sync are written with them.
+	// The gRPC API authenticates no user, so the sync calls checking the sync permission are all refused.
+	authzSrv := services.NewAuthzService(authzStore.NewAuthzDatastore(spiceDBClient), dt)
+	syncSrv := services.NewAuthzSyncService(authzSrv, services.NewSyncService(albumSrv, mediaSrv, fs, authzSrv))
+	smartAlbumSrv := services.NewSmartAlbumService(dt, mediaSrv)
 	statsSrv := services.NewStatsService(dt)
 
 	return &Handler{
-		albumSrv: albumSrv,
-		mediaSrv: mediaSrv,
-		statsSrv: statsSrv,
-		syncSrv:  syncSrv,
+		albumSrv:      albumSrv,
+		mediaSrv:      mediaSrv,
+		smartAlbumSrv: smartAlbumSrv,
+		searchSrv:     services.NewSearchService(dt),
+		statsSrv:      statsSrv,
+		syncSrv:       syncSrv,
+		bulkSrv:       services.NewBulkService(mediaSrv, nil),
 	}
 }
 
@@ -77,18 +92,29 @@ func (s *Handler) ListAlbums(ctx context.Context, req *v1grpc.ListAlbumsRequest)
 		services.WithHasParent(hasParent),
 	)
 
-	albums, err := s.albumSrv.List(ctx, opts)
+	if req.Cursor != nil {
+		cursor, err := services.DecodeAlbumCursor(*req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.AlbumCursor = cursor
+	}
+
+	if req.SortBy != nil {
+		opts.AlbumSortBy = req.GetSortBy().Entity()
+	}
+	opts.AlbumSortDescending = req.GetSortOrder() == v1grpc.SortOrder_SORT_ORDER_DESC
+
+	albums, nextCursor, err := s.albumSrv.List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get total count for pagination (without limit/offset)
-	totalOpts := services.NewListOptionsWithOptions()
-	allAlbums, err := s.albumSrv.List(ctx, totalOpts)
+	total, err := s.albumSrv.Count(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	total := len(allAlbums)
 
 	// Convert entity albums to gRPC albums
 	grpcAlbums := make([]*v1grpc.Album, 0, len(albums))
@@ -98,14 +124,55 @@ func (s *Handler) ListAlbums(ctx context.Context, req *v1grpc.ListAlbumsRequest)
 		grpcAlbums = append(grpcAlbums, v1grpc.NewAlbum(album, syncInProgress))
 	}
 
-	return &v1grpc.ListAlbumsResponse{
+	response := &v1grpc.ListAlbumsResponse{
 		Albums: grpcAlbums,
 		Pagination: &v1grpc.PaginationResponse{
 			Total:  int32(total),
 			Limit:  int32(limit),
 			Offset: int32(offset),
 		},
-	}, nil
+	}
+
+	if nextCursor != nil {
+		if encoded, err := nextCursor.Encode(); err == nil && encoded != "" {
+			response.NextCursor = &encoded
+		}
+	}
+
+	// Smart albums are listed alongside the albums and paged independently of them
+	smartAlbumsLimit := 20
+	if req.SmartAlbumsLimit != nil {
+		smartAlbumsLimit = int(req.GetSmartAlbumsLimit())
+	}
+	smartAlbumsOffset := int(req.GetSmartAlbumsOffset())
+
+	if smartAlbumsLimit > 0 {
+		smartAlbumOpts := services.NewSmartAlbumOptionsWithOptions(
+			services.WithSmartAlbumLimit(smartAlbumsLimit),
+			services.WithSmartAlbumOffset(smartAlbumsOffset),
+		)
+
+		smartAlbums, err := s.smartAlbumSrv.List(ctx, smartAlbumOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		smartAlbumsTotal, err := s.smartAlbumSrv.Count(ctx, smartAlbumOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, smartAlbum := range smartAlbums {
+			response.SmartAlbums = append(response.SmartAlbums, v1grpc.NewSmartAlbum(smartAlbum))
+		}
+		response.SmartAlbumsPagination = &v1grpc.PaginationResponse{
+			Total:  int32(smartAlbumsTotal),
+			Limit:  int32(smartAlbumsLimit),
+			Offset: int32(smartAlbumsOffset),
+		}
+	}
+
+	return response, nil
 }
 
 func (s *Handler) GetAlbum(ctx context.Context, req *v1grpc.GetAlbumRequest) (*v1grpc.Album, error) {
@@ -166,7 +233,7 @@ func (s *Handler) DeleteAlbum(ctx context.Context, req *v1grpc.DeleteAlbumReques
 
 func (s *Handler) SyncAlbum(ctx context.Context, req *v1grpc.SyncAlbumRequest) (*v1grpc.SyncAlbumResponse, error) {
 	// Note: SyncAlbum is handled via StartSync in this implementation
-	_, err := s.syncSrv.StartSync(ctx, req.Id)
+	_, err := s.syncSrv.StartSync(ctx, req.Id, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +244,23 @@ func (s *Handler) SyncAlbum(ctx context.Context, req *v1grpc.SyncAlbumRequest) (
 	}, nil
 }
 
+func (s *Handler) GetAlbumTree(ctx context.Context, req *v1grpc.GetAlbumTreeRequest) (*v1grpc.GetAlbumTreeResponse, error) {
+	tree, err := s.albumSrv.Tree(ctx, services.NewAlbumTreeOptionsWithOptions(
+		services.WithAlbumTreeRootID(req.RootId),
+		services.WithAlbumTreeDepth(int(req.Depth)),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	albums := make([]*v1grpc.AlbumTreeNode, 0, len(tree))
+	for _, node := range tree {
+		albums = append(albums, v1grpc.NewAlbumTreeNode(node))
+	}
+
+	return &v1grpc.GetAlbumTreeResponse{Albums: albums}, nil
+}
+
 // Media operations implementation
 func (s *Handler) ListMedia(req *v1grpc.ListMediaRequest, stream v1grpc.PhotosNGService_ListMediaServer) error {
 	// Build media options from parameters
@@ -212,6 +296,11 @@ func (s *Handler) ListMedia(req *v1grpc.ListMediaRequest, stream v1grpc.PhotosNG
 		opt.AlbumID = req.AlbumId
 	}
 
+	// Add query filter
+	if req.Query != nil {
+		opt.Query = *req.Query
+	}
+
 	// Add media type filter
 	if req.Type != nil && *req.Type != v1grpc.MediaType_MEDIA_TYPE_UNSPECIFIED {
 		var mediaType string
@@ -251,6 +340,110 @@ func (s *Handler) ListMedia(req *v1grpc.ListMediaRequest, stream v1grpc.PhotosNG
 	return nil
 }
 
+// ListSmartAlbumMedia streams the media matched by the filter of a smart album.
+// The cursor of the next page is sent in the "next-cursor" trailer, like for ListMedia.
+func (s *Handler) ListSmartAlbumMedia(req *v1grpc.ListSmartAlbumMediaRequest, stream v1grpc.PhotosNGService_ListSmartAlbumMediaServer) error {
+	opt := &services.MediaOptions{
+		MediaLimit: 50, // Default batch size for streaming
+		Direction:  "forward",
+	}
+
+	if req.Pagination != nil && req.Pagination.Limit > 0 {
+		opt.MediaLimit = int(req.Pagination.Limit)
+	}
+
+	if req.Pagination != nil && req.Pagination.Cursor != nil {
+		cursor, err := services.DecodeCursor(*req.Pagination.Cursor)
+		if err != nil {
+			return err
+		}
+		opt.Cursor = cursor
+	}
+
+	if req.Pagination != nil && req.Pagination.Direction != nil {
+		opt.Direction = *req.Pagination.Direction
+	}
+
+	mediaItems, nextCursor, err := s.smartAlbumSrv.ListMedia(stream.Context(), req.Id, opt)
+	if err != nil {
+		return err
+	}
+
+	for _, mediaItem := range mediaItems {
+		if err := stream.Send(v1grpc.NewMedia(mediaItem)); err != nil {
+			return err
+		}
+	}
+
+	if nextCursor != nil {
+		encodedCursor, err := nextCursor.Encode()
+		if err == nil {
+			stream.SetTrailer(metadata.Pairs("next-cursor", encodedCursor))
+		}
+	}
+
+	return nil
+}
+
+// Search returns the albums and media matching the query ordered by relevance.
+// The cursor of the next page is returned in the pagination metadata.
+func (s *Handler) Search(ctx context.Context, req *v1grpc.SearchRequest) (*v1grpc.SearchResponse, error) {
+	limit := 20
+	if req.Pagination != nil && req.Pagination.Limit > 0 {
+		limit = int(req.Pagination.Limit)
+	}
+
+	opts := services.NewSearchOptionsWithOptions(
+		services.WithSearchText(req.Query),
+		services.WithSearchLimit(limit),
+	)
+
+	if req.Pagination != nil && req.Pagination.Cursor != nil {
+		cursor, err := services.DecodeSearchCursor(*req.Pagination.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.SearchCursor = cursor
+	}
+
+	if req.Kind != nil {
+		var kind entity.SearchResultKind
+		switch *req.Kind {
+		case v1grpc.SearchResultKind_SEARCH_RESULT_KIND_ALBUM:
+			kind = entity.AlbumSearchResult
+		case v1grpc.SearchResultKind_SEARCH_RESULT_KIND_MEDIA:
+			kind = entity.MediaSearchResult
+		}
+		if kind != "" {
+			opts.SearchKind = &kind
+		}
+	}
+
+	results, nextCursor, err := s.searchSrv.Search(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcResults := make([]*v1grpc.SearchResult, 0, len(results))
+	for _, result := range results {
+		grpcResults = append(grpcResults, v1grpc.NewSearchResult(result))
+	}
+
+	pagination := &v1grpc.CursorPaginationResponse{
+		Limit: int32(limit),
+	}
+	if nextCursor != nil {
+		if encoded, err := nextCursor.Encode(); err == nil && encoded != "" {
+			pagination.NextCursor = &encoded
+		}
+	}
+
+	return &v1grpc.SearchResponse{
+		Results:    grpcResults,
+		Pagination: pagination,
+	}, nil
+}
+
 func (s *Handler) GetMedia(ctx context.Context, req *v1grpc.GetMediaRequest) (*v1grpc.Media, error) {
 	media, err := s.mediaSrv.Get(ctx, req.Id)
 	if err != nil {
@@ -288,6 +481,24 @@ func (s *Handler) DeleteMedia(ctx context.Context, req *v1grpc.DeleteMediaReques
 	return &emptypb.Empty{}, nil
 }
 
+func (s *Handler) StartBulkMediaJob(ctx context.Context, req *v1grpc.BulkMediaRequest) (*v1grpc.BulkJob, error) {
+	job, err := s.bulkSrv.Start(ctx, req.Entity())
+	if err != nil {
+		return nil, err
+	}
+
+	return v1grpc.NewBulkJob(*job), nil
+}
+
+func (s *Handler) GetBulkMediaJob(ctx context.Context, req *v1grpc.GetBulkMediaJobRequest) (*v1grpc.BulkJob, error) {
+	job, err := s.bulkSrv.GetJob(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1grpc.NewBulkJob(*job), nil
+}
+
 func (s *Handler) GetMediaThumbnail(ctx context.Context, req *v1grpc.GetMediaThumbnailRequest) (*v1grpc.BinaryDataResponse, error) {
 	// Get media to access thumbnail
 	media, err := s.mediaSrv.Get(ctx, req.Id)
@@ -307,6 +518,27 @@ func (s *Handler) GetMediaThumbnail(ctx context.Context, req *v1grpc.GetMediaThu
 	}, nil
 }
 
+func (s *Handler) GetMemories(ctx context.Context, req *v1grpc.GetMemoriesRequest) (*v1grpc.GetMemoriesResponse, error) {
+	opts := &services.MemoriesOptions{
+		MemoriesFavorite: req.GetFavorite(),
+		MemoriesLimit:    int(req.GetLimit()),
+	}
+	if req.Date != nil {
+		opts.MemoriesDay = req.Date.AsTime()
+	}
+	if req.MinRating != nil {
+		minRating := int(*req.MinRating)
+		opts.MemoriesMinRating = &minRating
+	}
+
+	memories, err := s.mediaSrv.Memories(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return v1grpc.NewGetMemoriesResponse(memories), nil
+}
+
 func (s *Handler) GetMediaContent(req *v1grpc.GetMediaContentRequest, stream v1grpc.PhotosNGService_GetMediaContentServer) error {
 	// Get media
 	media, err := s.mediaSrv.Get(stream.Context(), req.Id)
@@ -395,7 +627,21 @@ func (s *Handler) UploadMedia(ctx context.Context, req *v1grpc.UploadMediaReques
 
 // Sync operations implementation
 func (s *Handler) StartSyncJob(ctx context.Context, req *v1grpc.StartSyncRequest) (*v1grpc.StartSyncResponse, error) {
-	jobID, err := s.syncSrv.StartSync(ctx, req.Path)
+	opts := services.NewSyncOptionsWithOptionsAndDefaults(
+		services.WithFullRescan(req.FullRescan),
+	)
+
+	if req.DryRun {
+		report, err := s.syncSrv.DryRun(ctx, req.Path, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &v1grpc.StartSyncResponse{
+			Report: v1grpc.NewSyncReport(*report),
+		}, nil
+	}
+
+	jobID, err := s.syncSrv.StartSync(ctx, req.Path, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -405,14 +651,27 @@ func (s *Handler) StartSyncJob(ctx context.Context, req *v1grpc.StartSyncRequest
 	}, nil
 }
 
+// ListSyncJobs lists the jobs of the scheduler and a page of the finished jobs of the history
 func (s *Handler) ListSyncJobs(ctx context.Context, req *v1grpc.ListSyncJobsRequest) (*v1grpc.ListSyncJobsResponse, error) {
-	jobs, err := s.syncSrv.ListJobStatuses(ctx)
+	// Set default values for pagination
+	limit := 20
+	if req.Pagination != nil && req.Pagination.Limit > 0 {
+		limit = int(req.Pagination.Limit)
+	}
+	offset := 0
+	if req.Pagination != nil && req.Pagination.Offset >= 0 {
+		offset = int(req.Pagination.Offset)
+	}
+
+	jobs, err := s.syncSrv.ListJobStatuses(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	grpcJobs := make([]*v1grpc.SyncJob, 0, len(jobs))
 	for _, job := range jobs {
+		// the results of the tasks are returned by GetSyncJob only
+		job.Results = nil
 		grpcJobs = append(grpcJobs, v1grpc.NewSyncJob(job))
 	}
 
@@ -430,6 +689,22 @@ func (s *Handler) GetSyncJob(ctx context.Context, req *v1grpc.GetSyncJobRequest)
 	return v1grpc.NewSyncJob(*job), nil
 }
 
+// WatchSyncJobs streams the events of the sync jobs until the client goes away, or until the watched job is finished
+func (s *Handler) WatchSyncJobs(req *v1grpc.WatchSyncJobsRequest, stream v1grpc.PhotosNGService_WatchSyncJobsServer) error {
+	events, err := s.syncSrv.WatchJobs(stream.Context(), req.GetId())
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.Send(v1grpc.NewSyncJobEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Handler) StopSyncJob(ctx context.Context, req *v1grpc.StopSyncJobRequest) (*v1grpc.StopSyncJobResponse, error) {
 	err := s.syncSrv.StopJob(ctx, req.Id)
 	if err != nil {
@@ -449,15 +724,11 @@ func (s *Handler) ActionAllSyncJobs(ctx context.Context, req *v1grpc.ActionAllSy
 	switch req.Action {
 	case v1grpc.SyncJobAction_SYNC_JOB_ACTION_STOP:
 		// Get count of active jobs before stopping
-		runningJobs, err := s.syncSrv.ListJobStatusesByStatus(ctx, entity.StatusRunning)
+		count, err := s.syncSrv.CountJobs(ctx, entity.StatusRunning, entity.StatusPending)
 		if err != nil {
 			return nil, err
 		}
-		pendingJobs, err := s.syncSrv.ListJobStatusesByStatus(ctx, entity.StatusPending)
-		if err != nil {
-			return nil, err
-		}
-		affectedCount = int32(len(runningJobs) + len(pendingJobs))
+		affectedCount = int32(count)
 
 		err = s.syncSrv.StopAllJobs(ctx)
 		if err != nil {
@@ -510,19 +781,11 @@ func (s *Handler) ActionSyncJob(ctx context.Context, req *v1grpc.ActionSyncJobRe
 
 func (s *Handler) ClearFinishedSyncJobs(ctx context.Context, req *v1grpc.ClearFinishedSyncJobsRequest) (*v1grpc.ClearFinishedSyncJobsResponse, error) {
 	// Get count of finished jobs before clearing
-	completedJobs, err := s.syncSrv.ListJobStatusesByStatus(ctx, entity.StatusCompleted)
-	if err != nil {
-		return nil, err
-	}
-	stoppedJobs, err := s.syncSrv.ListJobStatusesByStatus(ctx, entity.StatusStopped)
-	if err != nil {
-		return nil, err
-	}
-	failedJobs, err := s.syncSrv.ListJobStatusesByStatus(ctx, entity.StatusFailed)
+	count, err := s.syncSrv.CountJobs(ctx, entity.StatusCompleted, entity.StatusStopped, entity.StatusFailed)
 	if err != nil {
 		return nil, err
 	}
-	clearedCount := int32(len(completedJobs) + len(stoppedJobs) + len(failedJobs))
+	clearedCount := int32(count)
 
 	err = s.syncSrv.ClearFinishedJobs(ctx)
 	if err != nil {
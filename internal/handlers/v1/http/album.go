@@ -10,8 +10,9 @@ import (
 )
 
 // ListAlbums handles GET /api/v1/albums requests to retrieve a list of albums.
-// It supports pagination through limit and offset parameters.
-// Returns HTTP 500 for server errors, or HTTP 200 with the album list on success.
+// It supports pagination through a cursor, or limit and offset parameters, and sorting.
+// Returns HTTP 400 for an invalid cursor or sort, HTTP 500 for server errors,
+// or HTTP 200 with the album list on success.
 func (s *Handler) ListAlbums(c *gin.Context, params v1.ListAlbumsParams) {
 	// Set default values for pagination
 	limit := 20
@@ -35,14 +36,30 @@ func (s *Handler) ListAlbums(c *gin.Context, params v1.ListAlbumsParams) {
 		services.WithHasParent(hasParent),
 	)
 
-	albums, err := s.albumSrv.List(c.Request.Context(), opts)
+	if params.Cursor != nil {
+		cursor, err := services.DecodeAlbumCursor(*params.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid cursor format: "+err.Error()))
+			return
+		}
+		opts.AlbumCursor = cursor
+	}
+
+	if params.SortBy != nil {
+		opts.AlbumSortBy = params.SortBy.Entity()
+	}
+	if params.SortOrder != nil {
+		opts.AlbumSortDescending = *params.SortOrder == v1.ListAlbumsParamsSortOrderDesc
+	}
+
+	albums, nextCursor, err := s.albumSrv.List(c.Request.Context(), opts)
 	if err != nil {
 		logError(requestid.FromGin(c), "ListAlbums", err)
 		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
 		return
 	}
 
-	count, err := s.albumSrv.Count(c.Request.Context(), hasParent)
+	count, err := s.albumSrv.Count(c.Request.Context(), opts)
 	if err != nil {
 		logError(requestid.FromGin(c), "ListAlbums", err)
 		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
@@ -64,6 +81,50 @@ func (s *Handler) ListAlbums(c *gin.Context, params v1.ListAlbumsParams) {
 		Offset: offset,
 	}
 
+	if nextCursor != nil {
+		if encoded, err := nextCursor.Encode(); err == nil && encoded != "" {
+			response.NextCursor = &encoded
+		}
+	}
+
+	// Smart albums are listed alongside the albums and paged independently of them
+	smartAlbumsLimit := 20
+	if params.SmartAlbumsLimit != nil {
+		smartAlbumsLimit = *params.SmartAlbumsLimit
+	}
+	smartAlbumsOffset := 0
+	if params.SmartAlbumsOffset != nil {
+		smartAlbumsOffset = *params.SmartAlbumsOffset
+	}
+
+	if smartAlbumsLimit > 0 {
+		smartAlbumOpts := services.NewSmartAlbumOptionsWithOptions(
+			services.WithSmartAlbumLimit(smartAlbumsLimit),
+			services.WithSmartAlbumOffset(smartAlbumsOffset),
+		)
+
+		smartAlbums, err := s.smartAlbumSrv.List(c.Request.Context(), smartAlbumOpts)
+		if err != nil {
+			logError(requestid.FromGin(c), "ListAlbums", err)
+			c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+			return
+		}
+
+		smartAlbumsTotal, err := s.smartAlbumSrv.Count(c.Request.Context(), smartAlbumOpts)
+		if err != nil {
+			logError(requestid.FromGin(c), "ListAlbums", err)
+			c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+			return
+		}
+
+		apiSmartAlbums := make([]v1.SmartAlbum, 0, len(smartAlbums))
+		for _, smartAlbum := range smartAlbums {
+			apiSmartAlbums = append(apiSmartAlbums, v1.NewSmartAlbum(smartAlbum))
+		}
+		response.SmartAlbums = &apiSmartAlbums
+		response.SmartAlbumsTotal = &smartAlbumsTotal
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -90,6 +151,36 @@ func (s *Handler) CreateAlbum(c *gin.Context) {
 	c.JSON(http.StatusCreated, v1.NewAlbum(*createdAlbum, syncInProgress))
 }
 
+// GetAlbumTree handles GET /api/v1/albums/tree requests to retrieve the album tree in one call.
+// Every node carries its media count and the media count and capture date range of its subtree.
+// Returns HTTP 400 for an invalid depth, HTTP 404 if the root album is not found,
+// HTTP 500 for server errors, or HTTP 200 with the roots of the tree on success.
+func (s *Handler) GetAlbumTree(c *gin.Context, params v1.GetAlbumTreeParams) {
+	opts := &services.AlbumTreeOptions{
+		AlbumTreeRootID: params.RootId,
+	}
+
+	if params.Depth != nil {
+		opts.AlbumTreeDepth = *params.Depth
+	}
+
+	tree, err := s.albumSrv.Tree(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetAlbumTree", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	response := v1.AlbumTreeResponse{
+		Albums: make([]v1.AlbumTreeNode, 0, len(tree)),
+	}
+	for _, node := range tree {
+		response.Albums = append(response.Albums, v1.NewAlbumTreeNode(node))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetAlbum handles GET /api/v1/albums/{id} requests to retrieve a specific album by ID.
 // Returns HTTP 404 if the album is not found, HTTP 500 for server errors,
 // or HTTP 200 with the album data on success.
@@ -154,6 +245,49 @@ func (s *Handler) DeleteAlbum(c *gin.Context, id string) {
 	c.Status(http.StatusNoContent)
 }
 
+// RenameAlbum handles POST /api/v1/albums/{id}/rename requests to rename the folder of an album.
+// Returns HTTP 400 for validation errors, HTTP 404 if album not found, HTTP 409 if an album
+// with the new name already exists, HTTP 500 for server errors, or HTTP 200 with the renamed album on success.
+func (s *Handler) RenameAlbum(c *gin.Context, id string) {
+	var request v1.RenameAlbumRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	album, err := s.albumSrv.Rename(c.Request.Context(), id, request.Name)
+	if err != nil {
+		logError(requestid.FromGin(c), "RenameAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	syncInProgress := s.syncSrv.IsAlbumSyncing(album.ID)
+	c.JSON(http.StatusOK, v1.NewAlbum(*album, syncInProgress))
+}
+
+// MoveAlbum handles POST /api/v1/albums/{id}/move requests to move the folder of an album into another album.
+// Returns HTTP 400 for validation errors, HTTP 404 if an album is not found, HTTP 409 if the target
+// album already has a subalbum with the same name, HTTP 500 for server errors,
+// or HTTP 200 with the moved album on success.
+func (s *Handler) MoveAlbum(c *gin.Context, id string) {
+	var request v1.MoveAlbumRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	album, err := s.albumSrv.Move(c.Request.Context(), id, request.ParentId)
+	if err != nil {
+		logError(requestid.FromGin(c), "MoveAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	syncInProgress := s.syncSrv.IsAlbumSyncing(album.ID)
+	c.JSON(http.StatusOK, v1.NewAlbum(*album, syncInProgress))
+}
+
 // SyncAlbum handles POST /api/v1/albums/{id}/sync requests to synchronize an album with the file system.
 // Returns HTTP 404 if album not found, HTTP 500 for server errors,
 // or HTTP 200 with sync results on success.
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCollections handles GET /api/v1/collections requests to retrieve a list of collections.
+// It supports pagination through limit and offset parameters.
+// Returns HTTP 500 for server errors, or HTTP 200 with the collection list on success.
+func (s *Handler) ListCollections(c *gin.Context, params v1.ListCollectionsParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	opts := services.NewCollectionOptionsWithOptions(
+		services.WithCollectionLimit(limit),
+		services.WithCollectionOffset(offset),
+	)
+
+	collections, err := s.collectionSrv.List(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListCollections", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	count, err := s.collectionSrv.Count(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListCollections", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiCollections := make([]v1.Collection, 0, len(collections))
+	for _, collection := range collections {
+		apiCollections = append(apiCollections, v1.NewCollection(collection))
+	}
+
+	c.JSON(http.StatusOK, v1.ListCollectionsResponse{
+		Collections: apiCollections,
+		Total:       count,
+		Limit:       limit,
+		Offset:      offset,
+	})
+}
+
+// CreateCollection handles POST /api/v1/collections requests to create a new collection.
+// Returns HTTP 400 for validation errors, HTTP 500 for server errors,
+// or HTTP 201 with the created collection on success.
+func (s *Handler) CreateCollection(c *gin.Context) {
+	var request v1.CreateCollectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	collection, err := s.collectionSrv.Create(c.Request.Context(), request.Entity())
+	if err != nil {
+		logError(requestid.FromGin(c), "CreateCollection", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, v1.NewCollection(*collection))
+}
+
+// GetCollection handles GET /api/v1/collections/{id} requests to retrieve a specific collection by ID.
+// Returns HTTP 404 if the collection is not found, HTTP 500 for server errors,
+// or HTTP 200 with the collection data on success.
+func (s *Handler) GetCollection(c *gin.Context, id string) {
+	collection, err := s.collectionSrv.Get(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetCollection", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewCollection(*collection))
+}
+
+// UpdateCollection handles PUT /api/v1/collections/{id} requests to update a collection's metadata.
+// Returns HTTP 400 for validation errors, HTTP 404 if collection not found,
+// HTTP 500 for server errors, or HTTP 200 with the updated collection on success.
+func (s *Handler) UpdateCollection(c *gin.Context, id string) {
+	var request v1.UpdateCollectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	collection, err := s.collectionSrv.Get(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateCollection", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	request.ApplyTo(collection)
+
+	updated, err := s.collectionSrv.Update(c.Request.Context(), *collection)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateCollection", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewCollection(*updated))
+}
+
+// DeleteCollection handles DELETE /api/v1/collections/{id} requests to delete a collection.
+// The media of the collection are not deleted.
+// Returns HTTP 404 if collection not found, HTTP 500 for server errors,
+// or HTTP 204 on successful deletion.
+func (s *Handler) DeleteCollection(c *gin.Context, id string) {
+	if err := s.collectionSrv.Delete(c.Request.Context(), id); err != nil {
+		logError(requestid.FromGin(c), "DeleteCollection", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListCollectionMedia handles GET /api/v1/collections/{id}/media requests to retrieve
+// the media of a collection in the collection order.
+// Returns HTTP 404 if collection not found, HTTP 500 for server errors,
+// or HTTP 200 with the media list on success.
+func (s *Handler) ListCollectionMedia(c *gin.Context, id string, params v1.ListCollectionMediaParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	media, err := s.collectionSrv.ListMedia(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListCollectionMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiMedia := make([]v1.Media, 0, len(media))
+	for _, m := range media {
+		apiMedia = append(apiMedia, v1.NewMedia(m))
+	}
+
+	c.JSON(http.StatusOK, v1.ListCollectionMediaResponse{
+		Media:  apiMedia,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// AddCollectionMedia handles POST /api/v1/collections/{id}/media requests to append media to a collection.
+// Returns HTTP 400 for validation errors, HTTP 404 if the collection or a media is not found,
+// HTTP 500 for server errors, or HTTP 200 with the updated collection on success.
+func (s *Handler) AddCollectionMedia(c *gin.Context, id string) {
+	var request v1.CollectionMediaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	collection, err := s.collectionSrv.AddMedia(c.Request.Context(), id, request.MediaIds)
+	if err != nil {
+		logError(requestid.FromGin(c), "AddCollectionMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewCollection(*collection))
+}
+
+// ReorderCollectionMedia handles PUT /api/v1/collections/{id}/media requests to set the order of the media.
+// Returns HTTP 400 if the request does not contain exactly the media of the collection,
+// HTTP 404 if collection not found, HTTP 500 for server errors,
+// or HTTP 200 with the updated collection on success.
+func (s *Handler) ReorderCollectionMedia(c *gin.Context, id string) {
+	var request v1.CollectionMediaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	collection, err := s.collectionSrv.Reorder(c.Request.Context(), id, request.MediaIds)
+	if err != nil {
+		logError(requestid.FromGin(c), "ReorderCollectionMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewCollection(*collection))
+}
+
+// RemoveCollectionMedia handles DELETE /api/v1/collections/{id}/media/{mediaId} requests
+// to remove a media from a collection. The media itself is not deleted.
+// Returns HTTP 404 if collection not found, HTTP 500 for server errors,
+// or HTTP 204 on success.
+func (s *Handler) RemoveCollectionMedia(c *gin.Context, id string, mediaId string) {
+	if _, err := s.collectionSrv.RemoveMedia(c.Request.Context(), id, []string{mediaId}); err != nil {
+		logError(requestid.FromGin(c), "RemoveCollectionMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListMediaComments handles GET /api/v1/media/{id}/comments requests to retrieve the comments of a media.
+// Returns HTTP 404 if the media is not found, HTTP 500 for server errors,
+// or HTTP 200 with the comments on success.
+func (s *Handler) ListMediaComments(c *gin.Context, id string) {
+	comments, err := s.commentSrv.List(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListMediaComments", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiComments := make([]v1.Comment, 0, len(comments))
+	for _, comment := range comments {
+		apiComments = append(apiComments, v1.NewComment(comment))
+	}
+
+	c.JSON(http.StatusOK, v1.ListCommentsResponse{
+		Comments: apiComments,
+	})
+}
+
+// CreateMediaComment handles POST /api/v1/media/{id}/comments requests to comment a media.
+// Returns HTTP 400 for validation errors, HTTP 404 if the media or the parent comment is not found,
+// HTTP 500 for server errors, or HTTP 201 with the created comment on success.
+func (s *Handler) CreateMediaComment(c *gin.Context, id string) {
+	var request v1.CreateCommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	comment, err := s.commentSrv.Create(c.Request.Context(), request.Entity(id))
+	if err != nil {
+		logError(requestid.FromGin(c), "CreateMediaComment", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, v1.NewComment(*comment))
+}
+
+// UpdateMediaComment handles PUT /api/v1/media/{id}/comments/{commentId} requests to edit a comment.
+// Returns HTTP 400 for validation errors, HTTP 403 if the user is not the author,
+// HTTP 404 if the comment is not found, HTTP 500 for server errors,
+// or HTTP 200 with the updated comment on success.
+func (s *Handler) UpdateMediaComment(c *gin.Context, id string, commentId string) {
+	var request v1.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	comment, err := s.commentSrv.Get(c.Request.Context(), id, commentId)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateMediaComment", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	request.ApplyTo(comment)
+
+	updated, err := s.commentSrv.Update(c.Request.Context(), *comment)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateMediaComment", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewComment(*updated))
+}
+
+// DeleteMediaComment handles DELETE /api/v1/media/{id}/comments/{commentId} requests to delete a comment
+// and its replies. Returns HTTP 403 if the user is not the author, HTTP 404 if the comment is not found,
+// HTTP 500 for server errors, or HTTP 204 on successful deletion.
+func (s *Handler) DeleteMediaComment(c *gin.Context, id string, commentId string) {
+	if err := s.commentSrv.Delete(c.Request.Context(), id, commentId); err != nil {
+		logError(requestid.FromGin(c), "DeleteMediaComment", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListEventSuggestions handles GET /api/v1/albums/{id}/events requests to cluster the media of an album into events.
+// Returns HTTP 400 for invalid options, HTTP 404 if the album is not found, HTTP 500 for server errors,
+// or HTTP 200 with the suggested events on success.
+func (s *Handler) ListEventSuggestions(c *gin.Context, id string, params v1.ListEventSuggestionsParams) {
+	events, err := s.eventSrv.Suggestions(c.Request.Context(), id, params.Entity())
+	if err != nil {
+		logError(requestid.FromGin(c), "ListEventSuggestions", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiEvents := make([]v1.EventSuggestion, 0, len(events))
+	for _, event := range events {
+		apiEvents = append(apiEvents, v1.NewEventSuggestion(event))
+	}
+
+	c.JSON(http.StatusOK, v1.ListEventSuggestionsResponse{Events: apiEvents})
+}
+
+// AcceptEventSuggestion handles POST /api/v1/albums/{id}/events/{eventId}/accept requests to create
+// an album or a collection from an event suggestion.
+// Returns HTTP 400 for validation errors, HTTP 404 if the album or the event is not found, HTTP 409 if an album
+// with the name already exists, HTTP 500 for server errors, or HTTP 201 with the album or the collection on success.
+func (s *Handler) AcceptEventSuggestion(c *gin.Context, id string, eventId string) {
+	var request v1.AcceptEventRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	name := ""
+	if request.Name != nil {
+		name = *request.Name
+	}
+
+	accepted, err := s.eventSrv.Accept(
+		c.Request.Context(),
+		id,
+		eventId,
+		request.ClusterOptions(),
+		entity.EventTarget(request.Target),
+		name,
+		request.ParentId,
+	)
+	if err != nil {
+		logError(requestid.FromGin(c), "AcceptEventSuggestion", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, v1.NewAcceptEventResponse(*accepted))
+}
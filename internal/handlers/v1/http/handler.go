@@ -14,23 +14,47 @@ import (
 // It contains the business logic for handling HTTP requests and responses
 // for all V1 endpoints including albums and media.
 type Handler struct {
-	albumSrv v1.AlbumService
-	mediaSrv v1.MediaService
-	statsSrv *services.StatsService
-	syncSrv  v1.SyncService
+	albumSrv      v1.AlbumService
+	mediaSrv      v1.MediaService
+	collectionSrv v1.CollectionService
+	smartAlbumSrv v1.SmartAlbumService
+	commentSrv    v1.CommentService
+	searchSrv     v1.SearchService
+	trashSrv      v1.TrashService
+	bulkSrv       v1.BulkService
+	timelineSrv   v1.TimelineService
+	eventSrv      v1.EventService
+	statsSrv      *services.StatsService
+	syncSrv       v1.SyncService
 }
 
 func NewHandler(dt *pg.Datastore, fs *fs.Datastore) *Handler {
 	baseAlbumSrv := services.NewAlbumService(dt, fs)
 	mediaSrv := services.NewMediaService(dt, fs)
-	syncSrv := services.NewSyncService(baseAlbumSrv, mediaSrv, fs)
+	syncSrv := services.NewSyncService(baseAlbumSrv, mediaSrv, fs, nil)
+	collectionSrv := services.NewCollectionService(dt)
+	smartAlbumSrv := services.NewSmartAlbumService(dt, mediaSrv)
+	commentSrv := services.NewCommentService(dt)
+	searchSrv := services.NewSearchService(dt)
 	statsSrv := services.NewStatsService(dt)
+	trashSrv := services.NewTrashService(dt, fs)
+	bulkSrv := services.NewBulkService(mediaSrv, nil)
+	timelineSrv := services.NewTimelineService(dt)
+	eventSrv := services.NewEventService(dt, baseAlbumSrv, mediaSrv, collectionSrv)
 
 	return &Handler{
-		albumSrv: baseAlbumSrv,
-		mediaSrv: mediaSrv,
-		statsSrv: statsSrv,
-		syncSrv:  syncSrv,
+		albumSrv:      baseAlbumSrv,
+		mediaSrv:      mediaSrv,
+		collectionSrv: collectionSrv,
+		smartAlbumSrv: smartAlbumSrv,
+		commentSrv:    commentSrv,
+		searchSrv:     searchSrv,
+		trashSrv:      trashSrv,
+		bulkSrv:       bulkSrv,
+		timelineSrv:   timelineSrv,
+		eventSrv:      eventSrv,
+		statsSrv:      statsSrv,
+		syncSrv:       syncSrv,
 	}
 }
 
@@ -42,11 +66,44 @@ func NewHandlerWithAuthorization(spiceDBClient *authzed.Client, dt *pg.Datastore
 	mediaSrv := services.NewMediaService(dt, fs)
 	authzMediaSrv := services.NewAuthzMediaService(authzSrv, mediaSrv)
 
+	collectionSrv := services.NewCollectionService(dt)
+	authzCollectionSrv := services.NewAuthzCollectionService(authzSrv, collectionSrv)
+
+	smartAlbumSrv := services.NewSmartAlbumService(dt, mediaSrv)
+	authzSmartAlbumSrv := services.NewAuthzSmartAlbumService(authzSrv, smartAlbumSrv, authzMediaSrv)
+
+	authzCommentSrv := services.NewAuthzCommentService(authzSrv, services.NewCommentService(dt))
+
+	authzSearchSrv := services.NewAuthzSearchService(authzSrv, services.NewSearchService(dt))
+
+	authzTrashSrv := services.NewAuthzTrashService(authzSrv, services.NewTrashService(dt, fs))
+
+	// every media of a bulk job goes through the authz media service
+	bulkSrv := services.NewBulkService(authzMediaSrv, authzMediaSrv)
+
+	authzTimelineSrv := services.NewAuthzTimelineService(authzSrv, services.NewTimelineService(dt))
+
+	// suggestions and their acceptance go through the authz album, media and collection services
+	eventSrv := services.NewEventService(dt, authzAlbumSrv, authzMediaSrv, authzCollectionSrv)
+
 	statsSrv := services.NewStatsService(dt)
 
+	// the relationships of the albums and media created and moved by a sync are written with them
+	syncSrv := services.NewSyncService(albumSrv, mediaSrv, fs, authzSrv)
+	authzSyncSrv := services.NewAuthzSyncService(authzSrv, syncSrv)
+
 	return &Handler{
-		albumSrv: authzAlbumSrv,
-		mediaSrv: authzMediaSrv,
-		statsSrv: statsSrv,
+		albumSrv:      authzAlbumSrv,
+		mediaSrv:      authzMediaSrv,
+		collectionSrv: authzCollectionSrv,
+		smartAlbumSrv: authzSmartAlbumSrv,
+		commentSrv:    authzCommentSrv,
+		searchSrv:     authzSearchSrv,
+		trashSrv:      authzTrashSrv,
+		bulkSrv:       bulkSrv,
+		timelineSrv:   authzTimelineSrv,
+		eventSrv:      eventSrv,
+		statsSrv:      statsSrv,
+		syncSrv:       authzSyncSrv,
 	}
 }
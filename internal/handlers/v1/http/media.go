@@ -49,6 +49,11 @@ func (s *Handler) ListMedia(c *gin.Context, params v1.ListMediaParams) {
 		opt.AlbumID = params.AlbumId
 	}
 
+	// Add query filter
+	if params.Q != nil {
+		opt.Query = *params.Q
+	}
+
 	// Add media type filter
 	if params.Type != nil {
 		mediaType := string(*params.Type)
@@ -96,6 +101,34 @@ func (s *Handler) ListMedia(c *gin.Context, params v1.ListMediaParams) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetMemories handles GET /api/v1/memories requests to retrieve the media captured on this day in the previous years.
+// Returns HTTP 400 for an invalid rating, HTTP 500 for server errors,
+// or HTTP 200 with the media grouped by year on success.
+func (s *Handler) GetMemories(c *gin.Context, params v1.GetMemoriesParams) {
+	opts := &services.MemoriesOptions{
+		MemoriesMinRating: params.MinRating,
+	}
+
+	if params.Date != nil {
+		opts.MemoriesDay = params.Date.Time
+	}
+	if params.Favorite != nil {
+		opts.MemoriesFavorite = *params.Favorite
+	}
+	if params.Limit != nil {
+		opts.MemoriesLimit = *params.Limit
+	}
+
+	memories, err := s.mediaSrv.Memories(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetMemories", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewMemoriesResponse(memories))
+}
+
 // GetMedia handles GET /api/v1/media/{id} requests to retrieve a specific media item by ID.
 // Returns HTTP 404 if the media is not found, HTTP 500 for server errors,
 // or HTTP 200 with the media data on success.
@@ -273,3 +306,79 @@ func (s *Handler) UploadMedia(c *gin.Context) {
 	}
 	c.JSON(http.StatusCreated, v1.NewMedia(*createdMedia))
 }
+
+// MoveMedia handles POST /api/v1/media/move requests to move media into another album.
+// Returns HTTP 400 for validation errors, HTTP 403 if the user cannot move a media or write in the album,
+// HTTP 404 if a media or the album is not found, HTTP 500 for server errors,
+// or HTTP 200 with the moved media on success.
+func (s *Handler) MoveMedia(c *gin.Context) {
+	var request v1.RelocateMediaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	media, err := s.mediaSrv.Move(c.Request.Context(), request.MediaIds, request.AlbumId)
+	if err != nil {
+		logError(requestid.FromGin(c), "MoveMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewRelocateMediaResponse(media))
+}
+
+// CopyMedia handles POST /api/v1/media/copy requests to copy media into another album.
+// Returns HTTP 400 for validation errors, HTTP 403 if the user cannot view a media or write in the album,
+// HTTP 404 if a media or the album is not found, HTTP 500 for server errors,
+// or HTTP 200 with the copies on success.
+func (s *Handler) CopyMedia(c *gin.Context) {
+	var request v1.RelocateMediaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	media, err := s.mediaSrv.Copy(c.Request.Context(), request.MediaIds, request.AlbumId)
+	if err != nil {
+		logError(requestid.FromGin(c), "CopyMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewRelocateMediaResponse(media))
+}
+
+// StartBulkMediaJob handles POST /api/v1/media/bulk requests to run an operation over many media.
+// The operation runs as a background job. Returns HTTP 400 for validation errors, HTTP 500 for server errors,
+// or HTTP 202 with the pending job on success.
+func (s *Handler) StartBulkMediaJob(c *gin.Context) {
+	var request v1.BulkMediaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	job, err := s.bulkSrv.Start(c.Request.Context(), request.Entity())
+	if err != nil {
+		logError(requestid.FromGin(c), "StartBulkMediaJob", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, v1.NewBulkJob(*job))
+}
+
+// GetBulkMediaJob handles GET /api/v1/media/bulk/{jobId} requests to get the progress of a bulk job.
+// Returns HTTP 404 if the job is not found, HTTP 500 for server errors,
+// or HTTP 200 with the job and the result of every processed media on success.
+func (s *Handler) GetBulkMediaJob(c *gin.Context, jobId string) {
+	job, err := s.bulkSrv.GetJob(c.Request.Context(), jobId)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetBulkMediaJob", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewBulkJob(*job))
+}
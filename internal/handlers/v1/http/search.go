@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// Search handles GET /api/v1/search requests to search albums and media.
+// Results are ordered by relevance and paginated with a cursor.
+// Returns HTTP 400 for an empty query or an invalid cursor, HTTP 500 for server errors,
+// or HTTP 200 with the results on success.
+func (s *Handler) Search(c *gin.Context, params v1.SearchParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	opts := services.NewSearchOptionsWithOptions(
+		services.WithSearchText(params.Q),
+		services.WithSearchLimit(limit),
+	)
+
+	if params.Cursor != nil {
+		cursor, err := services.DecodeSearchCursor(*params.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid cursor format: "+err.Error()))
+			return
+		}
+		opts.SearchCursor = cursor
+	}
+
+	if params.Type != nil {
+		kind := entity.SearchResultKind(*params.Type)
+		opts.SearchKind = &kind
+	}
+
+	results, nextCursor, err := s.searchSrv.Search(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "Search", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiResults := make([]v1.SearchResult, 0, len(results))
+	for _, result := range results {
+		apiResults = append(apiResults, v1.NewSearchResult(result))
+	}
+
+	var nextCursorStr *string
+	if nextCursor != nil {
+		if encoded, err := nextCursor.Encode(); err == nil && encoded != "" {
+			nextCursorStr = &encoded
+		}
+	}
+
+	c.JSON(http.StatusOK, v1.SearchResponse{
+		Results:    apiResults,
+		Limit:      limit,
+		NextCursor: nextCursorStr,
+	})
+}
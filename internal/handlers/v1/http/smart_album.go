@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListSmartAlbums handles GET /api/v1/smart-albums requests to retrieve a list of smart albums.
+// It supports pagination through limit and offset parameters.
+// Returns HTTP 500 for server errors, or HTTP 200 with the smart album list on success.
+func (s *Handler) ListSmartAlbums(c *gin.Context, params v1.ListSmartAlbumsParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	opts := services.NewSmartAlbumOptionsWithOptions(
+		services.WithSmartAlbumLimit(limit),
+		services.WithSmartAlbumOffset(offset),
+	)
+
+	smartAlbums, err := s.smartAlbumSrv.List(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListSmartAlbums", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	count, err := s.smartAlbumSrv.Count(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListSmartAlbums", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiSmartAlbums := make([]v1.SmartAlbum, 0, len(smartAlbums))
+	for _, smartAlbum := range smartAlbums {
+		apiSmartAlbums = append(apiSmartAlbums, v1.NewSmartAlbum(smartAlbum))
+	}
+
+	c.JSON(http.StatusOK, v1.ListSmartAlbumsResponse{
+		SmartAlbums: apiSmartAlbums,
+		Total:       count,
+		Limit:       limit,
+		Offset:      offset,
+	})
+}
+
+// CreateSmartAlbum handles POST /api/v1/smart-albums requests to create a new smart album.
+// Returns HTTP 400 for validation errors, HTTP 500 for server errors,
+// or HTTP 201 with the created smart album on success.
+func (s *Handler) CreateSmartAlbum(c *gin.Context) {
+	var request v1.CreateSmartAlbumRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	smartAlbum, err := s.smartAlbumSrv.Create(c.Request.Context(), request.Entity())
+	if err != nil {
+		logError(requestid.FromGin(c), "CreateSmartAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, v1.NewSmartAlbum(*smartAlbum))
+}
+
+// GetSmartAlbum handles GET /api/v1/smart-albums/{id} requests to retrieve a specific smart album by ID.
+// Returns HTTP 404 if the smart album is not found, HTTP 500 for server errors,
+// or HTTP 200 with the smart album data on success.
+func (s *Handler) GetSmartAlbum(c *gin.Context, id string) {
+	smartAlbum, err := s.smartAlbumSrv.Get(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetSmartAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewSmartAlbum(*smartAlbum))
+}
+
+// UpdateSmartAlbum handles PUT /api/v1/smart-albums/{id} requests to update a smart album.
+// Returns HTTP 400 for validation errors, HTTP 404 if smart album not found,
+// HTTP 500 for server errors, or HTTP 200 with the updated smart album on success.
+func (s *Handler) UpdateSmartAlbum(c *gin.Context, id string) {
+	var request v1.UpdateSmartAlbumRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	smartAlbum, err := s.smartAlbumSrv.Get(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateSmartAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	request.ApplyTo(smartAlbum)
+
+	updated, err := s.smartAlbumSrv.Update(c.Request.Context(), *smartAlbum)
+	if err != nil {
+		logError(requestid.FromGin(c), "UpdateSmartAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewSmartAlbum(*updated))
+}
+
+// DeleteSmartAlbum handles DELETE /api/v1/smart-albums/{id} requests to delete a smart album.
+// The media matched by its filter are not deleted.
+// Returns HTTP 404 if smart album not found, HTTP 500 for server errors,
+// or HTTP 204 on successful deletion.
+func (s *Handler) DeleteSmartAlbum(c *gin.Context, id string) {
+	if err := s.smartAlbumSrv.Delete(c.Request.Context(), id); err != nil {
+		logError(requestid.FromGin(c), "DeleteSmartAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSmartAlbumMedia handles GET /api/v1/smart-albums/{id}/media requests to retrieve
+// the media matched by the filter of a smart album. It uses the same cursor pagination as ListMedia.
+// Returns HTTP 400 for an invalid cursor, HTTP 404 if smart album not found,
+// HTTP 500 for server errors, or HTTP 200 with the media list on success.
+func (s *Handler) ListSmartAlbumMedia(c *gin.Context, id string, params v1.ListSmartAlbumMediaParams) {
+	opt := &services.MediaOptions{
+		MediaLimit: 20,
+		Direction:  "forward",
+	}
+
+	if params.Limit != nil {
+		opt.MediaLimit = *params.Limit
+	}
+
+	if params.Cursor != nil {
+		cursor, err := services.DecodeCursor(*params.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid cursor format: "+err.Error()))
+			return
+		}
+		opt.Cursor = cursor
+	}
+
+	if params.Direction != nil {
+		opt.Direction = string(*params.Direction)
+	}
+
+	mediaItems, nextCursor, err := s.smartAlbumSrv.ListMedia(c.Request.Context(), id, opt)
+	if err != nil {
+		logError(requestid.FromGin(c), "ListSmartAlbumMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	apiMedia := make([]v1.Media, 0, len(mediaItems))
+	for _, media := range mediaItems {
+		apiMedia = append(apiMedia, v1.NewMedia(media))
+	}
+
+	var nextCursorStr *string
+	if nextCursor != nil {
+		if encoded, err := nextCursor.Encode(); err == nil && encoded != "" {
+			nextCursorStr = &encoded
+		}
+	}
+
+	c.JSON(http.StatusOK, v1.ListMediaResponse{
+		Media:      apiMedia,
+		Limit:      opt.MediaLimit,
+		NextCursor: nextCursorStr,
+	})
+}
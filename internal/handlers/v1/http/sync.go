@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// StartSync handles POST /api/v1/sync requests to sync a folder and its subfolders in the background.
+// Returns HTTP 400 for an invalid body or a path outside the data folder, HTTP 403 without the sync permission, HTTP 404 if the folder does not exist,
+// HTTP 409 if the folder is empty while albums are known below it, HTTP 500 for server errors, or HTTP 202 with the id of the job on success.
+func (s *Handler) StartSync(c *gin.Context) {
+	var request v1.StartSyncRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	albumPath := ""
+	if request.Path != nil {
+		albumPath = *request.Path
+	}
+	// the path is relative to the data folder, it must not leave it
+	if albumPath != "" && !filepath.IsLocal(albumPath) {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid path: "+albumPath))
+		return
+	}
+	fullRescan := request.FullRescan != nil && *request.FullRescan
+
+	jobID, err := s.syncSrv.StartSync(c.Request.Context(), albumPath, services.NewSyncOptionsWithOptionsAndDefaults(
+		services.WithFullRescan(fullRescan),
+	))
+	if err != nil {
+		logError(requestid.FromGin(c), "StartSync", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, v1.StartSyncResponse{Id: jobID})
+}
+
+// DryRunSync handles POST /api/v1/sync/dry-run requests to preview a sync of a folder.
+// Returns HTTP 400 for an invalid body or a path outside the data folder, HTTP 404 if the folder does not exist, HTTP 409 if the folder is
+// empty while albums are known below it, HTTP 500 for server errors, or HTTP 200 with the report of what the sync would do on success.
+func (s *Handler) DryRunSync(c *gin.Context) {
+	var request v1.SyncDryRunRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	albumPath := ""
+	if request.Path != nil {
+		albumPath = *request.Path
+	}
+	// the path is relative to the data folder, it must not leave it
+	if albumPath != "" && !filepath.IsLocal(albumPath) {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid path: "+albumPath))
+		return
+	}
+	fullRescan := request.FullRescan != nil && *request.FullRescan
+
+	report, err := s.syncSrv.DryRun(c.Request.Context(), albumPath, services.NewSyncOptionsWithOptionsAndDefaults(
+		services.WithFullRescan(fullRescan),
+	))
+	if err != nil {
+		logError(requestid.FromGin(c), "DryRunSync", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewSyncReport(*report))
+}
+
+// WatchSyncJobs handles GET /api/v1/sync/events requests streaming the events of the sync jobs as Server-Sent
+// Events until the client goes away, or until the watched job is finished when an id is given.
+// Returns HTTP 404 if the job is not found or HTTP 500 for server errors before the stream starts.
+func (s *Handler) WatchSyncJobs(c *gin.Context, params v1.WatchSyncJobsParams) {
+	jobID := ""
+	if params.Id != nil {
+		jobID = *params.Id
+	}
+
+	events, err := s.syncSrv.WatchJobs(c.Request.Context(), jobID)
+	if err != nil {
+		logError(requestid.FromGin(c), "WatchSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(string(event.Type), v1.NewSyncJobEvent(event))
+		return true
+	})
+}
+
+// ListSyncJobs handles GET /api/v1/sync/jobs requests to list the sync jobs, including a page of the finished
+// jobs of the history. The jobs are listed without the results of their tasks.
+// Returns HTTP 500 for server errors or HTTP 200 with the jobs, the oldest first, on success.
+func (s *Handler) ListSyncJobs(c *gin.Context, params v1.ListSyncJobsParams) {
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	var (
+		jobs []entity.JobProgress
+		err  error
+	)
+	if params.Status != nil {
+		jobs, err = s.syncSrv.ListJobStatusesByStatus(c.Request.Context(), entity.JobStatus(*params.Status), limit, offset)
+	} else {
+		jobs, err = s.syncSrv.ListJobStatuses(c.Request.Context(), limit, offset)
+	}
+	if err != nil {
+		logError(requestid.FromGin(c), "ListSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	response := v1.ListSyncJobsResponse{
+		Jobs: make([]v1.SyncJob, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		// the results of the tasks are returned by GetSyncJob only
+		job.Results = nil
+		response.Jobs = append(response.Jobs, v1.NewSyncJob(job))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSyncJob handles GET /api/v1/sync/jobs/{id} requests to get the progress of a sync job.
+// Returns HTTP 404 if the job is not found, HTTP 500 for server errors,
+// or HTTP 200 with the job and the result of every task done so far on success.
+func (s *Handler) GetSyncJob(c *gin.Context, id string) {
+	job, err := s.syncSrv.GetJobStatus(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetSyncJob", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewSyncJob(*job))
+}
+
+// ActionSyncJob handles POST /api/v1/sync/jobs/{id}/action requests to stop, pause or resume a sync job.
+// Returns HTTP 400 for an invalid action, HTTP 404 if the job is not found, HTTP 409 if the job cannot take
+// the action in its current status, HTTP 500 for server errors, or HTTP 200 on success.
+func (s *Handler) ActionSyncJob(c *gin.Context, id string) {
+	var request v1.SyncJobActionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request body: "+err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	job, err := s.syncSrv.GetJobStatus(ctx, id)
+	if err != nil {
+		logError(requestid.FromGin(c), "ActionSyncJob", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	// PauseJob toggles the job between running and paused
+	var message string
+	switch request.Action {
+	case v1.Stop:
+		if job.Status.Finished() {
+			c.JSON(http.StatusConflict, errorResponse(c, "Sync job is already "+string(job.Status)))
+			return
+		}
+		err = s.syncSrv.StopJob(ctx, id)
+		message = "Sync job stopped successfully"
+	case v1.Pause:
+		if job.Status != entity.StatusRunning {
+			c.JSON(http.StatusConflict, errorResponse(c, "Only a running sync job can be paused, the job is "+string(job.Status)))
+			return
+		}
+		err = s.syncSrv.PauseJob(ctx, id)
+		message = "Sync job paused successfully"
+	case v1.Resume:
+		if job.Status != entity.StatusPause {
+			c.JSON(http.StatusConflict, errorResponse(c, "Only a paused sync job can be resumed, the job is "+string(job.Status)))
+			return
+		}
+		err = s.syncSrv.PauseJob(ctx, id)
+		message = "Sync job resumed successfully"
+	default:
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid action: "+string(request.Action)))
+		return
+	}
+	if err != nil {
+		logError(requestid.FromGin(c), "ActionSyncJob", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.SyncJobActionResponse{
+		Message:       message,
+		AffectedCount: 1,
+	})
+}
+
+// StopAllSyncJobs handles POST /api/v1/sync/jobs/stop requests to stop the running and pending sync jobs.
+// Returns HTTP 500 for server errors or HTTP 200 with the number of jobs stopped on success.
+func (s *Handler) StopAllSyncJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	count, err := s.countSyncJobs(ctx, entity.StatusRunning, entity.StatusPending)
+	if err != nil {
+		logError(requestid.FromGin(c), "StopAllSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	if err := s.syncSrv.StopAllJobs(ctx); err != nil {
+		logError(requestid.FromGin(c), "StopAllSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.SyncJobActionResponse{
+		Message:       "All sync jobs stopped successfully",
+		AffectedCount: count,
+	})
+}
+
+// ClearFinishedSyncJobs handles DELETE /api/v1/sync/jobs requests to remove the completed, stopped and failed
+// sync jobs and the history of the finished jobs.
+// Returns HTTP 500 for server errors or HTTP 200 with the number of jobs removed on success.
+func (s *Handler) ClearFinishedSyncJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	count, err := s.countSyncJobs(ctx, entity.StatusCompleted, entity.StatusStopped, entity.StatusFailed)
+	if err != nil {
+		logError(requestid.FromGin(c), "ClearFinishedSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	if err := s.syncSrv.ClearFinishedJobs(ctx); err != nil {
+		logError(requestid.FromGin(c), "ClearFinishedSyncJobs", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.SyncJobActionResponse{
+		Message:       "Finished sync jobs cleared successfully",
+		AffectedCount: count,
+	})
+}
+
+// countSyncJobs returns the number of sync jobs with one of the statuses
+func (s *Handler) countSyncJobs(ctx context.Context, statuses ...entity.JobStatus) (int, error) {
+	return s.syncSrv.CountJobs(ctx, statuses...)
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTimeline handles GET /api/v1/timeline requests to retrieve the media counts per month or per day.
+// Every bucket carries a cursor which lists the media from the start of the bucket with ListMedia.
+// Returns HTTP 404 if the album does not exist, HTTP 500 for server errors,
+// or HTTP 200 with the buckets on success.
+func (s *Handler) GetTimeline(c *gin.Context, params v1.GetTimelineParams) {
+	opts := &services.TimelineOptions{
+		TimelineAlbumTreeID: params.AlbumId,
+	}
+
+	if params.Granularity != nil && *params.Granularity == v1.Day {
+		opts.TimelineDaily = true
+	}
+
+	if params.StartDate != nil {
+		opts.TimelineStartDate = &params.StartDate.Time
+	}
+	if params.EndDate != nil {
+		opts.TimelineEndDate = &params.EndDate.Time
+	}
+
+	buckets, err := s.timelineSrv.Timeline(c.Request.Context(), opts)
+	if err != nil {
+		logError(requestid.FromGin(c), "GetTimeline", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	response := v1.TimelineResponse{
+		Buckets: make([]v1.Bucket, 0, len(buckets)),
+		Total:   buckets.Total(),
+	}
+
+	for _, bucket := range buckets {
+		// media are listed by capture date descending, so the media of the bucket
+		// come right after the end of the bucket
+		cursor := &services.PaginationCursor{CapturedAt: bucket.End()}
+		encoded, err := cursor.Encode()
+		if err != nil {
+			logError(requestid.FromGin(c), "GetTimeline", err)
+			c.JSON(http.StatusInternalServerError, errorResponse(c, "Failed to encode cursor"))
+			return
+		}
+
+		response.Buckets = append(response.Buckets, v1.NewBucket(bucket, encoded))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
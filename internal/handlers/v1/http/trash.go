@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+
+	v1 "git.tls.tupangiu.ro/cosmin/photos-ng/api/v1/http"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListTrash handles GET /api/v1/trash requests to list the deleted albums and media.
+// Returns HTTP 500 for server errors, or HTTP 200 with the content of the trash on success.
+func (s *Handler) ListTrash(c *gin.Context) {
+	albums, err := s.trashSrv.ListAlbums(c.Request.Context())
+	if err != nil {
+		logError(requestid.FromGin(c), "ListTrash", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	media, err := s.trashSrv.ListMedia(c.Request.Context())
+	if err != nil {
+		logError(requestid.FromGin(c), "ListTrash", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewTrashResponse(albums, media))
+}
+
+// RestoreAlbum handles POST /api/v1/trash/albums/{id}/restore requests to take an album out of the trash.
+// Returns HTTP 403 if the user cannot delete the album, HTTP 404 if the album is not in the trash,
+// HTTP 409 if its parent is in the trash or its path is used by another album, HTTP 500 for server errors,
+// or HTTP 200 with the restored album on success.
+func (s *Handler) RestoreAlbum(c *gin.Context, id string) {
+	album, err := s.trashSrv.RestoreAlbum(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "RestoreAlbum", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewAlbum(*album, false))
+}
+
+// RestoreMedia handles POST /api/v1/trash/media/{id}/restore requests to take a media out of the trash.
+// Returns HTTP 403 if the user cannot delete the media, HTTP 404 if the media is not in the trash,
+// HTTP 409 if its album is in the trash or its file name is used by another media, HTTP 500 for server errors,
+// or HTTP 200 with the restored media on success.
+func (s *Handler) RestoreMedia(c *gin.Context, id string) {
+	media, err := s.trashSrv.RestoreMedia(c.Request.Context(), id)
+	if err != nil {
+		logError(requestid.FromGin(c), "RestoreMedia", err)
+		c.JSON(getHTTPStatusFromError(err), errorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, v1.NewMedia(*media))
+}
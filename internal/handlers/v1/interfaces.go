@@ -8,12 +8,15 @@ import (
 )
 
 type AlbumService interface {
-	List(ctx context.Context, opts *services.ListOptions) ([]entity.Album, error)
-	Count(ctx context.Context, hasParent bool) (int, error)
+	List(ctx context.Context, opts *services.ListOptions) ([]entity.Album, *services.AlbumCursor, error)
+	Count(ctx context.Context, opts *services.ListOptions) (int, error)
+	Tree(ctx context.Context, opts *services.AlbumTreeOptions) ([]entity.AlbumTreeNode, error)
 	Get(ctx context.Context, id string) (*entity.Album, error)
 	Create(ctx context.Context, album entity.Album) (*entity.Album, error)
 	Update(ctx context.Context, album entity.Album) (*entity.Album, error)
 	Delete(ctx context.Context, id string) error
+	Rename(ctx context.Context, id, name string) (*entity.Album, error)
+	Move(ctx context.Context, id string, parentID *string) (*entity.Album, error)
 }
 
 type MediaService interface {
@@ -22,16 +25,78 @@ type MediaService interface {
 	WriteMedia(ctx context.Context, media entity.Media) (*entity.Media, error)
 	Update(ctx context.Context, media entity.Media) (*entity.Media, error)
 	Delete(ctx context.Context, id string) error
+	Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error)
+	Copy(ctx context.Context, ids []string, albumID string) ([]entity.Media, error)
+	Memories(ctx context.Context, opts *services.MemoriesOptions) ([]entity.Memory, error)
+}
+
+type BulkService interface {
+	Start(ctx context.Context, req entity.BulkRequest) (*entity.BulkJob, error)
+	GetJob(ctx context.Context, id string) (*entity.BulkJob, error)
+}
+
+type TrashService interface {
+	ListAlbums(ctx context.Context) ([]entity.Album, error)
+	ListMedia(ctx context.Context) ([]entity.Media, error)
+	RestoreAlbum(ctx context.Context, id string) (*entity.Album, error)
+	RestoreMedia(ctx context.Context, id string) (*entity.Media, error)
 }
 
 type SyncService interface {
-	StartSync(ctx context.Context, albumPath string) (string, error)
+	StartSync(ctx context.Context, albumPath string, opts *services.SyncOptions) (string, error)
+	DryRun(ctx context.Context, albumPath string, opts *services.SyncOptions) (*entity.SyncReport, error)
 	GetJobStatus(ctx context.Context, jobID string) (*entity.JobProgress, error)
-	ListJobStatuses(ctx context.Context) ([]entity.JobProgress, error)
-	ListJobStatusesByStatus(ctx context.Context, status entity.JobStatus) ([]entity.JobProgress, error)
+	ListJobStatuses(ctx context.Context, limit, offset int) ([]entity.JobProgress, error)
+	ListJobStatusesByStatus(ctx context.Context, status entity.JobStatus, limit, offset int) ([]entity.JobProgress, error)
+	CountJobs(ctx context.Context, statuses ...entity.JobStatus) (int, error)
 	StopJob(ctx context.Context, jobID string) error
 	PauseJob(ctx context.Context, jobID string) error
 	StopAllJobs(ctx context.Context) error
 	ClearFinishedJobs(ctx context.Context) error
 	IsAlbumSyncing(albumID string) bool
+	WatchJobs(ctx context.Context, jobID string) (<-chan entity.JobEvent, error)
+}
+
+type CollectionService interface {
+	List(ctx context.Context, opts *services.CollectionOptions) ([]entity.Collection, error)
+	Count(ctx context.Context, opts *services.CollectionOptions) (int, error)
+	Get(ctx context.Context, id string) (*entity.Collection, error)
+	Create(ctx context.Context, collection entity.Collection) (*entity.Collection, error)
+	Update(ctx context.Context, collection entity.Collection) (*entity.Collection, error)
+	Delete(ctx context.Context, id string) error
+	ListMedia(ctx context.Context, id string, limit, offset int) ([]entity.Media, error)
+	AddMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error)
+	RemoveMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error)
+	Reorder(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error)
+}
+
+type SmartAlbumService interface {
+	List(ctx context.Context, opts *services.SmartAlbumOptions) ([]entity.SmartAlbum, error)
+	Count(ctx context.Context, opts *services.SmartAlbumOptions) (int, error)
+	Get(ctx context.Context, id string) (*entity.SmartAlbum, error)
+	Create(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error)
+	Update(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error)
+	Delete(ctx context.Context, id string) error
+	ListMedia(ctx context.Context, id string, opts *services.MediaOptions) ([]entity.Media, *services.PaginationCursor, error)
+}
+
+type SearchService interface {
+	Search(ctx context.Context, opts *services.SearchOptions) ([]entity.SearchResult, *services.SearchCursor, error)
+}
+
+type EventService interface {
+	Suggestions(ctx context.Context, albumID string, opts entity.EventClusterOptions) ([]entity.EventSuggestion, error)
+	Accept(ctx context.Context, albumID, eventID string, opts entity.EventClusterOptions, target entity.EventTarget, name string, parentID *string) (*entity.AcceptedEvent, error)
+}
+
+type TimelineService interface {
+	Timeline(ctx context.Context, opts *services.TimelineOptions) (entity.Buckets, error)
+}
+
+type CommentService interface {
+	List(ctx context.Context, mediaID string) ([]entity.Comment, error)
+	Get(ctx context.Context, mediaID, id string) (*entity.Comment, error)
+	Create(ctx context.Context, comment entity.Comment) (*entity.Comment, error)
+	Update(ctx context.Context, comment entity.Comment) (*entity.Comment, error)
+	Delete(ctx context.Context, mediaID, id string) error
 }
@@ -0,0 +1,60 @@
+package query
+
+import (
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+)
+
+// QueryOptions compiles the query into filters for a media query.
+// The filters expect the albums table to be joined, like listMediaStmt does.
+func (q *Query) QueryOptions() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	for _, term := range q.Terms {
+		switch term.Field {
+		case CameraField:
+			if term.Negated {
+				qf = append(qf, pg.ExcludeCamera(term.Value))
+			} else {
+				qf = append(qf, pg.FilterByCamera(term.Value))
+			}
+		case TypeField:
+			if term.Negated {
+				qf = append(qf, pg.ExcludeMediaType(term.Value))
+			} else {
+				qf = append(qf, pg.FilterByMediaType(term.Value))
+			}
+		case AlbumField:
+			if term.Negated {
+				qf = append(qf, pg.ExcludeAlbumPath(albumPattern(term.Value)))
+			} else {
+				qf = append(qf, pg.FilterByAlbumPath(albumPattern(term.Value)))
+			}
+		}
+	}
+
+	if q.after != nil {
+		qf = append(qf, pg.FilterByMediaDate(q.after, nil))
+	}
+
+	if q.before != nil {
+		qf = append(qf, pg.FilterCapturedBefore(*q.before))
+	}
+
+	if len(q.tags) > 0 {
+		qf = append(qf, pg.FilterByTags(q.tags))
+	}
+
+	if len(q.notTags) > 0 {
+		qf = append(qf, pg.ExcludeTags(q.notTags))
+	}
+
+	if q.rating != nil {
+		qf = append(qf, pg.FilterByMinRating(*q.rating))
+	}
+
+	if text := q.Text(); text != "" {
+		qf = append(qf, pg.FilterByText(text))
+	}
+
+	return qf
+}
@@ -0,0 +1,344 @@
+// Package query implements the small query language used to filter media.
+//
+// A query is a list of terms separated by spaces. All the terms must match.
+//
+//	camera:"X-T4" after:2022-05 before:2022-09 type:video album:Trips/* tag:beach -tag:work sunset
+//
+// A term is either a field filter written as field:value or a free text word. Values containing
+// spaces are quoted with double quotes; a double quote inside a quoted value is escaped with a backslash.
+// A term prefixed with "-" is negated. The supported fields are:
+//
+//   - camera:X-T4       the camera model, case insensitive
+//   - after:2022-05     captured on or after the start of the year, month or day
+//   - before:2022-09    captured before the start of the year, month or day
+//   - type:video        the media type, photo or video
+//   - album:Trips/*     the path of the album, "*" matches any sequence of characters
+//   - tag:beach         the media has the tag
+//   - rating:4          rated at least 4
+//
+// Free text words are matched against the full-text search document of the media.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// Field is the name of a field filter.
+type Field string
+
+const (
+	CameraField Field = "camera"
+	AfterField  Field = "after"
+	BeforeField Field = "before"
+	TypeField   Field = "type"
+	AlbumField  Field = "album"
+	TagField    Field = "tag"
+	RatingField Field = "rating"
+)
+
+// negatable tells if a field can be prefixed with "-"
+var negatable = map[Field]bool{
+	CameraField: true,
+	AfterField:  false,
+	BeforeField: false,
+	TypeField:   true,
+	AlbumField:  true,
+	TagField:    true,
+	RatingField: false,
+}
+
+// dateLayouts are the accepted formats of after and before, from the most to the least precise.
+var dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// SyntaxError is returned when the query cannot be parsed.
+// Position is the byte offset of the offending term in the query.
+type SyntaxError struct {
+	Position int
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Position, e.Message)
+}
+
+// Term is a single term of a query. Field is empty for free text words.
+type Term struct {
+	Field    Field
+	Value    string
+	Negated  bool
+	Position int
+}
+
+// Query is a parsed query.
+type Query struct {
+	Terms []Term
+
+	// Compiled values of the terms
+	text    []string
+	after   *time.Time
+	before  *time.Time
+	rating  *int
+	tags    []string
+	notTags []string
+}
+
+// Parse parses the query. An empty query is valid and matches everything.
+func Parse(input string) (*Query, error) {
+	q := &Query{}
+
+	lexer := &lexer{input: input}
+	for {
+		term, ok, err := lexer.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		if err := q.add(term); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+// add validates the term and adds it to the query.
+func (q *Query) add(term Term) error {
+	if term.Field == "" {
+		text := term.Value
+		if strings.ContainsAny(text, " \t") {
+			text = `"` + text + `"`
+		}
+		if term.Negated {
+			text = "-" + text
+		}
+		q.text = append(q.text, text)
+		q.Terms = append(q.Terms, term)
+		return nil
+	}
+
+	canNegate, known := negatable[term.Field]
+	if !known {
+		return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("unknown field %q", term.Field)}
+	}
+
+	if term.Negated && !canNegate {
+		return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("field %q cannot be negated", term.Field)}
+	}
+
+	if term.Value == "" {
+		return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("missing value for field %q", term.Field)}
+	}
+
+	switch term.Field {
+	case AfterField, BeforeField:
+		date, err := parseDate(term.Value)
+		if err != nil {
+			return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("invalid date %q for field %q: expected YYYY, YYYY-MM or YYYY-MM-DD", term.Value, term.Field)}
+		}
+		if term.Field == AfterField {
+			if q.after != nil {
+				return &SyntaxError{Position: term.Position, Message: `field "after" is repeated`}
+			}
+			q.after = &date
+		} else {
+			if q.before != nil {
+				return &SyntaxError{Position: term.Position, Message: `field "before" is repeated`}
+			}
+			q.before = &date
+		}
+	case TypeField:
+		value := strings.ToLower(term.Value)
+		if value != strings.ToLower(string(entity.Photo)) && value != strings.ToLower(string(entity.Video)) {
+			return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("invalid type %q: expected photo or video", term.Value)}
+		}
+	case RatingField:
+		rating, err := strconv.Atoi(term.Value)
+		if err != nil || rating < 0 || rating > 5 {
+			return &SyntaxError{Position: term.Position, Message: fmt.Sprintf("invalid rating %q: expected a number between 0 and 5", term.Value)}
+		}
+		if q.rating != nil {
+			return &SyntaxError{Position: term.Position, Message: `field "rating" is repeated`}
+		}
+		q.rating = &rating
+	case TagField:
+		tag := entity.NormalizeTags([]string{term.Value})
+		if len(tag) == 0 {
+			return &SyntaxError{Position: term.Position, Message: "missing value for field \"tag\""}
+		}
+		if term.Negated {
+			q.notTags = append(q.notTags, tag...)
+		} else {
+			q.tags = append(q.tags, tag...)
+		}
+	}
+
+	if q.after != nil && q.before != nil && !q.before.After(*q.after) {
+		return &SyntaxError{Position: term.Position, Message: `"before" must be later than "after"`}
+	}
+
+	q.Terms = append(q.Terms, term)
+	return nil
+}
+
+// Text returns the free text words of the query in the websearch_to_tsquery syntax.
+func (q *Query) Text() string {
+	return strings.Join(q.text, " ")
+}
+
+// IsEmpty returns true if the query has no term.
+func (q *Query) IsEmpty() bool {
+	return len(q.Terms) == 0
+}
+
+func parseDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// albumPattern converts an album glob into a LIKE pattern.
+func albumPattern(glob string) string {
+	var sb strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '\\', '%', '_':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case '*':
+			sb.WriteRune('%')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// lexer splits the query into terms.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// next returns the next term. ok is false when the end of the input is reached.
+func (l *lexer) next() (term Term, ok bool, err error) {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return Term{}, false, nil
+	}
+
+	term.Position = l.pos
+
+	if l.input[l.pos] == '-' {
+		term.Negated = true
+		l.pos++
+		if l.pos >= len(l.input) || isSpace(l.input[l.pos]) {
+			return Term{}, false, &SyntaxError{Position: term.Position, Message: `"-" must be followed by a term`}
+		}
+	}
+
+	if l.input[l.pos] == '"' {
+		// quoted free text
+		value, err := l.quoted()
+		if err != nil {
+			return Term{}, false, err
+		}
+		term.Value = value
+		return term, true, l.endOfTerm()
+	}
+
+	start := l.pos
+	for l.pos < len(l.input) && !isSpace(l.input[l.pos]) && l.input[l.pos] != ':' && l.input[l.pos] != '"' {
+		l.pos++
+	}
+	word := l.input[start:l.pos]
+
+	if l.pos < len(l.input) && l.input[l.pos] == '"' {
+		return Term{}, false, &SyntaxError{Position: l.pos, Message: "unexpected quote"}
+	}
+
+	if l.pos >= len(l.input) || l.input[l.pos] != ':' {
+		term.Value = word
+		return term, true, nil
+	}
+
+	// field filter
+	if word == "" {
+		return Term{}, false, &SyntaxError{Position: term.Position, Message: "missing field name before \":\""}
+	}
+	term.Field = Field(strings.ToLower(word))
+	l.pos++ // skip ':'
+
+	if l.pos < len(l.input) && l.input[l.pos] == '"' {
+		value, err := l.quoted()
+		if err != nil {
+			return Term{}, false, err
+		}
+		term.Value = value
+		return term, true, l.endOfTerm()
+	}
+
+	start = l.pos
+	for l.pos < len(l.input) && !isSpace(l.input[l.pos]) {
+		if l.input[l.pos] == '"' {
+			return Term{}, false, &SyntaxError{Position: l.pos, Message: "unexpected quote"}
+		}
+		l.pos++
+	}
+	term.Value = l.input[start:l.pos]
+
+	return term, true, nil
+}
+
+// quoted reads a quoted value. The lexer must be positioned on the opening quote.
+func (l *lexer) quoted() (string, error) {
+	start := l.pos
+	l.pos++ // skip opening quote
+
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.input) && (l.input[l.pos+1] == '"' || l.input[l.pos+1] == '\\'):
+			sb.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		case c == '"':
+			l.pos++
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return "", &SyntaxError{Position: start, Message: "unterminated quote"}
+}
+
+// endOfTerm checks that a quoted value is followed by a space or the end of the input.
+func (l *lexer) endOfTerm() error {
+	if l.pos < len(l.input) && !isSpace(l.input[l.pos]) {
+		return &SyntaxError{Position: l.pos, Message: "expected a space after the closing quote"}
+	}
+	return nil
+}
+
+// isSpace tells if the byte is an ASCII space. The lexer walks the input byte by byte: the bytes of a multi-byte
+// UTF-8 character are never ASCII, so they are never taken for a space, a quote or a colon.
+func isSpace(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsSpace(rune(c))
+}
@@ -0,0 +1,179 @@
+package query_test
+
+import (
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/query"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// toSql applies the options of the query to a media select and returns the generated sql
+func toSql(q *query.Query) (string, []any) {
+	stmt := sq.Select("media.id").From("media").InnerJoin("albums on albums.id = media.album_id")
+	for _, opt := range q.QueryOptions() {
+		stmt = opt(stmt)
+	}
+	sql, args, err := stmt.ToSql()
+	Expect(err).To(BeNil())
+	return sql, args
+}
+
+var _ = Describe("Query", func() {
+	Context("Parse", func() {
+		It("parses an empty query", func() {
+			q, err := query.Parse("   ")
+			Expect(err).To(BeNil())
+			Expect(q.IsEmpty()).To(BeTrue())
+			Expect(q.QueryOptions()).To(BeEmpty())
+		})
+
+		It("parses fields, quoted values, negations and free text", func() {
+			q, err := query.Parse(`camera:"X-T4" after:2022-05 before:2022-09 type:video album:Trips/* tag:beach -tag:work sunset`)
+			Expect(err).To(BeNil())
+			Expect(q.Terms).To(Equal([]query.Term{
+				{Field: query.CameraField, Value: "X-T4", Position: 0},
+				{Field: query.AfterField, Value: "2022-05", Position: 14},
+				{Field: query.BeforeField, Value: "2022-09", Position: 28},
+				{Field: query.TypeField, Value: "video", Position: 43},
+				{Field: query.AlbumField, Value: "Trips/*", Position: 54},
+				{Field: query.TagField, Value: "beach", Position: 68},
+				{Field: query.TagField, Value: "work", Negated: true, Position: 78},
+				{Value: "sunset", Position: 88},
+			}))
+		})
+
+		It("unescapes quotes inside quoted values", func() {
+			q, err := query.Parse(`camera:"Canon \"EOS\" R5" "blue sky" -"red car"`)
+			Expect(err).To(BeNil())
+			Expect(q.Terms[0].Value).To(Equal(`Canon "EOS" R5`))
+			Expect(q.Text()).To(Equal(`"blue sky" -"red car"`))
+		})
+
+		It("keeps non-ASCII characters inside terms", func() {
+			q, err := query.Parse(`tag:plajà album:Å/* voilà "ą b"`)
+			Expect(err).To(BeNil())
+			Expect(q.Terms).To(Equal([]query.Term{
+				{Field: query.TagField, Value: "plajà", Position: 0},
+				{Field: query.AlbumField, Value: "Å/*", Position: 11},
+				{Value: "voilà", Position: 22},
+				{Value: "ą b", Position: 29},
+			}))
+		})
+
+		It("accepts field names in any case", func() {
+			q, err := query.Parse("TYPE:Photo")
+			Expect(err).To(BeNil())
+			Expect(q.Terms[0].Field).To(Equal(query.TypeField))
+		})
+
+		DescribeTable("returns a syntax error",
+			func(input string, position int, message string) {
+				_, err := query.Parse(input)
+				Expect(err).NotTo(BeNil())
+
+				var syntaxErr *query.SyntaxError
+				Expect(errors.As(err, &syntaxErr)).To(BeTrue())
+				Expect(syntaxErr.Position).To(Equal(position))
+				Expect(syntaxErr.Message).To(ContainSubstring(message))
+			},
+			Entry("unknown field", "tag:a color:red", 6, `unknown field "color"`),
+			Entry("missing value", "camera:", 0, "missing value"),
+			Entry("missing field name", ":value", 0, "missing field name"),
+			Entry("unterminated quote", `camera:"X-T4`, 7, "unterminated quote"),
+			Entry("quote inside a value", `camera:X"T4`, 8, "unexpected quote"),
+			Entry("no space after a quoted value", `camera:"X"T4`, 10, "expected a space"),
+			Entry("dangling negation", "tag:a -", 6, `"-" must be followed`),
+			Entry("negated date", "-after:2022", 0, "cannot be negated"),
+			Entry("invalid date", "after:2022-13", 0, "invalid date"),
+			Entry("repeated date", "after:2022 after:2023", 11, "repeated"),
+			Entry("before not later than after", "after:2022-05 before:2022-05", 14, "must be later"),
+			Entry("invalid type", "type:gif", 0, "invalid type"),
+			Entry("invalid rating", "rating:6", 0, "invalid rating"),
+			Entry("negative rating", "rating:-1", 0, "invalid rating"),
+			Entry("repeated rating", "rating:3 tag:a rating:4", 15, `field "rating" is repeated`),
+			Entry("negated rating", "-rating:3", 0, "cannot be negated"),
+			Entry("empty tag", `tag:" "`, 0, "missing value"),
+		)
+
+		DescribeTable("splits the query into terms",
+			func(input string, expected []query.Term) {
+				q, err := query.Parse(input)
+				Expect(err).To(BeNil())
+				Expect(q.Terms).To(Equal(expected))
+			},
+			Entry("free text", "sunset", []query.Term{{Value: "sunset"}}),
+			Entry("a negated free text", "-rain", []query.Term{{Value: "rain", Negated: true}}),
+			Entry("a quoted free text", `"blue sky"`, []query.Term{{Value: "blue sky"}}),
+			Entry("a field", "rating:4", []query.Term{{Field: query.RatingField, Value: "4"}}),
+			Entry("a negated field", "-camera:X-T4", []query.Term{{Field: query.CameraField, Value: "X-T4", Negated: true}}),
+			Entry("a quoted field value", `album:"Trips 2022/*"`, []query.Term{{Field: query.AlbumField, Value: "Trips 2022/*"}}),
+			Entry("terms separated by several spaces", "a \t b", []query.Term{{Value: "a"}, {Value: "b", Position: 4}}),
+			Entry("a year, a month and a day", "after:2022 before:2023-01-15", []query.Term{
+				{Field: query.AfterField, Value: "2022"},
+				{Field: query.BeforeField, Value: "2023-01-15", Position: 11},
+			}),
+		)
+	})
+
+	Context("QueryOptions", func() {
+		DescribeTable("compiles a term",
+			func(input, condition string, args []any) {
+				q, err := query.Parse(input)
+				Expect(err).To(BeNil())
+
+				sql, actualArgs := toSql(q)
+				Expect(sql).To(HaveSuffix("WHERE " + condition))
+				Expect(actualArgs).To(Equal(args))
+			},
+			Entry("camera", "camera:X-T4", "lower(media.camera) = lower(?)", []any{"X-T4"}),
+			Entry("type", "type:Photo", "lower(media.media_type) = lower(?)", []any{"Photo"}),
+			Entry("album", "album:Trips", "albums.path LIKE ?", []any{"Trips"}),
+			Entry("album with a wildcard", "album:Trips/*/2022", "albums.path LIKE ?", []any{"Trips/%/2022"}),
+			Entry("album with LIKE characters", `album:50%_off`, "albums.path LIKE ?", []any{`50\%\_off`}),
+			Entry("negated album", "-album:Trips/*", "albums.path NOT LIKE ?", []any{"Trips/%"}),
+			Entry("tag", "tag:Beach", "media.tags @> ?", []any{[]string{"beach"}}),
+			Entry("negated tag", "-tag:work", "NOT (media.tags && ?)", []any{[]string{"work"}}),
+			Entry("rating", "rating:0", "media.rating >= ?", []any{0}),
+			Entry("free text", `sunset -"red car"`, "media.search_vector @@ websearch_to_tsquery('simple', ?)", []any{`sunset -"red car"`}),
+		)
+
+		It("compiles the field filters", func() {
+			q, err := query.Parse(`camera:"X-T4" -type:video album:Trips/* -album:Trips/work_2022 rating:3`)
+			Expect(err).To(BeNil())
+
+			sql, args := toSql(q)
+			Expect(sql).To(ContainSubstring("lower(media.camera) = lower(?)"))
+			Expect(sql).To(ContainSubstring("lower(media.media_type) <> lower(?)"))
+			Expect(sql).To(ContainSubstring("albums.path LIKE ?"))
+			Expect(sql).To(ContainSubstring("albums.path NOT LIKE ?"))
+			Expect(sql).To(ContainSubstring("media.rating >= ?"))
+			Expect(args).To(Equal([]any{"X-T4", "video", "Trips/%", `Trips/work\_2022`, 3}))
+		})
+
+		It("compiles the dates to the start of the periods", func() {
+			q, err := query.Parse("after:2022-05 before:2022-09-15")
+			Expect(err).To(BeNil())
+
+			sql, args := toSql(q)
+			Expect(sql).To(ContainSubstring("media.captured_at >= ?"))
+			Expect(sql).To(ContainSubstring("media.captured_at < ?"))
+			Expect(args).To(HaveLen(2))
+			Expect(args[0]).To(HaveField("String()", "2022-05-01 00:00:00 +0000 UTC"))
+			Expect(args[1]).To(HaveField("String()", "2022-09-15 00:00:00 +0000 UTC"))
+		})
+
+		It("groups the tags and passes the free text to the full-text search", func() {
+			q, err := query.Parse("tag:Beach tag:sea -tag:work sunset -rain")
+			Expect(err).To(BeNil())
+
+			sql, args := toSql(q)
+			Expect(sql).To(ContainSubstring("media.tags @> ?"))
+			Expect(sql).To(ContainSubstring("NOT (media.tags && ?)"))
+			Expect(sql).To(ContainSubstring("media.search_vector @@ websearch_to_tsquery('simple', ?)"))
+			Expect(args).To(Equal([]any{[]string{"beach", "sea"}, []string{"work"}, "sunset -rain"}))
+		})
+	})
+})
@@ -3,6 +3,9 @@ package services
 import (
 	"context"
 	"path"
+	"slices"
+	"strings"
+	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
@@ -26,42 +29,93 @@ func NewAlbumService(dt *pg.Datastore, fsDatastore *fs.Datastore) *AlbumService
 	}
 }
 
-func (a *AlbumService) List(ctx context.Context, opts *ListOptions) ([]entity.Album, error) {
+// List returns a page of the albums sorted by opts.SortBy, the album id breaking the ties.
+// The returned cursor points to the last album of the page and is nil if there are no more albums.
+func (a *AlbumService) List(ctx context.Context, opts *ListOptions) ([]entity.Album, *AlbumCursor, error) {
 	logger := a.logger.WithContext(ctx).Debug("get_albums").
 		WithInt("limit", opts.Limit).
 		WithInt("offset", opts.Offset).
 		WithBool("has_parent", opts.HasParent).
-		WithInt("filter_count", len(opts.QueriesFn())).
+		WithString("sort_by", string(opts.sortBy())).
+		WithBool("sort_descending", opts.AlbumSortDescending).
 		Build()
 
+	if err := validateAlbumSort(ctx, opts); err != nil {
+		return nil, nil, err
+	}
+
+	// Request one extra album to know if there is a next page
+	keysOpts := NewListOptionsWithOptions(opts.ToOption())
+	if opts.Limit > 0 {
+		keysOpts.Limit = opts.Limit + 1
+	}
+
+	logger.Step("database_query").
+		WithString("query_type", "list_album_keys").
+		Log()
+
+	keys, err := a.dt.QueryAlbumKeys(ctx, opts.sortBy(), keysOpts.QueriesFn()...)
+	if err != nil {
+		return nil, nil, NewDatabaseWriteError(ctx, "get_albums", err).
+			AtStep("query_album_keys")
+	}
+
+	var nextCursor *AlbumCursor
+	if opts.Limit > 0 && len(keys) > opts.Limit {
+		keys = keys[:opts.Limit]
+		last := keys[len(keys)-1]
+		nextCursor = &AlbumCursor{SortBy: opts.sortBy(), Value: last.Value, ID: last.ID}
+	}
+
+	if len(keys) == 0 {
+		logger.Success().WithInt(TotalAlbums, 0).Log()
+		return []entity.Album{}, nil, nil
+	}
+
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.ID)
+	}
+
 	logger.Step("database_query").
 		WithString("query_type", "list_albums").
-		WithInt("filters", len(opts.QueriesFn())).
+		WithInt("albums", len(ids)).
 		Log()
 
-	allAlbums, err := a.dt.QueryAlbums(ctx, opts.QueriesFn()...)
+	albums, err := a.dt.QueryAlbums(ctx, pg.FilterAlbumsByIDs(ids))
 	if err != nil {
-		return nil, NewDatabaseWriteError(ctx, "get_albums", err).
+		return nil, nil, NewDatabaseWriteError(ctx, "get_albums", err).
 			AtStep("query_albums")
 	}
 
+	// QueryAlbums groups its rows by album, the order of the page comes from the keys
+	position := make(map[string]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	slices.SortFunc(albums, func(x, y entity.Album) int {
+		return position[x.ID] - position[y.ID]
+	})
+
 	logger.Success().
-		WithInt(TotalAlbums, len(allAlbums)).
+		WithInt(TotalAlbums, len(albums)).
+		WithBool("has_next_cursor", nextCursor != nil).
 		Log()
 
-	return allAlbums, nil
+	return albums, nextCursor, nil
 }
 
-func (a *AlbumService) Count(ctx context.Context, hasParent bool) (int, error) {
+// Count returns the number of albums matching the filters of the options, pagination aside.
+func (a *AlbumService) Count(ctx context.Context, opts *ListOptions) (int, error) {
 	logger := a.logger.WithContext(ctx).Debug("count_albums").
-		WithBool("has_parent", hasParent).
+		WithBool("has_parent", opts.HasParent).
 		Build()
 
 	logger.Step("database_count").
 		WithString("query_type", "count_albums").
 		Log()
 
-	count, err := a.dt.CountAlbums(ctx, pg.FilterAlbumWithParents(hasParent))
+	count, err := a.dt.CountAlbums(ctx, opts.FiltersFn()...)
 	if err != nil {
 		return 0, NewDatabaseWriteError(ctx, "count_albums", err).
 			AtStep("count_albums")
@@ -74,6 +128,75 @@ func (a *AlbumService) Count(ctx context.Context, hasParent bool) (int, error) {
 	return count, nil
 }
 
+// Tree returns the album tree, or the subtree of opts.AlbumTreeRootID, with the media statistics of every album.
+// The statistics are computed by Postgres in a single query, the tree is built from the flat list of albums.
+func (a *AlbumService) Tree(ctx context.Context, opts *AlbumTreeOptions) ([]entity.AlbumTreeNode, error) {
+	logger := a.logger.WithContext(ctx).Debug("get_album_tree").
+		WithInt("depth", opts.AlbumTreeDepth).
+		Build()
+
+	if opts.AlbumTreeDepth < 0 {
+		err := NewValidationError(ctx, "get_album_tree", "invalid_input")
+		err.WithContext("validation_error", "negative_depth")
+		return nil, err
+	}
+
+	if opts.AlbumTreeRootID != nil {
+		logger.Step("check_album").WithString(AlbumID, *opts.AlbumTreeRootID).Log()
+
+		album, err := a.dt.QueryAlbum(ctx, pg.FilterByAlbumId(*opts.AlbumTreeRootID))
+		if err != nil {
+			return nil, NewDatabaseWriteError(ctx, "get_album_tree", err).
+				WithAlbumID(*opts.AlbumTreeRootID).
+				AtStep("query_album")
+		}
+
+		if album == nil {
+			return nil, NewAlbumNotFoundError(ctx, *opts.AlbumTreeRootID)
+		}
+	}
+
+	logger.Step("database_query").
+		WithString("query_type", "album_tree").
+		Log()
+
+	nodes, err := a.dt.QueryAlbumTree(ctx, opts.QueriesFn()...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_album_tree", err).
+			AtStep("query_album_tree")
+	}
+
+	tree := entity.NewAlbumTree(nodes, opts.AlbumTreeDepth)
+
+	logger.Success().
+		WithInt(TotalAlbums, len(nodes)).
+		WithInt("roots", len(tree)).
+		Log()
+
+	return tree, nil
+}
+
+func validateAlbumSort(ctx context.Context, opts *ListOptions) error {
+	invalid := func(reason string) error {
+		err := NewValidationError(ctx, "get_albums", "invalid_input")
+		err.WithContext("validation_error", reason)
+		return err
+	}
+
+	switch opts.sortBy() {
+	case entity.AlbumSortByName, entity.AlbumSortByCreatedAt, entity.AlbumSortByLatestCapture, entity.AlbumSortByMediaCount:
+	default:
+		return invalid("invalid_sort_by")
+	}
+
+	// the value of the cursor is a value of the sort key it was created with
+	if opts.AlbumCursor != nil && opts.AlbumCursor.SortBy != opts.sortBy() {
+		return invalid("cursor_sort_mismatch")
+	}
+
+	return nil
+}
+
 func (a *AlbumService) Get(ctx context.Context, id string) (*entity.Album, error) {
 	logger := a.logger.WithContext(ctx).Debug("get_album").
 		WithString(AlbumID, id).
@@ -108,6 +231,74 @@ func (a *AlbumService) Get(ctx context.Context, id string) (*entity.Album, error
 	return album, nil
 }
 
+// GetByPath returns the album of the folder at albumPath.
+func (a *AlbumService) GetByPath(ctx context.Context, albumPath string) (*entity.Album, error) {
+	logger := a.logger.WithContext(ctx).Debug("get_album_by_path").
+		WithString(AlbumPath, albumPath).
+		Build()
+
+	if albumPath == "" {
+		err := NewValidationError(ctx, "get_album_by_path", "invalid_input")
+		err.WithContext("validation_error", "empty_album_path")
+		return nil, err
+	}
+
+	album, err := a.dt.QueryAlbum(ctx, pg.FilterAlbumByPath(albumPath))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_album_by_path", err).
+			WithAlbumPath(albumPath).
+			AtStep("query_album")
+	}
+
+	if album == nil {
+		err := NewNotFoundError(ctx, "get_album_by_path", "album_not_found")
+		err.WithAlbumPath(albumPath)
+		return nil, err
+	}
+
+	logger.Success().
+		WithString(AlbumID, album.ID).
+		Log()
+
+	return album, nil
+}
+
+// ListFolders returns the album at albumPath and all its descendants, or every album if the path is empty.
+// The albums in the trash are not returned.
+func (a *AlbumService) ListFolders(ctx context.Context, albumPath string) ([]entity.Album, error) {
+	albums, err := a.dt.QueryAlbums(ctx, pg.FilterByAlbumFolder(albumPath))
+	if err != nil {
+		return nil, NewInternalError(ctx, "list_album_folders", "query_albums", err).
+			WithAlbumPath(albumPath)
+	}
+	return albums, nil
+}
+
+// Forget puts in the trash an album whose folder was removed outside the app, with its subalbums and their
+// media. Only the database is changed, there is no folder to move to the trash folder. The album can be restored
+// from the trash if the folder comes back, like an unmounted share mounted again.
+func (a *AlbumService) Forget(ctx context.Context, album entity.Album) error {
+	logger := a.logger.WithContext(ctx).Debug("forget_album").
+		WithString(AlbumID, album.ID).
+		WithString(AlbumPath, album.Path).
+		Build()
+
+	err := a.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.TrashAlbum(ctx, album.Path, time.Now())
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, "forget_album", err).
+			WithAlbumID(album.ID).
+			AtStep("trash_album")
+	}
+
+	logger.Success().
+		WithBool("trashed", true).
+		Log()
+
+	return nil
+}
+
 func (a *AlbumService) Create(ctx context.Context, album entity.Album) (*entity.Album, error) {
 	logger := a.logger.WithContext(ctx).Debug("create_album").
 		WithString(AlbumID, album.ID).
@@ -136,12 +327,12 @@ func (a *AlbumService) Create(ctx context.Context, album entity.Album) (*entity.
 		}
 
 		album.Path = path.Join(parent.Path, album.Path)
-		album.ID = entity.GenerateId(album.Path)
 	}
 
-	_, err := a.Get(ctx, album.ID)
+	// ids are not derived from the path anymore, an album is identified on disk by its path
+	existing, err := a.GetByPath(ctx, album.Path)
 	if err == nil {
-		return nil, NewAlbumExistsError(ctx, album.ID, album.Path)
+		return nil, NewAlbumExistsError(ctx, existing.ID, album.Path)
 	}
 	switch err.(type) {
 	case *NotFoundError:
@@ -219,6 +410,7 @@ func (a *AlbumService) Update(ctx context.Context, album entity.Album) (*entity.
 		Log()
 
 	existingAlbum.Description = album.Description
+	existingAlbum.Hidden = album.Hidden
 
 	// if thumbnail is present, check if the media belongs to the album
 	if album.Thumbnail != nil {
@@ -258,6 +450,134 @@ func (a *AlbumService) Update(ctx context.Context, album entity.Album) (*entity.
 	return existingAlbum, nil
 }
 
+// Rename renames the folder of the album. The album keeps its id and its parent.
+func (a *AlbumService) Rename(ctx context.Context, id, name string) (*entity.Album, error) {
+	logger := a.logger.WithContext(ctx).Debug("rename_album").
+		WithString(AlbumID, id).
+		WithString("name", name).
+		Build()
+
+	album, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAlbumName(ctx, "rename_album", name); err != nil {
+		return nil, err
+	}
+
+	renamed, err := a.relocate(ctx, "rename_album", album, album.ParentId, path.Join(path.Dir(album.Path), name))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Success().
+		WithString(AlbumPath, renamed.Path).
+		Log()
+
+	return renamed, nil
+}
+
+// Move moves the folder of the album into the folder of another album.
+// The album is moved at the top level when parentID is nil.
+// The album keeps its id, its name and its descendants.
+func (a *AlbumService) Move(ctx context.Context, id string, parentID *string) (*entity.Album, error) {
+	logger := a.logger.WithContext(ctx).Debug("move_album").
+		WithString(AlbumID, id).
+		WithStringPtr("parent_id", parentID).
+		Build()
+
+	album, err := a.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	newPath := path.Base(album.Path)
+	if parentID != nil {
+		parent, err := a.Get(ctx, *parentID)
+		if err != nil {
+			switch err.(type) {
+			case *NotFoundError:
+				return nil, NewParentAlbumNotFoundError(ctx, *parentID)
+			default:
+				return nil, err
+			}
+		}
+
+		// an album cannot be moved inside itself or inside one of its descendants
+		if parent.ID == album.ID || strings.HasPrefix(parent.Path+"/", album.Path+"/") {
+			err := NewValidationError(ctx, "move_album", "invalid_parent")
+			err.WithAlbumID(id).WithParentID(*parentID)
+			return nil, err
+		}
+
+		newPath = path.Join(parent.Path, newPath)
+	}
+
+	moved, err := a.relocate(ctx, "move_album", album, parentID, newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Success().
+		WithString(AlbumPath, moved.Path).
+		Log()
+
+	return moved, nil
+}
+
+// relocate moves the folder of the album to newPath and updates the paths of the album and of its descendants.
+// The database is updated first so a failure on disk rolls back the transaction.
+func (a *AlbumService) relocate(ctx context.Context, operation string, album *entity.Album, parentID *string, newPath string) (*entity.Album, error) {
+	logger := a.logger.WithContext(ctx).Debug(operation).
+		WithString(AlbumID, album.ID).
+		WithString("old_path", album.Path).
+		WithString("new_path", newPath).
+		Build()
+
+	if newPath == album.Path {
+		logger.Step("album already at the path, nothing to do").Log()
+		return album, nil
+	}
+
+	logger.Step("existence_check").WithString("checking", "target_path").Log()
+	existing, err := a.GetByPath(ctx, newPath)
+	if err == nil {
+		return nil, NewAlbumExistsError(ctx, existing.ID, newPath)
+	}
+	if _, notFound := err.(*NotFoundError); !notFound {
+		return nil, NewInternalError(ctx, operation, "check_album_exists", err).
+			WithAlbumID(album.ID)
+	}
+
+	err = a.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		logger.Step("database_update").WithString("table", "albums").Log()
+
+		if err := writer.MoveAlbum(ctx, album.ID, parentID, album.Path, newPath); err != nil {
+			return NewDatabaseWriteError(ctx, operation, err).
+				WithAlbumID(album.ID).
+				WithAlbumPath(newPath)
+		}
+
+		logger.Step("filesystem_rename").Log()
+
+		if err := a.fs.Rename(ctx, album.Path, newPath); err != nil {
+			return NewFilesystemError(ctx, operation, "filesystem_rename", album.Path, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, NewInternalError(ctx, operation, "transaction", err).
+			WithAlbumID(album.ID).
+			WithAlbumPath(album.Path)
+	}
+
+	return a.Get(ctx, album.ID)
+}
+
+// Delete puts an album in the trash together with its subalbums and media. The folder is moved to the trash
+// folder of the data folder and the album is hidden until it is restored or purged by the TrashService.
 func (a *AlbumService) Delete(ctx context.Context, id string) error {
 	logger := a.logger.WithContext(ctx).Debug("delete_album").
 		WithString(AlbumID, id).
@@ -269,22 +589,22 @@ func (a *AlbumService) Delete(ctx context.Context, id string) error {
 			WithAlbumID(id)
 	}
 
-	logger.Step("album found, proceeding with deletion").WithString(AlbumID, album.ID).WithString(AlbumPath, album.Path).Log()
+	logger.Step("album found, moving to trash").WithString(AlbumID, album.ID).WithString(AlbumPath, album.Path).Log()
 
 	err = a.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
-		logger.Step("filesystem_delete").
-			WithString("folder_path", album.Path).
-			Log()
+		logger.Step("database_trash").WithString("table", "albums").WithString(AlbumID, id).Log()
 
-		if err := a.fs.DeleteFolder(ctx, album.Path); err != nil {
-			return NewFilesystemError(ctx, "delete_album", "filesystem_delete", album.Path, err)
+		if err := writer.TrashAlbum(ctx, album.Path, time.Now()); err != nil {
+			return NewDatabaseWriteError(ctx, "delete_album", err).
+				WithAlbumID(id)
 		}
 
-		logger.Step("database_delete").WithString("table", "albums").WithString(AlbumID, id).Log()
+		logger.Step("filesystem_trash").
+			WithString("folder_path", album.Path).
+			Log()
 
-		if err := writer.DeleteAlbum(ctx, id); err != nil {
-			return NewDatabaseWriteError(ctx, "delete_album", err).
-				WithAlbumID(id)
+		if err := a.fs.MoveToTrash(ctx, album.Path, album.ID); err != nil {
+			return NewFilesystemError(ctx, "delete_album", "filesystem_trash", album.Path, err)
 		}
 
 		return nil
@@ -298,9 +618,30 @@ func (a *AlbumService) Delete(ctx context.Context, id string) error {
 	logger.Success().
 		WithString(AlbumID, id).
 		WithString(AlbumPath, album.Path).
-		WithBool(FilesystemDeleted, true).
-		WithBool(DatabaseDeleted, true).
+		WithBool("trashed", true).
 		Log()
 
 	return nil
 }
+
+// validateAlbumName checks that name can be used as the name of a folder inside the data folder.
+func validateAlbumName(ctx context.Context, operation, name string) error {
+	reason := ""
+	switch {
+	case strings.TrimSpace(name) == "":
+		reason = "empty_album_name"
+	case name == "." || name == "..", name == fs.TrashFolder:
+		// the trash folder holds the deleted albums, it is never an album
+		reason = "reserved_album_name"
+	case strings.ContainsAny(name, `/\`):
+		reason = "album_name_contains_separator"
+	}
+
+	if reason != "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", reason).WithContext("name", name)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validateAlbumName", func() {
+	DescribeTable("refuses the names which cannot be the name of an album folder",
+		func(name, reason string) {
+			err := validateAlbumName(context.Background(), "rename_album", name)
+
+			var validation *ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+			Expect(err.(*ValidationError).Context["validation_error"]).To(Equal(reason))
+		},
+		Entry("empty", " ", "empty_album_name"),
+		Entry("the current folder", ".", "reserved_album_name"),
+		Entry("the parent folder", "..", "reserved_album_name"),
+		Entry("the trash folder", fs.TrashFolder, "reserved_album_name"),
+		Entry("with a separator", "a/b", "album_name_contains_separator"),
+		Entry("with a windows separator", `a\b`, "album_name_contains_separator"),
+	)
+
+	It("accepts the other names", func() {
+		Expect(validateAlbumName(context.Background(), "rename_album", "Summer 2024")).To(Succeed())
+		Expect(validateAlbumName(context.Background(), "rename_album", ".hidden")).To(Succeed())
+	})
+})
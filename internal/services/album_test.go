@@ -2,13 +2,19 @@ package services_test
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/datastore"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5/pgxpool"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +32,15 @@ var (
 	insertMediaStmt = psql.Insert("media").Columns("id", "created_at", "captured_at", "album_id", "file_name", "thumbnail", "exif", "media_type")
 )
 
+// newDatastore connects a datastore to the test database
+func newDatastore(ctx context.Context, uri string) (*pg.Datastore, error) {
+	pool, err := datastore.NewConnPool(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewPostgresDatastore(pool), nil
+}
+
 var _ = Describe("AlbumService", Ordered, func() {
 	var (
 		albumService *services.AlbumService
@@ -35,7 +50,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 	)
 
 	BeforeAll(func() {
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
 		Expect(pgDt).ToNot(BeNil())
 
@@ -77,7 +92,46 @@ var _ = Describe("AlbumService", Ordered, func() {
 		testAlbum.Description = stringPtr("Test Album")
 	})
 
-	Context("GetAlbums", func() {
+	// writeAlbum writes an album below the parent, a root album if parent is nil
+	writeAlbum := func(path string, createdAt time.Time, parent *entity.Album) entity.Album {
+		album := entity.NewAlbum(path)
+		album.CreatedAt = createdAt
+		if parent != nil {
+			album.ParentId = &parent.ID
+		}
+		err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+			return w.WriteAlbum(ctx, album)
+		})
+		Expect(err).To(BeNil())
+		return album
+	}
+
+	// writeMedia writes media captured at the dates in the album, which updates its statistics
+	writeMedia := func(album entity.Album, capturedAt ...time.Time) []entity.Media {
+		written := []entity.Media{}
+		err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+			for _, date := range capturedAt {
+				media := entity.NewMedia(entity.NewId()+".jpg", album)
+				media.CapturedAt = date
+				media.MediaType = entity.Photo
+				media.Thumbnail = []byte("thumb")
+				media.Exif = map[string]string{}
+				if err := w.WriteMedia(ctx, media); err != nil {
+					return err
+				}
+				written = append(written, media)
+			}
+			return nil
+		})
+		Expect(err).To(BeNil())
+		return written
+	}
+
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	Context("List", func() {
 		It("retrieves albums successfully", func() {
 			// Insert test album
 			now := time.Now()
@@ -88,8 +142,8 @@ var _ = Describe("AlbumService", Ordered, func() {
 			_, err = pgPool.Exec(context.TODO(), sql, args...)
 			Expect(err).To(BeNil())
 
-			options := &services.AlbumOptions{Limit: 10}
-			albums, err := albumService.GetAlbums(context.TODO(), options)
+			options := &services.ListOptions{Limit: 10}
+			albums, _, err := albumService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(albums).To(HaveLen(1))
@@ -97,8 +151,8 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 
 		It("returns empty result when no albums exist", func() {
-			options := &services.AlbumOptions{Limit: 10}
-			albums, err := albumService.GetAlbums(context.TODO(), options)
+			options := &services.ListOptions{Limit: 10}
+			albums, _, err := albumService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(albums).To(HaveLen(0))
@@ -120,11 +174,11 @@ var _ = Describe("AlbumService", Ordered, func() {
 			_, err = pgPool.Exec(context.TODO(), sql, args...)
 			Expect(err).To(BeNil())
 
-			options := &services.AlbumOptions{
+			options := &services.ListOptions{
 				Limit:  2,
 				Offset: 1,
 			}
-			albums, err := albumService.GetAlbums(context.TODO(), options)
+			albums, _, err := albumService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(albums).To(HaveLen(2))
@@ -138,7 +192,394 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 	})
 
-	Context("GetAlbum", func() {
+	Context("List pages", func() {
+		var albums map[string]entity.Album
+
+		BeforeEach(func() {
+			albums = map[string]entity.Album{}
+			albums["Alpha"] = writeAlbum("Alpha", day(2024, 1, 5), nil)
+			albums["bravo"] = writeAlbum("bravo", day(2024, 1, 4), nil)
+			albums["Charlie"] = writeAlbum("Charlie", day(2024, 1, 3), nil)
+			albums["delta"] = writeAlbum("delta", day(2024, 1, 2), nil)
+			albums["Echo"] = writeAlbum("Echo", day(2024, 1, 1), nil)
+			charlie := albums["Charlie"]
+			albums["kids"] = writeAlbum("Charlie/kids", day(2024, 1, 6), &charlie)
+
+			writeMedia(albums["Alpha"], day(2023, 6, 1), day(2023, 6, 2), day(2023, 5, 1))
+			writeMedia(albums["bravo"], day(2021, 1, 1), day(2021, 1, 2), day(2021, 1, 3), day(2021, 1, 4))
+			writeMedia(albums["Charlie"], day(2024, 2, 1))
+			writeMedia(albums["kids"], day(2024, 3, 1))
+			writeMedia(albums["delta"], day(2022, 1, 1))
+		})
+
+		AfterEach(func() {
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+			Expect(err).To(BeNil())
+		})
+
+		// page returns the names of the albums of a page and the cursor of the next one
+		page := func(opts services.ListOptions) ([]string, *services.AlbumCursor) {
+			listed, cursor, err := albumService.List(context.TODO(), &opts)
+			Expect(err).To(BeNil())
+			names := []string{}
+			for _, album := range listed {
+				names = append(names, path.Base(album.Path))
+			}
+			return names, cursor
+		}
+
+		// walk follows the cursors from the first page to the last one and returns the names of all the albums
+		walk := func(opts services.ListOptions) []string {
+			names := []string{}
+			for pages := 0; ; pages++ {
+				Expect(pages).To(BeNumerically("<", 10))
+				pageNames, cursor := page(opts)
+				Expect(len(pageNames)).To(BeNumerically("<=", opts.Limit))
+				names = append(names, pageNames...)
+				if cursor == nil {
+					return names
+				}
+				opts.AlbumCursor = cursor
+			}
+		}
+
+		DescribeTable("walks every root album once in the order of the sort",
+			func(sortBy entity.AlbumSortBy, descending bool, expected []string) {
+				for _, limit := range []int{1, 2, 4, 5} {
+					Expect(walk(services.ListOptions{Limit: limit, AlbumSortBy: sortBy, AlbumSortDescending: descending})).
+						To(Equal(expected), "with pages of %d", limit)
+				}
+			},
+			Entry("by name", entity.AlbumSortByName, false, []string{"Alpha", "bravo", "Charlie", "delta", "Echo"}),
+			Entry("by name descending", entity.AlbumSortByName, true, []string{"Echo", "delta", "Charlie", "bravo", "Alpha"}),
+			Entry("by creation date", entity.AlbumSortByCreatedAt, false, []string{"Echo", "delta", "Charlie", "bravo", "Alpha"}),
+			Entry("by latest capture of the subtree", entity.AlbumSortByLatestCapture, false, []string{"Echo", "bravo", "delta", "Alpha", "Charlie"}),
+			Entry("by latest capture descending, without media last", entity.AlbumSortByLatestCapture, true, []string{"Charlie", "Alpha", "delta", "bravo", "Echo"}),
+			Entry("by media count of the subtree", entity.AlbumSortByMediaCount, false, []string{"Echo", "delta", "Charlie", "Alpha", "bravo"}),
+			Entry("by media count descending", entity.AlbumSortByMediaCount, true, []string{"bravo", "Alpha", "Charlie", "delta", "Echo"}),
+		)
+
+		It("breaks the ties with the album id across pages", func() {
+			// kids and delta have one media each
+			tied := []entity.Album{albums["kids"], albums["delta"]}
+			slices.SortFunc(tied, func(x, y entity.Album) int { return strings.Compare(x.ID, y.ID) })
+
+			names := walk(services.ListOptions{Limit: 1, HasParent: true, AlbumSortBy: entity.AlbumSortByMediaCount})
+			Expect(names).To(Equal([]string{"Echo", path.Base(tied[0].Path), path.Base(tied[1].Path), "Charlie", "Alpha", "bravo"}))
+		})
+
+		It("returns a cursor only when albums are left after the page", func() {
+			names, cursor := page(services.ListOptions{Limit: 5})
+			Expect(names).To(HaveLen(5))
+			Expect(cursor).To(BeNil())
+
+			names, cursor = page(services.ListOptions{Limit: 4})
+			Expect(names).To(Equal([]string{"Alpha", "bravo", "Charlie", "delta"}))
+			Expect(cursor).To(Equal(&services.AlbumCursor{SortBy: entity.AlbumSortByName, Value: "delta", ID: albums["delta"].ID}))
+
+			names, cursor = page(services.ListOptions{Limit: 4, AlbumCursor: cursor})
+			Expect(names).To(Equal([]string{"Echo"}))
+			Expect(cursor).To(BeNil())
+		})
+
+		It("returns an empty page after the last album", func() {
+			names, cursor := page(services.ListOptions{
+				Limit:       2,
+				AlbumCursor: &services.AlbumCursor{SortBy: entity.AlbumSortByName, Value: "echo", ID: albums["Echo"].ID},
+			})
+			Expect(names).To(BeEmpty())
+			Expect(cursor).To(BeNil())
+		})
+
+		It("returns every album without limit", func() {
+			names, cursor := page(services.ListOptions{HasParent: true})
+			Expect(names).To(HaveLen(6))
+			Expect(cursor).To(BeNil())
+		})
+
+		It("skips the albums of the offset unless a cursor is set", func() {
+			names, cursor := page(services.ListOptions{Limit: 2, Offset: 3})
+			Expect(names).To(Equal([]string{"delta", "Echo"}))
+			Expect(cursor).To(BeNil())
+
+			names, _ = page(services.ListOptions{Limit: 2, Offset: 5})
+			Expect(names).To(BeEmpty())
+
+			_, cursor = page(services.ListOptions{Limit: 1})
+			names, _ = page(services.ListOptions{Limit: 1, Offset: 3, AlbumCursor: cursor})
+			Expect(names).To(Equal([]string{"bravo"}))
+		})
+
+		It("counts the albums whatever the page", func() {
+			count, err := albumService.Count(context.TODO(), &services.ListOptions{Limit: 2, Offset: 4})
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(5))
+
+			count, err = albumService.Count(context.TODO(), &services.ListOptions{Limit: 2, HasParent: true})
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(6))
+		})
+
+		DescribeTable("refuses an invalid sort",
+			func(opts services.ListOptions, reason string) {
+				_, _, err := albumService.List(context.TODO(), &opts)
+				var validation *services.ValidationError
+				Expect(errors.As(err, &validation)).To(BeTrue())
+				Expect(validation.Context).To(HaveKeyWithValue("validation_error", reason))
+			},
+			Entry("with an unknown sort key", services.ListOptions{AlbumSortBy: "size"}, "invalid_sort_by"),
+			Entry("with the cursor of another sort key", services.ListOptions{
+				AlbumSortBy: entity.AlbumSortByMediaCount,
+				AlbumCursor: &services.AlbumCursor{SortBy: entity.AlbumSortByName, Value: "alpha", ID: entity.NewId()},
+			}, "cursor_sort_mismatch"),
+		)
+	})
+
+	Context("Tree", func() {
+		var (
+			albums map[string]entity.Album
+			media  map[string][]entity.Media
+		)
+
+		BeforeEach(func() {
+			albums = map[string]entity.Album{}
+			albums["2023"] = writeAlbum("2023", day(2024, 1, 1), nil)
+			albums["2024"] = writeAlbum("2024", day(2024, 1, 1), nil)
+			year := albums["2023"]
+			albums["summer"] = writeAlbum("2023/summer", day(2024, 1, 1), &year)
+			albums["winter"] = writeAlbum("2023/winter", day(2024, 1, 1), &year)
+			summer := albums["summer"]
+			albums["beach"] = writeAlbum("2023/summer/beach", day(2024, 1, 1), &summer)
+
+			media = map[string][]entity.Media{}
+			media["2023"] = writeMedia(albums["2023"], day(2023, 1, 1))
+			media["summer"] = writeMedia(albums["summer"], day(2023, 7, 10), day(2023, 7, 1))
+			media["beach"] = writeMedia(albums["beach"], day(2023, 8, 1))
+			media["winter"] = writeMedia(albums["winter"], day(2023, 12, 24))
+		})
+
+		AfterEach(func() {
+			_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+			Expect(err).To(BeNil())
+		})
+
+		// flatten returns the nodes of the tree by album path
+		var flatten func(tree []entity.AlbumTreeNode, nodes map[string]entity.AlbumTreeNode) map[string]entity.AlbumTreeNode
+		flatten = func(tree []entity.AlbumTreeNode, nodes map[string]entity.AlbumTreeNode) map[string]entity.AlbumTreeNode {
+			for _, node := range tree {
+				nodes[node.Album.Path] = node
+				flatten(node.Children, nodes)
+			}
+			return nodes
+		}
+
+		// paths returns the paths of the albums of the nodes
+		paths := func(tree []entity.AlbumTreeNode) []string {
+			p := []string{}
+			for _, node := range tree {
+				p = append(p, node.Album.Path)
+			}
+			return p
+		}
+
+		It("nests the albums and returns the statistics of every album and of its subtree", func() {
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{})
+			Expect(err).To(BeNil())
+			Expect(paths(tree)).To(Equal([]string{"2023", "2024"}))
+			Expect(paths(tree[0].Children)).To(Equal([]string{"2023/summer", "2023/winter"}))
+			Expect(paths(tree[0].Children[0].Children)).To(Equal([]string{"2023/summer/beach"}))
+
+			nodes := flatten(tree, map[string]entity.AlbumTreeNode{})
+
+			root := nodes["2023"]
+			Expect(root.MediaCount).To(Equal(1))
+			Expect(root.SubtreeMediaCount).To(Equal(5))
+			Expect(root.Album.MediaCount).To(Equal(5))
+			Expect(root.EarliestCapturedAt).To(HaveValue(BeTemporally("==", day(2023, 1, 1))))
+			Expect(root.LatestCapturedAt).To(HaveValue(BeTemporally("==", day(2023, 12, 24))))
+			Expect(root.CoverID).To(HaveValue(Equal(media["winter"][0].ID)))
+
+			summer := nodes["2023/summer"]
+			Expect(summer.MediaCount).To(Equal(2))
+			Expect(summer.SubtreeMediaCount).To(Equal(3))
+			Expect(summer.EarliestCapturedAt).To(HaveValue(BeTemporally("==", day(2023, 7, 1))))
+			Expect(summer.LatestCapturedAt).To(HaveValue(BeTemporally("==", day(2023, 8, 1))))
+			Expect(summer.CoverID).To(HaveValue(Equal(media["beach"][0].ID)))
+
+			beach := nodes["2023/summer/beach"]
+			Expect(beach.MediaCount).To(Equal(1))
+			Expect(beach.SubtreeMediaCount).To(Equal(1))
+			Expect(beach.Children).To(BeEmpty())
+
+			empty := nodes["2024"]
+			Expect(empty.MediaCount).To(Equal(0))
+			Expect(empty.SubtreeMediaCount).To(Equal(0))
+			Expect(empty.EarliestCapturedAt).To(BeNil())
+			Expect(empty.LatestCapturedAt).To(BeNil())
+			Expect(empty.CoverID).To(BeNil())
+		})
+
+		It("uses the thumbnail of the album as cover", func() {
+			summer := albums["summer"]
+			summer.Thumbnail = &media["summer"][1].ID
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				return w.WriteAlbum(ctx, summer)
+			})
+			Expect(err).To(BeNil())
+
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{})
+			Expect(err).To(BeNil())
+			nodes := flatten(tree, map[string]entity.AlbumTreeNode{})
+			Expect(nodes["2023/summer"].CoverID).To(HaveValue(Equal(media["summer"][1].ID)))
+			Expect(nodes["2023"].CoverID).To(HaveValue(Equal(media["winter"][0].ID)))
+		})
+
+		It("leaves the media and the albums in the trash out", func() {
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				if err := w.TrashMedia(ctx, media["winter"][0].ID, time.Now()); err != nil {
+					return err
+				}
+				return w.TrashAlbum(ctx, albums["beach"].Path, time.Now())
+			})
+			Expect(err).To(BeNil())
+
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{})
+			Expect(err).To(BeNil())
+			nodes := flatten(tree, map[string]entity.AlbumTreeNode{})
+			Expect(nodes).ToNot(HaveKey("2023/summer/beach"))
+
+			root := nodes["2023"]
+			Expect(root.SubtreeMediaCount).To(Equal(3))
+			Expect(root.LatestCapturedAt).To(HaveValue(BeTemporally("==", day(2023, 7, 10))))
+			Expect(root.CoverID).To(HaveValue(Equal(media["summer"][0].ID)))
+			Expect(nodes["2023/winter"].SubtreeMediaCount).To(Equal(0))
+			Expect(nodes["2023/winter"].CoverID).To(BeNil())
+		})
+
+		It("agrees with the cached statistics of the albums", func() {
+			// change the statistics of several subtrees after the albums were written
+			err := dt.WriteTx(context.TODO(), func(ctx context.Context, w *pg.Writer) error {
+				if err := w.MoveMedia(ctx, media["beach"][0].ID, albums["2024"].ID, "moved.jpg"); err != nil {
+					return err
+				}
+				return w.TrashMedia(ctx, media["summer"][0].ID, time.Now())
+			})
+			Expect(err).To(BeNil())
+			writeMedia(albums["winter"], day(2022, 12, 24))
+
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{})
+			Expect(err).To(BeNil())
+			nodes := flatten(tree, map[string]entity.AlbumTreeNode{})
+			Expect(nodes).To(HaveLen(len(albums)))
+
+			rows, err := pgPool.Query(context.TODO(),
+				"SELECT path, media_count, subtree_media_count, subtree_latest_captured_at FROM albums WHERE trashed_at IS NULL")
+			Expect(err).To(BeNil())
+			defer rows.Close()
+
+			cached := 0
+			for rows.Next() {
+				var (
+					albumPath         string
+					mediaCount        int
+					subtreeMediaCount int
+					subtreeLatest     *time.Time
+				)
+				Expect(rows.Scan(&albumPath, &mediaCount, &subtreeMediaCount, &subtreeLatest)).To(Succeed())
+				Expect(nodes).To(HaveKey(albumPath))
+
+				node := nodes[albumPath]
+				Expect(node.MediaCount).To(Equal(mediaCount), albumPath)
+				Expect(node.SubtreeMediaCount).To(Equal(subtreeMediaCount), albumPath)
+				if subtreeLatest == nil {
+					Expect(node.LatestCapturedAt).To(BeNil(), albumPath)
+				} else {
+					Expect(node.LatestCapturedAt).To(HaveValue(BeTemporally("==", *subtreeLatest)), albumPath)
+				}
+				cached++
+			}
+			Expect(rows.Err()).To(BeNil())
+			Expect(cached).To(Equal(len(nodes)))
+
+			Expect(nodes["2023"].SubtreeMediaCount).To(Equal(4))
+			Expect(nodes["2024"].SubtreeMediaCount).To(Equal(1))
+		})
+
+		It("limits the depth of the tree and keeps the statistics of the subtrees", func() {
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{AlbumTreeDepth: 1})
+			Expect(err).To(BeNil())
+			Expect(paths(tree)).To(Equal([]string{"2023", "2024"}))
+			Expect(tree[0].Children).To(BeEmpty())
+			Expect(tree[0].SubtreeMediaCount).To(Equal(5))
+
+			tree, err = albumService.Tree(context.TODO(), &services.AlbumTreeOptions{AlbumTreeDepth: 2})
+			Expect(err).To(BeNil())
+			Expect(paths(tree[0].Children)).To(Equal([]string{"2023/summer", "2023/winter"}))
+			Expect(tree[0].Children[0].Children).To(BeEmpty())
+			Expect(tree[0].Children[0].SubtreeMediaCount).To(Equal(3))
+		})
+
+		It("returns the subtree of an album", func() {
+			tree, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{AlbumTreeRootID: stringPtr(albums["summer"].ID)})
+			Expect(err).To(BeNil())
+			Expect(paths(tree)).To(Equal([]string{"2023/summer"}))
+			Expect(paths(tree[0].Children)).To(Equal([]string{"2023/summer/beach"}))
+			Expect(tree[0].SubtreeMediaCount).To(Equal(3))
+		})
+
+		It("does not find the subtree of an unknown album", func() {
+			id := entity.NewId()
+			_, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{AlbumTreeRootID: &id})
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+
+		It("refuses a negative depth", func() {
+			_, err := albumService.Tree(context.TODO(), &services.AlbumTreeOptions{AlbumTreeDepth: -1})
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		})
+
+		Context("with authorization", func() {
+			var (
+				authz        *viewAuthz
+				authzService *services.AuthzAlbumService
+			)
+
+			ctx := user.ToContext(context.Background(), &entity.User{ID: "alice-id", Username: "alice"})
+
+			BeforeEach(func() {
+				authz = &viewAuthz{}
+				authzService = services.NewAuthzAlbumService(authz, albumService)
+			})
+
+			It("returns the albums the user can view and counts only their media", func() {
+				authz.viewable = []entity.Resource{entity.NewAlbumResource(albums["2023"].ID), entity.NewAlbumResource(albums["beach"].ID)}
+
+				tree, err := authzService.Tree(ctx, &services.AlbumTreeOptions{})
+				Expect(err).To(BeNil())
+				// the parent of beach cannot be viewed, beach is a root
+				Expect(paths(tree)).To(Equal([]string{"2023", "2023/summer/beach"}))
+				Expect(tree[0].Children).To(BeEmpty())
+				Expect(tree[0].SubtreeMediaCount).To(Equal(1))
+				Expect(tree[0].CoverID).To(HaveValue(Equal(media["2023"][0].ID)))
+				Expect(tree[1].SubtreeMediaCount).To(Equal(1))
+			})
+
+			It("refuses the subtree of an album the user cannot view", func() {
+				authz.viewable = []entity.Resource{entity.NewAlbumResource(albums["beach"].ID)}
+
+				_, err := authzService.Tree(ctx, &services.AlbumTreeOptions{AlbumTreeRootID: stringPtr(albums["summer"].ID)})
+				var forbidden *services.ForbiddenAccessError
+				Expect(err).To(BeAssignableToTypeOf(forbidden))
+			})
+		})
+	})
+
+	Context("Get", func() {
 		It("retrieves single album successfully", func() {
 			// Insert test album
 			now := time.Now()
@@ -149,7 +590,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			_, err = pgPool.Exec(context.TODO(), sql, args...)
 			Expect(err).To(BeNil())
 
-			album, err := albumService.GetAlbum(context.TODO(), testAlbum.ID)
+			album, err := albumService.Get(context.TODO(), testAlbum.ID)
 
 			Expect(err).To(BeNil())
 			Expect(album).ToNot(BeNil())
@@ -158,10 +599,10 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 
 		It("returns not found error when album doesn't exist", func() {
-			album, err := albumService.GetAlbum(context.TODO(), "non-existent-id")
+			album, err := albumService.Get(context.TODO(), "non-existent-id")
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 			Expect(album).To(BeNil())
 		})
 
@@ -173,9 +614,9 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 	})
 
-	Context("CreateAlbum", func() {
+	Context("Create", func() {
 		It("creates new album successfully", func() {
-			result, err := albumService.CreateAlbum(context.TODO(), testAlbum)
+			result, err := albumService.Create(context.TODO(), testAlbum)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -199,7 +640,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			parentAlbum := entity.NewAlbum("/parent")
 			parentAlbum.Description = stringPtr("Parent Album")
 
-			_, err := albumService.CreateAlbum(context.TODO(), parentAlbum)
+			_, err := albumService.Create(context.TODO(), parentAlbum)
 			Expect(err).To(BeNil())
 
 			// Verify parent folder was created
@@ -212,7 +653,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			childAlbum := entity.NewAlbum("child")
 			childAlbum.ParentId = &parentAlbum.ID
 
-			result, err := albumService.CreateAlbum(context.TODO(), childAlbum)
+			result, err := albumService.Create(context.TODO(), childAlbum)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -238,10 +679,10 @@ var _ = Describe("AlbumService", Ordered, func() {
 			childAlbum := entity.NewAlbum("child")
 			childAlbum.ParentId = stringPtr("non-existent-parent")
 
-			result, err := albumService.CreateAlbum(context.TODO(), childAlbum)
+			result, err := albumService.Create(context.TODO(), childAlbum)
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 			Expect(result).To(BeNil())
 
 			// Verify no folder was created on filesystem since parent doesn't exist
@@ -256,7 +697,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			invalidAlbum := entity.NewAlbum("/test/invalid\x00album")
 			invalidAlbum.Description = stringPtr("Invalid Album")
 
-			result, err := albumService.CreateAlbum(context.TODO(), invalidAlbum)
+			result, err := albumService.Create(context.TODO(), invalidAlbum)
 
 			Expect(err).ToNot(BeNil())
 			Expect(result).To(BeNil())
@@ -282,7 +723,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 	})
 
-	Context("UpdateAlbum", func() {
+	Context("Update", func() {
 		It("updates existing album successfully", func() {
 			// First create the album
 			now := time.Now()
@@ -297,7 +738,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			updatedAlbum := testAlbum
 			updatedAlbum.Description = stringPtr("Updated Description")
 
-			result, err := albumService.UpdateAlbum(context.TODO(), updatedAlbum)
+			result, err := albumService.Update(context.TODO(), updatedAlbum)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -324,7 +765,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			updatedAlbum := testAlbum
 			updatedAlbum.Thumbnail = stringPtr("non-existent-media-id")
 
-			result, err := albumService.UpdateAlbum(context.TODO(), updatedAlbum)
+			result, err := albumService.Update(context.TODO(), updatedAlbum)
 
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(ContainSubstring("thumbnail"))
@@ -358,7 +799,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			updatedAlbum.Description = stringPtr("Updated with thumbnail")
 			updatedAlbum.Thumbnail = &mediaID
 
-			result, err := albumService.UpdateAlbum(context.TODO(), updatedAlbum)
+			result, err := albumService.Update(context.TODO(), updatedAlbum)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -375,10 +816,10 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 
 		It("returns error when album doesn't exist", func() {
-			result, err := albumService.UpdateAlbum(context.TODO(), testAlbum)
+			result, err := albumService.Update(context.TODO(), testAlbum)
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 			Expect(result).To(BeNil())
 		})
 
@@ -390,7 +831,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 	})
 
-	Context("DeleteAlbum", func() {
+	Context("Delete", func() {
 		It("deletes existing album successfully", func() {
 			// First create the album
 			now := time.Now()
@@ -401,7 +842,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 			_, err = pgPool.Exec(context.TODO(), sql, args...)
 			Expect(err).To(BeNil())
 
-			err = albumService.DeleteAlbum(context.TODO(), testAlbum.ID)
+			err = albumService.Delete(context.TODO(), testAlbum.ID)
 
 			Expect(err).To(BeNil())
 
@@ -413,10 +854,10 @@ var _ = Describe("AlbumService", Ordered, func() {
 		})
 
 		It("returns error when album doesn't exist", func() {
-			err := albumService.DeleteAlbum(context.TODO(), "non-existent-id")
+			err := albumService.Delete(context.TODO(), "non-existent-id")
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 		})
 
 		It("handles file system deletion failure gracefully", func() {
@@ -431,7 +872,7 @@ var _ = Describe("AlbumService", Ordered, func() {
 
 			// This test demonstrates that even if fs deletion could fail,
 			// the service should handle it properly
-			err = albumService.DeleteAlbum(context.TODO(), testAlbum.ID)
+			err = albumService.Delete(context.TODO(), testAlbum.ID)
 
 			// In the real implementation, fs operations are part of the transaction
 			// If fs fails, the whole transaction should roll back
@@ -457,42 +898,15 @@ var _ = Describe("AlbumService", Ordered, func() {
 			Expect(err).To(BeNil())
 		})
 	})
-
-	Context("SyncAlbum", func() {
-		It("syncs existing album successfully", func() {
-			// First create the album
-			now := time.Now()
-			sql, args, err := insertAlbumStmt.
-				Values(testAlbum.ID, now, testAlbum.Path, testAlbum.Description, nil, nil).
-				ToSql()
-			Expect(err).To(BeNil())
-			_, err = pgPool.Exec(context.TODO(), sql, args...)
-			Expect(err).To(BeNil())
-
-			count, err := albumService.SyncAlbum(context.TODO(), testAlbum.ID)
-
-			Expect(err).To(BeNil())
-			Expect(count).To(Equal(0)) // Current implementation returns 0
-		})
-
-		It("returns error when album doesn't exist", func() {
-			count, err := albumService.SyncAlbum(context.TODO(), "non-existent-id")
-
-			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
-			Expect(count).To(Equal(0))
-		})
-
-		AfterEach(func() {
-			_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
-			Expect(err).To(BeNil())
-			_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
-			Expect(err).To(BeNil())
-		})
-	})
 })
 
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
 }
+
+// isNotFound checks if the error is a not found error of the services
+func isNotFound(err error) bool {
+	var notFound *services.NotFoundError
+	return errors.As(err, &notFound)
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"slices"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/authz"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
@@ -16,7 +15,7 @@ type Authz interface {
 	// HasPermission checks if a user has a specific permission on a resource
 	HasPermission(ctx context.Context, user entity.User, resource entity.Resource, permission entity.Permission) (bool, error)
 
-	// GetPermissions returns all permissions a user has on a list of resources
+	// GetPermissions returns the permissions of entity.AllPermissions a user has on a list of resources
 	GetPermissions(ctx context.Context, zedToken string, user entity.User, resources []entity.Resource) (map[entity.Resource][]entity.Permission, error)
 
 	// ListResources returns all resource IDs of a given type that a user can access with a specific permission
@@ -24,6 +23,9 @@ type Authz interface {
 
 	// DeleteRelationships deletes all relationships for a resource
 	DeleteRelationships(ctx context.Context, resource entity.Resource) error
+
+	// UpdateRelationships removes and writes relationships in a single write
+	UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error
 }
 
 type AuthzService struct {
@@ -38,6 +40,7 @@ func NewAuthzService(authzS *authz.Datastore, pg *pg.Datastore) *AuthzService {
 	}
 }
 
+// HasPermission checks the permission itself, it does not have to be one of entity.AllPermissions.
 func (s *AuthzService) HasPermission(ctx context.Context, user entity.User, resource entity.Resource, permission entity.Permission) (bool, error) {
 	hasPermission := false
 	if err := s.pg.ExecWithSharedLock(ctx, func(ctx context.Context) error {
@@ -46,22 +49,10 @@ func (s *AuthzService) HasPermission(ctx context.Context, user entity.User, reso
 			return err
 		}
 
-		permissions, err := s.authzStore.GetPermissions(ctx, token, user.ID, []entity.Resource{resource})
-		if err != nil {
-			return err
-		}
-
-		userPermissions, exists := permissions[resource]
-		if !exists {
-			return nil
-		}
-
-		hasPermission = slices.ContainsFunc(userPermissions, func(p entity.Permission) bool {
-			return p == permission
-		})
-		return nil
+		hasPermission, err = s.authzStore.CheckPermission(ctx, token, user.ID, resource, permission)
+		return err
 	}); err != nil {
-		return hasPermission, err
+		return false, err
 	}
 	return hasPermission, nil
 }
@@ -129,7 +120,13 @@ func (s *AuthzService) DeleteRelationships(ctx context.Context, resource entity.
 	})
 }
 
+// WriteRelationships writes the relationships. Nothing is written, not even the consistency token, when there
+// is no relationship.
 func (s *AuthzService) WriteRelationships(ctx context.Context, relationships ...entity.Relationship) error {
+	if len(relationships) == 0 {
+		return nil
+	}
+
 	relationshipFns := make([]authz.RelationshipFn, 0, len(relationships))
 	for _, rel := range relationships {
 		relationshipFns = append(relationshipFns, authz.WithRelationship(rel.Subject, rel.Resource, rel.Kind))
@@ -150,3 +147,34 @@ func (s *AuthzService) WriteRelationships(ctx context.Context, relationships ...
 		return nil
 	})
 }
+
+// UpdateRelationships removes and writes relationships in a single write. Nothing is written, not even the
+// consistency token, when both lists are empty.
+func (s *AuthzService) UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error {
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+
+	relationshipFns := make([]authz.RelationshipFn, 0, len(removed)+len(added))
+	for _, rel := range removed {
+		relationshipFns = append(relationshipFns, authz.WithoutRelationship(rel.Subject, rel.Resource, rel.Kind))
+	}
+	for _, rel := range added {
+		relationshipFns = append(relationshipFns, authz.WithRelationship(rel.Subject, rel.Resource, rel.Kind))
+	}
+
+	return s.pg.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		writer.AcquireGlobalLock(ctx)
+
+		token, err := s.authzStore.WriteRelationships(ctx, relationshipFns...)
+		if err != nil {
+			return err
+		}
+
+		if err := writer.WriteToken(ctx, token); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
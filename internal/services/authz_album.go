@@ -29,29 +29,82 @@ func NewAuthzAlbumService(authzSrv Authz, albumSrv *AlbumService) *AuthzAlbumSer
 }
 
 // List returns albums that the authenticated user has view permission on.
-// Filters albums based on ListResources authorization check.
-func (s *AuthzAlbumService) List(ctx context.Context, opts *ListOptions) ([]entity.Album, error) {
-	logger := s.logger.WithContext(ctx).Debug("authz_list_albums").Build()
+// The albums are filtered in SQL with the ids returned by ListResources so pagination is not affected.
+func (s *AuthzAlbumService) List(ctx context.Context, opts *ListOptions) ([]entity.Album, *AlbumCursor, error) {
+	listOptions, err := s.allowedOptions(ctx, "authz_list_albums", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.albumSrv.List(ctx, listOptions)
+}
+
+// Count returns the number of albums the authenticated user has view permission on.
+func (s *AuthzAlbumService) Count(ctx context.Context, opts *ListOptions) (int, error) {
+	listOptions, err := s.allowedOptions(ctx, "authz_count_albums", opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.albumSrv.Count(ctx, listOptions)
+}
+
+// Tree returns the tree of the albums the authenticated user has view permission on.
+// If the tree is restricted to a subtree, entity.ViewPermission is required on its root album.
+// An album whose parent cannot be viewed is a root of the tree.
+func (s *AuthzAlbumService) Tree(ctx context.Context, opts *AlbumTreeOptions) ([]entity.AlbumTreeNode, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_album_tree").Build()
 
 	user := user.MustFromContext(ctx)
 
+	if opts.AlbumTreeRootID != nil {
+		logger.Step("check_view_permission").WithString(AlbumID, *opts.AlbumTreeRootID).Log()
+
+		resource := entity.NewAlbumResource(*opts.AlbumTreeRootID)
+		hasPermission, err := s.authzSrv.HasPermission(ctx, user, resource, entity.ViewPermission)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasPermission {
+			return nil, NewForbiddenAccessError(ctx, "authz_album_tree", resource, entity.ViewPermission)
+		}
+	}
+
 	logger.Step("list_allowed_resources").Log()
 
 	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.AlbumResource)
 	if err != nil {
-		return nil, NewInternalError(ctx, "authz_list_albums", "list_allowed_resources", err)
+		return nil, NewInternalError(ctx, "authz_album_tree", "list_allowed_resources", err)
 	}
 
-	listOptions := NewListOptionsWithOptions(opts.ToOption(), SetAllowedIDs(allowedIds))
+	// nil would lift the restriction
+	if allowedIds == nil {
+		allowedIds = []string{}
+	}
 
-	return s.albumSrv.List(ctx, listOptions)
+	return s.albumSrv.Tree(ctx, NewAlbumTreeOptionsWithOptions(opts.ToOption(), SetAllowedAlbumTreeIDs(allowedIds)))
 }
 
-// Count returns the total number of albums.
-// Note: This doesn't require authorization filtering.
-func (s *AuthzAlbumService) Count(ctx context.Context, hasParent bool) (int, error) {
-	// Count doesn't require authorization filtering
-	return s.albumSrv.Count(ctx, hasParent)
+// allowedOptions restricts the options to the albums the user has view permission on.
+func (s *AuthzAlbumService) allowedOptions(ctx context.Context, operation string, opts *ListOptions) (*ListOptions, error) {
+	logger := s.logger.WithContext(ctx).Debug(operation).Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("list_allowed_resources").Log()
+
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.AlbumResource)
+	if err != nil {
+		return nil, NewInternalError(ctx, operation, "list_allowed_resources", err)
+	}
+
+	// nil would lift the restriction
+	if allowedIds == nil {
+		allowedIds = []string{}
+	}
+
+	return NewListOptionsWithOptions(opts.ToOption(), SetAllowedIDs(allowedIds)), nil
 }
 
 // Get retrieves a specific album by ID.
@@ -162,9 +215,113 @@ func (s *AuthzAlbumService) Update(ctx context.Context, album entity.Album) (*en
 	return s.albumSrv.Update(ctx, album)
 }
 
+// Rename renames the folder of an album.
+// Requires entity.EditPermission on the album resource.
+// The id of the album does not change so its relationships are kept.
+func (s *AuthzAlbumService) Rename(ctx context.Context, id, name string) (*entity.Album, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_rename_album").
+		WithString(AlbumID, id).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_edit_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewAlbumResource(id), entity.EditPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPermission {
+		return nil, NewForbiddenAccessError(ctx, "authz_rename_album", entity.NewAlbumResource(id), entity.EditPermission)
+	}
+
+	return s.albumSrv.Rename(ctx, id, name)
+}
+
+// Move moves an album into another album or at the top level when parentID is nil.
+// Requires entity.DeletePermission on the album resource and entity.EditPermission on the new parent,
+// or entity.CreatePermission on entity.LocalDatastore when the album is moved at the top level.
+// The parent relationship is replaced before the move and restored if the move fails.
+func (s *AuthzAlbumService) Move(ctx context.Context, id string, parentID *string) (*entity.Album, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_move_album").
+		WithString(AlbumID, id).
+		WithStringPtr("parent_id", parentID).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_delete_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewAlbumResource(id), entity.DeletePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPermission {
+		return nil, NewForbiddenAccessError(ctx, "authz_move_album", entity.NewAlbumResource(id), entity.DeletePermission)
+	}
+
+	target, permission := entity.NewDatastoreResource(entity.LocalDatastore), entity.CreatePermission
+	if parentID != nil {
+		target, permission = entity.NewAlbumResource(*parentID), entity.EditPermission
+	}
+
+	logger.Step("check_target_permission").WithString("permission", permission.String()).Log()
+	hasPermission, err = s.authzSrv.HasPermission(ctx, user, target, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPermission {
+		return nil, NewForbiddenAccessError(ctx, "authz_move_album", target, permission)
+	}
+
+	album, err := s.albumSrv.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	parentRelationships := func(parentID *string) []entity.Relationship {
+		if parentID == nil {
+			return nil
+		}
+		return []entity.Relationship{entity.NewRelationship(
+			entity.NewAlbumSubject(*parentID),
+			entity.NewAlbumResource(id),
+			entity.ParentRelationship,
+		)}
+	}
+	oldParent, newParent := parentRelationships(album.ParentId), parentRelationships(parentID)
+
+	// SpiceDB refuses to delete and write the same relationship in one request
+	sameParent := (album.ParentId == nil && parentID == nil) ||
+		(album.ParentId != nil && parentID != nil && *album.ParentId == *parentID)
+
+	if !sameParent {
+		logger.Step("update_parent_relationship").Log()
+		if err := s.authzSrv.UpdateRelationships(ctx, oldParent, newParent); err != nil {
+			return nil, NewDatabaseWriteError(ctx, "authz_move_album", err).WithAlbumID(id)
+		}
+	}
+
+	moved, err := s.albumSrv.Move(ctx, id, parentID)
+	if err != nil {
+		if !sameParent {
+			logger.Step("move_failed_restoring_relationships").Log()
+			if restoreErr := s.authzSrv.UpdateRelationships(ctx, newParent, oldParent); restoreErr != nil {
+				logger.Step("restore_failed").WithString("error", restoreErr.Error()).Log()
+			}
+		}
+		return nil, err
+	}
+
+	logger.Success().Log()
+	return moved, nil
+}
+
 // Delete deletes an album by ID.
 // Requires entity.DeletePermission on the album resource.
-// Also deletes associated authorization relationships.
+// The album is moved to the trash and keeps its authorization relationships so it can be restored,
+// they are deleted when the trash is purged.
 func (s *AuthzAlbumService) Delete(ctx context.Context, id string) error {
 	logger := s.logger.WithContext(ctx).Debug("authz_delete_album").
 		WithString(AlbumID, id).
@@ -186,15 +343,6 @@ func (s *AuthzAlbumService) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	// If successful, delete authorization relationships
-	logger.Step("delete_authorization_relationships").Log()
-	err = s.authzSrv.DeleteRelationships(ctx, entity.NewAlbumResource(id))
-	if err != nil {
-		logger.Step("failed_to_delete_relationships").WithString("error", err.Error()).Log()
-		// Note: Album is already deleted, but relationships cleanup failed
-		// This is logged but we don't fail the operation
-	}
-
 	logger.Success().Log()
 	return nil
 }
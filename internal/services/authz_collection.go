@@ -0,0 +1,255 @@
+// Package services provides authorization-wrapped collection service implementations.
+// This file contains the AuthzCollectionService which wraps CollectionService with authorization checks.
+package services
+
+import (
+	"context"
+	"slices"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzCollectionService wraps CollectionService with authorization checks.
+// Operations check permissions on collection resources using the Authz service.
+type AuthzCollectionService struct {
+	collectionSrv *CollectionService
+	authzSrv      Authz
+	logger        *logger.StructuredLogger
+}
+
+// NewAuthzCollectionService creates a new authorization-wrapped collection service.
+func NewAuthzCollectionService(authzSrv Authz, collectionSrv *CollectionService) *AuthzCollectionService {
+	return &AuthzCollectionService{
+		collectionSrv: collectionSrv,
+		authzSrv:      authzSrv,
+		logger:        logger.New("authz_collection_service"),
+	}
+}
+
+// List returns the collections that the authenticated user has view permission on.
+func (s *AuthzCollectionService) List(ctx context.Context, opts *CollectionOptions) ([]entity.Collection, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_list_collections").Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("list_allowed_resources").Log()
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.CollectionResource)
+	if err != nil {
+		return nil, NewInternalError(ctx, "authz_list_collections", "list_allowed_resources", err)
+	}
+
+	return s.collectionSrv.List(ctx, NewCollectionOptionsWithOptions(opts.ToOption(), SetAllowedCollectionIDs(allowedIds)))
+}
+
+// Count returns the number of collections that the authenticated user has view permission on.
+func (s *AuthzCollectionService) Count(ctx context.Context, opts *CollectionOptions) (int, error) {
+	user := user.MustFromContext(ctx)
+
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.CollectionResource)
+	if err != nil {
+		return 0, NewInternalError(ctx, "authz_count_collections", "list_allowed_resources", err)
+	}
+
+	return s.collectionSrv.Count(ctx, NewCollectionOptionsWithOptions(opts.ToOption(), SetAllowedCollectionIDs(allowedIds)))
+}
+
+// Get retrieves a specific collection by ID.
+// Requires entity.ViewPermission on the collection resource.
+func (s *AuthzCollectionService) Get(ctx context.Context, id string) (*entity.Collection, error) {
+	if err := s.checkPermission(ctx, "authz_get_collection", id, entity.ViewPermission); err != nil {
+		return nil, err
+	}
+	return s.collectionSrv.Get(ctx, id)
+}
+
+// Create creates a new collection.
+// Requires entity.CreatePermission on entity.LocalDatastore.
+// Creates authorization relationships: datastore and owner.
+func (s *AuthzCollectionService) Create(ctx context.Context, collection entity.Collection) (*entity.Collection, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_create_collection").
+		WithString(CollectionID, collection.ID).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_create_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewDatastoreResource(entity.LocalDatastore), entity.CreatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPermission {
+		return nil, NewForbiddenAccessError(ctx, "authz_create_collection", entity.NewDatastoreResource(entity.LocalDatastore), entity.CreatePermission)
+	}
+
+	logger.Step("write_authorization_relationships").Log()
+	err = s.authzSrv.WriteRelationships(ctx,
+		entity.NewRelationship(
+			entity.NewDatastoreSubject(entity.LocalDatastore),
+			entity.NewCollectionResource(collection.ID),
+			entity.DatastoreRelationship,
+		),
+		entity.NewRelationship(
+			entity.NewUserSubject(user.Username),
+			entity.NewCollectionResource(collection.ID),
+			entity.OwnerRelationship,
+		),
+	)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "authz_create_collection", err).WithContext(CollectionID, collection.ID)
+	}
+
+	created, err := s.collectionSrv.Create(ctx, collection)
+	if err != nil {
+		logger.Step("create_failed_rolling_back_relationships").Log()
+		if delErr := s.authzSrv.DeleteRelationships(ctx, entity.NewCollectionResource(collection.ID)); delErr != nil {
+			logger.Step("rollback_failed").WithString("error", delErr.Error()).Log()
+		}
+		return nil, err
+	}
+
+	logger.Success().Log()
+	return created, nil
+}
+
+// Update updates an existing collection.
+// Requires entity.EditPermission on the collection resource.
+func (s *AuthzCollectionService) Update(ctx context.Context, collection entity.Collection) (*entity.Collection, error) {
+	if err := s.checkPermission(ctx, "authz_update_collection", collection.ID, entity.EditPermission); err != nil {
+		return nil, err
+	}
+	return s.collectionSrv.Update(ctx, collection)
+}
+
+// Delete deletes a collection by ID.
+// Requires entity.DeletePermission on the collection resource.
+// Also deletes associated authorization relationships.
+func (s *AuthzCollectionService) Delete(ctx context.Context, id string) error {
+	logger := s.logger.WithContext(ctx).Debug("authz_delete_collection").
+		WithString(CollectionID, id).
+		Build()
+
+	if err := s.checkPermission(ctx, "authz_delete_collection", id, entity.DeletePermission); err != nil {
+		return err
+	}
+
+	if err := s.collectionSrv.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Step("delete_authorization_relationships").Log()
+	if err := s.authzSrv.DeleteRelationships(ctx, entity.NewCollectionResource(id)); err != nil {
+		// Collection is already deleted, the failure is logged but the operation does not fail
+		logger.Step("failed_to_delete_relationships").WithString("error", err.Error()).Log()
+	}
+
+	logger.Success().Log()
+	return nil
+}
+
+// ListMedia returns the media of the collection which the user is allowed to view.
+// Requires entity.ViewPermission on the collection resource.
+// Media the user cannot view in their album are hidden even if they belong to the collection.
+func (s *AuthzCollectionService) ListMedia(ctx context.Context, id string, limit, offset int) ([]entity.Media, error) {
+	if err := s.checkPermission(ctx, "authz_list_collection_media", id, entity.ViewPermission); err != nil {
+		return nil, err
+	}
+
+	media, err := s.collectionSrv.ListMedia(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.filterViewable(ctx, media)
+}
+
+// AddMedia adds media to the collection.
+// Requires entity.EditPermission on the collection and entity.ViewPermission on every media.
+func (s *AuthzCollectionService) AddMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	if err := s.checkPermission(ctx, "authz_add_collection_media", id, entity.EditPermission); err != nil {
+		return nil, err
+	}
+
+	user := user.MustFromContext(ctx)
+
+	resources := make([]entity.Resource, 0, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		resources = append(resources, entity.NewMediaResource(mediaID))
+	}
+
+	permissions, err := s.authzSrv.GetPermissions(ctx, "", user, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		if !slices.Contains(permissions[resource], entity.ViewPermission) {
+			return nil, NewForbiddenAccessError(ctx, "authz_add_collection_media", resource, entity.ViewPermission)
+		}
+	}
+
+	return s.collectionSrv.AddMedia(ctx, id, mediaIDs)
+}
+
+// RemoveMedia removes media from the collection.
+// Requires entity.EditPermission on the collection resource.
+func (s *AuthzCollectionService) RemoveMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	if err := s.checkPermission(ctx, "authz_remove_collection_media", id, entity.EditPermission); err != nil {
+		return nil, err
+	}
+	return s.collectionSrv.RemoveMedia(ctx, id, mediaIDs)
+}
+
+// Reorder sets the order of the media in the collection.
+// Requires entity.EditPermission on the collection resource.
+func (s *AuthzCollectionService) Reorder(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	if err := s.checkPermission(ctx, "authz_reorder_collection", id, entity.EditPermission); err != nil {
+		return nil, err
+	}
+	return s.collectionSrv.Reorder(ctx, id, mediaIDs)
+}
+
+func (s *AuthzCollectionService) checkPermission(ctx context.Context, operation, id string, permission entity.Permission) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString(CollectionID, id).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_permission").WithString("permission", permission.String()).Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewCollectionResource(id), permission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, entity.NewCollectionResource(id), permission)
+	}
+
+	logger.Step("authorization granted").Log()
+	return nil
+}
+
+func (s *AuthzCollectionService) filterViewable(ctx context.Context, media []entity.Media) ([]entity.Media, error) {
+	if len(media) == 0 {
+		return media, nil
+	}
+
+	user := user.MustFromContext(ctx)
+
+	resources := make([]entity.Resource, 0, len(media))
+	for _, m := range media {
+		resources = append(resources, entity.NewMediaResource(m.ID))
+	}
+
+	permissions, err := s.authzSrv.GetPermissions(ctx, "", user, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(media, func(m entity.Media) bool {
+		return !slices.Contains(permissions[entity.NewMediaResource(m.ID)], entity.ViewPermission)
+	}), nil
+}
@@ -0,0 +1,95 @@
+// Package services provides authorization-wrapped comment service implementations.
+// This file contains the AuthzCommentService which wraps CommentService with authorization checks.
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzCommentService wraps CommentService with authorization checks.
+// Comments are not resources of their own: every operation requires entity.ViewPermission
+// on the commented media. Editing and deleting are further restricted to the author by CommentService.
+type AuthzCommentService struct {
+	commentSrv *CommentService
+	authzSrv   Authz
+	logger     *logger.StructuredLogger
+}
+
+// NewAuthzCommentService creates a new authorization-wrapped comment service.
+func NewAuthzCommentService(authzSrv Authz, commentSrv *CommentService) *AuthzCommentService {
+	return &AuthzCommentService{
+		commentSrv: commentSrv,
+		authzSrv:   authzSrv,
+		logger:     logger.New("authz_comment_service"),
+	}
+}
+
+// List returns the comments of a media.
+// Requires entity.ViewPermission on the media resource.
+func (s *AuthzCommentService) List(ctx context.Context, mediaID string) ([]entity.Comment, error) {
+	if err := s.checkPermission(ctx, "authz_list_comments", mediaID); err != nil {
+		return nil, err
+	}
+	return s.commentSrv.List(ctx, mediaID)
+}
+
+// Get returns a comment of a media.
+// Requires entity.ViewPermission on the media resource.
+func (s *AuthzCommentService) Get(ctx context.Context, mediaID, id string) (*entity.Comment, error) {
+	if err := s.checkPermission(ctx, "authz_get_comment", mediaID); err != nil {
+		return nil, err
+	}
+	return s.commentSrv.Get(ctx, mediaID, id)
+}
+
+// Create adds a comment to a media.
+// Requires entity.ViewPermission on the media resource.
+func (s *AuthzCommentService) Create(ctx context.Context, comment entity.Comment) (*entity.Comment, error) {
+	if err := s.checkPermission(ctx, "authz_create_comment", comment.MediaID); err != nil {
+		return nil, err
+	}
+	return s.commentSrv.Create(ctx, comment)
+}
+
+// Update changes the body of a comment.
+// Requires entity.ViewPermission on the media resource.
+func (s *AuthzCommentService) Update(ctx context.Context, comment entity.Comment) (*entity.Comment, error) {
+	if err := s.checkPermission(ctx, "authz_update_comment", comment.MediaID); err != nil {
+		return nil, err
+	}
+	return s.commentSrv.Update(ctx, comment)
+}
+
+// Delete removes a comment and its replies.
+// Requires entity.ViewPermission on the media resource.
+func (s *AuthzCommentService) Delete(ctx context.Context, mediaID, id string) error {
+	if err := s.checkPermission(ctx, "authz_delete_comment", mediaID); err != nil {
+		return err
+	}
+	return s.commentSrv.Delete(ctx, mediaID, id)
+}
+
+func (s *AuthzCommentService) checkPermission(ctx context.Context, operation, mediaID string) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString(MediaID, mediaID).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_permission").WithString("permission", entity.ViewPermission.String()).Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewMediaResource(mediaID), entity.ViewPermission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, entity.NewMediaResource(mediaID), entity.ViewPermission)
+	}
+
+	logger.Step("authorization granted").Log()
+	return nil
+}
@@ -128,6 +128,78 @@ func (s *AuthzMediaService) List(ctx context.Context, filter *MediaOptions) ([]e
 	return results, responseCursor, nil
 }
 
+// Memories returns the memories of the day with the media the user has view permission on.
+// The memories are read in pages of twice the limit until the limit is reached or every media was read.
+func (s *AuthzMediaService) Memories(ctx context.Context, opts *MemoriesOptions) ([]entity.Memory, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_memories").Build()
+
+	user := user.MustFromContext(ctx)
+
+	limit := opts.MemoriesLimit
+	if limit <= 0 {
+		limit = maxPageSize
+	}
+
+	// Use a larger batch size to reduce round trips
+	batchSize := limit * 2
+
+	allowed := make([]entity.Media, 0, limit)
+	totalFetched := 0
+
+	for len(allowed) < limit {
+		logger.Step("fetch_batch").
+			WithInt("offset", totalFetched).
+			WithInt("results_so_far", len(allowed)).
+			Log()
+
+		batch, err := s.mediaSrv.memories(ctx, NewMemoriesOptionsWithOptions(
+			opts.ToOption(),
+			WithMemoriesLimit(batchSize),
+			WithMemoriesOffset(opts.MemoriesOffset+totalFetched),
+		))
+		if err != nil {
+			return nil, err
+		}
+
+		totalFetched += len(batch)
+
+		if len(batch) == 0 {
+			break
+		}
+
+		resources := make([]entity.Resource, len(batch))
+		for i, m := range batch {
+			resources[i] = entity.NewMediaResource(m.ID)
+		}
+
+		permissions, err := s.authzSrv.GetPermissions(ctx, "", user, resources)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range batch {
+			if slices.Contains(permissions[entity.NewMediaResource(m.ID)], entity.ViewPermission) {
+				allowed = append(allowed, m)
+			}
+			if len(allowed) >= limit {
+				break
+			}
+		}
+
+		// a short batch is the last one
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	logger.Success().
+		WithInt("returned", len(allowed)).
+		WithInt("total_fetched", totalFetched).
+		Log()
+
+	return entity.NewMemories(allowed), nil
+}
+
 // Get retrieves a specific media item by ID.
 // Requires entity.ViewPermission on the media resource.
 func (s *AuthzMediaService) Get(ctx context.Context, id string) (*entity.Media, error) {
@@ -153,7 +225,7 @@ func (s *AuthzMediaService) Get(ctx context.Context, id string) (*entity.Media,
 
 // WriteMedia creates or updates a media item.
 // Requires entity.CreatePermission on the parent album resource.
-// Creates authorization relationships: album parent and user owner.
+// Creates authorization relationships: album parent and user editor.
 func (s *AuthzMediaService) WriteMedia(ctx context.Context, media entity.Media) (*entity.Media, error) {
 	logger := s.logger.WithContext(ctx).Debug("authz_write_media").
 		WithString(MediaID, media.ID).
@@ -185,7 +257,7 @@ func (s *AuthzMediaService) WriteMedia(ctx context.Context, media entity.Media)
 		entity.NewRelationship(
 			entity.NewUserSubject(user.Username),
 			entity.NewMediaResource(media.ID),
-			entity.OwnerRelationship,
+			entity.EditorRelationship,
 		),
 	}
 
@@ -233,7 +305,8 @@ func (s *AuthzMediaService) Update(ctx context.Context, media entity.Media) (*en
 
 // Delete deletes a media item by ID.
 // Requires entity.DeletePermission on the media resource.
-// Also deletes associated authorization relationships.
+// The media is moved to the trash and keeps its authorization relationships so it can be restored,
+// they are deleted when the trash is purged.
 func (s *AuthzMediaService) Delete(ctx context.Context, id string) error {
 	logger := s.logger.WithContext(ctx).Debug("authz_delete_media").
 		WithString(MediaID, id).
@@ -257,15 +330,183 @@ func (s *AuthzMediaService) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	// If successful, delete authorization relationships
-	logger.Step("delete_authorization_relationships").Log()
-	err = s.authzSrv.DeleteRelationships(ctx, entity.NewMediaResource(id))
+	logger.Success().Log()
+	return nil
+}
+
+// SetPermission grants the subject the relationship on a media, or revokes it when granted is false.
+// Only the viewer and editor relationships can be changed.
+// Requires entity.SetPermissionsPermission on the media resource.
+func (s *AuthzMediaService) SetPermission(ctx context.Context, id string, subject entity.Subject, kind entity.RelationshipKind, granted bool) error {
+	logger := s.logger.WithContext(ctx).Debug("authz_set_media_permission").
+		WithString(MediaID, id).
+		WithString("subject", subject.ID).
+		WithString("relationship", kind.String()).
+		WithBool("granted", granted).
+		Build()
+
+	if kind != entity.ViewerRelationship && kind != entity.EditorRelationship {
+		err := NewValidationError(ctx, "authz_set_media_permission", "invalid_input")
+		err.WithContext(MediaID, id).WithContext("validation_error", "invalid_relationship")
+		return err
+	}
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_set_permissions_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewMediaResource(id), entity.SetPermissionsPermission)
 	if err != nil {
-		logger.Step("failed_to_delete_relationships").WithString("error", err.Error()).Log()
-		// Note: Media is already deleted, but relationships cleanup failed
-		// This is logged but we don't fail the operation
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, "authz_set_media_permission", entity.NewMediaResource(id), entity.SetPermissionsPermission)
+	}
+
+	// the media must exist, relationships of unknown media are not written
+	if _, err := s.mediaSrv.Get(ctx, id); err != nil {
+		return err
+	}
+
+	relationship := entity.NewRelationship(subject, entity.NewMediaResource(id), kind)
+
+	var added, removed []entity.Relationship
+	if granted {
+		added = append(added, relationship)
+	} else {
+		removed = append(removed, relationship)
+	}
+
+	if err := s.authzSrv.UpdateRelationships(ctx, removed, added); err != nil {
+		return NewDatabaseWriteError(ctx, "authz_set_media_permission", err).
+			WithMediaID(id)
+	}
+
+	logger.Success().Log()
+	return nil
+}
+
+// Move moves media into another album.
+// Requires entity.DeletePermission on every media and entity.EditPermission on the target album.
+// Media are moved one by one so the parent relationship of a media always matches its album.
+func (s *AuthzMediaService) Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_move_media").
+		WithString(AlbumID, albumID).
+		WithInt("count", len(ids)).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	if err := s.checkRelocation(ctx, "authz_move_media", user, ids, entity.DeletePermission, albumID); err != nil {
+		return nil, err
+	}
+
+	moved := make([]entity.Media, 0, len(ids))
+	for _, id := range ids {
+		media, err := s.mediaSrv.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.mediaSrv.Move(ctx, []string{id}, albumID)
+		if err != nil {
+			return nil, err
+		}
+
+		if media.Album.ID != albumID {
+			logger.Step("update_parent_relationship").WithString(MediaID, id).Log()
+			err := s.authzSrv.UpdateRelationships(ctx,
+				[]entity.Relationship{entity.NewRelationship(entity.NewAlbumSubject(media.Album.ID), entity.NewMediaResource(id), entity.ParentRelationship)},
+				[]entity.Relationship{entity.NewRelationship(entity.NewAlbumSubject(albumID), entity.NewMediaResource(id), entity.ParentRelationship)},
+			)
+			if err != nil {
+				return nil, NewDatabaseWriteError(ctx, "authz_move_media", err).WithMediaID(id)
+			}
+		}
+
+		moved = append(moved, result...)
+	}
+
+	logger.Success().Log()
+	return moved, nil
+}
+
+// Copy copies media into another album.
+// Requires entity.ViewPermission on every media and entity.EditPermission on the target album.
+// The copies get the parent and editor relationships of a new media, media have no owner. A copy is removed if its
+// relationships cannot be written.
+func (s *AuthzMediaService) Copy(ctx context.Context, ids []string, albumID string) ([]entity.Media, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_copy_media").
+		WithString(AlbumID, albumID).
+		WithInt("count", len(ids)).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	if err := s.checkRelocation(ctx, "authz_copy_media", user, ids, entity.ViewPermission, albumID); err != nil {
+		return nil, err
+	}
+
+	copies := make([]entity.Media, 0, len(ids))
+	for _, id := range ids {
+		result, err := s.mediaSrv.Copy(ctx, []string{id}, albumID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, copied := range result {
+			logger.Step("write_authorization_relationships").WithString(MediaID, copied.ID).Log()
+			err := s.authzSrv.WriteRelationships(ctx,
+				entity.NewRelationship(entity.NewAlbumSubject(albumID), entity.NewMediaResource(copied.ID), entity.ParentRelationship),
+				entity.NewRelationship(entity.NewUserSubject(user.Username), entity.NewMediaResource(copied.ID), entity.EditorRelationship),
+			)
+			if err != nil {
+				logger.Step("write_failed_removing_copy").WithString(MediaID, copied.ID).Log()
+				if delErr := s.mediaSrv.Delete(ctx, copied.ID); delErr != nil {
+					logger.Step("rollback_failed").WithString("error", delErr.Error()).Log()
+				}
+				return nil, NewDatabaseWriteError(ctx, "authz_copy_media", err).WithMediaID(copied.ID)
+			}
+			copies = append(copies, copied)
+		}
 	}
 
 	logger.Success().Log()
+	return copies, nil
+}
+
+// checkRelocation checks the permission on every source media and entity.EditPermission on the target album.
+func (s *AuthzMediaService) checkRelocation(ctx context.Context, operation string, user entity.User, ids []string, permission entity.Permission, albumID string) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString(AlbumID, albumID).
+		Build()
+
+	logger.Step("check_edit_permission_on_album").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewAlbumResource(albumID), entity.EditPermission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, entity.NewAlbumResource(albumID), entity.EditPermission)
+	}
+
+	for _, id := range ids {
+		logger.Step("check_media_permission").
+			WithString(MediaID, id).
+			WithString("permission", permission.String()).
+			Log()
+
+		hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewMediaResource(id), permission)
+		if err != nil {
+			return err
+		}
+
+		if !hasPermission {
+			return NewForbiddenAccessError(ctx, operation, entity.NewMediaResource(id), permission)
+		}
+	}
+
+	logger.Step("authorization granted").Log()
 	return nil
 }
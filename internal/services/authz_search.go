@@ -0,0 +1,133 @@
+// Package services provides authorization-wrapped search service implementations.
+// This file contains the AuthzSearchService which wraps SearchService with authorization checks.
+package services
+
+import (
+	"context"
+	"slices"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzSearchService wraps SearchService with authorization checks.
+// Only the albums and media the user has view permission on are returned.
+type AuthzSearchService struct {
+	searchSrv *SearchService
+	authzSrv  Authz
+	logger    *logger.StructuredLogger
+}
+
+// NewAuthzSearchService creates a new authorization-wrapped search service.
+func NewAuthzSearchService(authzSrv Authz, searchSrv *SearchService) *AuthzSearchService {
+	return &AuthzSearchService{
+		searchSrv: searchSrv,
+		authzSrv:  authzSrv,
+		logger:    logger.New("authz_search_service"),
+	}
+}
+
+// Search returns a page of the albums and media matching the search text which the user is allowed to view.
+// Like AuthzMediaService.List, it uses a loop-until-full approach: matches are fetched in batches,
+// filtered by permission and the loop continues until the page is full or there are no more matches.
+// Matches are resolved into albums and media only after the permission check.
+func (s *AuthzSearchService) Search(ctx context.Context, opts *SearchOptions) ([]entity.SearchResult, *SearchCursor, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_search").
+		WithString(SearchText, opts.SearchText).
+		WithInt("requested_limit", opts.SearchLimit).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	requestedLimit := opts.SearchLimit
+	if requestedLimit <= 0 {
+		requestedLimit = maxPageSize
+	}
+
+	// Use a larger batch size to reduce round trips
+	batchSize := requestedLimit * 2
+	filter := NewSearchOptionsWithOptions(opts.ToOption(), WithSearchLimit(batchSize))
+
+	var results []entity.SearchResult
+	var lastCursor *SearchCursor
+	totalFetched := 0
+	exhausted := false
+
+	for len(results) < requestedLimit {
+		logger.Step("fetch_batch").
+			WithInt("batch_size", batchSize).
+			WithInt("results_so_far", len(results)).
+			Log()
+
+		batch, err := s.searchSrv.match(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		totalFetched += len(batch)
+
+		if len(batch) == 0 {
+			exhausted = true
+			break
+		}
+
+		resources := make([]entity.Resource, len(batch))
+		for i, result := range batch {
+			resources[i] = searchResultResource(result)
+		}
+
+		permissions, err := s.authzSrv.GetPermissions(ctx, "", user, resources)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		consumed := 0
+		for _, result := range batch {
+			consumed++
+			perms, hasPerms := permissions[searchResultResource(result)]
+			if hasPerms && slices.Contains(perms, entity.ViewPermission) {
+				results = append(results, result)
+			}
+
+			// the cursor advances over the matches the user cannot view as well
+			lastCursor = newSearchCursor(result)
+
+			if len(results) >= requestedLimit {
+				break
+			}
+		}
+
+		if len(batch) < batchSize {
+			// the last batch is fully consumed only if the loop did not stop in the middle of it
+			exhausted = consumed == len(batch)
+			break
+		}
+		filter.SearchCursor = lastCursor
+	}
+
+	var responseCursor *SearchCursor
+	if !exhausted && lastCursor != nil {
+		responseCursor = lastCursor
+	}
+
+	results, err := s.searchSrv.resolve(ctx, results)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.Success().
+		WithInt("returned", len(results)).
+		WithInt("total_fetched", totalFetched).
+		WithBool("has_next", responseCursor != nil).
+		Log()
+
+	return results, responseCursor, nil
+}
+
+func searchResultResource(result entity.SearchResult) entity.Resource {
+	if result.Kind == entity.AlbumSearchResult {
+		return entity.NewAlbumResource(result.ID)
+	}
+	return entity.NewMediaResource(result.ID)
+}
@@ -0,0 +1,189 @@
+// Package services provides authorization-wrapped smart album service implementations.
+// This file contains the AuthzSmartAlbumService which wraps SmartAlbumService with authorization checks.
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzSmartAlbumService wraps SmartAlbumService with authorization checks.
+// Operations check permissions on smart album resources using the Authz service.
+// The media of a smart album are listed through AuthzMediaService, so a user only
+// sees the media they are allowed to view even if the filter matches more.
+type AuthzSmartAlbumService struct {
+	smartAlbumSrv *SmartAlbumService
+	mediaSrv      *AuthzMediaService
+	authzSrv      Authz
+	logger        *logger.StructuredLogger
+}
+
+// NewAuthzSmartAlbumService creates a new authorization-wrapped smart album service.
+func NewAuthzSmartAlbumService(authzSrv Authz, smartAlbumSrv *SmartAlbumService, mediaSrv *AuthzMediaService) *AuthzSmartAlbumService {
+	return &AuthzSmartAlbumService{
+		smartAlbumSrv: smartAlbumSrv,
+		mediaSrv:      mediaSrv,
+		authzSrv:      authzSrv,
+		logger:        logger.New("authz_smart_album_service"),
+	}
+}
+
+// List returns the smart albums that the authenticated user has view permission on.
+func (s *AuthzSmartAlbumService) List(ctx context.Context, opts *SmartAlbumOptions) ([]entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_list_smart_albums").Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("list_allowed_resources").Log()
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.SmartAlbumResource)
+	if err != nil {
+		return nil, NewInternalError(ctx, "authz_list_smart_albums", "list_allowed_resources", err)
+	}
+
+	return s.smartAlbumSrv.List(ctx, NewSmartAlbumOptionsWithOptions(opts.ToOption(), SetAllowedSmartAlbumIDs(allowedIds)))
+}
+
+// Count returns the number of smart albums that the authenticated user has view permission on.
+func (s *AuthzSmartAlbumService) Count(ctx context.Context, opts *SmartAlbumOptions) (int, error) {
+	user := user.MustFromContext(ctx)
+
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.SmartAlbumResource)
+	if err != nil {
+		return 0, NewInternalError(ctx, "authz_count_smart_albums", "list_allowed_resources", err)
+	}
+
+	return s.smartAlbumSrv.Count(ctx, NewSmartAlbumOptionsWithOptions(opts.ToOption(), SetAllowedSmartAlbumIDs(allowedIds)))
+}
+
+// Get retrieves a specific smart album by ID.
+// Requires entity.ViewPermission on the smart album resource.
+func (s *AuthzSmartAlbumService) Get(ctx context.Context, id string) (*entity.SmartAlbum, error) {
+	if err := s.checkPermission(ctx, "authz_get_smart_album", id, entity.ViewPermission); err != nil {
+		return nil, err
+	}
+	return s.smartAlbumSrv.Get(ctx, id)
+}
+
+// Create creates a new smart album.
+// Requires entity.CreatePermission on entity.LocalDatastore.
+// Creates authorization relationships: datastore and owner.
+func (s *AuthzSmartAlbumService) Create(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_create_smart_album").
+		WithString(SmartAlbumID, smartAlbum.ID).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_create_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewDatastoreResource(entity.LocalDatastore), entity.CreatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasPermission {
+		return nil, NewForbiddenAccessError(ctx, "authz_create_smart_album", entity.NewDatastoreResource(entity.LocalDatastore), entity.CreatePermission)
+	}
+
+	logger.Step("write_authorization_relationships").Log()
+	err = s.authzSrv.WriteRelationships(ctx,
+		entity.NewRelationship(
+			entity.NewDatastoreSubject(entity.LocalDatastore),
+			entity.NewSmartAlbumResource(smartAlbum.ID),
+			entity.DatastoreRelationship,
+		),
+		entity.NewRelationship(
+			entity.NewUserSubject(user.Username),
+			entity.NewSmartAlbumResource(smartAlbum.ID),
+			entity.OwnerRelationship,
+		),
+	)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "authz_create_smart_album", err).WithContext(SmartAlbumID, smartAlbum.ID)
+	}
+
+	created, err := s.smartAlbumSrv.Create(ctx, smartAlbum)
+	if err != nil {
+		logger.Step("create_failed_rolling_back_relationships").Log()
+		if delErr := s.authzSrv.DeleteRelationships(ctx, entity.NewSmartAlbumResource(smartAlbum.ID)); delErr != nil {
+			logger.Step("rollback_failed").WithString("error", delErr.Error()).Log()
+		}
+		return nil, err
+	}
+
+	logger.Success().Log()
+	return created, nil
+}
+
+// Update updates an existing smart album.
+// Requires entity.EditPermission on the smart album resource.
+func (s *AuthzSmartAlbumService) Update(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error) {
+	if err := s.checkPermission(ctx, "authz_update_smart_album", smartAlbum.ID, entity.EditPermission); err != nil {
+		return nil, err
+	}
+	return s.smartAlbumSrv.Update(ctx, smartAlbum)
+}
+
+// Delete deletes a smart album by ID.
+// Requires entity.DeletePermission on the smart album resource.
+// Also deletes associated authorization relationships.
+func (s *AuthzSmartAlbumService) Delete(ctx context.Context, id string) error {
+	logger := s.logger.WithContext(ctx).Debug("authz_delete_smart_album").
+		WithString(SmartAlbumID, id).
+		Build()
+
+	if err := s.checkPermission(ctx, "authz_delete_smart_album", id, entity.DeletePermission); err != nil {
+		return err
+	}
+
+	if err := s.smartAlbumSrv.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	logger.Step("delete_authorization_relationships").Log()
+	if err := s.authzSrv.DeleteRelationships(ctx, entity.NewSmartAlbumResource(id)); err != nil {
+		// Smart album is already deleted, the failure is logged but the operation does not fail
+		logger.Step("failed_to_delete_relationships").WithString("error", err.Error()).Log()
+	}
+
+	logger.Success().Log()
+	return nil
+}
+
+// ListMedia returns a page of the media matched by the smart album which the user is allowed to view.
+// Requires entity.ViewPermission on the smart album resource.
+func (s *AuthzSmartAlbumService) ListMedia(ctx context.Context, id string, opts *MediaOptions) ([]entity.Media, *PaginationCursor, error) {
+	if err := s.checkPermission(ctx, "authz_list_smart_album_media", id, entity.ViewPermission); err != nil {
+		return nil, nil, err
+	}
+
+	smartAlbum, err := s.smartAlbumSrv.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.mediaSrv.List(ctx, withSmartAlbumFilter(opts, smartAlbum.Filter))
+}
+
+func (s *AuthzSmartAlbumService) checkPermission(ctx context.Context, operation, id string, permission entity.Permission) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString(SmartAlbumID, id).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_permission").WithString("permission", permission.String()).Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, entity.NewSmartAlbumResource(id), permission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, entity.NewSmartAlbumResource(id), permission)
+	}
+
+	logger.Step("authorization granted").Log()
+	return nil
+}
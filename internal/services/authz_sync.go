@@ -0,0 +1,271 @@
+// Package services provides authorization-wrapped sync service implementations.
+// This file contains the AuthzSyncService which wraps SyncService with authorization checks.
+package services
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzSyncService wraps SyncService with authorization checks.
+// Syncing a folder requires entity.SyncPermission on the album of the folder. A folder which has no album yet is
+// checked on the closest album above it, and the whole data folder on entity.LocalDatastore.
+// A job is checked on the folder it syncs, the jobs without a folder, like bulk jobs, on entity.LocalDatastore.
+// Every operation is refused when no user is authenticated.
+type AuthzSyncService struct {
+	syncSrv  *SyncService
+	authzSrv Authz
+	logger   *logger.StructuredLogger
+}
+
+// NewAuthzSyncService creates a new authorization-wrapped sync service.
+func NewAuthzSyncService(authzSrv Authz, syncSrv *SyncService) *AuthzSyncService {
+	return &AuthzSyncService{
+		syncSrv:  syncSrv,
+		authzSrv: authzSrv,
+		logger:   logger.New("authz_sync_service"),
+	}
+}
+
+// StartSync starts a sync of the folder at albumPath.
+// Requires entity.SyncPermission on the folder.
+func (s *AuthzSyncService) StartSync(ctx context.Context, albumPath string, opts *SyncOptions) (string, error) {
+	if err := s.checkFolderPermission(ctx, "authz_start_sync", albumPath); err != nil {
+		return "", err
+	}
+	return s.syncSrv.StartSync(ctx, albumPath, opts)
+}
+
+// DryRun reports what a sync of the folder at albumPath would do.
+// Requires entity.SyncPermission on the folder.
+func (s *AuthzSyncService) DryRun(ctx context.Context, albumPath string, opts *SyncOptions) (*entity.SyncReport, error) {
+	if err := s.checkFolderPermission(ctx, "authz_dry_run_sync", albumPath); err != nil {
+		return nil, err
+	}
+	return s.syncSrv.DryRun(ctx, albumPath, opts)
+}
+
+// GetJobStatus returns the status of a job.
+// Requires entity.SyncPermission on the folder of the job.
+func (s *AuthzSyncService) GetJobStatus(ctx context.Context, jobID string) (*entity.JobProgress, error) {
+	return s.checkJobPermission(ctx, "authz_get_sync_job_status", jobID)
+}
+
+// ListJobStatuses returns the jobs on whose folder the user has entity.SyncPermission.
+func (s *AuthzSyncService) ListJobStatuses(ctx context.Context, limit, offset int) ([]entity.JobProgress, error) {
+	return s.listJobs(ctx, s.syncSrv.scheduler.GetAll(), limit, offset)
+}
+
+// ListJobStatusesByStatus returns the jobs with the status on whose folder the user has entity.SyncPermission.
+func (s *AuthzSyncService) ListJobStatusesByStatus(ctx context.Context, status entity.JobStatus, limit, offset int) ([]entity.JobProgress, error) {
+	return s.listJobs(ctx, s.syncSrv.scheduler.GetByStatus(status), limit, offset, status)
+}
+
+// CountJobs returns the number of jobs of every user having one of the statuses.
+// Requires entity.SyncPermission on entity.LocalDatastore.
+func (s *AuthzSyncService) CountJobs(ctx context.Context, statuses ...entity.JobStatus) (int, error) {
+	if err := s.checkPermission(ctx, "authz_count_sync_jobs", entity.NewDatastoreResource(entity.LocalDatastore)); err != nil {
+		return 0, err
+	}
+	return s.syncSrv.CountJobs(ctx, statuses...)
+}
+
+// StopJob stops a job.
+// Requires entity.SyncPermission on the folder of the job.
+func (s *AuthzSyncService) StopJob(ctx context.Context, jobID string) error {
+	if _, err := s.checkJobPermission(ctx, "authz_stop_sync_job", jobID); err != nil {
+		return err
+	}
+	return s.syncSrv.StopJob(ctx, jobID)
+}
+
+// PauseJob pauses or resumes a job.
+// Requires entity.SyncPermission on the folder of the job.
+func (s *AuthzSyncService) PauseJob(ctx context.Context, jobID string) error {
+	if _, err := s.checkJobPermission(ctx, "authz_pause_sync_job", jobID); err != nil {
+		return err
+	}
+	return s.syncSrv.PauseJob(ctx, jobID)
+}
+
+// StopAllJobs stops the jobs of every user.
+// Requires entity.SyncPermission on entity.LocalDatastore.
+func (s *AuthzSyncService) StopAllJobs(ctx context.Context) error {
+	if err := s.checkPermission(ctx, "authz_stop_all_sync_jobs", entity.NewDatastoreResource(entity.LocalDatastore)); err != nil {
+		return err
+	}
+	return s.syncSrv.StopAllJobs(ctx)
+}
+
+// ClearFinishedJobs removes the finished jobs of every user and the job history.
+// Requires entity.SyncPermission on entity.LocalDatastore.
+func (s *AuthzSyncService) ClearFinishedJobs(ctx context.Context) error {
+	if err := s.checkPermission(ctx, "authz_clear_finished_sync_jobs", entity.NewDatastoreResource(entity.LocalDatastore)); err != nil {
+		return err
+	}
+	return s.syncSrv.ClearFinishedJobs(ctx)
+}
+
+// IsAlbumSyncing checks if there's an active sync job for the album. It tells nothing about the job
+// so no permission is checked.
+func (s *AuthzSyncService) IsAlbumSyncing(albumID string) bool {
+	return s.syncSrv.IsAlbumSyncing(albumID)
+}
+
+// WatchJobs returns the events of a job, or of every job if jobID is empty.
+// Requires entity.SyncPermission on the folder of the job, or on entity.LocalDatastore to watch every job.
+func (s *AuthzSyncService) WatchJobs(ctx context.Context, jobID string) (<-chan entity.JobEvent, error) {
+	if jobID == "" {
+		if err := s.checkPermission(ctx, "authz_watch_sync_jobs", entity.NewDatastoreResource(entity.LocalDatastore)); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.checkJobPermission(ctx, "authz_watch_sync_jobs", jobID); err != nil {
+		return nil, err
+	}
+	return s.syncSrv.WatchJobs(ctx, jobID)
+}
+
+// checkJobPermission returns the status of the job if the user has entity.SyncPermission on its folder
+func (s *AuthzSyncService) checkJobPermission(ctx context.Context, operation, jobID string) (*entity.JobProgress, error) {
+	job, err := s.syncSrv.GetJobStatus(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFolderPermission(ctx, operation, job.Path); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *AuthzSyncService) checkFolderPermission(ctx context.Context, operation, albumPath string) error {
+	resource, err := s.folderResource(ctx, albumPath)
+	if err != nil {
+		return err
+	}
+	return s.checkPermission(ctx, operation, resource)
+}
+
+func (s *AuthzSyncService) checkPermission(ctx context.Context, operation string, resource entity.Resource) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString("resource_kind", resource.Kind.String()).
+		WithString("resource_id", resource.ID).
+		Build()
+
+	user := user.FromContext(ctx)
+	if user == nil {
+		return NewUnauthenticatedError(ctx, operation)
+	}
+
+	logger.Step("check_sync_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, *user, resource, entity.SyncPermission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, resource, entity.SyncPermission)
+	}
+
+	logger.Step("authorization granted").Log()
+	return nil
+}
+
+// listJobs returns the sync jobs of the scheduler and a page of the jobs of the history having one of the
+// statuses, on whose folder the user has entity.SyncPermission. The history is filtered before it is paged,
+// its pages are read until limit jobs are allowed or the history has no more jobs.
+func (s *AuthzSyncService) listJobs(ctx context.Context, jobs []Job, limit, offset int, statuses ...entity.JobStatus) ([]entity.JobProgress, error) {
+	user := user.FromContext(ctx)
+	if user == nil {
+		return nil, NewUnauthenticatedError(ctx, "authz_list_sync_jobs")
+	}
+
+	// the jobs of a tree share their folders, each folder is checked once
+	allowedFolders := make(map[string]bool)
+	isAllowed := func(folder string) (bool, error) {
+		if allowed, found := allowedFolders[folder]; found {
+			return allowed, nil
+		}
+		resource, err := s.folderResource(ctx, folder)
+		if err != nil {
+			return false, err
+		}
+		allowed, err := s.authzSrv.HasPermission(ctx, *user, resource, entity.SyncPermission)
+		if err != nil {
+			return false, err
+		}
+		allowedFolders[folder] = allowed
+		return allowed, nil
+	}
+
+	allowedJobs := []Job{}
+	for _, job := range syncJobs(jobs) {
+		allowed, err := isAllowed(job.Status().Path)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			allowedJobs = append(allowedJobs, job)
+		}
+	}
+
+	history := []entity.JobProgress{}
+	skipped := 0
+	for pageOffset := 0; ; pageOffset += limit {
+		page, err := s.syncSrv.scheduler.GetHistory(ctx, limit, pageOffset, otherJobTypes, statuses...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, job := range page {
+			allowed, err := isAllowed(job.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				continue
+			}
+			if skipped < offset {
+				skipped++
+				continue
+			}
+			history = append(history, job)
+			if len(history) == limit {
+				return withJobHistory(allowedJobs, history), nil
+			}
+		}
+
+		// without limit the whole history is read at once
+		if limit <= 0 || len(page) < limit {
+			return withJobHistory(allowedJobs, history), nil
+		}
+	}
+}
+
+// folderResource returns the album of the folder at albumPath, or the album of the closest folder above it
+// which has one, or entity.LocalDatastore if none has.
+func (s *AuthzSyncService) folderResource(ctx context.Context, albumPath string) (entity.Resource, error) {
+	albumPath = strings.TrimSuffix(albumPath, "/")
+	for albumPath != "" && albumPath != "." && albumPath != "/" {
+		album, err := s.syncSrv.albumService.GetByPath(ctx, albumPath)
+		if err == nil {
+			return entity.NewAlbumResource(album.ID), nil
+		}
+
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) {
+			return entity.Resource{}, err
+		}
+
+		albumPath = path.Dir(albumPath)
+	}
+
+	return entity.NewDatastoreResource(entity.LocalDatastore), nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"container/list"
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeAuthz grants the permissions of allowed, by user id, on every resource
+type fakeAuthz struct {
+	allowed map[string][]entity.Permission
+	checked []entity.Permission
+}
+
+func (f *fakeAuthz) WriteRelationships(ctx context.Context, relationships ...entity.Relationship) error {
+	return nil
+}
+
+func (f *fakeAuthz) HasPermission(ctx context.Context, u entity.User, resource entity.Resource, permission entity.Permission) (bool, error) {
+	f.checked = append(f.checked, permission)
+	for _, p := range f.allowed[u.ID] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeAuthz) GetPermissions(ctx context.Context, zedToken string, u entity.User, resources []entity.Resource) (map[entity.Resource][]entity.Permission, error) {
+	permissions := make(map[entity.Resource][]entity.Permission)
+	for _, resource := range resources {
+		permissions[resource] = f.allowed[u.ID]
+	}
+	return permissions, nil
+}
+
+func (f *fakeAuthz) ListResources(ctx context.Context, zedToken string, u entity.User, permission entity.Permission, resourceKind entity.ResourceKind) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeAuthz) DeleteRelationships(ctx context.Context, resource entity.Resource) error {
+	return nil
+}
+
+func (f *fakeAuthz) UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error {
+	return nil
+}
+
+var _ = Describe("AuthzSyncService", func() {
+	var (
+		authz   *fakeAuthz
+		syncSrv *AuthzSyncService
+		job     Job
+	)
+
+	alice := &entity.User{ID: "alice", Username: "alice"}
+	bob := &entity.User{ID: "bob", Username: "bob"}
+
+	BeforeEach(func() {
+		// a scheduler without background worker, its jobs stay pending
+		s := &Scheduler{
+			queue:      list.New(),
+			maxRunning: defaultMaxRunningJobs,
+			logger:     logger.New("scheduler"),
+			infoLogger: logger.New("scheduler"),
+		}
+
+		var err error
+		// the job of the whole data folder is checked on the datastore
+		job, err = NewSyncJob(entity.NewLinkedList[Task[string]](), map[string]string{"path": "", "type": syncJob})
+		Expect(err).To(BeNil())
+		Expect(s.Add(job)).To(Succeed())
+
+		authz = &fakeAuthz{allowed: map[string][]entity.Permission{
+			alice.ID: {entity.SyncPermission},
+			// every permission but sync
+			bob.ID: entity.AllPermissions,
+		}}
+
+		inner := NewSyncService(nil, nil, nil, authz)
+		inner.scheduler = s
+		syncSrv = NewAuthzSyncService(authz, inner)
+	})
+
+	Context("with a user having the sync permission", func() {
+		ctx := user.ToContext(context.Background(), alice)
+
+		It("returns the jobs", func() {
+			jobs, err := syncSrv.ListJobStatuses(ctx, 10, 0)
+			Expect(err).To(BeNil())
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].Id).To(Equal(job.GetID()))
+
+			jobs, err = syncSrv.ListJobStatusesByStatus(ctx, entity.StatusPending, 10, 0)
+			Expect(err).To(BeNil())
+			Expect(jobs).To(HaveLen(1))
+
+			status, err := syncSrv.GetJobStatus(ctx, job.GetID().String())
+			Expect(err).To(BeNil())
+			Expect(status.Id).To(Equal(job.GetID()))
+
+			count, err := syncSrv.CountJobs(ctx, entity.StatusPending)
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(1))
+
+			Expect(authz.checked).To(HaveEach(entity.SyncPermission))
+		})
+
+		It("stops the jobs", func() {
+			Expect(syncSrv.StopJob(ctx, job.GetID().String())).To(Succeed())
+			Expect(syncSrv.StopAllJobs(ctx)).To(Succeed())
+			Expect(syncSrv.ClearFinishedJobs(ctx)).To(Succeed())
+		})
+	})
+
+	Context("with a user without the sync permission", func() {
+		ctx := user.ToContext(context.Background(), bob)
+
+		It("leaves the jobs out of the listings", func() {
+			jobs, err := syncSrv.ListJobStatuses(ctx, 10, 0)
+			Expect(err).To(BeNil())
+			Expect(jobs).To(BeEmpty())
+
+			jobs, err = syncSrv.ListJobStatusesByStatus(ctx, entity.StatusPending, 10, 0)
+			Expect(err).To(BeNil())
+			Expect(jobs).To(BeEmpty())
+		})
+
+		It("refuses every other operation", func() {
+			var forbidden *ForbiddenAccessError
+
+			_, err := syncSrv.StartSync(ctx, "", nil)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = syncSrv.DryRun(ctx, "", nil)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = syncSrv.GetJobStatus(ctx, job.GetID().String())
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = syncSrv.CountJobs(ctx, entity.StatusPending)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = syncSrv.WatchJobs(ctx, "")
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			Expect(syncSrv.StopJob(ctx, job.GetID().String())).To(BeAssignableToTypeOf(forbidden))
+			Expect(syncSrv.PauseJob(ctx, job.GetID().String())).To(BeAssignableToTypeOf(forbidden))
+			Expect(syncSrv.StopAllJobs(ctx)).To(BeAssignableToTypeOf(forbidden))
+			Expect(syncSrv.ClearFinishedJobs(ctx)).To(BeAssignableToTypeOf(forbidden))
+
+			Expect(job.Status().Status).To(Equal(entity.StatusPending))
+			Expect(authz.checked).To(HaveEach(entity.SyncPermission))
+		})
+	})
+
+	It("refuses every operation without user", func() {
+		var forbidden *ForbiddenAccessError
+
+		_, err := syncSrv.ListJobStatuses(context.Background(), 10, 0)
+		Expect(err).To(BeAssignableToTypeOf(forbidden))
+		_, err = syncSrv.StartSync(context.Background(), "", nil)
+		Expect(err).To(BeAssignableToTypeOf(forbidden))
+		Expect(authz.checked).To(BeEmpty())
+	})
+})
@@ -0,0 +1,65 @@
+// Package services provides authorization-wrapped timeline service implementations.
+// This file contains the AuthzTimelineService which wraps TimelineService with authorization checks.
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzTimelineService wraps TimelineService with authorization checks.
+// Only the media of the albums the user has view permission on are counted.
+// Media shared with the user without their album are not part of the timeline.
+type AuthzTimelineService struct {
+	timelineSrv *TimelineService
+	authzSrv    Authz
+	logger      *logger.StructuredLogger
+}
+
+// NewAuthzTimelineService creates a new authorization-wrapped timeline service.
+func NewAuthzTimelineService(authzSrv Authz, timelineSrv *TimelineService) *AuthzTimelineService {
+	return &AuthzTimelineService{
+		timelineSrv: timelineSrv,
+		authzSrv:    authzSrv,
+		logger:      logger.New("authz_timeline_service"),
+	}
+}
+
+// Timeline returns the media counts of the albums the user can view.
+// If the timeline is restricted to an album tree, entity.ViewPermission is required on its root album.
+func (s *AuthzTimelineService) Timeline(ctx context.Context, opts *TimelineOptions) (entity.Buckets, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_timeline").Build()
+
+	user := user.MustFromContext(ctx)
+
+	if opts.TimelineAlbumTreeID != nil {
+		logger.Step("check_view_permission").WithString(AlbumID, *opts.TimelineAlbumTreeID).Log()
+
+		resource := entity.NewAlbumResource(*opts.TimelineAlbumTreeID)
+		hasPermission, err := s.authzSrv.HasPermission(ctx, user, resource, entity.ViewPermission)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasPermission {
+			return nil, NewForbiddenAccessError(ctx, "authz_timeline", resource, entity.ViewPermission)
+		}
+	}
+
+	logger.Step("list_allowed_resources").Log()
+
+	allowedIds, err := s.authzSrv.ListResources(ctx, "", user, entity.ViewPermission, entity.AlbumResource)
+	if err != nil {
+		return nil, NewInternalError(ctx, "authz_timeline", "list_allowed_resources", err)
+	}
+
+	// nil would lift the restriction
+	if allowedIds == nil {
+		allowedIds = []string{}
+	}
+
+	return s.timelineSrv.Timeline(ctx, NewTimelineOptionsWithOptions(opts.ToOption(), SetAllowedTimelineAlbumIDs(allowedIds)))
+}
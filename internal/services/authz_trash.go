@@ -0,0 +1,151 @@
+// Package services provides authorization-wrapped trash service implementations.
+// This file contains the AuthzTrashService which wraps TrashService with authorization checks.
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// AuthzTrashService wraps TrashService with authorization checks.
+// The relationships of trashed albums and media are kept until they are purged, so the permissions
+// on an item in the trash are the permissions it had when it was deleted.
+// Restoring an item requires the entity.DeletePermission which was needed to delete it,
+// and the trash only lists the items the user can restore.
+type AuthzTrashService struct {
+	trashSrv *TrashService
+	authzSrv Authz
+	logger   *logger.StructuredLogger
+}
+
+// NewAuthzTrashService creates a new authorization-wrapped trash service.
+func NewAuthzTrashService(authzSrv Authz, trashSrv *TrashService) *AuthzTrashService {
+	return &AuthzTrashService{
+		trashSrv: trashSrv,
+		authzSrv: authzSrv,
+		logger:   logger.New("authz_trash_service"),
+	}
+}
+
+// ListAlbums returns the albums in the trash the user has entity.DeletePermission on.
+func (s *AuthzTrashService) ListAlbums(ctx context.Context) ([]entity.Album, error) {
+	albums, err := s.trashSrv.ListAlbums(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterByPermission(ctx, s.authzSrv, albums, func(a entity.Album) entity.Resource {
+		return entity.NewAlbumResource(a.ID)
+	})
+}
+
+// ListMedia returns the media in the trash the user has entity.DeletePermission on.
+func (s *AuthzTrashService) ListMedia(ctx context.Context) ([]entity.Media, error) {
+	media, err := s.trashSrv.ListMedia(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterByPermission(ctx, s.authzSrv, media, func(m entity.Media) entity.Resource {
+		return entity.NewMediaResource(m.ID)
+	})
+}
+
+// RestoreAlbum takes an album out of the trash.
+// Requires entity.DeletePermission on the album resource.
+func (s *AuthzTrashService) RestoreAlbum(ctx context.Context, id string) (*entity.Album, error) {
+	if err := s.checkPermission(ctx, "authz_restore_album", entity.NewAlbumResource(id)); err != nil {
+		return nil, err
+	}
+	return s.trashSrv.RestoreAlbum(ctx, id)
+}
+
+// RestoreMedia takes a media out of the trash.
+// Requires entity.DeletePermission on the media resource.
+func (s *AuthzTrashService) RestoreMedia(ctx context.Context, id string) (*entity.Media, error) {
+	if err := s.checkPermission(ctx, "authz_restore_media", entity.NewMediaResource(id)); err != nil {
+		return nil, err
+	}
+	return s.trashSrv.RestoreMedia(ctx, id)
+}
+
+// Purge deletes for good the items trashed before the time and their authorization relationships.
+// It is run by the scheduler, not on behalf of a user, so no permission is checked.
+func (s *AuthzTrashService) Purge(ctx context.Context, before time.Time) ([]entity.Resource, error) {
+	logger := s.logger.WithContext(ctx).Debug("authz_purge_trash").Build()
+
+	purged, err := s.trashSrv.Purge(ctx, before)
+
+	// relationships of the deleted items are removed even if the purge stopped half way
+	for _, resource := range purged {
+		if delErr := s.authzSrv.DeleteRelationships(ctx, resource); delErr != nil {
+			logger.Step("failed_to_delete_relationships").
+				WithString("resource_kind", resource.Kind.String()).
+				WithString("resource_id", resource.ID).
+				WithString("error", delErr.Error()).
+				Log()
+		}
+	}
+
+	if err != nil {
+		return purged, err
+	}
+
+	logger.Success().WithInt("purged", len(purged)).Log()
+	return purged, nil
+}
+
+func (s *AuthzTrashService) checkPermission(ctx context.Context, operation string, resource entity.Resource) error {
+	logger := s.logger.WithContext(ctx).Debug(operation).
+		WithString("resource_kind", resource.Kind.String()).
+		WithString("resource_id", resource.ID).
+		Build()
+
+	user := user.MustFromContext(ctx)
+
+	logger.Step("check_delete_permission").Log()
+	hasPermission, err := s.authzSrv.HasPermission(ctx, user, resource, entity.DeletePermission)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return NewForbiddenAccessError(ctx, operation, resource, entity.DeletePermission)
+	}
+
+	logger.Step("authorization granted").Log()
+	return nil
+}
+
+// filterByPermission keeps the items the authenticated user has entity.DeletePermission on.
+func filterByPermission[T any](ctx context.Context, authzSrv Authz, items []T, resourceFn func(T) entity.Resource) ([]T, error) {
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	user := user.MustFromContext(ctx)
+
+	resources := make([]entity.Resource, 0, len(items))
+	for _, item := range items {
+		resources = append(resources, resourceFn(item))
+	}
+
+	permissions, err := authzSrv.GetPermissions(ctx, "", user, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make([]T, 0, len(items))
+	for _, item := range items {
+		if slices.Contains(permissions[resourceFn(item)], entity.DeletePermission) {
+			allowed = append(allowed, item)
+		}
+	}
+
+	return allowed, nil
+}
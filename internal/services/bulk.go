@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+const (
+	// bulkJob is the type of the jobs running bulk requests
+	bulkJob = "bulk"
+
+	// maxBulkItems is the maximum number of media of a bulk request
+	maxBulkItems = 1000
+)
+
+// BulkMediaService is the media service the operations of a bulk job are run with.
+// It is implemented by MediaService and AuthzMediaService. With the latter, authorization
+// is checked for every media and a forbidden media fails alone.
+type BulkMediaService interface {
+	Get(ctx context.Context, id string) (*entity.Media, error)
+	Update(ctx context.Context, media entity.Media) (*entity.Media, error)
+	Delete(ctx context.Context, id string) error
+	Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error)
+}
+
+// MediaPermissionService changes who can access a media. It is implemented by AuthzMediaService.
+type MediaPermissionService interface {
+	SetPermission(ctx context.Context, id string, subject entity.Subject, kind entity.RelationshipKind, granted bool) error
+}
+
+// BulkService runs an operation over a list of media as a scheduler job.
+// Each media is processed by its own task so the job reports the result of every media
+// and a failure does not stop the others.
+type BulkService struct {
+	mediaSrv      BulkMediaService
+	permissionSrv MediaPermissionService
+	scheduler     *Scheduler
+	logger        *logger.StructuredLogger
+}
+
+// NewBulkService creates a new bulk service.
+// permissionSrv is nil when authorization is disabled, permission changes are rejected then.
+func NewBulkService(mediaSrv BulkMediaService, permissionSrv MediaPermissionService) *BulkService {
+	return &BulkService{
+		mediaSrv:      mediaSrv,
+		permissionSrv: permissionSrv,
+		scheduler:     GetScheduler(),
+		logger:        logger.New("bulk_service"),
+	}
+}
+
+// Start validates the request and schedules a job applying the operation to every media.
+// It returns the job which is pending until the scheduler starts it.
+func (b *BulkService) Start(ctx context.Context, req entity.BulkRequest) (*entity.BulkJob, error) {
+	logger := b.logger.WithContext(ctx).Debug("start_bulk").
+		WithString("operation", string(req.Operation)).
+		WithInt("count", len(req.MediaIDs)).
+		Build()
+
+	req, err := b.validate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the job is run by the scheduler without the request context, the user is passed to every task
+	u := user.FromContext(ctx)
+
+	tasks := entity.NewLinkedList[Task[string]]()
+	for _, id := range req.MediaIDs {
+		tasks.PushBack(b.task(u, req, id))
+	}
+
+	opts := map[string]string{
+		"type":      bulkJob,
+		"operation": string(req.Operation),
+	}
+	if u != nil {
+		opts["user"] = u.ID
+	}
+
+	job, err := NewSyncJob(tasks, opts)
+	if err != nil {
+		return nil, NewInternalError(ctx, "start_bulk", "create_job", err)
+	}
+
+	logger.Step("schedule_job").WithString(JobID, job.GetID().String()).Log()
+	if err := b.scheduler.Add(job); err != nil {
+		return nil, NewSyncJobError(ctx, "start_bulk", job.GetID().String(), err)
+	}
+
+	logger.Success().WithString(JobID, job.GetID().String()).Log()
+	return newBulkJob(job), nil
+}
+
+// GetJob returns the progress of a bulk job.
+// A job started by another user is not found.
+func (b *BulkService) GetJob(ctx context.Context, id string) (*entity.BulkJob, error) {
+	if id == "" {
+		err := NewValidationError(ctx, "get_bulk_job", "invalid_input")
+		err.WithContext("validation_error", "empty_job_id")
+		return nil, err
+	}
+
+	job := b.scheduler.Get(id)
+	if job == nil || job.Metadata()["type"] != bulkJob {
+		err := NewNotFoundError(ctx, "get_bulk_job", "job_not_found")
+		err.WithContext(JobID, id)
+		return nil, err
+	}
+
+	if u := user.FromContext(ctx); u != nil && job.Metadata()["user"] != u.ID {
+		err := NewNotFoundError(ctx, "get_bulk_job", "job_not_found")
+		err.WithContext(JobID, id)
+		return nil, err
+	}
+
+	return newBulkJob(job), nil
+}
+
+func (b *BulkService) validate(ctx context.Context, req entity.BulkRequest) (entity.BulkRequest, error) {
+	invalid := func(reason string) error {
+		err := NewValidationError(ctx, "start_bulk", "invalid_input")
+		err.WithContext("operation", string(req.Operation)).WithContext("validation_error", reason)
+		return err
+	}
+
+	// duplicated ids would be processed twice
+	ids := make([]string, 0, len(req.MediaIDs))
+	for _, id := range req.MediaIDs {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	req.MediaIDs = ids
+
+	if len(req.MediaIDs) == 0 {
+		return req, invalid("empty_media_ids")
+	}
+	if len(req.MediaIDs) > maxBulkItems {
+		return req, invalid("too_many_media")
+	}
+
+	switch req.Operation {
+	case entity.BulkDelete, entity.BulkFavorite:
+	case entity.BulkMove:
+		if req.AlbumID == "" {
+			return req, invalid("empty_album_id")
+		}
+	case entity.BulkTag:
+		req.AddTags = entity.NormalizeTags(req.AddTags)
+		req.RemoveTags = entity.NormalizeTags(req.RemoveTags)
+		if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+			return req, invalid("empty_tags")
+		}
+	case entity.BulkDateShift:
+		if req.Shift == 0 {
+			return req, invalid("empty_shift")
+		}
+	case entity.BulkPermission:
+		if b.permissionSrv == nil {
+			return req, invalid("authorization_disabled")
+		}
+		if req.Subject.ID == "" {
+			return req, invalid("empty_subject")
+		}
+		if req.Relationship != entity.ViewerRelationship && req.Relationship != entity.EditorRelationship {
+			return req, invalid("invalid_relationship")
+		}
+	default:
+		return req, invalid("unknown_operation")
+	}
+
+	return req, nil
+}
+
+// task returns the task applying the operation to a media. The result holds the id of the media.
+// The tasks of a bulk operation are independent, they run in parallel.
+func (b *BulkService) task(u *entity.User, req entity.BulkRequest, id string) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		if u != nil {
+			ctx = user.ToContext(ctx, u)
+		}
+		return entity.Result[string]{Data: id, Err: b.apply(ctx, req, id)}
+	})
+}
+
+func (b *BulkService) apply(ctx context.Context, req entity.BulkRequest, id string) error {
+	switch req.Operation {
+	case entity.BulkDelete:
+		return b.mediaSrv.Delete(ctx, id)
+	case entity.BulkMove:
+		_, err := b.mediaSrv.Move(ctx, []string{id}, req.AlbumID)
+		return err
+	case entity.BulkPermission:
+		return b.permissionSrv.SetPermission(ctx, id, req.Subject, req.Relationship, req.Grant)
+	}
+
+	// the other operations update the metadata of the media
+	media, err := b.mediaSrv.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	switch req.Operation {
+	case entity.BulkTag:
+		tags := append(slices.Clone(media.Tags), req.AddTags...)
+		tags = slices.DeleteFunc(tags, func(tag string) bool {
+			return slices.Contains(req.RemoveTags, tag)
+		})
+		media.Tags = entity.NormalizeTags(tags)
+	case entity.BulkFavorite:
+		media.Favorite = req.Favorite
+	case entity.BulkDateShift:
+		media.CapturedAt = media.CapturedAt.Add(req.Shift)
+	}
+
+	_, err = b.mediaSrv.Update(ctx, *media)
+	return err
+}
+
+func newBulkJob(job Job) *entity.BulkJob {
+	progress := job.Status()
+
+	items := make([]entity.BulkItemResult, 0, len(progress.Results))
+	for _, result := range progress.Results {
+		items = append(items, entity.BulkItemResult{MediaID: result.Result, Err: result.Err})
+	}
+
+	return &entity.BulkJob{
+		JobProgress: progress,
+		Operation:   entity.BulkOperation(job.Metadata()["operation"]),
+		Items:       items,
+	}
+}
@@ -0,0 +1,416 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// memoryMedia keeps the media of the bulk jobs in memory
+type memoryMedia struct {
+	m     sync.Mutex
+	media map[string]entity.Media
+}
+
+func newMemoryMedia(media ...entity.Media) *memoryMedia {
+	mm := &memoryMedia{media: make(map[string]entity.Media)}
+	for _, m := range media {
+		mm.media[m.ID] = m
+	}
+	return mm
+}
+
+// count returns the number of media kept
+func (mm *memoryMedia) count() int {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+	return len(mm.media)
+}
+
+// get returns the media with the id as it is kept
+func (mm *memoryMedia) get(id string) entity.Media {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+	return mm.media[id]
+}
+
+func (mm *memoryMedia) Get(ctx context.Context, id string) (*entity.Media, error) {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+
+	media, found := mm.media[id]
+	if !found {
+		return nil, services.NewNotFoundError(ctx, "get_media", "media_not_found")
+	}
+	return &media, nil
+}
+
+func (mm *memoryMedia) Update(ctx context.Context, media entity.Media) (*entity.Media, error) {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+
+	if _, found := mm.media[media.ID]; !found {
+		return nil, services.NewNotFoundError(ctx, "update_media", "media_not_found")
+	}
+	mm.media[media.ID] = media
+	return &media, nil
+}
+
+func (mm *memoryMedia) Delete(ctx context.Context, id string) error {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+
+	if _, found := mm.media[id]; !found {
+		return services.NewNotFoundError(ctx, "delete_media", "media_not_found")
+	}
+	delete(mm.media, id)
+	return nil
+}
+
+func (mm *memoryMedia) Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error) {
+	mm.m.Lock()
+	defer mm.m.Unlock()
+
+	moved := []entity.Media{}
+	for _, id := range ids {
+		media, found := mm.media[id]
+		if !found {
+			return nil, services.NewNotFoundError(ctx, "move_media", "media_not_found")
+		}
+		media.Album = entity.Album{ID: albumID}
+		mm.media[id] = media
+		moved = append(moved, media)
+	}
+	return moved, nil
+}
+
+// relationshipsAuthz grants the permissions of allowed on every media and keeps the relationships written
+type relationshipsAuthz struct {
+	m             sync.Mutex
+	allowed       []entity.Permission
+	relationships []entity.Relationship
+}
+
+// written returns the relationships kept
+func (r *relationshipsAuthz) written() []entity.Relationship {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return slices.Clone(r.relationships)
+}
+
+func (r *relationshipsAuthz) WriteRelationships(ctx context.Context, relationships ...entity.Relationship) error {
+	return r.UpdateRelationships(ctx, nil, relationships)
+}
+
+func (r *relationshipsAuthz) HasPermission(ctx context.Context, u entity.User, resource entity.Resource, permission entity.Permission) (bool, error) {
+	return slices.Contains(r.allowed, permission), nil
+}
+
+func (r *relationshipsAuthz) GetPermissions(ctx context.Context, zedToken string, u entity.User, resources []entity.Resource) (map[entity.Resource][]entity.Permission, error) {
+	permissions := make(map[entity.Resource][]entity.Permission)
+	for _, resource := range resources {
+		permissions[resource] = r.allowed
+	}
+	return permissions, nil
+}
+
+func (r *relationshipsAuthz) ListResources(ctx context.Context, zedToken string, u entity.User, permission entity.Permission, resourceKind entity.ResourceKind) ([]string, error) {
+	return nil, nil
+}
+
+func (r *relationshipsAuthz) DeleteRelationships(ctx context.Context, resource entity.Resource) error {
+	return nil
+}
+
+func (r *relationshipsAuthz) UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.relationships = slices.DeleteFunc(r.relationships, func(relationship entity.Relationship) bool {
+		return slices.Contains(removed, relationship)
+	})
+	r.relationships = append(r.relationships, added...)
+	return nil
+}
+
+// finishedBulkJob waits for the scheduler to run the bulk job
+func finishedBulkJob(ctx context.Context, bulkSrv *services.BulkService, id string) *entity.BulkJob {
+	var job *entity.BulkJob
+	Eventually(func(g Gomega) {
+		var err error
+		job, err = bulkSrv.GetJob(ctx, id)
+		g.Expect(err).To(BeNil())
+		g.Expect(job.Status.Finished()).To(BeTrue())
+	}).WithTimeout(10 * time.Second).WithPolling(100 * time.Millisecond).Should(Succeed())
+	return job
+}
+
+var _ = Describe("BulkService", func() {
+	var (
+		media   *memoryMedia
+		bulkSrv *services.BulkService
+		first   entity.Media
+		second  entity.Media
+	)
+
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		album := entity.NewAlbum("bulk")
+		first = entity.NewMedia("first.jpg", album)
+		first.Tags = []string{"beach", "summer"}
+		first.CapturedAt = time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+		second = entity.NewMedia("second.jpg", album)
+		second.CapturedAt = time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC)
+
+		media = newMemoryMedia(first, second)
+		bulkSrv = services.NewBulkService(media, nil)
+	})
+
+	DescribeTable("rejects the invalid requests",
+		func(req entity.BulkRequest) {
+			_, err := bulkSrv.Start(ctx, req)
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		},
+		Entry("without media", entity.BulkRequest{Operation: entity.BulkDelete, MediaIDs: []string{""}}),
+		Entry("with too many media", entity.BulkRequest{Operation: entity.BulkDelete, MediaIDs: func() []string {
+			ids := make([]string, 0, 1001)
+			for range 1001 {
+				ids = append(ids, entity.NewId())
+			}
+			return ids
+		}()}),
+		Entry("moving without album", entity.BulkRequest{Operation: entity.BulkMove, MediaIDs: []string{"a"}}),
+		Entry("tagging without tags", entity.BulkRequest{Operation: entity.BulkTag, MediaIDs: []string{"a"}, AddTags: []string{" "}}),
+		Entry("shifting the date by nothing", entity.BulkRequest{Operation: entity.BulkDateShift, MediaIDs: []string{"a"}}),
+		Entry("changing the permissions without authorization", entity.BulkRequest{
+			Operation:    entity.BulkPermission,
+			MediaIDs:     []string{"a"},
+			Subject:      entity.NewUserSubject("bob"),
+			Relationship: entity.ViewerRelationship,
+		}),
+		Entry("with an unknown operation", entity.BulkRequest{Operation: "rotate", MediaIDs: []string{"a"}}),
+	)
+
+	It("deletes the media", func() {
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{Operation: entity.BulkDelete, MediaIDs: []string{first.ID, second.ID}})
+		Expect(err).To(BeNil())
+		Expect(job.Operation).To(Equal(entity.BulkDelete))
+
+		job = finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(job.Succeeded()).To(Equal(2))
+		Expect(media.count()).To(BeZero())
+	})
+
+	It("moves the media", func() {
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{Operation: entity.BulkMove, MediaIDs: []string{first.ID}, AlbumID: "other"})
+		Expect(err).To(BeNil())
+
+		job = finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(job.Succeeded()).To(Equal(1))
+		Expect(media.get(first.ID).Album.ID).To(Equal("other"))
+		Expect(media.get(second.ID).Album.ID).To(Equal(second.Album.ID))
+	})
+
+	It("adds and removes the tags of the media", func() {
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{
+			Operation:  entity.BulkTag,
+			MediaIDs:   []string{first.ID, second.ID},
+			AddTags:    []string{"Sea", "beach"},
+			RemoveTags: []string{"summer"},
+		})
+		Expect(err).To(BeNil())
+
+		job = finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(job.Succeeded()).To(Equal(2))
+		Expect(media.get(first.ID).Tags).To(ConsistOf("beach", "sea"))
+		Expect(media.get(second.ID).Tags).To(ConsistOf("beach", "sea"))
+	})
+
+	It("marks the media as favorite", func() {
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{Operation: entity.BulkFavorite, MediaIDs: []string{first.ID}, Favorite: true})
+		Expect(err).To(BeNil())
+
+		finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(media.get(first.ID).Favorite).To(BeTrue())
+		Expect(media.get(second.ID).Favorite).To(BeFalse())
+	})
+
+	It("shifts the capture date of the media", func() {
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{Operation: entity.BulkDateShift, MediaIDs: []string{first.ID, second.ID}, Shift: -2 * time.Hour})
+		Expect(err).To(BeNil())
+
+		finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(media.get(first.ID).CapturedAt).To(Equal(first.CapturedAt.Add(-2 * time.Hour)))
+		Expect(media.get(second.ID).CapturedAt).To(Equal(second.CapturedAt.Add(-2 * time.Hour)))
+	})
+
+	It("processes every media once and the others when one fails", func() {
+		missing := entity.NewId()
+		job, err := bulkSrv.Start(ctx, entity.BulkRequest{
+			Operation: entity.BulkFavorite,
+			MediaIDs:  []string{first.ID, missing, first.ID, second.ID},
+			Favorite:  true,
+		})
+		Expect(err).To(BeNil())
+
+		job = finishedBulkJob(ctx, bulkSrv, job.Id.String())
+		Expect(job.Items).To(HaveLen(3))
+		Expect(job.Succeeded()).To(Equal(2))
+		Expect(job.Failed()).To(Equal(1))
+		for _, item := range job.Items {
+			if item.MediaID == missing {
+				Expect(isNotFound(item.Err)).To(BeTrue())
+			} else {
+				Expect(item.Err).To(BeNil())
+			}
+		}
+		Expect(media.get(first.ID).Favorite).To(BeTrue())
+		Expect(media.get(second.ID).Favorite).To(BeTrue())
+	})
+
+	It("does not find the jobs of another user", func() {
+		aliceCtx := user.ToContext(ctx, &entity.User{ID: "alice"})
+		job, err := bulkSrv.Start(aliceCtx, entity.BulkRequest{Operation: entity.BulkFavorite, MediaIDs: []string{first.ID}})
+		Expect(err).To(BeNil())
+
+		_, err = bulkSrv.GetJob(aliceCtx, job.Id.String())
+		Expect(err).To(BeNil())
+
+		_, err = bulkSrv.GetJob(user.ToContext(ctx, &entity.User{ID: "bob"}), job.Id.String())
+		Expect(isNotFound(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("BulkService with authorization", Ordered, func() {
+	var (
+		dt       *pg.Datastore
+		pgPool   *pgxpool.Pool
+		authz    *relationshipsAuthz
+		bulkSrv  *services.BulkService
+		testDir  string
+		ids      []string
+		aliceCtx context.Context
+	)
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		album := entity.NewAlbum("/bulk")
+		_, err = pgPool.Exec(context.TODO(),
+			"INSERT INTO albums (id, created_at, path) VALUES ($1, $2, $3)",
+			album.ID, time.Now(), album.Path)
+		Expect(err).To(BeNil())
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		ids = []string{}
+		for _, filename := range []string{"first.jpg", "second.jpg"} {
+			media := entity.NewMedia(filename, album)
+			sql, args, err := insertMediaStmt.
+				Values(media.ID, time.Now(), time.Now(), album.ID, media.Filename, []byte("thumb"), exifJSON, string(entity.Photo)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+			ids = append(ids, media.ID)
+		}
+
+		testDir = "/tmp/photos-ng-bulk-test"
+		Expect(os.MkdirAll(testDir, 0755)).To(Succeed())
+		aliceCtx = user.ToContext(context.Background(), &entity.User{ID: "alice"})
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+		os.RemoveAll(testDir)
+	})
+
+	BeforeEach(func() {
+		authz = &relationshipsAuthz{}
+		mediaSrv := services.NewAuthzMediaService(authz, services.NewMediaService(dt, fs.NewFsDatastore(testDir)))
+		bulkSrv = services.NewBulkService(mediaSrv, mediaSrv)
+	})
+
+	grant := func(granted bool) entity.BulkRequest {
+		return entity.BulkRequest{
+			Operation:    entity.BulkPermission,
+			MediaIDs:     append(slices.Clone(ids), entity.NewId()),
+			Subject:      entity.NewUserSubject("bob"),
+			Relationship: entity.ViewerRelationship,
+			Grant:        granted,
+		}
+	}
+
+	It("grants and revokes the relationship on the media the user can set the permissions of", func() {
+		authz.allowed = []entity.Permission{entity.SetPermissionsPermission}
+
+		job, err := bulkSrv.Start(aliceCtx, grant(true))
+		Expect(err).To(BeNil())
+
+		job = finishedBulkJob(aliceCtx, bulkSrv, job.Id.String())
+		Expect(job.Succeeded()).To(Equal(2))
+		// the unknown media fails alone
+		Expect(job.Failed()).To(Equal(1))
+
+		expected := []entity.Relationship{}
+		for _, id := range ids {
+			expected = append(expected, entity.NewRelationship(entity.NewUserSubject("bob"), entity.NewMediaResource(id), entity.ViewerRelationship))
+		}
+		Expect(authz.written()).To(ConsistOf(expected))
+
+		job, err = bulkSrv.Start(aliceCtx, grant(false))
+		Expect(err).To(BeNil())
+
+		finishedBulkJob(aliceCtx, bulkSrv, job.Id.String())
+		Expect(authz.written()).To(BeEmpty())
+	})
+
+	It("fails on the media the user cannot set the permissions of", func() {
+		authz.allowed = entity.AllPermissions
+
+		job, err := bulkSrv.Start(aliceCtx, grant(true))
+		Expect(err).To(BeNil())
+
+		job = finishedBulkJob(aliceCtx, bulkSrv, job.Id.String())
+		Expect(job.Failed()).To(Equal(3))
+		var forbidden *services.ForbiddenAccessError
+		for _, item := range job.Items {
+			Expect(item.Err).To(BeAssignableToTypeOf(forbidden))
+		}
+		Expect(authz.written()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// CollectionService provides business logic for collection operations without authorization.
+// Collections are virtual groupings of media and never touch the filesystem.
+type CollectionService struct {
+	dt     *pg.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewCollectionService creates a new instance of CollectionService
+func NewCollectionService(dt *pg.Datastore) *CollectionService {
+	return &CollectionService{
+		dt:     dt,
+		logger: logger.New("collection_service"),
+	}
+}
+
+func (c *CollectionService) List(ctx context.Context, opts *CollectionOptions) ([]entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("list_collections").
+		WithInt("limit", opts.CollectionLimit).
+		WithInt("offset", opts.CollectionOffset).
+		Build()
+
+	logger.Step("database_query").
+		WithString("query_type", "list_collections").
+		Log()
+
+	collections, err := c.dt.QueryCollections(ctx, opts.QueriesFn()...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_collections", err).
+			AtStep("query_collections")
+	}
+
+	logger.Success().
+		WithInt(TotalCollections, len(collections)).
+		Log()
+
+	return collections, nil
+}
+
+func (c *CollectionService) Count(ctx context.Context, opts *CollectionOptions) (int, error) {
+	logger := c.logger.WithContext(ctx).Debug("count_collections").Build()
+
+	count, err := c.dt.CountCollections(ctx, opts.FiltersFn()...)
+	if err != nil {
+		return 0, NewDatabaseWriteError(ctx, "count_collections", err).
+			AtStep("count_collections")
+	}
+
+	logger.Success().
+		WithInt(TotalCollections, count).
+		Log()
+
+	return count, nil
+}
+
+func (c *CollectionService) Get(ctx context.Context, id string) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("get_collection").
+		WithString(CollectionID, id).
+		Build()
+
+	if id == "" {
+		err := NewValidationError(ctx, "get_collection", "invalid_input")
+		err.WithContext("validation_error", "empty_collection_id")
+		return nil, err
+	}
+
+	collections, err := c.dt.QueryCollections(ctx, pg.FilterCollectionById(id), pg.Limit(1))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_collection", err).
+			WithContext(CollectionID, id).
+			AtStep("query_collection")
+	}
+
+	if len(collections) == 0 {
+		return nil, NewCollectionNotFoundError(ctx, id)
+	}
+
+	logger.Success().
+		WithString(CollectionID, id).
+		WithInt("media_count", collections[0].MediaCount).
+		Log()
+
+	return &collections[0], nil
+}
+
+func (c *CollectionService) Create(ctx context.Context, collection entity.Collection) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("create_collection").
+		WithString(CollectionID, collection.ID).
+		WithString("name", collection.Name).
+		Build()
+
+	if collection.Name == "" {
+		err := NewValidationError(ctx, "create_collection", "invalid_input")
+		err.WithContext("validation_error", "empty_collection_name")
+		return nil, err
+	}
+
+	logger.Step("database_write").
+		WithString("table", "collections").
+		Log()
+
+	err := c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteCollection(ctx, collection)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "create_collection", err).
+			WithContext(CollectionID, collection.ID)
+	}
+
+	logger.Success().
+		WithString(CollectionID, collection.ID).
+		Log()
+
+	return &collection, nil
+}
+
+// Update updates the name, description and cover of a collection.
+// The cover must be one of the media of the collection, a nil cover removes it.
+func (c *CollectionService) Update(ctx context.Context, collection entity.Collection) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("update_collection").
+		WithString(CollectionID, collection.ID).
+		WithStringPtr("thumbnail", collection.Thumbnail).
+		Build()
+
+	existing, err := c.Get(ctx, collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.Name == "" {
+		err := NewValidationError(ctx, "update_collection", "invalid_input")
+		err.WithContext("validation_error", "empty_collection_name")
+		return nil, err
+	}
+
+	existing.Name = collection.Name
+	existing.Description = collection.Description
+
+	// the current cover is not checked again, its media may be in the trash by now
+	thumbnailUpdated := (existing.Thumbnail == nil) != (collection.Thumbnail == nil) ||
+		(existing.Thumbnail != nil && *existing.Thumbnail != *collection.Thumbnail)
+	if thumbnailUpdated && collection.Thumbnail != nil {
+		logger.Step("validate_thumbnail").WithString("thumbnail_id", *collection.Thumbnail).Log()
+
+		mediaIDs, err := c.mediaIDs(ctx, collection.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(mediaIDs, *collection.Thumbnail) {
+			err := NewValidationError(ctx, "update_collection", "thumbnail_not_in_collection")
+			err.WithContext(CollectionID, collection.ID).WithContext("thumbnail_id", *collection.Thumbnail)
+			return nil, err
+		}
+	}
+	existing.Thumbnail = collection.Thumbnail
+
+	err = c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteCollection(ctx, *existing)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "update_collection", err).
+			WithContext(CollectionID, collection.ID)
+	}
+
+	logger.Success().
+		WithString(CollectionID, collection.ID).
+		WithBool(ThumbnailUpdated, thumbnailUpdated).
+		Log()
+
+	return existing, nil
+}
+
+// Delete removes the collection. The media of the collection are not affected.
+func (c *CollectionService) Delete(ctx context.Context, id string) error {
+	logger := c.logger.WithContext(ctx).Debug("delete_collection").
+		WithString(CollectionID, id).
+		Build()
+
+	if _, err := c.Get(ctx, id); err != nil {
+		return err
+	}
+
+	err := c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.DeleteCollection(ctx, id)
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, "delete_collection", err).
+			WithContext(CollectionID, id)
+	}
+
+	logger.Success().
+		WithString(CollectionID, id).
+		WithBool(DatabaseDeleted, true).
+		Log()
+
+	return nil
+}
+
+// ListMedia returns the media of the collection in the collection order.
+func (c *CollectionService) ListMedia(ctx context.Context, id string, limit, offset int) ([]entity.Media, error) {
+	logger := c.logger.WithContext(ctx).Debug("list_collection_media").
+		WithString(CollectionID, id).
+		WithInt("limit", limit).
+		WithInt("offset", offset).
+		Build()
+
+	if _, err := c.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	media, err := c.dt.QueryMedia(ctx, pg.FilterByCollectionId(id), pg.Limit(limit), pg.Offset(offset))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_collection_media", err).
+			WithContext(CollectionID, id).
+			AtStep("query_media")
+	}
+
+	logger.Success().
+		WithInt(MediaReturned, len(media)).
+		Log()
+
+	return media, nil
+}
+
+// AddMedia appends media to the end of the collection.
+// Media already present in the collection keep their position.
+func (c *CollectionService) AddMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("add_collection_media").
+		WithString(CollectionID, id).
+		WithInt("media_count", len(mediaIDs)).
+		Build()
+
+	current, err := c.allMediaIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Step("validate_media").Log()
+
+	existing, err := c.dt.QueryMediaIDs(ctx, pg.FilterMediaByIDs(mediaIDs))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "add_collection_media", err).
+			WithContext(CollectionID, id).
+			AtStep("validate_media")
+	}
+
+	for _, mediaID := range mediaIDs {
+		if !slices.Contains(existing, mediaID) {
+			return nil, NewMediaNotFoundError(ctx, mediaID)
+		}
+		if slices.Contains(current, mediaID) {
+			continue
+		}
+		current = append(current, mediaID)
+	}
+
+	if err := c.writeMedia(ctx, "add_collection_media", id, current); err != nil {
+		return nil, err
+	}
+
+	logger.Success().
+		WithInt("total_media", len(current)).
+		Log()
+
+	return c.Get(ctx, id)
+}
+
+// RemoveMedia removes media from the collection. The order of the remaining media is preserved,
+// the media in the trash stay in the collection unless they are removed.
+func (c *CollectionService) RemoveMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("remove_collection_media").
+		WithString(CollectionID, id).
+		WithInt("media_count", len(mediaIDs)).
+		Build()
+
+	current, err := c.allMediaIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := slices.DeleteFunc(current, func(mediaID string) bool {
+		return slices.Contains(mediaIDs, mediaID)
+	})
+
+	if err := c.writeMedia(ctx, "remove_collection_media", id, remaining); err != nil {
+		return nil, err
+	}
+
+	logger.Success().
+		WithInt("total_media", len(remaining)).
+		Log()
+
+	return c.Get(ctx, id)
+}
+
+// Reorder sets the order of the media in the collection.
+// mediaIDs must contain exactly the media of the collection which are not in the trash.
+// The media in the trash keep their position.
+func (c *CollectionService) Reorder(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error) {
+	logger := c.logger.WithContext(ctx).Debug("reorder_collection").
+		WithString(CollectionID, id).
+		WithInt("media_count", len(mediaIDs)).
+		Build()
+
+	current, err := c.mediaIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sortedCurrent := slices.Sorted(slices.Values(current))
+	sortedNew := slices.Sorted(slices.Values(mediaIDs))
+	if !slices.Equal(sortedCurrent, sortedNew) {
+		err := NewValidationError(ctx, "reorder_collection", "media_mismatch")
+		err.WithContext(CollectionID, id).
+			WithContext("expected_media_count", len(current)).
+			WithContext("media_count", len(mediaIDs))
+		return nil, err
+	}
+
+	all, err := c.allMediaIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the media which are not in the trash take the positions in the new order
+	next := 0
+	for i, mediaID := range all {
+		if slices.Contains(current, mediaID) {
+			all[i] = mediaIDs[next]
+			next++
+		}
+	}
+
+	if err := c.writeMedia(ctx, "reorder_collection", id, all); err != nil {
+		return nil, err
+	}
+
+	logger.Success().Log()
+
+	return c.Get(ctx, id)
+}
+
+// mediaIDs returns the ids of the media of the collection in the collection order
+func (c *CollectionService) mediaIDs(ctx context.Context, id string) ([]string, error) {
+	if _, err := c.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ids, err := c.dt.QueryMediaIDs(ctx, pg.FilterByCollectionId(id))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_collection_media", err).
+			WithContext(CollectionID, id).
+			AtStep("query_media")
+	}
+
+	return ids, nil
+}
+
+// allMediaIDs returns the ids of the media of the collection in the collection order, the media in the trash included
+func (c *CollectionService) allMediaIDs(ctx context.Context, id string) ([]string, error) {
+	if _, err := c.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ids, err := c.dt.QueryCollectionMediaIDs(ctx, id)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_collection_media", err).
+			WithContext(CollectionID, id).
+			AtStep("query_collection_media")
+	}
+
+	return ids, nil
+}
+
+func (c *CollectionService) writeMedia(ctx context.Context, operation, id string, mediaIDs []string) error {
+	err := c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteCollectionMedia(ctx, id, mediaIDs)
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, operation, err).
+			WithContext(CollectionID, id)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CollectionService", Ordered, func() {
+	var (
+		collectionService *services.CollectionService
+		dt                *pg.Datastore
+		pgPool            *pgxpool.Pool
+		testAlbum         entity.Album
+		collection        *entity.Collection
+		mediaIDs          []string
+	)
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+		Expect(pgDt).ToNot(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+		Expect(pool).ToNot(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+		collectionService = services.NewCollectionService(dt)
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM collections;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+	})
+
+	BeforeEach(func() {
+		// Clean up any existing data
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM collections;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		testAlbum = entity.NewAlbum("/test/collection")
+		sql, args, err := insertAlbumStmt.Values(testAlbum.ID, time.Now(), testAlbum.Path, nil, nil, nil).ToSql()
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), sql, args...)
+		Expect(err).To(BeNil())
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		mediaIDs = []string{}
+		for _, filename := range []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"} {
+			media := entity.NewMedia(filename, testAlbum)
+			sql, args, err := insertMediaStmt.
+				Values(media.ID, time.Now(), time.Now(), testAlbum.ID, media.Filename, []byte("thumb"), exifJSON, string(entity.Photo)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+			mediaIDs = append(mediaIDs, media.ID)
+		}
+
+		collection, err = collectionService.Create(context.TODO(), entity.NewCollection("Best of"))
+		Expect(err).To(BeNil())
+	})
+
+	// listedIDs returns the ids of the media listed in the collection
+	listedIDs := func() []string {
+		media, err := collectionService.ListMedia(context.TODO(), collection.ID, 0, 0)
+		Expect(err).To(BeNil())
+		ids := make([]string, 0, len(media))
+		for _, m := range media {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	setTrashed := func(id string, trashed bool) {
+		var trashedAt *time.Time
+		if trashed {
+			now := time.Now()
+			trashedAt = &now
+		}
+		_, err := pgPool.Exec(context.TODO(), "UPDATE media SET trashed_at = $1 WHERE id = $2", trashedAt, id)
+		Expect(err).To(BeNil())
+	}
+
+	Context("Create", func() {
+		It("refuses a collection without name", func() {
+			_, err := collectionService.Create(context.TODO(), entity.NewCollection(""))
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		})
+	})
+
+	Context("AddMedia", func() {
+		It("appends the media in the given order", func() {
+			updated, err := collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[2], mediaIDs[0]})
+			Expect(err).To(BeNil())
+			Expect(updated.MediaCount).To(Equal(2))
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[2], mediaIDs[0]}))
+		})
+
+		It("keeps the position of the media already in the collection", func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[0], mediaIDs[1]})
+			Expect(err).To(BeNil())
+
+			_, err = collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[2], mediaIDs[0]})
+			Expect(err).To(BeNil())
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[0], mediaIDs[1], mediaIDs[2]}))
+		})
+
+		It("refuses unknown media and changes nothing", func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[0], entity.NewId()})
+			Expect(isNotFound(err)).To(BeTrue())
+			Expect(listedIDs()).To(BeEmpty())
+		})
+
+		It("does not find an unknown collection", func() {
+			_, err := collectionService.AddMedia(context.TODO(), entity.NewId(), []string{mediaIDs[0]})
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+
+		It("keeps the trashed media when media are added", func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[0], mediaIDs[1]})
+			Expect(err).To(BeNil())
+			setTrashed(mediaIDs[0], true)
+
+			updated, err := collectionService.AddMedia(context.TODO(), collection.ID, []string{mediaIDs[2]})
+			Expect(err).To(BeNil())
+			Expect(updated.MediaCount).To(Equal(2))
+
+			setTrashed(mediaIDs[0], false)
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[0], mediaIDs[1], mediaIDs[2]}))
+		})
+	})
+
+	Context("Reorder", func() {
+		BeforeEach(func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, mediaIDs)
+			Expect(err).To(BeNil())
+		})
+
+		It("sets the order of the media", func() {
+			order := []string{mediaIDs[3], mediaIDs[1], mediaIDs[0], mediaIDs[2]}
+			_, err := collectionService.Reorder(context.TODO(), collection.ID, order)
+			Expect(err).To(BeNil())
+			Expect(listedIDs()).To(Equal(order))
+		})
+
+		It("refuses an order without every media of the collection", func() {
+			_, err := collectionService.Reorder(context.TODO(), collection.ID, mediaIDs[:3])
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+			Expect(listedIDs()).To(Equal(mediaIDs))
+		})
+
+		It("orders the media which are not in the trash and keeps the position of the trashed ones", func() {
+			setTrashed(mediaIDs[1], true)
+
+			// the trashed media is not part of the order
+			_, err := collectionService.Reorder(context.TODO(), collection.ID, mediaIDs)
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+
+			_, err = collectionService.Reorder(context.TODO(), collection.ID, []string{mediaIDs[3], mediaIDs[2], mediaIDs[0]})
+			Expect(err).To(BeNil())
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[3], mediaIDs[2], mediaIDs[0]}))
+
+			setTrashed(mediaIDs[1], false)
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[3], mediaIDs[1], mediaIDs[2], mediaIDs[0]}))
+		})
+	})
+
+	Context("RemoveMedia", func() {
+		BeforeEach(func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, mediaIDs)
+			Expect(err).To(BeNil())
+		})
+
+		It("removes the media and keeps the order of the others", func() {
+			updated, err := collectionService.RemoveMedia(context.TODO(), collection.ID, []string{mediaIDs[1], entity.NewId()})
+			Expect(err).To(BeNil())
+			Expect(updated.MediaCount).To(Equal(3))
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[0], mediaIDs[2], mediaIDs[3]}))
+		})
+
+		It("keeps the trashed media unless they are removed", func() {
+			setTrashed(mediaIDs[0], true)
+			setTrashed(mediaIDs[3], true)
+
+			_, err := collectionService.RemoveMedia(context.TODO(), collection.ID, []string{mediaIDs[1], mediaIDs[3]})
+			Expect(err).To(BeNil())
+
+			setTrashed(mediaIDs[0], false)
+			setTrashed(mediaIDs[3], false)
+			Expect(listedIDs()).To(Equal([]string{mediaIDs[0], mediaIDs[2]}))
+		})
+
+		It("leaves the media of the collection when the collection is deleted", func() {
+			Expect(collectionService.Delete(context.TODO(), collection.ID)).To(Succeed())
+
+			_, err := collectionService.Get(context.TODO(), collection.ID)
+			Expect(isNotFound(err)).To(BeTrue())
+
+			var count int
+			Expect(pgPool.QueryRow(context.TODO(), "SELECT count(*) FROM media").Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(len(mediaIDs)))
+		})
+	})
+
+	Context("Update", func() {
+		BeforeEach(func() {
+			_, err := collectionService.AddMedia(context.TODO(), collection.ID, mediaIDs[:2])
+			Expect(err).To(BeNil())
+		})
+
+		It("sets and clears the cover", func() {
+			update := *collection
+			update.Thumbnail = &mediaIDs[1]
+			updated, err := collectionService.Update(context.TODO(), update)
+			Expect(err).To(BeNil())
+			Expect(updated.Thumbnail).To(HaveValue(Equal(mediaIDs[1])))
+
+			update.Thumbnail = nil
+			updated, err = collectionService.Update(context.TODO(), update)
+			Expect(err).To(BeNil())
+			Expect(updated.Thumbnail).To(BeNil())
+		})
+
+		It("refuses a cover which is not a media of the collection", func() {
+			update := *collection
+			update.Thumbnail = &mediaIDs[3]
+			_, err := collectionService.Update(context.TODO(), update)
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		})
+
+		It("keeps a cover whose media was put in the trash", func() {
+			update := *collection
+			update.Thumbnail = &mediaIDs[0]
+			_, err := collectionService.Update(context.TODO(), update)
+			Expect(err).To(BeNil())
+			setTrashed(mediaIDs[0], true)
+
+			update.Name = "Renamed"
+			updated, err := collectionService.Update(context.TODO(), update)
+			Expect(err).To(BeNil())
+			Expect(updated.Name).To(Equal("Renamed"))
+			Expect(updated.Thumbnail).To(HaveValue(Equal(mediaIDs[0])))
+		})
+	})
+})
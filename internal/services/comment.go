@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// maxCommentLength is the maximum number of characters of a comment body
+const maxCommentLength = 4000
+
+// CommentService provides business logic for the comments of media without authorization.
+// The author of a comment is the authenticated user and only the author can edit or delete it.
+type CommentService struct {
+	dt     *pg.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewCommentService creates a new instance of CommentService
+func NewCommentService(dt *pg.Datastore) *CommentService {
+	return &CommentService{
+		dt:     dt,
+		logger: logger.New("comment_service"),
+	}
+}
+
+// List returns the comments of a media, the oldest first.
+// Replies are returned in the same list, the thread is rebuilt from their parent id.
+func (c *CommentService) List(ctx context.Context, mediaID string) ([]entity.Comment, error) {
+	logger := c.logger.WithContext(ctx).Debug("list_comments").
+		WithString(MediaID, mediaID).
+		Build()
+
+	if err := c.checkMedia(ctx, "list_comments", mediaID); err != nil {
+		return nil, err
+	}
+
+	comments, err := c.dt.QueryComments(ctx, pg.FilterCommentsByMediaId(mediaID))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_comments", err).
+			WithMediaID(mediaID).
+			AtStep("query_comments")
+	}
+
+	logger.Success().
+		WithInt(TotalComments, len(comments)).
+		Log()
+
+	return comments, nil
+}
+
+// Get returns a comment of a media.
+func (c *CommentService) Get(ctx context.Context, mediaID, id string) (*entity.Comment, error) {
+	logger := c.logger.WithContext(ctx).Debug("get_comment").
+		WithString(MediaID, mediaID).
+		WithString(CommentID, id).
+		Build()
+
+	if id == "" {
+		err := NewValidationError(ctx, "get_comment", "invalid_input")
+		err.WithContext("validation_error", "empty_comment_id")
+		return nil, err
+	}
+
+	if err := c.checkMedia(ctx, "get_comment", mediaID); err != nil {
+		return nil, err
+	}
+
+	comments, err := c.dt.QueryComments(ctx, pg.FilterCommentById(id), pg.FilterCommentsByMediaId(mediaID), pg.Limit(1))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_comment", err).
+			WithContext(CommentID, id).
+			AtStep("query_comment")
+	}
+
+	if len(comments) == 0 {
+		return nil, NewCommentNotFoundError(ctx, id)
+	}
+
+	logger.Success().
+		WithString(CommentID, id).
+		Log()
+
+	return &comments[0], nil
+}
+
+// Create adds a comment to a media. The author is the authenticated user.
+// A reply must answer a comment of the same media.
+func (c *CommentService) Create(ctx context.Context, comment entity.Comment) (*entity.Comment, error) {
+	logger := c.logger.WithContext(ctx).Debug("create_comment").
+		WithString(MediaID, comment.MediaID).
+		WithString(CommentID, comment.ID).
+		WithStringPtr("parent_id", comment.ParentID).
+		Build()
+
+	author, err := c.author(ctx, "create_comment")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateBody(ctx, "create_comment", comment.Body); err != nil {
+		return nil, err
+	}
+
+	if comment.ParentID != nil {
+		logger.Step("validate_parent").WithString("parent_id", *comment.ParentID).Log()
+		// the parent is looked up within the media so a reply cannot cross media
+		if _, err := c.Get(ctx, comment.MediaID, *comment.ParentID); err != nil {
+			return nil, err
+		}
+	} else if err := c.checkMedia(ctx, "create_comment", comment.MediaID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment.Author = author
+	comment.Body = strings.TrimSpace(comment.Body)
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+
+	logger.Step("database_write").
+		WithString("table", "media_comments").
+		Log()
+
+	err = c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteComment(ctx, comment)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "create_comment", err).
+			WithMediaID(comment.MediaID).
+			WithContext(CommentID, comment.ID)
+	}
+
+	logger.Success().
+		WithString(CommentID, comment.ID).
+		Log()
+
+	return &comment, nil
+}
+
+// Update changes the body of a comment. Only the author of the comment can edit it.
+func (c *CommentService) Update(ctx context.Context, comment entity.Comment) (*entity.Comment, error) {
+	logger := c.logger.WithContext(ctx).Debug("update_comment").
+		WithString(MediaID, comment.MediaID).
+		WithString(CommentID, comment.ID).
+		Build()
+
+	existing, err := c.authored(ctx, "update_comment", comment.MediaID, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateBody(ctx, "update_comment", comment.Body); err != nil {
+		return nil, err
+	}
+
+	existing.Body = strings.TrimSpace(comment.Body)
+	existing.UpdatedAt = time.Now()
+
+	err = c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteComment(ctx, *existing)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "update_comment", err).
+			WithMediaID(comment.MediaID).
+			WithContext(CommentID, comment.ID)
+	}
+
+	logger.Success().
+		WithString(CommentID, comment.ID).
+		Log()
+
+	return existing, nil
+}
+
+// Delete removes a comment and its replies. Only the author of the comment can delete it.
+func (c *CommentService) Delete(ctx context.Context, mediaID, id string) error {
+	logger := c.logger.WithContext(ctx).Debug("delete_comment").
+		WithString(MediaID, mediaID).
+		WithString(CommentID, id).
+		Build()
+
+	if _, err := c.authored(ctx, "delete_comment", mediaID, id); err != nil {
+		return err
+	}
+
+	err := c.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.DeleteComment(ctx, id)
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, "delete_comment", err).
+			WithMediaID(mediaID).
+			WithContext(CommentID, id)
+	}
+
+	logger.Success().
+		WithString(CommentID, id).
+		WithBool(DatabaseDeleted, true).
+		Log()
+
+	return nil
+}
+
+// authored returns the comment if the authenticated user is its author.
+func (c *CommentService) authored(ctx context.Context, operation, mediaID, id string) (*entity.Comment, error) {
+	author, err := c.author(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := c.Get(ctx, mediaID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Author != author {
+		return nil, NewNotCommentAuthorError(ctx, operation, id, author)
+	}
+
+	return comment, nil
+}
+
+// author returns the username of the authenticated user.
+func (c *CommentService) author(ctx context.Context, operation string) (string, error) {
+	u := user.FromContext(ctx)
+	if u == nil || u.Username == "" {
+		return "", NewUnauthenticatedError(ctx, operation)
+	}
+	return u.Username, nil
+}
+
+func (c *CommentService) validateBody(ctx context.Context, operation, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_comment_body")
+		return err
+	}
+	if len([]rune(body)) > maxCommentLength {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "comment_body_too_long").WithContext("max_length", maxCommentLength)
+		return err
+	}
+	return nil
+}
+
+// checkMedia returns a NotFoundError if the media does not exist.
+func (c *CommentService) checkMedia(ctx context.Context, operation, mediaID string) error {
+	if mediaID == "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_media_id")
+		return err
+	}
+
+	ids, err := c.dt.QueryMediaIDs(ctx, pg.FilterMediaByIDs([]string{mediaID}))
+	if err != nil {
+		return NewDatabaseWriteError(ctx, operation, err).
+			WithMediaID(mediaID).
+			AtStep("query_media")
+	}
+
+	if len(ids) == 0 {
+		return NewMediaNotFoundError(ctx, mediaID)
+	}
+
+	return nil
+}
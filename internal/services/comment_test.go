@@ -0,0 +1,275 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommentService", Ordered, func() {
+	var (
+		commentService *services.CommentService
+		dt             *pg.Datastore
+		pgPool         *pgxpool.Pool
+		testMedia      entity.Media
+		otherMedia     entity.Media
+	)
+
+	aliceCtx := user.ToContext(context.Background(), &entity.User{ID: "alice-id", Username: "alice"})
+	bobCtx := user.ToContext(context.Background(), &entity.User{ID: "bob-id", Username: "bob"})
+
+	var forbidden *services.ForbiddenAccessError
+	var validation *services.ValidationError
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+		commentService = services.NewCommentService(dt)
+
+		// Clean up any existing data
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		album := entity.NewAlbum("/test/comments")
+		sql, args, err := insertAlbumStmt.Values(album.ID, time.Now(), album.Path, nil, nil, nil).ToSql()
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), sql, args...)
+		Expect(err).To(BeNil())
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		testMedia = entity.NewMedia("commented.jpg", album)
+		otherMedia = entity.NewMedia("other.jpg", album)
+		for _, media := range []entity.Media{testMedia, otherMedia} {
+			sql, args, err := insertMediaStmt.
+				Values(media.ID, time.Now(), time.Now(), album.ID, media.Filename, []byte("thumb"), exifJSON, string(entity.Photo)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+		}
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+	})
+
+	AfterEach(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media_comments;")
+		Expect(err).To(BeNil())
+	})
+
+	Context("Create", func() {
+		It("adds a comment of the authenticated user", func() {
+			created, err := commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "mallory", "  Nice light  "))
+			Expect(err).To(BeNil())
+			Expect(created.Author).To(Equal("alice"))
+			Expect(created.Body).To(Equal("Nice light"))
+
+			comments, err := commentService.List(aliceCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0].ID).To(Equal(created.ID))
+			Expect(comments[0].Author).To(Equal("alice"))
+		})
+
+		It("adds a reply to a comment of the same media", func() {
+			parent, err := commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Where is it?"))
+			Expect(err).To(BeNil())
+
+			reply := entity.NewComment(testMedia.ID, "", "At the beach")
+			reply.ParentID = &parent.ID
+			created, err := commentService.Create(bobCtx, reply)
+			Expect(err).To(BeNil())
+			Expect(created.ParentID).To(HaveValue(Equal(parent.ID)))
+
+			comments, err := commentService.List(bobCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(2))
+			Expect(comments[0].ID).To(Equal(parent.ID))
+		})
+
+		It("refuses a reply to a comment of another media", func() {
+			parent, err := commentService.Create(aliceCtx, entity.NewComment(otherMedia.ID, "", "Where is it?"))
+			Expect(err).To(BeNil())
+
+			reply := entity.NewComment(testMedia.ID, "", "At the beach")
+			reply.ParentID = &parent.ID
+			_, err = commentService.Create(bobCtx, reply)
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+
+		It("refuses a comment of an unknown media", func() {
+			_, err := commentService.Create(aliceCtx, entity.NewComment(entity.NewId(), "", "Nice"))
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+
+		DescribeTable("refuses an invalid body",
+			func(body string) {
+				_, err := commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", body))
+				Expect(err).To(BeAssignableToTypeOf(validation))
+			},
+			Entry("empty", " \n "),
+			Entry("too long", strings.Repeat("a", 4001)),
+		)
+
+		It("refuses a comment without authenticated user", func() {
+			_, err := commentService.Create(context.Background(), entity.NewComment(testMedia.ID, "alice", "Nice"))
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+		})
+	})
+
+	Context("Update", func() {
+		var comment *entity.Comment
+
+		BeforeEach(func() {
+			var err error
+			comment, err = commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Nice light"))
+			Expect(err).To(BeNil())
+		})
+
+		It("changes the body of a comment of its author", func() {
+			edit := *comment
+			edit.Body = "Very nice light"
+			updated, err := commentService.Update(aliceCtx, edit)
+			Expect(err).To(BeNil())
+			Expect(updated.Body).To(Equal("Very nice light"))
+			Expect(updated.Author).To(Equal("alice"))
+			Expect(updated.UpdatedAt).To(BeTemporally(">=", comment.UpdatedAt))
+
+			stored, err := commentService.Get(aliceCtx, testMedia.ID, comment.ID)
+			Expect(err).To(BeNil())
+			Expect(stored.Body).To(Equal("Very nice light"))
+		})
+
+		It("refuses to change the comment of another user", func() {
+			edit := *comment
+			edit.Body = "Ugly"
+			_, err := commentService.Update(bobCtx, edit)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+
+			stored, err := commentService.Get(aliceCtx, testMedia.ID, comment.ID)
+			Expect(err).To(BeNil())
+			Expect(stored.Body).To(Equal("Nice light"))
+		})
+
+		It("refuses an empty body", func() {
+			edit := *comment
+			edit.Body = ""
+			_, err := commentService.Update(aliceCtx, edit)
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		})
+
+		It("does not find the comment under another media", func() {
+			edit := *comment
+			edit.MediaID = otherMedia.ID
+			_, err := commentService.Update(aliceCtx, edit)
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Delete", func() {
+		var comment *entity.Comment
+
+		BeforeEach(func() {
+			var err error
+			comment, err = commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Where is it?"))
+			Expect(err).To(BeNil())
+
+			reply := entity.NewComment(testMedia.ID, "", "At the beach")
+			reply.ParentID = &comment.ID
+			_, err = commentService.Create(bobCtx, reply)
+			Expect(err).To(BeNil())
+		})
+
+		It("removes a comment of its author with its replies", func() {
+			Expect(commentService.Delete(aliceCtx, testMedia.ID, comment.ID)).To(Succeed())
+
+			comments, err := commentService.List(aliceCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(BeEmpty())
+		})
+
+		It("refuses to remove the comment of another user", func() {
+			Expect(commentService.Delete(bobCtx, testMedia.ID, comment.ID)).To(BeAssignableToTypeOf(forbidden))
+
+			comments, err := commentService.List(aliceCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(2))
+		})
+	})
+
+	Context("with authorization", func() {
+		var (
+			authz        *relationshipsAuthz
+			authzService *services.AuthzCommentService
+		)
+
+		BeforeEach(func() {
+			authz = &relationshipsAuthz{}
+			authzService = services.NewAuthzCommentService(authz, commentService)
+		})
+
+		It("lets the users who can view the media comment it", func() {
+			authz.allowed = []entity.Permission{entity.ViewPermission}
+
+			created, err := authzService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Nice light"))
+			Expect(err).To(BeNil())
+
+			comments, err := authzService.List(bobCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+
+			// viewing the media does not let another user edit the comment
+			edit := *created
+			edit.Body = "Ugly"
+			_, err = authzService.Update(bobCtx, edit)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			Expect(authzService.Delete(aliceCtx, testMedia.ID, created.ID)).To(Succeed())
+		})
+
+		It("refuses every operation to the users who cannot view the media", func() {
+			created, err := commentService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Nice light"))
+			Expect(err).To(BeNil())
+
+			authz.allowed = []entity.Permission{entity.EditPermission}
+
+			_, err = authzService.List(aliceCtx, testMedia.ID)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = authzService.Get(aliceCtx, testMedia.ID, created.ID)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = authzService.Create(aliceCtx, entity.NewComment(testMedia.ID, "", "Again"))
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			_, err = authzService.Update(aliceCtx, *created)
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+			Expect(authzService.Delete(aliceCtx, testMedia.ID, created.ID)).To(BeAssignableToTypeOf(forbidden))
+
+			comments, err := commentService.List(aliceCtx, testMedia.ID)
+			Expect(err).To(BeNil())
+			Expect(comments).To(HaveLen(1))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"strings"
@@ -208,6 +209,60 @@ func NewMediaNotFoundError(ctx context.Context, mediaID string) *NotFoundError {
 	}
 }
 
+func NewCollectionNotFoundError(ctx context.Context, collectionID string) *NotFoundError {
+	return &NotFoundError{
+		ServiceError: NewServiceErrorWithContext(ctx, "get_collection").
+			WithCondition("collection_not_found").
+			WithContext("collection_id", collectionID),
+	}
+}
+
+func NewSmartAlbumNotFoundError(ctx context.Context, smartAlbumID string) *NotFoundError {
+	return &NotFoundError{
+		ServiceError: NewServiceErrorWithContext(ctx, "get_smart_album").
+			WithCondition("smart_album_not_found").
+			WithContext("smart_album_id", smartAlbumID),
+	}
+}
+
+func NewCommentNotFoundError(ctx context.Context, commentID string) *NotFoundError {
+	return &NotFoundError{
+		ServiceError: NewServiceErrorWithContext(ctx, "get_comment").
+			WithCondition("comment_not_found").
+			WithContext("comment_id", commentID),
+	}
+}
+
+// NewNotCommentAuthorError is returned when a user edits or deletes a comment written by somebody else.
+func NewNotCommentAuthorError(ctx context.Context, operation, commentID, username string) *ForbiddenAccessError {
+	return &ForbiddenAccessError{
+		ServiceError: NewServiceErrorWithContext(ctx, operation).
+			WithCondition("not_comment_author").
+			WithContext("comment_id", commentID).
+			WithCause(fmt.Errorf("user %s is not the author of comment %s", username, commentID)),
+	}
+}
+
+// NewUnauthenticatedError is returned when an operation needs to know the user but none is authenticated.
+func NewUnauthenticatedError(ctx context.Context, operation string) *ForbiddenAccessError {
+	return &ForbiddenAccessError{
+		ServiceError: NewServiceErrorWithContext(ctx, operation).
+			WithCondition("user_not_authenticated").
+			WithCause(errors.New("user not found in context")),
+	}
+}
+
+// NewQuerySyntaxError is returned when a media query cannot be parsed.
+// The cause carries the position and the reason of the error.
+func NewQuerySyntaxError(ctx context.Context, operation, query string, cause error) *ValidationError {
+	return &ValidationError{
+		ServiceError: NewServiceErrorWithContext(ctx, operation).
+			WithCondition("invalid_query").
+			WithContext("query", query).
+			WithCause(cause),
+	}
+}
+
 func NewMediaProcessingError(ctx context.Context, step, filename string, cause error) *InternalError {
 	return &InternalError{
 		ServiceError: NewServiceErrorWithContext(ctx, "write_media").
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// EventAlbumService is the album service used by the events. It is implemented by AlbumService and AuthzAlbumService.
+type EventAlbumService interface {
+	Get(ctx context.Context, id string) (*entity.Album, error)
+	Create(ctx context.Context, album entity.Album) (*entity.Album, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// EventMediaService moves the media of an event into its album. It is implemented by MediaService and AuthzMediaService.
+type EventMediaService interface {
+	Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error)
+}
+
+// EventCollectionService links the media of an event into a collection.
+// It is implemented by CollectionService and AuthzCollectionService.
+type EventCollectionService interface {
+	Create(ctx context.Context, collection entity.Collection) (*entity.Collection, error)
+	AddMedia(ctx context.Context, id string, mediaIDs []string) (*entity.Collection, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// EventService suggests events by clustering the media of an album by capture date and location,
+// and turns an accepted suggestion into an album or a collection.
+// Authorization is done by the album, media and collection services it is created with:
+// with the authz services, suggesting requires view permission on the album and accepting requires
+// the permissions of creating the album or the collection and moving or linking the media.
+type EventService struct {
+	dt            *pg.Datastore
+	albumSrv      EventAlbumService
+	mediaSrv      EventMediaService
+	collectionSrv EventCollectionService
+	logger        *logger.StructuredLogger
+}
+
+// NewEventService creates a new instance of EventService
+func NewEventService(dt *pg.Datastore, albumSrv EventAlbumService, mediaSrv EventMediaService, collectionSrv EventCollectionService) *EventService {
+	return &EventService{
+		dt:            dt,
+		albumSrv:      albumSrv,
+		mediaSrv:      mediaSrv,
+		collectionSrv: collectionSrv,
+		logger:        logger.New("event_service"),
+	}
+}
+
+// Suggestions returns the events found in the media of the album, the oldest first.
+// Media of the subalbums are not clustered.
+func (s *EventService) Suggestions(ctx context.Context, albumID string, opts entity.EventClusterOptions) ([]entity.EventSuggestion, error) {
+	logger := s.logger.WithContext(ctx).Debug("suggest_events").
+		WithString(AlbumID, albumID).
+		WithParam("gap", opts.Gap).
+		WithParam("max_distance", opts.MaxDistance).
+		WithInt("min_media", opts.MinMedia).
+		Build()
+
+	if err := s.validate(ctx, "suggest_events", opts); err != nil {
+		return nil, err
+	}
+
+	// the album service checks the album exists and the user can view it
+	if _, err := s.albumSrv.Get(ctx, albumID); err != nil {
+		return nil, err
+	}
+
+	media, err := s.dt.QueryMediaLocations(ctx, pg.FilterByColumnName("media.album_id", albumID))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "suggest_events", err).
+			WithAlbumID(albumID).
+			AtStep("query_media")
+	}
+
+	suggestions := entity.ClusterEvents(media, opts)
+
+	logger.Success().
+		WithInt("media", len(media)).
+		WithInt("events", len(suggestions)).
+		Log()
+
+	return suggestions, nil
+}
+
+// Accept creates an album or a collection from a suggestion of the album.
+// The suggestion is computed again with the same options, a suggestion whose media changed is not found.
+// For an album, the media are moved into a new album created under parentID, or at the root if it is nil.
+// For a collection, the media stay in their album. If name is empty, the name suggested by the event is used.
+// If the media cannot be moved or linked, the album or the collection created for them is deleted.
+func (s *EventService) Accept(ctx context.Context, albumID, eventID string, opts entity.EventClusterOptions, target entity.EventTarget, name string, parentID *string) (*entity.AcceptedEvent, error) {
+	logger := s.logger.WithContext(ctx).Debug("accept_event").
+		WithString(AlbumID, albumID).
+		WithString("event_id", eventID).
+		WithString("target", string(target)).
+		Build()
+
+	if target != entity.EventAlbumTarget && target != entity.EventCollectionTarget {
+		err := NewValidationError(ctx, "accept_event", "invalid_input")
+		err.WithContext("validation_error", "invalid_target")
+		return nil, err
+	}
+
+	suggestions, err := s.Suggestions(ctx, albumID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var event *entity.EventSuggestion
+	for i := range suggestions {
+		if suggestions[i].ID == eventID {
+			event = &suggestions[i]
+			break
+		}
+	}
+
+	if event == nil {
+		err := NewNotFoundError(ctx, "accept_event", "event_not_found")
+		err.WithAlbumID(albumID).WithContext("event_id", eventID)
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = event.Name()
+	}
+
+	accepted := &entity.AcceptedEvent{Target: target}
+
+	switch target {
+	case entity.EventAlbumTarget:
+		album := entity.NewAlbum(name)
+		album.ParentId = parentID
+
+		logger.Step("create_album").WithString(AlbumPath, name).Log()
+		created, err := s.albumSrv.Create(ctx, album)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Step("move_media").WithInt("count", len(event.MediaIDs)).Log()
+		if _, err := s.mediaSrv.Move(ctx, event.MediaIDs, created.ID); err != nil {
+			// the media moved before the failure are moved back, the media still in the album are skipped
+			logger.Step("move_failed_deleting_album").WithString(AlbumID, created.ID).Log()
+			if _, moveErr := s.mediaSrv.Move(ctx, event.MediaIDs, albumID); moveErr != nil {
+				return nil, errors.Join(err, moveErr)
+			}
+			if delErr := s.albumSrv.Delete(ctx, created.ID); delErr != nil {
+				err = errors.Join(err, delErr)
+			}
+			return nil, err
+		}
+
+		created.MediaCount = len(event.MediaIDs)
+		accepted.Album = created
+	case entity.EventCollectionTarget:
+		logger.Step("create_collection").WithString("name", name).Log()
+		created, err := s.collectionSrv.Create(ctx, entity.NewCollection(name))
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Step("add_media").WithInt("count", len(event.MediaIDs)).Log()
+		collection, err := s.collectionSrv.AddMedia(ctx, created.ID, event.MediaIDs)
+		if err != nil {
+			logger.Step("add_failed_deleting_collection").WithString(CollectionID, created.ID).Log()
+			if delErr := s.collectionSrv.Delete(ctx, created.ID); delErr != nil {
+				err = errors.Join(err, delErr)
+			}
+			return nil, err
+		}
+
+		accepted.Collection = collection
+	}
+
+	logger.Success().WithInt("media", len(event.MediaIDs)).Log()
+	return accepted, nil
+}
+
+func (s *EventService) validate(ctx context.Context, operation string, opts entity.EventClusterOptions) error {
+	invalid := func(reason string) error {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", reason)
+		return err
+	}
+
+	if opts.Gap <= 0 {
+		return invalid("invalid_gap")
+	}
+	if opts.MaxDistance < 0 {
+		return invalid("invalid_max_distance")
+	}
+	if opts.MinMedia < 1 {
+		return invalid("invalid_min_media")
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -13,6 +15,14 @@ import (
 
 type Task[R any] func(ctx context.Context) entity.Result[R]
 
+// JobListener is told about the changes of a job as they happen. It is called synchronously by the job.
+type JobListener interface {
+	// JobChanged is called when the status of the job changed
+	JobChanged(job Job)
+	// TaskDone is called when a task of the job finished. The tasks are numbered from 0 in the order they finished.
+	TaskDone(job Job, position int, result entity.JobResult)
+}
+
 // Object contains general metadata about a job that doesn't change during execution
 type Object struct {
 	ID          uuid.UUID         // Unique identifier for the job
@@ -24,34 +34,67 @@ type Object struct {
 	opts        map[string]string // Job configuration options and metadata
 }
 
-// SyncJob represents a sync job that executes pre-generated tasks
+// SyncJob represents a sync job that executes pre-generated tasks.
+// The tasks are run by stages: the tasks of a stage run in parallel, up to the number of task workers,
+// and a stage starts once all the tasks of the previous one are done.
+// A job may depend on the first stages of other jobs, it is not started before they are done.
 type SyncJob struct {
 	Object
-	tasks        *entity.LinkedList[Task[string]] // Queue of tasks to execute
-	doneCh       chan bool                        // Channel to signal job cancellation
-	stopResumeCh chan bool                        // Channel to handle pause/resume operations
-	status       entity.JobStatus                 // Current job status
-	results      []entity.JobResult               // Results from completed tasks
-	logger       *logger.StructuredLogger         // Logger for job operations
-	mu           sync.Mutex                       // Mutex for thread-safe access
+	stages       []*entity.LinkedList[Task[string]] // Queues of tasks to execute, one per stage
+	stagesDone   int                                // Number of stages whose tasks are all done
+	stageFailed  []bool                             // Whether a task of the stage failed, by stage
+	dependencies []dependency                       // Stages of other jobs to wait for
+	doneCh       chan bool                          // Channel to signal job cancellation
+	stopResumeCh chan bool                          // Channel to handle pause/resume operations
+	status       entity.JobStatus                   // Current job status
+	results      []entity.JobResult                 // Results from completed tasks
+	listener     JobListener                        // Told about the changes of the job, may be nil
+	parent       *ParentJob                         // The parent job of a sub-job, nil otherwise
+	logger       *logger.StructuredLogger           // Logger for job operations
+	mu           sync.Mutex                         // Mutex for thread-safe access
+}
+
+// dependency is a job whose first stages must be done before a job starts
+type dependency struct {
+	job    *SyncJob
+	stages int
 }
 
+// taskDone is the result of a task run by a worker
+type taskDone struct {
+	result      entity.Result[string]
+	startedAt   time.Time
+	completedAt time.Time
+}
+
+// NewSyncJob creates a job whose tasks are independent and may run in parallel
 func NewSyncJob(tasks *entity.LinkedList[Task[string]], opts map[string]string) (*SyncJob, error) {
+	return NewStagedSyncJob([]*entity.LinkedList[Task[string]]{tasks}, opts)
+}
+
+// NewStagedSyncJob creates a job running its stages one after the other
+func NewStagedSyncJob(stages []*entity.LinkedList[Task[string]], opts map[string]string) (*SyncJob, error) {
 	if opts == nil {
 		opts = make(map[string]string)
 	}
 
+	total := 0
+	for _, tasks := range stages {
+		total += tasks.Len()
+	}
+
 	job := &SyncJob{
 		Object: Object{
 			ID:        uuid.New(),
 			createdAt: time.Now(),
-			total:     tasks.Len(),
+			total:     total,
 			opts:      opts,
 		},
-		tasks:   tasks,
-		status:  entity.StatusPending,
-		results: []entity.JobResult{},
-		logger:  logger.New("sync-job"),
+		stages:      stages,
+		stageFailed: make([]bool, len(stages)),
+		status:      entity.StatusPending,
+		results:     []entity.JobResult{},
+		logger:      logger.New("sync-job"),
 	}
 
 	return job, nil
@@ -68,63 +111,87 @@ func (j *SyncJob) Start(ctx context.Context) error {
 
 	// Start the job
 	now := time.Now()
+	j.mu.Lock()
 	j.startedAt = &now
 	j.status = entity.StatusRunning
+	j.mu.Unlock()
+	j.notifyChanged()
 
 	defer func() {
+		j.mu.Lock()
+		// A cancelled job is stopped by Cancel, which tells the listener
+		if j.status == entity.StatusStopping {
+			j.mu.Unlock()
+			return
+		}
+
 		// Stop the job
 		now := time.Now()
 		j.completedAt = &now
 		j.status = entity.StatusCompleted
+		j.mu.Unlock()
+		j.notifyChanged()
 	}()
 
+	workers := taskWorkers()
 	tracer := j.logger.Operation("process_tasks").
 		WithString(JobID, j.ID.String()).
-		WithInt("tasks_count", j.tasks.Len()).
+		WithInt("tasks_count", j.total).
+		WithInt("workers", workers).
 		Build()
 
-	next, _, cancel := entity.TaskIterator(j.iter(ctx, j.tasks))
-	taskIndex := 0
-	for {
-		start := time.Now()
-		result, ok := next()
-
-		if result.Err != nil {
-			j.logger.Operation("task_failed").
-				WithString(JobID, j.ID.String()).
-				WithInt("task_index", taskIndex).
-				WithString("error", result.Err.Error()).
-				Build().Success().Log()
-		}
-
-		end := time.Now()
+	// the running tasks are cancelled when the job is
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		// Record result directly in job
-		j.results = append(j.results, entity.JobResult{
-			Result:      result.Data,
-			Err:         result.Err,
-			StartedAt:   start,
-			CompletedAt: end,
-		})
+	doneCh := make(chan taskDone, workers)
+	running := 0
+	paused := false
+	taskIndex := 0
 
-		if !ok {
-			break
+	// stop cancels the running tasks and waits for them, their results are dropped
+	stop := func() {
+		cancel()
+		for ; running > 0; running-- {
+			<-doneCh
 		}
+	}
 
-		taskIndex++
-
-		select {
-		case <-ctx.Done():
-			cancel()
-			return ctx.Err()
-		case <-j.doneCh:
-			cancel()
-			j.doneCh <- true
-			return nil
-		case <-j.stopResumeCh:
-			<-j.stopResumeCh
-		default:
+	for stage, tasks := range j.stages {
+		for tasks.Len() > 0 || running > 0 {
+			for !paused && running < workers && tasks.Len() > 0 {
+				// Status counts the remaining tasks under the lock
+				j.mu.Lock()
+				task, _ := tasks.Pop()
+				j.mu.Unlock()
+				running++
+				go func() {
+					start := time.Now()
+					r := task(taskCtx)
+					doneCh <- taskDone{result: r, startedAt: start, completedAt: time.Now()}
+				}()
+			}
+
+			select {
+			case done := <-doneCh:
+				running--
+				j.recordResult(stage, taskIndex, done)
+				taskIndex++
+			case <-ctx.Done():
+				stop()
+				return ctx.Err()
+			case <-j.doneCh:
+				stop()
+				j.doneCh <- true
+				return nil
+			case <-j.stopResumeCh:
+				paused = !paused
+			}
 		}
+
+		j.mu.Lock()
+		j.stagesDone++
+		j.mu.Unlock()
 	}
 
 	tracer.Success().
@@ -134,6 +201,39 @@ func (j *SyncJob) Start(ctx context.Context) error {
 	return nil
 }
 
+// recordResult records the result of a task and tells the listener about it
+func (j *SyncJob) recordResult(stage, taskIndex int, done taskDone) {
+	if done.result.Err != nil {
+		j.logger.Operation("task_failed").
+			WithString(JobID, j.ID.String()).
+			WithInt("task_index", taskIndex).
+			WithString("error", done.result.Err.Error()).
+			Build().Success().Log()
+	}
+
+	jobResult := entity.JobResult{
+		Result:      done.result.Data,
+		Err:         done.result.Err,
+		StartedAt:   done.startedAt,
+		CompletedAt: done.completedAt,
+	}
+
+	j.mu.Lock()
+	j.results = append(j.results, jobResult)
+	if done.result.Err != nil {
+		j.stageFailed[stage] = true
+	}
+	j.mu.Unlock()
+
+	if j.listener != nil {
+		j.listener.TaskDone(j, taskIndex, jobResult)
+	}
+
+	event := entity.NewJobEvent(entity.TaskDoneEvent, j.Status())
+	event.Result = &jobResult
+	j.publish(event)
+}
+
 func (j *SyncJob) GetID() uuid.UUID {
 	return j.ID
 }
@@ -143,22 +243,36 @@ func (j *SyncJob) Pause() {
 		return
 	}
 
+	j.mu.Lock()
 	switch j.status {
 	case entity.StatusPause:
 		j.status = entity.StatusRunning
 	case entity.StatusRunning:
 		j.status = entity.StatusPause
 	}
+	j.mu.Unlock()
 	j.stopResumeCh <- true
+	j.notifyChanged()
 }
 
 func (j *SyncJob) Cancel() error {
+	j.mu.Lock()
+	// a job stopped with its parent job may be stopped again
+	if j.status.Finished() || j.status == entity.StatusStopping {
+		j.mu.Unlock()
+		return nil
+	}
 	j.status = entity.StatusStopping
+	j.mu.Unlock()
+
 	defer func() {
 		// Stop the job
 		now := time.Now()
+		j.mu.Lock()
 		j.completedAt = &now
 		j.status = entity.StatusStopped
+		j.mu.Unlock()
+		j.notifyChanged()
 	}()
 	if j.doneCh == nil {
 		return nil
@@ -184,24 +298,95 @@ func (j *SyncJob) Status() entity.JobProgress {
 		Status:      j.status,
 		CreatedAt:   j.createdAt,
 		Total:       j.total,
-		Remaining:   j.tasks.Len(),
+		Remaining:   j.remaining(),
 		StartedAt:   j.startedAt,
 		CompletedAt: j.completedAt,
 		Results:     j.results,
 		Reason:      j.reason,
+		Metadata:    j.opts,
+	}
+}
+
+// DependsOn makes the job wait for the first stages of another job. If that job stops or fails before,
+// or if a task of these stages fails, the job fails too.
+func (j *SyncJob) DependsOn(job *SyncJob, stages int) {
+	j.dependencies = append(j.dependencies, dependency{job: job, stages: min(stages, len(job.stages))})
+}
+
+// Ready tells if the stages the job depends on are done. It returns an error if they will never be.
+func (j *SyncJob) Ready() (bool, error) {
+	ready := true
+	for _, d := range j.dependencies {
+		d.job.mu.Lock()
+		stagesDone := d.job.stagesDone
+		failed := slices.Contains(d.job.stageFailed[:min(stagesDone, d.stages)], true)
+		d.job.mu.Unlock()
+
+		if failed {
+			return false, fmt.Errorf("a task of job %s (%s) failed", d.job.ID, d.job.opts["path"])
+		}
+		if stagesDone >= d.stages {
+			continue
+		}
+		if status := d.job.Status().Status; status.Finished() || status == entity.StatusStopping {
+			return false, fmt.Errorf("job %s (%s) is %s", d.job.ID, d.job.opts["path"], status)
+		}
+		ready = false
 	}
+	return ready, nil
+}
+
+// Fail marks the job as failed without running it. Only a pending job can fail.
+func (j *SyncJob) Fail(reason string) {
+	j.mu.Lock()
+	if j.status != entity.StatusPending {
+		j.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	j.completedAt = &now
+	j.reason = reason
+	j.status = entity.StatusFailed
+	j.mu.Unlock()
+	j.notifyChanged()
+}
+
+// SubJobs returns nil, a SyncJob runs its own tasks
+func (j *SyncJob) SubJobs() []Job {
+	return nil
 }
 
 func (j *SyncJob) Metadata() map[string]string {
 	return j.opts
 }
 
-func (j *SyncJob) iter(ctx context.Context, tasks *entity.LinkedList[Task[string]]) func(yield func(result entity.Result[string]) bool) {
-	return func(yield func(result entity.Result[string]) bool) {
-		for tasks.Len() > 0 {
-			task, _ := tasks.Pop()
-			r := task(ctx)
-			yield(r)
-		}
+// SetListener sets the listener told about the changes of the job
+func (j *SyncJob) SetListener(listener JobListener) {
+	j.listener = listener
+}
+
+// notifyChanged tells the listener and the subscribers of the job events that the status of the job changed
+func (j *SyncJob) notifyChanged() {
+	if j.listener != nil {
+		j.listener.JobChanged(j)
+	}
+	j.publish(entity.NewJobEvent(entity.JobChangedEvent, j.Status()))
+}
+
+// publish publishes the event of the job. The progress of the parent job changes with it, it is told about it.
+func (j *SyncJob) publish(event entity.JobEvent) {
+	GetJobEventBus().Publish(event)
+	if j.parent != nil {
+		j.parent.subJobChanged(j)
+	}
+}
+
+// remaining returns the number of tasks not started yet
+func (j *SyncJob) remaining() int {
+	remaining := 0
+	for _, tasks := range j.stages {
+		remaining += tasks.Len()
 	}
+	return remaining
 }
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"sync"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// jobEventsBuffer is how many events a subscriber may be late before its events are dropped
+const jobEventsBuffer = 256
+
+var (
+	jobEventsOnce sync.Once
+	jobEvents     *JobEventBus
+)
+
+// JobEventBus passes the events of the jobs, published by the scheduler and the jobs, to the subscribers.
+// Publishing never blocks: the events a slow subscriber has no room for are dropped, the subscriber
+// reads the status of the job again to catch up.
+type JobEventBus struct {
+	m           sync.RWMutex
+	subscribers map[chan entity.JobEvent]struct{}
+	logger      *logger.StructuredLogger
+}
+
+// GetJobEventBus returns the event bus of the jobs
+func GetJobEventBus() *JobEventBus {
+	jobEventsOnce.Do(func() {
+		jobEvents = &JobEventBus{
+			subscribers: make(map[chan entity.JobEvent]struct{}),
+			logger:      logger.New("job_events"),
+		}
+	})
+	return jobEvents
+}
+
+// Subscribe returns the channel receiving the events published from now on and the function to call
+// once they are not needed anymore, which closes the channel.
+func (b *JobEventBus) Subscribe() (<-chan entity.JobEvent, func()) {
+	ch := make(chan entity.JobEvent, jobEventsBuffer)
+
+	b.m.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.m.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.m.Lock()
+			delete(b.subscribers, ch)
+			b.m.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends the event to the subscribers
+func (b *JobEventBus) Publish(event entity.JobEvent) {
+	b.m.RLock()
+	defer b.m.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.logger.Debug("drop_job_event").
+				WithString(JobID, event.Job.Id.String()).
+				WithString("event", string(event.Type)).
+				Build().Success().Log()
+		}
+	}
+}
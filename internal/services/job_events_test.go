@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobEventBus", func() {
+	var bus *services.JobEventBus
+
+	BeforeEach(func() {
+		bus = services.GetJobEventBus()
+	})
+
+	// eventsOf returns the events of the job received until the channel has no more events
+	eventsOf := func(events <-chan entity.JobEvent, id uuid.UUID) []entity.JobEvent {
+		received := []entity.JobEvent{}
+		for {
+			select {
+			case event := <-events:
+				if event.Job.Id == id {
+					received = append(received, event)
+				}
+			default:
+				return received
+			}
+		}
+	}
+
+	It("is shared", func() {
+		Expect(services.GetJobEventBus()).To(BeIdenticalTo(bus))
+	})
+
+	It("sends the events published after the subscription to every subscriber", func() {
+		id := uuid.New()
+		bus.Publish(entity.NewJobEvent(entity.JobChangedEvent, entity.JobProgress{Id: id}))
+
+		first, unsubscribeFirst := bus.Subscribe()
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe()
+		defer unsubscribeSecond()
+
+		bus.Publish(entity.NewJobEvent(entity.JobRemovedEvent, entity.JobProgress{Id: id}))
+
+		for _, events := range []<-chan entity.JobEvent{first, second} {
+			received := eventsOf(events, id)
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].Type).To(Equal(entity.JobRemovedEvent))
+		}
+	})
+
+	It("closes the channel once unsubscribed and stops sending to it", func() {
+		events, unsubscribe := bus.Subscribe()
+
+		unsubscribe()
+		Eventually(events).Should(BeClosed())
+
+		// unsubscribing again does nothing
+		unsubscribe()
+		bus.Publish(entity.NewJobEvent(entity.JobChangedEvent, entity.JobProgress{Id: uuid.New()}))
+	})
+
+	It("drops the events of a subscriber without room instead of blocking", func() {
+		id := uuid.New()
+		events, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+
+		for range 300 {
+			bus.Publish(entity.NewJobEvent(entity.JobChangedEvent, entity.JobProgress{Id: id}))
+		}
+
+		received := eventsOf(events, id)
+		Expect(len(received)).To(BeNumerically("<", 300))
+		Expect(received).ToNot(BeEmpty())
+	})
+
+	It("receives the changes and the task results of the jobs without the results of the job", func() {
+		events, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+
+		syncJob, err := services.NewSyncJob(newTaskList(succeed("photo")), map[string]string{"path": "photos"})
+		Expect(err).To(BeNil())
+		Expect(syncJob.Start(context.Background())).To(Succeed())
+
+		received := eventsOf(events, syncJob.ID)
+		Expect(received).To(HaveLen(3))
+
+		Expect(received[0].Type).To(Equal(entity.JobChangedEvent))
+		Expect(received[0].Job.Status).To(Equal(entity.StatusRunning))
+
+		Expect(received[1].Type).To(Equal(entity.TaskDoneEvent))
+		Expect(received[1].Result).ToNot(BeNil())
+		Expect(received[1].Result.Result).To(Equal("photo"))
+		Expect(received[1].Job.Results).To(BeNil())
+
+		Expect(received[2].Type).To(Equal(entity.JobChangedEvent))
+		Expect(received[2].Job.Status).To(Equal(entity.StatusCompleted))
+		Expect(received[2].Job.Path).To(Equal("photos"))
+	})
+})
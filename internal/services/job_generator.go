@@ -2,45 +2,354 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
 	"path"
+	"slices"
+	"strings"
+	"sync"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
+const (
+	// syncJob is the type of the jobs syncing a folder
+	syncJob = "sync"
+	// reconcileJob is the type of the jobs removing the albums and media whose folder or file is gone
+	reconcileJob = "reconcile"
+)
+
+// The job of a folder creates its album, then moves the media moved into the folder, then processes the
+// new and changed media files, then writes the relationships of these media, each in a stage.
+const (
+	// folderAlbumStages are the stages after which the album of the folder exists
+	folderAlbumStages = 1
+	// folderMoveStages are the stages after which the media moved into the folder are in its album
+	folderMoveStages = 2
+)
+
+// relationshipsPerWrite is the most relationships written at once, SpiceDB refuses writes of more
+// than 1000 updates by default
+const relationshipsPerWrite = 500
+
+// relationshipBatch collects the relationships of the media processed by the tasks of a stage, they are
+// written together by the next stage. The attributes of the files of the media are recorded once the
+// relationships are written, so the next sync processes again the media left without relationships.
+type relationshipBatch struct {
+	relationships []entity.Relationship
+	files         map[string]entity.FileInfo
+	mu            sync.Mutex
+}
+
+func (b *relationshipBatch) add(relationship entity.Relationship, mediaID string, file *entity.FileInfo) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.relationships = append(b.relationships, relationship)
+	if file != nil {
+		if b.files == nil {
+			b.files = make(map[string]entity.FileInfo)
+		}
+		b.files[mediaID] = *file
+	}
+}
+
+func (b *relationshipBatch) take() ([]entity.Relationship, map[string]entity.FileInfo) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	relationships, files := b.relationships, b.files
+	b.relationships, b.files = nil, nil
+	return relationships, files
+}
+
 // JobGenerator generates SyncJobs based on folder structure discovery
 type JobGenerator struct {
-	albumSrv *AlbumService
-	mediaSrv *MediaService
-	fs       *fs.Datastore
+	albumSrv      *AlbumService
+	mediaSrv      *MediaService
+	fs            *fs.Datastore
+	relationships Relationships
 }
 
-// NewJobGenerator creates a new JobGenerator instance
-func NewJobGenerator(albumSrv *AlbumService, mediaSrv *MediaService, fsDatastore *fs.Datastore) *JobGenerator {
+// NewJobGenerator creates a new JobGenerator instance.
+// relationships is nil when authorization is disabled, the albums and media have no relationships then.
+func NewJobGenerator(albumSrv *AlbumService, mediaSrv *MediaService, fsDatastore *fs.Datastore, relationships Relationships) *JobGenerator {
 	return &JobGenerator{
-		albumSrv: albumSrv,
-		mediaSrv: mediaSrv,
-		fs:       fsDatastore,
+		albumSrv:      albumSrv,
+		mediaSrv:      mediaSrv,
+		fs:            fsDatastore,
+		relationships: relationships,
 	}
 }
 
+// syncPlan is the difference between the folder tree and the database which the jobs of a sync apply
+type syncPlan struct {
+	// albums holds the paths of the known albums
+	albums map[string]bool
+	// known holds the paths of the files of the known media
+	known map[string]bool
+	// synced holds the attributes of the files recorded by the last sync, by path. Empty on a full rescan.
+	synced map[string]entity.FileInfo
+	// moved holds the media whose file was moved, by the new path of the file
+	moved map[string]entity.Media
+	// orphanAlbums are the albums whose folder is gone, their subalbums are not listed
+	orphanAlbums []entity.Album
+	// orphanMedia are the media whose file is gone from a folder which still exists
+	orphanMedia []entity.Media
+}
+
 // Generate generates SyncJobs for a given path
 // For root path: creates a SyncJob for each folder found at all levels
 // For specific album: creates a SyncJob for that album and its content
-func (g *JobGenerator) Generate(ctx context.Context, albumPath string) ([]*SyncJob, error) {
+// Unless opts asks for a full rescan, the files whose attributes did not change since they were synced
+// get no task and the known folders left without tasks get no job.
+// The albums and media below the path whose folder or file is gone are put in the trash by a last job, except
+// the media whose file was moved, found by hash, which are moved to the album of their new folder. A path
+// without folders nor files while albums are known below it is refused, it is likely an unmounted share.
+func (g *JobGenerator) Generate(ctx context.Context, albumPath string, opts *SyncOptions) ([]*SyncJob, error) {
+	if opts == nil {
+		opts = NewSyncOptionsWithOptionsAndDefaults()
+	}
+
 	// First, discover the complete folder structure
 	tree, err := g.discoverFolderStructure(ctx, albumPath)
 	if err != nil {
 		return nil, err
 	}
 
+	plan, err := g.diff(ctx, tree, albumPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate SyncJobs from the discovered tree
-	jobs := g.createJobsFromTree(ctx, tree, albumPath)
+	jobs := g.createJobsFromTree(ctx, tree, albumPath, plan)
+
+	// The orphans are removed last so the media moved out of a removed folder are moved first
+	if job := g.createReconcileJob(albumPath, plan); job != nil {
+		for _, folderJob := range jobs {
+			job.DependsOn(folderJob, folderMoveStages)
+		}
+		jobs = append(jobs, job)
+	}
 
 	return jobs, nil
 }
 
+// Report compares the folder tree at albumPath with the database like Generate does and reports what the
+// sync jobs would do. Nothing is written. The files to add or update are opened to find the unreadable ones.
+func (g *JobGenerator) Report(ctx context.Context, albumPath string, opts *SyncOptions) (*entity.SyncReport, error) {
+	if opts == nil {
+		opts = NewSyncOptionsWithOptionsAndDefaults()
+	}
+
+	tree, err := g.discoverFolderStructure(ctx, albumPath)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := g.diff(ctx, tree, albumPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := entity.NewSyncReport(albumPath)
+	for _, node := range tree.GetAllNodes() {
+		// The root folder is not an album
+		if node.Path != "" && !plan.albums[node.Path] {
+			report.AlbumsToCreate = append(report.AlbumsToCreate, node.Path)
+		}
+
+		for _, mediaFilePath := range node.MediaFiles {
+			if file, found := plan.synced[mediaFilePath]; found && file.Same(node.MediaFileInfos[mediaFilePath]) {
+				continue
+			}
+			if media, found := plan.moved[mediaFilePath]; found {
+				report.MediaToMove = append(report.MediaToMove, entity.SyncMove{From: media.Filepath(), To: mediaFilePath})
+				continue
+			}
+			if err := g.fs.CheckReadable(ctx, mediaFilePath); err != nil {
+				report.UnreadableItems = append(report.UnreadableItems, mediaFilePath)
+				continue
+			}
+			if plan.known[mediaFilePath] {
+				report.MediaToUpdate = append(report.MediaToUpdate, mediaFilePath)
+			} else {
+				report.MediaToAdd = append(report.MediaToAdd, mediaFilePath)
+			}
+		}
+
+		report.UnreadableItems = append(report.UnreadableItems, node.UnreadableItems...)
+		report.UnsupportedFiles = append(report.UnsupportedFiles, node.UnsupportedFiles...)
+	}
+
+	for _, album := range plan.orphanAlbums {
+		report.AlbumsToRemove = append(report.AlbumsToRemove, album.Path)
+	}
+	for _, media := range plan.orphanMedia {
+		report.MediaToRemove = append(report.MediaToRemove, media.Filepath())
+	}
+
+	return &report, nil
+}
+
+// diff compares the folder tree with the albums and media known below albumPath.
+func (g *JobGenerator) diff(ctx context.Context, tree *entity.FolderNode, albumPath string, opts *SyncOptions) (*syncPlan, error) {
+	known, err := g.mediaSrv.ListFiles(ctx, albumPath)
+	if err != nil {
+		return nil, err
+	}
+	albums, err := g.albumSrv.ListFolders(ctx, albumPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.plan(ctx, tree, albumPath, albums, known, opts)
+}
+
+// plan computes the sync plan from the folder tree and the albums and media known below albumPath.
+func (g *JobGenerator) plan(ctx context.Context, tree *entity.FolderNode, albumPath string, albums []entity.Album, known []entity.Media, opts *SyncOptions) (*syncPlan, error) {
+	plan := &syncPlan{
+		albums: make(map[string]bool),
+		known:  make(map[string]bool),
+		synced: make(map[string]entity.FileInfo),
+		moved:  make(map[string]entity.Media),
+	}
+
+	// an empty mount point, like an unmounted share, looks like every folder was removed
+	if len(tree.Children) == 0 && len(tree.MediaFiles) == 0 &&
+		slices.ContainsFunc(albums, func(a entity.Album) bool { return a.Path != tree.Path }) {
+		err := NewConflictError(ctx, "sync_diff", "empty_folder_with_albums")
+		err.WithAlbumPath(albumPath)
+		return nil, err
+	}
+
+	folders := make(map[string]bool)
+	files := make(map[string]entity.FileInfo)
+	unreadable := []string{}
+	for _, node := range tree.GetAllNodes() {
+		folders[node.Path] = true
+		for _, mediaFilePath := range node.MediaFiles {
+			files[mediaFilePath] = node.MediaFileInfos[mediaFilePath]
+		}
+		unreadable = append(unreadable, node.UnreadableItems...)
+	}
+
+	orphans := []entity.Media{}
+	for _, m := range known {
+		plan.known[m.Filepath()] = true
+		if _, found := files[m.Filepath()]; !found {
+			// the file of a media in an unreadable folder may still be there
+			if !isInFolders(m.Filepath(), unreadable) {
+				orphans = append(orphans, m)
+			}
+			continue
+		}
+		if m.File != nil && !opts.FullRescan {
+			plan.synced[m.Filepath()] = *m.File
+		}
+	}
+
+	// a new file with the hash of an orphan is the file of the orphan moved elsewhere
+	newFiles := []string{}
+	for mediaFilePath := range files {
+		// the media files of the data folder itself do not belong to any album
+		if !plan.known[mediaFilePath] && path.Dir(mediaFilePath) != "." {
+			newFiles = append(newFiles, mediaFilePath)
+		}
+	}
+	slices.Sort(newFiles)
+
+	for _, mediaFilePath := range newFiles {
+		if len(orphans) == 0 {
+			break
+		}
+
+		size := files[mediaFilePath].Size
+		if !slices.ContainsFunc(orphans, func(m entity.Media) bool { return m.File == nil || m.File.Size == size }) {
+			continue
+		}
+
+		hash, err := g.hashFile(ctx, mediaFilePath)
+		if err != nil {
+			// the file is processed as a new one
+			continue
+		}
+
+		if i := slices.IndexFunc(orphans, func(m entity.Media) bool { return m.Hash == hash }); i >= 0 {
+			plan.moved[mediaFilePath] = orphans[i]
+			orphans = slices.Delete(orphans, i, i+1)
+		}
+	}
+
+	// removing an album removes its subalbums and their media
+	removed := []string{}
+	slices.SortFunc(albums, func(a, b entity.Album) int { return strings.Compare(a.Path, b.Path) })
+	for _, album := range albums {
+		plan.albums[album.Path] = true
+		if folders[album.Path] || isInFolders(album.Path, removed) || isInFolders(album.Path, unreadable) {
+			continue
+		}
+		plan.orphanAlbums = append(plan.orphanAlbums, album)
+		removed = append(removed, album.Path)
+	}
+
+	for _, m := range orphans {
+		if !isInFolders(m.Album.Path, removed) {
+			plan.orphanMedia = append(plan.orphanMedia, m)
+		}
+	}
+
+	return plan, nil
+}
+
+// hashFile computes the hash of a media file the way MediaService.WriteMedia does.
+func (g *JobGenerator) hashFile(ctx context.Context, mediaFilePath string) (string, error) {
+	r, err := g.fs.Read(ctx, mediaFilePath)()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// GenerateForFiles generates a single SyncJob for some media files of a folder: the album of the folder
+// is created if needed and only the given files are processed. The parent album of the folder must exist.
+func (g *JobGenerator) GenerateForFiles(ctx context.Context, albumPath string, files []string) (*SyncJob, error) {
+	var parent *entity.Album
+	if dir := path.Dir(albumPath); dir != "." && dir != "/" {
+		parent, _ = g.resolveAlbum(ctx, dir)
+	}
+	album, _ := g.resolveAlbum(ctx, albumPath)
+
+	albumStage := entity.NewLinkedList[Task[string]]()
+	albumStage.PushBack(g.createAlbumTaskWithParent(*album, parent))
+
+	batch := &relationshipBatch{}
+	mediaStage := entity.NewLinkedList[Task[string]]()
+	for _, mediaFilePath := range files {
+		mediaStage.PushBack(g.createMediaTask(mediaFilePath, *album, batch))
+	}
+
+	stages := []*entity.LinkedList[Task[string]]{albumStage, mediaStage}
+	if g.relationships != nil && mediaStage.Len() > 0 {
+		relationshipStage := entity.NewLinkedList[Task[string]]()
+		relationshipStage.PushBack(g.createWriteRelationshipsTask(batch))
+		stages = append(stages, relationshipStage)
+	}
+
+	return NewStagedSyncJob(stages, map[string]string{
+		"albumId": album.ID,
+		"path":    album.Path,
+		"type":    syncJob,
+	})
+}
+
 // discoverFolderStructure discovers the complete folder structure using WalkTree
 func (g *JobGenerator) discoverFolderStructure(ctx context.Context, albumPath string) (*entity.FolderNode, error) {
 	// Use the WalkTree method to get the complete structure as a tree
@@ -52,103 +361,163 @@ func (g *JobGenerator) discoverFolderStructure(ctx context.Context, albumPath st
 }
 
 // createJobsFromTree processes the folder tree and creates SyncJobs for each folder
-func (g *JobGenerator) createJobsFromTree(ctx context.Context, tree *entity.FolderNode, rootPath string) []*SyncJob {
+func (g *JobGenerator) createJobsFromTree(ctx context.Context, tree *entity.FolderNode, rootPath string, plan *syncPlan) []*SyncJob {
 	var jobs []*SyncJob
 
+	// The parent of the top folder is not part of the tree, it must already be known
+	var parent *entity.Album
+	if dir := path.Dir(tree.Path); tree.Path != "" && dir != "." && dir != "/" {
+		parent, _ = g.resolveAlbum(ctx, dir)
+	}
+
 	// Process the tree recursively to create a job for each folder
-	g.processNodeForJobs(ctx, tree, rootPath, &jobs)
+	g.processNodeForJobs(ctx, tree, parent, nil, rootPath, plan, &jobs)
 
 	return jobs
 }
 
-// processNodeForJobs recursively processes a folder node and creates SyncJobs
-func (g *JobGenerator) processNodeForJobs(ctx context.Context, node *entity.FolderNode, rootPath string, jobs *[]*SyncJob) {
+// resolveAlbum returns the album of the folder if it is already known, or a new album otherwise.
+// Album ids are not derived from the path so the id of an existing folder must be read from the database.
+func (g *JobGenerator) resolveAlbum(ctx context.Context, albumPath string) (*entity.Album, bool) {
+	if album, err := g.albumSrv.GetByPath(ctx, albumPath); err == nil {
+		return album, true
+	}
+	album := entity.NewAlbum(albumPath)
+	return &album, false
+}
+
+// processNodeForJobs recursively processes a folder node and creates SyncJobs.
+// parentJob is the job of the parent folder, nil if the parent folder has no job. The job of the folder
+// waits for it to create the parent album.
+func (g *JobGenerator) processNodeForJobs(ctx context.Context, node *entity.FolderNode, parent *entity.Album, parentJob *SyncJob, rootPath string, plan *syncPlan, jobs *[]*SyncJob) {
+	var album *entity.Album
+	var job *SyncJob
+
 	// For each folder (including the root), create a SyncJob that handles only that folder's content
 	if node.Path != "" || rootPath == "" {
-		// Create album entity for this folder
-		album := entity.NewAlbum(node.Path)
+		// Resolve the album entity for this folder. The root folder is not an album, it is always known.
+		known := true
+		if node.Path != "" {
+			album, known = g.resolveAlbum(ctx, node.Path)
+		} else {
+			root := entity.NewAlbum(node.Path)
+			album = &root
+		}
 
 		// Generate tasks for this specific folder (album creation + its direct media files)
-		albumTasks, mediaTasks := g.createTasksForSingleFolder(node, album)
-
-		// Create linked list with album tasks at the beginning
-		taskList := entity.NewLinkedList[Task[string]]()
+		batch := &relationshipBatch{}
+		albumTasks, moveTasks, mediaTasks := g.createTasksForSingleFolder(node, *album, parent, plan, batch)
 
-		// Add album tasks first
-		for _, task := range albumTasks {
-			taskList.PushBack(task)
+		// the relationships of the processed media are written at once when they all are
+		relationshipTasks := []Task[string]{}
+		if g.relationships != nil && len(mediaTasks) > 0 {
+			relationshipTasks = append(relationshipTasks, g.createWriteRelationshipsTask(batch))
 		}
 
-		// Add media tasks after
-		for _, task := range mediaTasks {
-			taskList.PushBack(task)
+		// The album must exist before its media are moved or processed, the tasks of each stage run in parallel
+		stages := []*entity.LinkedList[Task[string]]{}
+		for _, tasks := range [][]Task[string]{albumTasks, moveTasks, mediaTasks, relationshipTasks} {
+			stage := entity.NewLinkedList[Task[string]]()
+			for _, task := range tasks {
+				stage.PushBack(task)
+			}
+			stages = append(stages, stage)
 		}
 
 		// Create options map with album metadata
 		opts := map[string]string{
 			"albumId": album.ID,
 			"path":    album.Path,
+			"type":    syncJob,
 		}
 
-		// Create a SyncJob for this folder
-		job, err := NewSyncJob(taskList, opts)
-		if err == nil {
-			*jobs = append(*jobs, job)
+		// Create a SyncJob for this folder, unless the folder is known and none of its files changed
+		if !known || len(moveTasks) > 0 || len(mediaTasks) > 0 {
+			newJob, err := NewStagedSyncJob(stages, opts)
+			if err == nil {
+				if parentJob != nil {
+					newJob.DependsOn(parentJob, folderAlbumStages)
+				}
+				job = newJob
+				*jobs = append(*jobs, job)
+			}
 		}
 	}
 
+	// The root folder is not an album, its subfolders are top level albums
+	if album != nil && album.Path == "" {
+		album = nil
+	}
+
 	// Recursively process children to create jobs for subfolders
 	for _, child := range node.Children {
-		g.processNodeForJobs(ctx, child, rootPath, jobs)
+		g.processNodeForJobs(ctx, child, album, job, rootPath, plan, jobs)
 	}
 }
 
-// createTasksForSingleFolder creates tasks for a single folder (album creation + media processing)
-func (g *JobGenerator) createTasksForSingleFolder(node *entity.FolderNode, album entity.Album) ([]Task[string], []Task[string]) {
+// createTasksForSingleFolder creates tasks for a single folder (album creation + media processing).
+// The media files whose attributes are the ones recorded by the last sync get no task and the moved
+// media files get a task moving their media instead of processing them again. The relationships of the
+// processed media are collected in batch.
+func (g *JobGenerator) createTasksForSingleFolder(node *entity.FolderNode, album entity.Album, parent *entity.Album, plan *syncPlan, batch *relationshipBatch) ([]Task[string], []Task[string], []Task[string]) {
 	albumTasks := []Task[string]{}
+	moveTasks := []Task[string]{}
 	mediaTasks := []Task[string]{}
 
 	// Create album creation task (only if not root)
 	if node.Path != "" {
-		// Determine parent album based on the node's parent
-		var parentAlbum *entity.Album
-		if node.Parent != nil && node.Parent.Path != "" {
-			parentEntity := entity.NewAlbum(node.Parent.Path)
-			parentAlbum = &parentEntity
-		}
-
-		albumTasks = append(albumTasks, g.createAlbumTaskWithParent(node.Path, parentAlbum))
+		albumTasks = append(albumTasks, g.createAlbumTaskWithParent(album, parent))
 	}
 
 	// Create media processing tasks for all media files in this folder
 	for _, mediaFilePath := range node.MediaFiles {
-		mediaTasks = append(mediaTasks, g.createMediaTask(mediaFilePath, album))
+		if file, found := plan.synced[mediaFilePath]; found && file.Same(node.MediaFileInfos[mediaFilePath]) {
+			continue
+		}
+		if media, found := plan.moved[mediaFilePath]; found {
+			moveTasks = append(moveTasks, g.createMoveTask(media, mediaFilePath))
+			continue
+		}
+		mediaTasks = append(mediaTasks, g.createMediaTask(mediaFilePath, album, batch))
 	}
 
-	return albumTasks, mediaTasks
+	return albumTasks, moveTasks, mediaTasks
 }
 
-// createAlbumTaskWithParent creates a task to create an album with proper parent relationship
-func (g *JobGenerator) createAlbumTaskWithParent(albumPath string, parent *entity.Album) Task[string] {
-	return func(ctx context.Context) entity.Result[string] {
-		// FIX: look for parent id based on the path.
-		// FIX: don't create the album from full path
-		album := entity.NewAlbum(albumPath)
+// createAlbumTaskWithParent creates a task to create an album with proper parent relationship.
+// The album is created with the id it was given at generation time because the media tasks of the folder use it.
+func (g *JobGenerator) createAlbumTaskWithParent(album entity.Album, parent *entity.Album) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		if existing, err := g.albumSrv.GetByPath(ctx, album.Path); err == nil {
+			return entity.NewResult(fmt.Sprintf("album %s exists", existing.Path))
+		}
+
 		if parent != nil {
+			// the album service builds the path from the parent path and the folder name
 			album.ParentId = &parent.ID
+			album.Path = path.Base(album.Path)
+		}
+
+		// the relationships are written first so the album is never visible without them
+		if err := g.writeRelationships(ctx, albumRelationships(album)...); err != nil {
+			return entity.NewResultWithError[string](err)
 		}
 
 		createdAlbum, err := g.albumSrv.Create(ctx, album)
 		if err != nil {
+			if delErr := g.deleteRelationships(ctx, entity.NewAlbumResource(album.ID)); delErr != nil {
+				err = errors.Join(err, delErr)
+			}
 			return entity.NewResultWithError[string](err)
 		}
 		return entity.NewResult(fmt.Sprintf("album %s created", createdAlbum.Path))
-	}
+	})
 }
 
-// createMediaTask creates a task to process a media file
-func (g *JobGenerator) createMediaTask(mediaFilePath string, album entity.Album) Task[string] {
-	return func(ctx context.Context) entity.Result[string] {
+// createMediaTask creates a task to process a media file. The relationship of the media is added to batch,
+// it is written by the task of createWriteRelationshipsTask.
+func (g *JobGenerator) createMediaTask(mediaFilePath string, album entity.Album, batch *relationshipBatch) Task[string] {
+	return cpuBound(func(ctx context.Context) entity.Result[string] {
 		// Extract filename from full path
 		filename := path.Base(mediaFilePath)
 
@@ -159,11 +528,161 @@ func (g *JobGenerator) createMediaTask(mediaFilePath string, album entity.Album)
 		contentFn := g.mediaSrv.GetContentFn(ctx, media)
 		media.Content = contentFn
 
-		// Process the media
-		createdMedia, err := g.mediaSrv.WriteMedia(ctx, media)
+		// Process the media, the attributes of its file are recorded with its relationship when there is one
+		createdMedia, err := g.mediaSrv.writeMedia(ctx, media, g.relationships == nil)
 		if err != nil {
 			return entity.NewResultWithError[string](err)
 		}
+
+		// the media of a changed file keeps its id, writing its relationship again changes nothing
+		batch.add(mediaParentRelationship(createdMedia.ID, album.ID), createdMedia.ID, createdMedia.File)
 		return entity.NewResult(fmt.Sprintf("media %s processed", createdMedia.Filepath()))
+	})
+}
+
+// createWriteRelationshipsTask creates a task writing the relationships collected in batch, by chunks of
+// relationshipsPerWrite relationships, then recording the attributes of the files of their media
+func (g *JobGenerator) createWriteRelationshipsTask(batch *relationshipBatch) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		relationships, files := batch.take()
+		for chunk := range slices.Chunk(relationships, relationshipsPerWrite) {
+			if err := g.writeRelationships(ctx, chunk...); err != nil {
+				return entity.NewResultWithError[string](err)
+			}
+		}
+		if err := g.mediaSrv.recordFiles(ctx, files); err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+		return entity.NewResult(fmt.Sprintf("%d media relationships written", len(relationships)))
+	})
+}
+
+// createMoveTask creates a task moving a media whose file was moved to another folder.
+// The album is read when the task runs because it is created by the job of its folder.
+func (g *JobGenerator) createMoveTask(media entity.Media, mediaFilePath string) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		album, err := g.albumSrv.GetByPath(ctx, path.Dir(mediaFilePath))
+		if err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+
+		movedMedia, err := g.mediaSrv.Relocate(ctx, media.ID, *album, path.Base(mediaFilePath))
+		if err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+
+		if media.Album.ID != album.ID {
+			removed := []entity.Relationship{mediaParentRelationship(media.ID, media.Album.ID)}
+			added := []entity.Relationship{mediaParentRelationship(media.ID, album.ID)}
+			if err := g.updateRelationships(ctx, removed, added); err != nil {
+				return entity.NewResultWithError[string](err)
+			}
+		}
+		return entity.NewResult(fmt.Sprintf("media %s moved to %s", media.Filepath(), movedMedia.Filepath()))
+	})
+}
+
+// createReconcileJob creates the job removing the orphaned albums and media of the plan, nil if there are none.
+func (g *JobGenerator) createReconcileJob(albumPath string, plan *syncPlan) *SyncJob {
+	if len(plan.orphanAlbums) == 0 && len(plan.orphanMedia) == 0 {
+		return nil
+	}
+
+	taskList := entity.NewLinkedList[Task[string]]()
+	for _, album := range plan.orphanAlbums {
+		taskList.PushBack(g.createForgetAlbumTask(album))
 	}
+	for _, media := range plan.orphanMedia {
+		taskList.PushBack(g.createForgetMediaTask(media))
+	}
+
+	job, err := NewSyncJob(taskList, map[string]string{
+		"path": albumPath,
+		"type": reconcileJob,
+	})
+	if err != nil {
+		return nil
+	}
+	return job
+}
+
+// createForgetAlbumTask creates a task putting in the trash an album whose folder is gone, with its subalbums
+// and media. Their relationships are kept until the trash is purged.
+func (g *JobGenerator) createForgetAlbumTask(album entity.Album) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		if err := g.albumSrv.Forget(ctx, album); err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+		return entity.NewResult(fmt.Sprintf("album %s moved to the trash: folder not found", album.Path))
+	})
+}
+
+// createForgetMediaTask creates a task putting in the trash a media whose file is gone.
+func (g *JobGenerator) createForgetMediaTask(media entity.Media) Task[string] {
+	return ioBound(func(ctx context.Context) entity.Result[string] {
+		if err := g.mediaSrv.Forget(ctx, media.ID); err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+		return entity.NewResult(fmt.Sprintf("media %s moved to the trash: file not found", media.Filepath()))
+	})
+}
+
+// writeRelationships writes the relationships of the created albums and media when authorization is enabled.
+func (g *JobGenerator) writeRelationships(ctx context.Context, relationships ...entity.Relationship) error {
+	if g.relationships == nil {
+		return nil
+	}
+	return g.relationships.WriteRelationships(ctx, relationships...)
+}
+
+// updateRelationships replaces the relationships of the moved media when authorization is enabled.
+func (g *JobGenerator) updateRelationships(ctx context.Context, removed, added []entity.Relationship) error {
+	if g.relationships == nil {
+		return nil
+	}
+	return g.relationships.UpdateRelationships(ctx, removed, added)
+}
+
+// deleteRelationships removes the relationships of the albums which could not be created when authorization
+// is enabled.
+func (g *JobGenerator) deleteRelationships(ctx context.Context, resources ...entity.Resource) error {
+	if g.relationships == nil {
+		return nil
+	}
+	for _, resource := range resources {
+		if err := g.relationships.DeleteRelationships(ctx, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// albumRelationships returns the relationships of an album created by a sync: its datastore and its parent album.
+// Unlike an album created by a user it has no owner, the users see it through the permissions they have on
+// its parent album or on the datastore.
+func albumRelationships(album entity.Album) []entity.Relationship {
+	relationships := []entity.Relationship{
+		entity.NewRelationship(
+			entity.NewDatastoreSubject(entity.LocalDatastore),
+			entity.NewAlbumResource(album.ID),
+			entity.DatastoreRelationship,
+		),
+	}
+	if album.ParentId != nil {
+		relationships = append(relationships, entity.NewRelationship(
+			entity.NewAlbumSubject(*album.ParentId),
+			entity.NewAlbumResource(album.ID),
+			entity.ParentRelationship,
+		))
+	}
+	return relationships
+}
+
+// mediaParentRelationship returns the relationship of a media with its album
+func mediaParentRelationship(mediaID, albumID string) entity.Relationship {
+	return entity.NewRelationship(
+		entity.NewAlbumSubject(albumID),
+		entity.NewMediaResource(mediaID),
+		entity.ParentRelationship,
+	)
 }
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobGenerator plan", func() {
+	var (
+		ctx       context.Context
+		tmpDir    string
+		generator *JobGenerator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "photos-ng-plan-test-*")
+		Expect(err).To(BeNil())
+
+		// the plan only reads the files, the albums and media are given to it
+		generator = &JobGenerator{fs: fs.NewFsDatastore(tmpDir)}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	// writeFiles writes the files with their content, the folders are created
+	writeFiles := func(files map[string]string) {
+		for filePath, content := range files {
+			fullPath := filepath.Join(tmpDir, filePath)
+			Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(fullPath, []byte(content), 0644)).To(Succeed())
+		}
+	}
+
+	walk := func(albumPath string) *entity.FolderNode {
+		tree, err := generator.fs.WalkTree(ctx, albumPath)
+		Expect(err).To(BeNil())
+		return tree
+	}
+
+	// knownMedia returns a media of the album recorded with the attributes of its file in the tree
+	knownMedia := func(tree *entity.FolderNode, album entity.Album, filename, content string) entity.Media {
+		media := entity.NewMedia(filename, album)
+		media.Hash = fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+		for _, node := range tree.GetAllNodes() {
+			if info, found := node.MediaFileInfos[media.Filepath()]; found {
+				media.File = &info
+			}
+		}
+		return media
+	}
+
+	It("plans to create everything when nothing is known", func() {
+		writeFiles(map[string]string{
+			"photos/a.jpg":      "a",
+			"photos/2023/b.jpg": "b",
+		})
+
+		plan, err := generator.plan(ctx, walk("photos"), "photos", nil, nil, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+
+		Expect(plan.albums).To(BeEmpty())
+		Expect(plan.known).To(BeEmpty())
+		Expect(plan.synced).To(BeEmpty())
+		Expect(plan.moved).To(BeEmpty())
+		Expect(plan.orphanAlbums).To(BeEmpty())
+		Expect(plan.orphanMedia).To(BeEmpty())
+	})
+
+	It("records the attributes of the known files unless the sync is a full rescan", func() {
+		writeFiles(map[string]string{"photos/a.jpg": "a"})
+		tree := walk("photos")
+		album := entity.NewAlbum("photos")
+		media := knownMedia(tree, album, "a.jpg", "a")
+		Expect(media.File).ToNot(BeNil())
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{album}, []entity.Media{media}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.albums).To(HaveKey("photos"))
+		Expect(plan.known).To(HaveKey("photos/a.jpg"))
+		Expect(plan.synced).To(HaveKeyWithValue("photos/a.jpg", *media.File))
+
+		plan, err = generator.plan(ctx, tree, "photos", []entity.Album{album}, []entity.Media{media}, &SyncOptions{FullRescan: true})
+		Expect(err).To(BeNil())
+		Expect(plan.known).To(HaveKey("photos/a.jpg"))
+		Expect(plan.synced).To(BeEmpty())
+	})
+
+	It("removes the media whose file is gone from a folder which still exists", func() {
+		writeFiles(map[string]string{"photos/a.jpg": "a"})
+		tree := walk("photos")
+		album := entity.NewAlbum("photos")
+		kept := knownMedia(tree, album, "a.jpg", "a")
+		removed := knownMedia(tree, album, "b.jpg", "b")
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{album}, []entity.Media{kept, removed}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.orphanAlbums).To(BeEmpty())
+		Expect(plan.orphanMedia).To(ConsistOf(removed))
+	})
+
+	It("removes the albums whose folder is gone without listing their subalbums and media", func() {
+		writeFiles(map[string]string{"photos/a.jpg": "a"})
+		tree := walk("photos")
+		root := entity.NewAlbum("photos")
+		removed := entity.NewAlbum("photos/2023")
+		subalbum := entity.NewAlbum("photos/2023/summer")
+		media := knownMedia(tree, subalbum, "b.jpg", "b")
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{subalbum, root, removed}, []entity.Media{media}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.albums).To(HaveLen(3))
+		Expect(plan.orphanAlbums).To(ConsistOf(removed))
+		Expect(plan.orphanMedia).To(BeEmpty())
+	})
+
+	It("moves the media whose file was moved to another folder", func() {
+		writeFiles(map[string]string{
+			"photos/2024/a.jpg": "moved content",
+			"photos/2024/b.jpg": "other content",
+		})
+		tree := walk("photos")
+		root := entity.NewAlbum("photos")
+		removed := entity.NewAlbum("photos/2023")
+		media := knownMedia(tree, removed, "a.jpg", "moved content")
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{root, removed}, []entity.Media{media}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.moved).To(HaveLen(1))
+		Expect(plan.moved).To(HaveKeyWithValue("photos/2024/a.jpg", media))
+		Expect(plan.orphanAlbums).To(ConsistOf(removed))
+		Expect(plan.orphanMedia).To(BeEmpty())
+	})
+
+	It("does not hash the new files whose size is not the one of an orphan", func() {
+		writeFiles(map[string]string{"photos/2024/a.jpg": "moved content"})
+		tree := walk("photos")
+		album := entity.NewAlbum("photos/2023")
+		media := knownMedia(tree, album, "a.jpg", "moved content")
+		// the file recorded for the media had another size, it cannot be the same file
+		media.File = &entity.FileInfo{Size: 1}
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{album}, []entity.Media{media}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.moved).To(BeEmpty())
+		Expect(plan.orphanAlbums).To(ConsistOf(album))
+	})
+
+	It("keeps the albums and media of the unreadable folders", func() {
+		tree := entity.NewFolderNode("photos")
+		tree.AddMediaFile("photos/a.jpg", entity.FileInfo{Size: 1})
+		tree.UnreadableItems = []string{"photos/locked"}
+
+		root := entity.NewAlbum("photos")
+		locked := entity.NewAlbum("photos/locked")
+		inside := entity.NewAlbum("photos/locked/inside")
+		media := entity.NewMedia("b.jpg", inside)
+
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{root, locked, inside}, []entity.Media{media}, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.orphanAlbums).To(BeEmpty())
+		Expect(plan.orphanMedia).To(BeEmpty())
+	})
+
+	It("refuses an empty folder while albums are known below it", func() {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, "photos"), 0755)).To(Succeed())
+		tree := walk("photos")
+		root := entity.NewAlbum("photos")
+
+		_, err := generator.plan(ctx, tree, "photos", []entity.Album{root, entity.NewAlbum("photos/2023")}, nil, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeAssignableToTypeOf(&ConflictError{}))
+
+		// the album of the empty folder itself is not removed by the sync
+		plan, err := generator.plan(ctx, tree, "photos", []entity.Album{root}, nil, NewSyncOptionsWithOptionsAndDefaults())
+		Expect(err).To(BeNil())
+		Expect(plan.orphanAlbums).To(BeEmpty())
+	})
+})
+
+// failingRelationships refuses every write
+type failingRelationships struct{}
+
+func (failingRelationships) WriteRelationships(ctx context.Context, relationships ...entity.Relationship) error {
+	return errors.New("authorization unavailable")
+}
+
+func (failingRelationships) UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error {
+	return errors.New("authorization unavailable")
+}
+
+func (failingRelationships) DeleteRelationships(ctx context.Context, resource entity.Resource) error {
+	return errors.New("authorization unavailable")
+}
+
+var _ = Describe("JobGenerator relationships", func() {
+	It("collects the attributes of the files with the relationships of their media", func() {
+		batch := &relationshipBatch{}
+		file := entity.FileInfo{Size: 10}
+		batch.add(mediaParentRelationship("media", "album"), "media", &file)
+		batch.add(mediaParentRelationship("unknown", "album"), "unknown", nil)
+
+		relationships, files := batch.take()
+		Expect(relationships).To(HaveLen(2))
+		Expect(files).To(Equal(map[string]entity.FileInfo{"media": file}))
+
+		relationships, files = batch.take()
+		Expect(relationships).To(BeEmpty())
+		Expect(files).To(BeEmpty())
+	})
+
+	It("does not record the attributes of the files when the relationships are not written", func() {
+		// the generator has no media service, recording the files would fail
+		generator := &JobGenerator{relationships: failingRelationships{}}
+
+		batch := &relationshipBatch{}
+		batch.add(mediaParentRelationship("media", "album"), "media", &entity.FileInfo{Size: 10})
+
+		result := generator.createWriteRelationshipsTask(batch)(context.Background())
+		Expect(result.Err).To(MatchError("authorization unavailable"))
+	})
+})
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+// parentProgressInterval is the least time between two publications of the progress of a parent job
+// whose status did not change
+const parentProgressInterval = time.Second
+
+// ParentJob tracks the sub-jobs of an operation, like the jobs syncing the folders of a tree, as one job.
+// It has no task of its own, the scheduler runs its sub-jobs and its status is computed from theirs.
+// The sub-jobs tell it about their changes, it keeps running counts of their statuses and of their
+// remaining tasks so its status is computed without reading every sub-job.
+type ParentJob struct {
+	Object
+	subJobs     []*SyncJob
+	listener    JobListener
+	subStates   map[uuid.UUID]subJobState // Last known state of each sub-job
+	counts      map[entity.JobStatus]int  // Number of sub-jobs by status
+	finished    int                       // Number of finished sub-jobs
+	remaining   int                       // Number of tasks of the sub-jobs not started yet
+	published   entity.JobStatus          // Status of the last published progress
+	publishedAt time.Time                 // When the last progress was published
+	mu          sync.Mutex                // Mutex for the counters
+	notifyMu    sync.Mutex                // Keeps the progress published in the order of the changes
+}
+
+// subJobState is the state of a sub-job counted by its parent job
+type subJobState struct {
+	status    entity.JobStatus
+	remaining int
+}
+
+// NewParentJob creates the parent job of the sub-jobs. The sub-jobs are given the id of the parent job
+// in their metadata.
+func NewParentJob(subJobs []*SyncJob, opts map[string]string) *ParentJob {
+	if opts == nil {
+		opts = make(map[string]string)
+	}
+
+	p := &ParentJob{
+		Object: Object{
+			ID:        uuid.New(),
+			createdAt: time.Now(),
+			opts:      opts,
+		},
+		subJobs:   subJobs,
+		subStates: make(map[uuid.UUID]subJobState, len(subJobs)),
+		counts:    make(map[entity.JobStatus]int),
+	}
+
+	for _, j := range subJobs {
+		j.opts["parentId"] = p.ID.String()
+		j.parent = p
+		p.total += j.total
+		p.count(j.Status())
+	}
+	p.published = p.progress().Status
+
+	return p
+}
+
+func (p *ParentJob) GetID() uuid.UUID {
+	return p.ID
+}
+
+// Start does nothing, the sub-jobs are started by the scheduler
+func (p *ParentJob) Start(ctx context.Context) error {
+	return nil
+}
+
+// Pause pauses the running sub-jobs, or resumes the paused ones if there is no running sub-job
+func (p *ParentJob) Pause() {
+	status := p.Status().Status
+	for _, j := range p.subJobs {
+		switch j.Status().Status {
+		case entity.StatusRunning:
+			if status == entity.StatusRunning {
+				j.Pause()
+			}
+		case entity.StatusPause:
+			if status == entity.StatusPause {
+				j.Pause()
+			}
+		}
+	}
+}
+
+// Cancel stops the sub-jobs which are not finished
+func (p *ParentJob) Cancel() error {
+	var errs []error
+	for _, j := range p.subJobs {
+		if err := j.Cancel(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to stop %d sub-jobs: %v", len(errs), errs)
+	}
+	return nil
+}
+
+// Status returns the status computed from the sub-jobs:
+//   - running while a sub-job runs, paused while sub-jobs are paused and none runs
+//   - once all the sub-jobs are finished, stopped if one was stopped, failed if one failed, completed otherwise
+//   - pending before a sub-job starts
+//
+// The results of the sub-jobs are not included, they are returned by Results.
+func (p *ParentJob) Status() entity.JobProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.progress()
+}
+
+// Results returns the results of the sub-jobs
+func (p *ParentJob) Results() []entity.JobResult {
+	results := []entity.JobResult{}
+	for _, j := range p.subJobs {
+		results = append(results, j.Status().Results...)
+	}
+	return results
+}
+
+// progress returns the status computed from the counters. The caller holds p.mu.
+func (p *ParentJob) progress() entity.JobProgress {
+	progress := entity.JobProgress{
+		Id:          p.ID,
+		Path:        p.opts["path"],
+		Status:      entity.StatusPending,
+		CreatedAt:   p.createdAt,
+		Total:       p.total,
+		Remaining:   p.remaining,
+		StartedAt:   p.startedAt,
+		CompletedAt: p.completedAt,
+		Results:     []entity.JobResult{},
+		Metadata:    p.opts,
+	}
+
+	switch {
+	case p.counts[entity.StatusStopping] > 0:
+		progress.Status = entity.StatusStopping
+	case p.counts[entity.StatusRunning] > 0:
+		progress.Status = entity.StatusRunning
+	case p.counts[entity.StatusPause] > 0:
+		progress.Status = entity.StatusPause
+	case p.finished == len(p.subJobs):
+		switch {
+		case p.counts[entity.StatusStopped] > 0 || p.counts[entity.StatusInterrupted] > 0:
+			progress.Status = entity.StatusStopped
+		case p.counts[entity.StatusFailed] > 0:
+			progress.Status = entity.StatusFailed
+			progress.Reason = fmt.Sprintf("%d of %d sub-jobs failed", p.counts[entity.StatusFailed], len(p.subJobs))
+		default:
+			progress.Status = entity.StatusCompleted
+		}
+	case p.finished > 0:
+		// the remaining sub-jobs wait for their dependencies
+		progress.Status = entity.StatusRunning
+	}
+
+	if !progress.Status.Finished() {
+		progress.CompletedAt = nil
+	}
+
+	return progress
+}
+
+// count updates the counters with the status of a sub-job. The caller holds p.mu.
+func (p *ParentJob) count(status entity.JobProgress) {
+	if previous, found := p.subStates[status.Id]; found {
+		p.counts[previous.status]--
+		if previous.status.Finished() {
+			p.finished--
+		}
+		p.remaining -= previous.remaining
+	}
+
+	p.subStates[status.Id] = subJobState{status: status.Status, remaining: status.Remaining}
+	p.counts[status.Status]++
+	if status.Status.Finished() {
+		p.finished++
+	}
+	p.remaining += status.Remaining
+
+	if status.StartedAt != nil && (p.startedAt == nil || status.StartedAt.Before(*p.startedAt)) {
+		p.startedAt = status.StartedAt
+	}
+	if status.CompletedAt != nil && (p.completedAt == nil || status.CompletedAt.After(*p.completedAt)) {
+		p.completedAt = status.CompletedAt
+	}
+}
+
+// subJobChanged counts the new state of a sub-job. The progress of the parent job is written to the listener
+// and published when its status changed, or at most every parentProgressInterval while only its remaining
+// tasks change.
+func (p *ParentJob) subJobChanged(j *SyncJob) {
+	p.notifyMu.Lock()
+	defer p.notifyMu.Unlock()
+
+	// the status is read under notifyMu so the last change counted is the last one of the sub-job
+	status := j.Status()
+
+	p.mu.Lock()
+	p.count(status)
+	progress := p.progress()
+	now := time.Now()
+	changed := progress.Status != p.published || now.Sub(p.publishedAt) >= parentProgressInterval
+	if changed {
+		p.published = progress.Status
+		p.publishedAt = now
+	}
+	p.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if p.listener != nil {
+		p.listener.JobChanged(p)
+	}
+	GetJobEventBus().Publish(entity.NewJobEvent(entity.JobChangedEvent, progress))
+}
+
+func (p *ParentJob) Metadata() map[string]string {
+	return p.opts
+}
+
+// SetListener sets the listener told about the changes of the job and of its sub-jobs
+func (p *ParentJob) SetListener(listener JobListener) {
+	p.listener = listener
+	for _, j := range p.subJobs {
+		j.SetListener(p)
+	}
+}
+
+// Ready returns true, the parent job waits for nothing itself
+func (p *ParentJob) Ready() (bool, error) {
+	return true, nil
+}
+
+// Fail fails the sub-jobs which did not start
+func (p *ParentJob) Fail(reason string) {
+	for _, j := range p.subJobs {
+		j.Fail(reason)
+	}
+}
+
+// SubJobs returns the sub-jobs
+func (p *ParentJob) SubJobs() []Job {
+	jobs := make([]Job, 0, len(p.subJobs))
+	for _, j := range p.subJobs {
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// JobChanged tells the listener about the change of a sub-job. The sub-job tells the parent job about it
+// with subJobChanged.
+func (p *ParentJob) JobChanged(job Job) {
+	if p.listener == nil {
+		return
+	}
+	p.listener.JobChanged(job)
+}
+
+// TaskDone tells the listener about the task of a sub-job. The sub-job tells the parent job about its
+// progress with subJobChanged.
+func (p *ParentJob) TaskDone(job Job, position int, result entity.JobResult) {
+	if p.listener == nil {
+		return
+	}
+	p.listener.TaskDone(job, position, result)
+}
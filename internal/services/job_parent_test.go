@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParentJob", func() {
+	var (
+		first  *services.SyncJob
+		second *services.SyncJob
+		parent *services.ParentJob
+	)
+
+	BeforeEach(func() {
+		var err error
+		first, err = services.NewSyncJob(newTaskList(succeed("album"), succeed("photo")), map[string]string{"path": "photos"})
+		Expect(err).To(BeNil())
+		second, err = services.NewSyncJob(newTaskList(succeed("album"), failWith(errors.New("unreadable"))), map[string]string{"path": "photos/2023"})
+		Expect(err).To(BeNil())
+
+		parent = services.NewParentJob([]*services.SyncJob{first, second}, map[string]string{"path": "photos"})
+	})
+
+	It("gives its id to the sub-jobs", func() {
+		Expect(first.Metadata()).To(HaveKeyWithValue("parentId", parent.ID.String()))
+		Expect(second.Metadata()).To(HaveKeyWithValue("parentId", parent.ID.String()))
+		Expect(parent.SubJobs()).To(HaveLen(2))
+	})
+
+	It("is pending before a sub-job starts", func() {
+		status := parent.Status()
+		Expect(status.Status).To(Equal(entity.StatusPending))
+		Expect(status.Path).To(Equal("photos"))
+		Expect(status.Total).To(Equal(4))
+		Expect(status.Remaining).To(Equal(4))
+		Expect(status.StartedAt).To(BeNil())
+		Expect(status.CompletedAt).To(BeNil())
+	})
+
+	It("is running while a sub-job runs", func() {
+		blocked, err := services.NewSyncJob(newTaskList(blockUntilCancelled()), nil)
+		Expect(err).To(BeNil())
+		parent = services.NewParentJob([]*services.SyncJob{first, blocked}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- blocked.Start(ctx)
+		}()
+
+		Eventually(func() entity.JobStatus { return parent.Status().Status }).Should(Equal(entity.StatusRunning))
+		Expect(parent.Status().StartedAt).ToNot(BeNil())
+
+		cancel()
+		Eventually(done).Should(Receive(MatchError(context.Canceled)))
+	})
+
+	It("is running while sub-jobs wait for the finished ones", func() {
+		Expect(first.Start(context.Background())).To(Succeed())
+
+		status := parent.Status()
+		Expect(status.Status).To(Equal(entity.StatusRunning))
+		Expect(status.Remaining).To(Equal(2))
+		Expect(status.CompletedAt).To(BeNil())
+	})
+
+	It("completes once all the sub-jobs completed", func() {
+		Expect(first.Start(context.Background())).To(Succeed())
+		Expect(second.Start(context.Background())).To(Succeed())
+
+		status := parent.Status()
+		Expect(status.Status).To(Equal(entity.StatusCompleted))
+		Expect(status.Remaining).To(Equal(0))
+		Expect(status.StartedAt).To(Equal(first.Status().StartedAt))
+		Expect(status.CompletedAt).To(Equal(second.Status().CompletedAt))
+
+		// the results are read from the sub-jobs
+		Expect(status.Results).To(BeEmpty())
+		Expect(parent.Results()).To(HaveLen(4))
+	})
+
+	It("fails once all the sub-jobs are finished if one failed", func() {
+		Expect(first.Start(context.Background())).To(Succeed())
+		second.Fail("parent album failed")
+
+		status := parent.Status()
+		Expect(status.Status).To(Equal(entity.StatusFailed))
+		Expect(status.Reason).To(Equal("1 of 2 sub-jobs failed"))
+	})
+
+	It("is stopped once all the sub-jobs are finished if one was stopped", func() {
+		Expect(first.Start(context.Background())).To(Succeed())
+		Expect(parent.Cancel()).To(Succeed())
+
+		Expect(second.Status().Status).To(Equal(entity.StatusStopped))
+		Expect(parent.Status().Status).To(Equal(entity.StatusStopped))
+	})
+
+	It("fails the sub-jobs which did not start", func() {
+		Expect(first.Start(context.Background())).To(Succeed())
+
+		parent.Fail("interrupted")
+
+		Expect(first.Status().Status).To(Equal(entity.StatusCompleted))
+		Expect(second.Status().Status).To(Equal(entity.StatusFailed))
+		Expect(parent.Status().Status).To(Equal(entity.StatusFailed))
+	})
+
+	It("tells its listener about its status changes and the changes of its sub-jobs", func() {
+		listener := &recordingListener{}
+		parent.SetListener(listener)
+
+		Expect(first.Start(context.Background())).To(Succeed())
+		Expect(second.Start(context.Background())).To(Succeed())
+
+		// the progress of the parent job is told when its status changes
+		Expect(listener.statuses(parent.ID)).To(Equal([]entity.JobStatus{entity.StatusRunning, entity.StatusCompleted}))
+		Expect(listener.statuses(first.ID)).To(Equal([]entity.JobStatus{entity.StatusRunning, entity.StatusCompleted}))
+		Expect(listener.statuses(second.ID)).To(Equal([]entity.JobStatus{entity.StatusRunning, entity.StatusCompleted}))
+		Expect(listener.positions()).To(HaveLen(4))
+	})
+})
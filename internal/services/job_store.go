@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// interruptedReason is the reason of the jobs which were not finished when the server stopped
+const interruptedReason = "interrupted by a server restart"
+
+// JobStore keeps the history of the scheduler jobs in the database: the jobs, their status and the result
+// of every task they ran. It listens to the jobs of the scheduler so the history is written as they run.
+type JobStore struct {
+	dt     *pg.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewJobStore creates a new job store
+func NewJobStore(dt *pg.Datastore) *JobStore {
+	return &JobStore{
+		dt:     dt,
+		logger: logger.New("job_store"),
+	}
+}
+
+// JobChanged writes the job. A failed write is logged, it does not stop the job.
+func (s *JobStore) JobChanged(job Job) {
+	status := job.Status()
+	err := s.dt.WriteTx(context.Background(), func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteJob(ctx, status)
+	})
+	if err != nil {
+		s.logger.Operation("write_job").
+			WithString(JobID, status.Id.String()).
+			Build().Error(err).Log()
+	}
+}
+
+// TaskDone writes the result of the task and the progress of the job. A failed write is logged, it does not
+// stop the job.
+func (s *JobStore) TaskDone(job Job, position int, result entity.JobResult) {
+	status := job.Status()
+	err := s.dt.WriteTx(context.Background(), func(ctx context.Context, writer *pg.Writer) error {
+		if err := writer.WriteJob(ctx, status); err != nil {
+			return err
+		}
+		return writer.WriteJobResult(ctx, status.Id, position, result)
+	})
+	if err != nil {
+		s.logger.Operation("write_job_result").
+			WithString(JobID, status.Id.String()).
+			WithInt("task_index", position).
+			Build().Error(err).Log()
+	}
+}
+
+// List returns a page of the jobs of the history having one of the statuses, all of them if there are none,
+// the oldest first. The excluded jobs and the jobs of the excluded types are left out and the results of
+// the tasks are not loaded.
+func (s *JobStore) List(ctx context.Context, limit, offset int, excluded, excludedTypes []string, statuses ...entity.JobStatus) ([]entity.JobProgress, error) {
+	opts := append(historyFilters(excluded, excludedTypes, statuses), pg.Limit(limit), pg.Offset(offset))
+
+	jobs, err := s.dt.QueryJobs(ctx, opts...)
+	if err != nil {
+		return nil, NewInternalError(ctx, "list_job_history", "query_jobs", err)
+	}
+	return jobs, nil
+}
+
+// Count returns the number of jobs of the history having one of the statuses, all of them if there are none.
+// The excluded jobs and the jobs of the excluded types are not counted.
+func (s *JobStore) Count(ctx context.Context, excluded, excludedTypes []string, statuses ...entity.JobStatus) (int, error) {
+	count, err := s.dt.CountJobs(ctx, historyFilters(excluded, excludedTypes, statuses)...)
+	if err != nil {
+		return 0, NewInternalError(ctx, "count_job_history", "count_jobs", err)
+	}
+	return count, nil
+}
+
+// Get returns a job of the history with the results of its tasks, nil if it is not found.
+func (s *JobStore) Get(ctx context.Context, id string) (*entity.JobProgress, error) {
+	job, err := s.find(ctx, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	results, err := s.dt.QueryJobResults(ctx, job.Id)
+	if err != nil {
+		return nil, NewInternalError(ctx, "get_job_history", "query_job_results", err).
+			WithContext(JobID, id)
+	}
+	job.Results = results
+
+	return job, nil
+}
+
+// find returns a job of the history without the results of its tasks, nil if it is not found.
+func (s *JobStore) find(ctx context.Context, id string) (*entity.JobProgress, error) {
+	jobs, err := s.dt.QueryJobs(ctx, pg.FilterJobByID(id))
+	if err != nil {
+		return nil, NewInternalError(ctx, "get_job_history", "query_jobs", err).
+			WithContext(JobID, id)
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+// historyFilters returns the filters of a history query
+func historyFilters(excluded, excludedTypes []string, statuses []entity.JobStatus) []pg.QueryOption {
+	opts := []pg.QueryOption{pg.ExcludeJobs(excluded...), pg.ExcludeJobTypes(excludedTypes...)}
+	if len(statuses) > 0 {
+		opts = append(opts, pg.FilterJobsByStatus(statuses...))
+	}
+	return opts
+}
+
+// Interrupt marks the jobs of the history which were not finished as interrupted and returns them.
+// It is called at startup, before any job runs: such jobs were running or waiting when the server stopped.
+func (s *JobStore) Interrupt(ctx context.Context) ([]entity.JobProgress, error) {
+	var ids []string
+	err := s.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		var err error
+		ids, err = writer.InterruptJobs(ctx, interruptedReason, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "interrupt_jobs", err).AtStep("interrupt_jobs")
+	}
+
+	jobs := make([]entity.JobProgress, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			jobs = append(jobs, *job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// Purge deletes the jobs of the history which finished before the given time and returns how many were deleted.
+func (s *JobStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := s.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		var err error
+		deleted, err = writer.DeleteFinishedJobs(ctx, before)
+		return err
+	})
+	if err != nil {
+		return 0, NewDatabaseWriteError(ctx, "purge_job_history", err).AtStep("delete_jobs")
+	}
+	return deleted, nil
+}
+
+// SchedulePurgeJobHistory deletes the jobs of the history older than retention every interval until the
+// context is done.
+func SchedulePurgeJobHistory(ctx context.Context, store *JobStore, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tracer := store.logger.WithContext(ctx).Operation("purge_job_history").
+			WithParam("retention", retention.String()).
+			Build()
+
+		if deleted, err := store.Purge(ctx, time.Now().Add(-retention)); err != nil {
+			tracer.Error(err).Log()
+		} else {
+			tracer.Success().WithInt("deleted_jobs", int(deleted)).Log()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,11 +3,15 @@ package services_test
 import (
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
-	"testing"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
@@ -15,6 +19,7 @@ import (
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -28,99 +33,26 @@ func getSampleJPEGPath() string {
 	return filepath.Join(testDir, "testdata/sample.jpg")
 }
 
-func TestJob(t *testing.T) {
-	RegisterFailHandler(Fail)
-	RunSpecs(t, "SyncAlbumJob Suite")
-}
-
-var _ = Describe("SyncAlbumJob", Ordered, func() {
-	var (
-		albumService *services.AlbumService
-		mediaService *services.MediaService
-		dt           *pg.Datastore
-		pgPool       *pgxpool.Pool
-		tmpDir       string
-		rootAlbum    entity.Album
-		realFs       *fs.Datastore
-	)
-
-	BeforeAll(func() {
-		// Set up PostgreSQL connection
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
-		Expect(err).To(BeNil())
-		Expect(pgDt).ToNot(BeNil())
-
-		pgxConfig, err := pgxpool.ParseConfig(pgUri)
-		Expect(err).To(BeNil())
-
-		pool, err := pgxpool.NewWithConfig(context.TODO(), pgxConfig)
-		Expect(err).To(BeNil())
-		Expect(pool).ToNot(BeNil())
-
-		dt = pgDt
-		pgPool = pool
-
-		// Clean up any existing data
-		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
-		Expect(err).To(BeNil())
-		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
-		Expect(err).To(BeNil())
-	})
-
-	AfterAll(func() {
-		dt.Close()
-		if tmpDir != "" {
-			os.RemoveAll(tmpDir)
-		}
-	})
-
-	BeforeEach(func() {
-		// Create a temporary directory structure for testing
-		var err error
-		tmpDir, err = os.MkdirTemp("", "photos-ng-sync-test-*")
-		Expect(err).To(BeNil())
-
-		// Set up filesystem datastore
-		realFs = fs.NewFsDatastore(tmpDir)
-
-		// Set up services
-		albumService = services.NewAlbumService(dt, realFs)
-		mediaService = services.NewMediaService(dt, realFs)
-
-		// Create root album for testing - fs datastore works with relative paths
-		rootAlbum = entity.NewAlbum("photos")
-		rootAlbum.Description = stringPtr("Root Photos Album")
-	})
-
-	AfterEach(func() {
-		// Clean up database
-		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
-		Expect(err).To(BeNil())
-		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
-		Expect(err).To(BeNil())
-
-		// Clean up filesystem
-		if tmpDir != "" {
-			os.RemoveAll(tmpDir)
-		}
-	})
-
+var _ = Describe("SyncJob", func() {
 	Context("Job Creation", func() {
 		It("creates a new sync job successfully", func() {
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a"), succeed("b")), map[string]string{"path": "photos"})
 
 			Expect(err).To(BeNil())
 			Expect(syncJob).ToNot(BeNil())
 
 			status := syncJob.Status()
+			Expect(status.Id).To(Equal(syncJob.ID))
+			Expect(status.Path).To(Equal("photos"))
 			Expect(status.Status).To(Equal(entity.StatusPending))
-			Expect(status.Total).To(Equal(0))
-			Expect(status.Remaining).To(Equal(0))
+			Expect(status.Total).To(Equal(2))
+			Expect(status.Remaining).To(Equal(2))
+			Expect(status.Results).To(BeEmpty())
 		})
 
 		It("initializes job with correct timestamps", func() {
 			beforeCreation := time.Now()
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
 			afterCreation := time.Now()
 
 			Expect(err).To(BeNil())
@@ -130,877 +62,965 @@ var _ = Describe("SyncAlbumJob", Ordered, func() {
 			Expect(status.StartedAt).To(BeNil())
 			Expect(status.CompletedAt).To(BeNil())
 		})
-	})
 
-	Context("Album Discovery and Creation", func() {
-		It("discovers and creates albums from directory structure", func() {
-			// Create directory structure - only one level of sub-albums
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {},
-				"photos/2024": {},
-				"photos/2025": {},
-			})
+		It("counts the tasks of all the stages", func() {
+			syncJob, err := services.NewStagedSyncJob([]*entity.LinkedList[services.Task[string]]{
+				newTaskList(succeed("album")),
+				newTaskList(),
+				newTaskList(succeed("photo1"), succeed("photo2")),
+			}, nil)
 
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
 			Expect(err).To(BeNil())
+			Expect(syncJob.Status().Total).To(Equal(3))
+		})
+	})
 
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+	Context("Execution", func() {
+		It("runs the tasks and records their results", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a"), failWith(errors.New("unreadable")), succeed("c")), nil)
 			Expect(err).To(BeNil())
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
+			err = syncJob.Start(context.Background())
 			Expect(err).To(BeNil())
 
-			// Verify job completed successfully
+			// the job completes despite the failed task
 			status := syncJob.Status()
 			Expect(status.Status).To(Equal(entity.StatusCompleted))
+			Expect(status.Total).To(Equal(3))
+			Expect(status.Remaining).To(Equal(0))
+			Expect(status.Results).To(HaveLen(3))
 			Expect(status.StartedAt).ToNot(BeNil())
 			Expect(status.CompletedAt).ToNot(BeNil())
+			Expect(*status.CompletedAt).To(BeTemporally(">=", *status.StartedAt))
 
-			// With LEFT JOIN, we get albums with children nested
-			// The query returns root albums with their direct children in the Children field
-			albums, err := albumService.GetAlbums(context.TODO(), &services.AlbumOptions{Limit: 100})
-			Expect(err).To(BeNil())
-
-			// We should get back the root album with its children nested
-			Expect(albums).To(HaveLen(1))
-
-			rootAlbumResult := albums[0]
-			Expect(rootAlbumResult.Path).To(Equal("photos"))
-			Expect(rootAlbumResult.Children).To(HaveLen(3)) // 2023, 2024, 2025
-
-			// Verify specific child albums exist
-			childPaths := make(map[string]bool)
-			for _, child := range rootAlbumResult.Children {
-				childPaths[child.Path] = true
+			failed := 0
+			for _, result := range status.Results {
+				if result.Err != nil {
+					failed++
+					Expect(result.Err).To(MatchError("unreadable"))
+				}
 			}
-			Expect(childPaths).To(HaveKey("photos/2023"))
-			Expect(childPaths).To(HaveKey("photos/2024"))
-			Expect(childPaths).To(HaveKey("photos/2025"))
+			Expect(failed).To(Equal(1))
 		})
 
-		It("handles empty directory structure", func() {
-			// Create only root directory
-			err := os.MkdirAll(filepath.Join(tmpDir, "photos"), 0755)
-			Expect(err).To(BeNil())
-
-			// Create root album in database
-			_, err = albumService.CreateAlbum(context.TODO(), rootAlbum)
-			Expect(err).To(BeNil())
-
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+		It("completes a job without tasks", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
 			Expect(err).To(BeNil())
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
+			err = syncJob.Start(context.Background())
 			Expect(err).To(BeNil())
 
-			// Verify job completed successfully
 			status := syncJob.Status()
 			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			Expect(status.Total).To(Equal(0)) // No subdirectories or files to process
+			Expect(status.Total).To(Equal(0))
+			Expect(status.Remaining).To(Equal(0))
 		})
 
-		It("handles root album with empty path (starts from root data folder)", func() {
-			// Create some media files in various directories (directories will be created automatically)
-			// NOTE: NO media files in root data folder - they only exist in subdirectories
-			createTestMediaFiles(tmpDir, map[string]string{
-				"2023/summer/beach.jpg":    "SAMPLE_JPEG",
-				"2023/summer/vacation.jpg": "SAMPLE_JPEG",
-				"2023/winter/skiing.jpg":   "SAMPLE_JPEG",
-				"2024/spring/flowers.jpg":  "SAMPLE_JPEG",
-				"documents/readme.txt":     "SAMPLE_JPEG", // Non-media file, should be ignored
-			})
-
-			// Create root album with empty path (does NOT go in database - represents root data folder)
-			emptyRootAlbum := entity.NewAlbum("")
-			emptyRootAlbum.Description = stringPtr("Root Data Folder")
+		It("starts a stage once the tasks of the previous one are done", func() {
+			var done atomic.Int32
+			slow := func(ctx context.Context) entity.Result[string] {
+				time.Sleep(10 * time.Millisecond)
+				done.Add(1)
+				return entity.NewResult("slow")
+			}
+			var seen int32
+			check := func(ctx context.Context) entity.Result[string] {
+				seen = done.Load()
+				return entity.NewResult("check")
+			}
 
-			// DON'T create the root album in database - empty path means "start from root data folder"
-			// The job will discover top-level directories as albums
+			syncJob, err := services.NewStagedSyncJob([]*entity.LinkedList[services.Task[string]]{
+				newTaskList(slow, slow),
+				newTaskList(check),
+			}, nil)
+			Expect(err).To(BeNil())
 
-			syncJob, err := services.NewSyncJob(emptyRootAlbum, albumService, mediaService, realFs)
+			err = syncJob.Start(context.Background())
 			Expect(err).To(BeNil())
+			Expect(seen).To(Equal(int32(2)))
+		})
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
+		It("tells the listener about the tasks and the status changes", func() {
+			listener := &recordingListener{}
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a"), succeed("b")), nil)
 			Expect(err).To(BeNil())
+			syncJob.SetListener(listener)
 
-			// Verify job completed successfully
-			status := syncJob.Status()
+			err = syncJob.Start(context.Background())
+			Expect(err).To(BeNil())
 
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			// Should discover: 2023, 2023/summer, 2023/winter, 2024, 2024/spring, documents = 6 albums
-			// Should process: 2 + 1 + 1 = 4 media files (readme.txt ignored as non-media, no root media)
-			Expect(status.Total).To(Equal(10)) // 6 albums + 4 media files
+			Expect(listener.positions()).To(Equal([]int{0, 1}))
+			Expect(listener.statuses(syncJob.ID)).To(Equal([]entity.JobStatus{entity.StatusRunning, entity.StatusCompleted}))
+		})
 
-			// Verify albums were created - should find top-level directories as albums (no parent)
-			albums, err := albumService.GetAlbums(context.TODO(), &services.AlbumOptions{})
+		It("returns the context error when the context is cancelled", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(blockUntilCancelled(), blockUntilCancelled()), nil)
 			Expect(err).To(BeNil())
 
-			// Top-level albums should be: 2023, 2024, documents (no empty path album in database)
-			topLevelAlbums := []entity.Album{}
-			for _, album := range albums {
-				if album.ParentId == nil { // Top-level albums have no parent
-					topLevelAlbums = append(topLevelAlbums, album)
-				}
-			}
-			Expect(topLevelAlbums).To(HaveLen(3))
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- syncJob.Start(ctx)
+			}()
 
-			// Check paths of top-level albums
-			topLevelPaths := make(map[string]bool)
-			for _, album := range topLevelAlbums {
-				topLevelPaths[album.Path] = true
-			}
-			Expect(topLevelPaths).To(HaveKey("2023"))
-			Expect(topLevelPaths).To(HaveKey("2024"))
-			Expect(topLevelPaths).To(HaveKey("documents"))
+			Eventually(func() entity.JobStatus { return syncJob.Status().Status }).Should(Equal(entity.StatusRunning))
+			cancel()
 
-			// Verify that there's NO album with empty path in the database
-			for _, album := range albums {
-				Expect(album.Path).ToNot(Equal(""))
-			}
+			Eventually(done).Should(Receive(MatchError(context.Canceled)))
+
+			// Job should still report its progress state even after cancellation
+			status := syncJob.Status()
+			Expect(status.Total).To(Equal(2))
+			Expect(status.Remaining).To(BeNumerically(">=", 0))
 		})
 	})
 
-	Context("Media Processing", func() {
-		It("processes media files in album directories", func() {
-			// Create directory structure with media files - only one level
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {"photo1.jpg", "photo2.jpeg", "document.txt", "photo3.png"},
-				"photos/2024": {"vacation1.jpg", "vacation2.JPG"},
-			})
-
-			// Create actual media files
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg":    "SAMPLE_JPEG",
-				"photos/2023/photo2.jpeg":   "SAMPLE_JPEG",
-				"photos/2023/photo3.png":    "SAMPLE_JPEG", // Will be treated as JPEG for simplicity
-				"photos/2024/vacation1.jpg": "SAMPLE_JPEG",
-				"photos/2024/vacation2.JPG": "SAMPLE_JPEG",
-			})
-
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
-			Expect(err).To(BeNil())
-
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+	Context("Cancel", func() {
+		It("stops a pending job", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a")), nil)
 			Expect(err).To(BeNil())
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
-			Expect(err).To(BeNil())
+			Expect(syncJob.Cancel()).To(Succeed())
 
-			// Verify job completed successfully
 			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			Expect(status.StartedAt).ToNot(BeNil())
+			Expect(status.Status).To(Equal(entity.StatusStopped))
 			Expect(status.CompletedAt).ToNot(BeNil())
+		})
 
-			// Verify album structure
-			albums, err := albumService.GetAlbums(context.TODO(), &services.AlbumOptions{Limit: 100})
+		It("stops a running job and drops the results of its running tasks", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(blockUntilCancelled()), nil)
 			Expect(err).To(BeNil())
 
-			// With LEFT JOIN, we get the root album with children and media nested
-			Expect(albums).To(HaveLen(1)) // Just the root album with children nested
-			rootAlbumResult := albums[0]
-			Expect(rootAlbumResult.Children).To(HaveLen(2)) // 2023, 2024
+			done := make(chan error, 1)
+			go func() {
+				done <- syncJob.Start(context.Background())
+			}()
 
-			// Verify media files were processed (5 media files total)
-			allMedia, err := mediaService.GetMedia(context.TODO(), &services.MediaOptions{MediaLimit: 100})
-			Expect(err).To(BeNil())
-			GinkgoWriter.Printf("Found %d media files\n", len(allMedia))
-			Expect(allMedia).To(HaveLen(5))
+			Eventually(func() entity.JobStatus { return syncJob.Status().Status }).Should(Equal(entity.StatusRunning))
+			Expect(syncJob.Cancel()).To(Succeed())
 
-			// Verify specific media files exist
-			mediaFilenames := make(map[string]bool)
-			for _, media := range allMedia {
-				mediaFilenames[media.Filename] = true
-			}
-			Expect(mediaFilenames).To(HaveKey("photo1.jpg"))
-			Expect(mediaFilenames).To(HaveKey("photo2.jpeg"))
-			Expect(mediaFilenames).To(HaveKey("photo3.png"))
-			Expect(mediaFilenames).To(HaveKey("vacation1.jpg"))
-			Expect(mediaFilenames).To(HaveKey("vacation2.JPG"))
-			// document.txt should not be processed
-			Expect(mediaFilenames).ToNot(HaveKey("document.txt"))
+			Eventually(done).Should(Receive(BeNil()))
+			status := syncJob.Status()
+			Expect(status.Status).To(Equal(entity.StatusStopped))
+			Expect(status.Results).To(BeEmpty())
 
-			// Count successful tasks
-			successfulTasks := 0
-			for _, result := range status.Results {
-				if result.Err == nil {
-					successfulTasks++
-				}
-			}
-			Expect(successfulTasks).To(Equal(7))
+			// a stopped job may be stopped again
+			Expect(syncJob.Cancel()).To(Succeed())
+			Expect(syncJob.Status().Status).To(Equal(entity.StatusStopped))
 		})
-
 	})
 
-	Context("Multi-Level Nested Albums", func() {
-		It("processes deeply nested album structures (3+ levels)", func() {
-			// Create a complex nested directory structure - 3 levels deep
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023/summer":        {"vacation1.jpg", "beach.jpg"},
-				"photos/2023/summer/europe": {"paris.jpg", "rome.jpeg"},
-				"photos/2023/winter":        {"skiing.jpg"},
-				"photos/2023/winter/alps":   {"mountain1.jpg", "mountain2.JPG"},
-				"photos/2024/work":          {"conference.jpg"},
-				"photos/2024/work/projects": {"demo.jpg", "presentation.png"},
-			})
-
-			// Create actual media files for all locations
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/summer/vacation1.jpg":           "SAMPLE_JPEG",
-				"photos/2023/summer/beach.jpg":               "SAMPLE_JPEG",
-				"photos/2023/summer/europe/paris.jpg":        "SAMPLE_JPEG",
-				"photos/2023/summer/europe/rome.jpeg":        "SAMPLE_JPEG",
-				"photos/2023/winter/skiing.jpg":              "SAMPLE_JPEG",
-				"photos/2023/winter/alps/mountain1.jpg":      "SAMPLE_JPEG",
-				"photos/2023/winter/alps/mountain2.JPG":      "SAMPLE_JPEG",
-				"photos/2024/work/conference.jpg":            "SAMPLE_JPEG",
-				"photos/2024/work/projects/demo.jpg":         "SAMPLE_JPEG",
-				"photos/2024/work/projects/presentation.png": "SAMPLE_JPEG",
-			})
+	Context("Dependencies", func() {
+		It("is ready without dependencies", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
+			Expect(err).To(BeNil())
 
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
+			ready, err := syncJob.Ready()
 			Expect(err).To(BeNil())
+			Expect(ready).To(BeTrue())
+		})
 
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+		It("is ready once the stages it depends on are done", func() {
+			release := make(chan struct{})
+			dependency, err := services.NewStagedSyncJob([]*entity.LinkedList[services.Task[string]]{
+				newTaskList(succeed("album")),
+				newTaskList(func(ctx context.Context) entity.Result[string] {
+					<-release
+					return entity.NewResult("photo")
+				}),
+			}, nil)
 			Expect(err).To(BeNil())
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
 			Expect(err).To(BeNil())
+			syncJob.DependsOn(dependency, 1)
 
-			// Verify job completed successfully
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			Expect(status.StartedAt).ToNot(BeNil())
-			Expect(status.CompletedAt).ToNot(BeNil())
+			ready, err := syncJob.Ready()
+			Expect(err).To(BeNil())
+			Expect(ready).To(BeFalse())
 
-			// With multi-level nesting, we should have discovered ALL albums recursively
-			// Expected structure: photos/{2023,2024,2023/summer,2023/winter,2024/work,2023/summer/europe,2023/winter/alps,2024/work/projects}
-			// That's 8 albums + 10 media files = 18 total tasks
-			GinkgoWriter.Printf("Expected: 8 albums + 10 media files = 18 tasks, Got: %d tasks\n", status.Total)
-			Expect(status.Total).To(BeNumerically(">=", 18))
+			done := make(chan error, 1)
+			go func() {
+				done <- dependency.Start(context.Background())
+			}()
 
-			// Verify all albums were created - recursive discovery should find all levels
-			albums, err := albumService.GetAlbums(context.TODO(), &services.AlbumOptions{Limit: 100})
-			Expect(err).To(BeNil())
-			Expect(albums).To(HaveLen(1)) // Root album with nested children
+			// the second stage of the dependency is still running
+			Eventually(syncJob.Ready).Should(BeTrue())
+			Expect(dependency.Status().Status).To(Equal(entity.StatusRunning))
 
-			rootAlbumResult := albums[0]
-			GinkgoWriter.Printf("Root album has %d direct children\n", len(rootAlbumResult.Children))
+			close(release)
+			Eventually(done).Should(Receive(BeNil()))
+		})
 
-			// The root should have immediate children (2023, 2024)
-			// Each of those should have their own nested children
-			Expect(rootAlbumResult.Children).To(HaveLen(2)) // 2023, 2024
+		It("waits for every stage when it depends on more stages than the job has", func() {
+			dependency, err := services.NewSyncJob(newTaskList(succeed("album")), nil)
+			Expect(err).To(BeNil())
 
-			// Verify all media files were processed (10 media files total)
-			allMedia, err := mediaService.GetMedia(context.TODO(), &services.MediaOptions{MediaLimit: 100})
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
 			Expect(err).To(BeNil())
-			GinkgoWriter.Printf("Found %d media files in database\n", len(allMedia))
-			Expect(allMedia).To(HaveLen(10))
+			syncJob.DependsOn(dependency, 3)
 
-			// Verify some specific files exist at different nesting levels
-			mediaFilenames := make(map[string]bool)
-			for _, media := range allMedia {
-				mediaFilenames[media.Filename] = true
-			}
+			Expect(dependency.Start(context.Background())).To(Succeed())
 
-			// Level 2 files (photos/2023/summer/)
-			Expect(mediaFilenames).To(HaveKey("vacation1.jpg"))
-			Expect(mediaFilenames).To(HaveKey("beach.jpg"))
+			ready, err := syncJob.Ready()
+			Expect(err).To(BeNil())
+			Expect(ready).To(BeTrue())
+		})
 
-			// Level 3 files (photos/2023/summer/europe/)
-			Expect(mediaFilenames).To(HaveKey("paris.jpg"))
-			Expect(mediaFilenames).To(HaveKey("rome.jpeg"))
+		It("returns an error when a task of these stages failed", func() {
+			dependency, err := services.NewStagedSyncJob([]*entity.LinkedList[services.Task[string]]{
+				newTaskList(failWith(errors.New("cannot create album"))),
+				newTaskList(succeed("photo")),
+			}, map[string]string{"path": "photos"})
+			Expect(err).To(BeNil())
 
-			// Level 3 files (photos/2023/winter/alps/)
-			Expect(mediaFilenames).To(HaveKey("mountain1.jpg"))
-			Expect(mediaFilenames).To(HaveKey("mountain2.JPG"))
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
+			Expect(err).To(BeNil())
+			syncJob.DependsOn(dependency, 1)
 
-			// Level 3 files (photos/2024/work/projects/)
-			Expect(mediaFilenames).To(HaveKey("demo.jpg"))
-			Expect(mediaFilenames).To(HaveKey("presentation.png"))
+			Expect(dependency.Start(context.Background())).To(Succeed())
 
-			// Verify all tasks completed successfully
-			successfulTasks := 0
-			for _, result := range status.Results {
-				if result.Err == nil {
-					successfulTasks++
-				}
-			}
-			Expect(successfulTasks).To(Equal(len(status.Results)))
+			ready, err := syncJob.Ready()
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("photos"))
+			Expect(ready).To(BeFalse())
 		})
 
-		It("handles mixed content with some invalid media files", func() {
-			// Create directory structure
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/mixed": {"valid.jpg", "invalid.jpg", "another_valid.jpeg"},
-			})
-
-			// Create media files - one invalid
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/mixed/valid.jpg":          "SAMPLE_JPEG",
-				"photos/mixed/invalid.jpg":        "invalid-image-data",
-				"photos/mixed/another_valid.jpeg": "SAMPLE_JPEG",
-			})
+		It("ignores the failures of the later stages", func() {
+			dependency, err := services.NewStagedSyncJob([]*entity.LinkedList[services.Task[string]]{
+				newTaskList(succeed("album")),
+				newTaskList(failWith(errors.New("unreadable photo"))),
+			}, nil)
+			Expect(err).To(BeNil())
 
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
+			syncJob, err := services.NewSyncJob(newTaskList(), nil)
 			Expect(err).To(BeNil())
+			syncJob.DependsOn(dependency, 1)
 
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+			Expect(dependency.Start(context.Background())).To(Succeed())
+
+			ready, err := syncJob.Ready()
 			Expect(err).To(BeNil())
+			Expect(ready).To(BeTrue())
+		})
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
+		It("returns an error when the job it depends on stopped or failed before", func() {
+			stopped, err := services.NewSyncJob(newTaskList(succeed("album")), nil)
 			Expect(err).To(BeNil())
+			Expect(stopped.Cancel()).To(Succeed())
 
-			// Job should complete even with some failed media processing
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
+			failed, err := services.NewSyncJob(newTaskList(succeed("album")), nil)
+			Expect(err).To(BeNil())
+			failed.Fail("the parent album failed")
 
-			// Check task results
-			Expect(status.Results).To(HaveLen(4)) // 1 album + 3 media files
+			for _, dependency := range []*services.SyncJob{stopped, failed} {
+				syncJob, err := services.NewSyncJob(newTaskList(), nil)
+				Expect(err).To(BeNil())
+				syncJob.DependsOn(dependency, 1)
 
-			// Count successful vs failed tasks
-			successfulTasks := 0
-			failedTasks := 0
-			for _, result := range status.Results {
-				if result.Err == nil {
-					successfulTasks++
-				} else {
-					failedTasks++
-				}
+				ready, err := syncJob.Ready()
+				Expect(err).ToNot(BeNil())
+				Expect(ready).To(BeFalse())
 			}
-
-			// Album creation should succeed, and valid media should succeed
-			Expect(successfulTasks).To(BeNumerically(">=", 2)) // At least album + 1 valid media
-			Expect(failedTasks).To(BeNumerically(">=", 1))     // At least 1 failed media
 		})
-	})
-
-	Context("Job Progress Tracking", func() {
-		It("tracks progress correctly during execution", func() {
-			// Create a simple structure - one level
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/album1": {"photo1.jpg"},
-				"photos/album2": {"photo2.jpg"},
-			})
-
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/album1/photo1.jpg": "SAMPLE_JPEG",
-				"photos/album2/photo2.jpg": "SAMPLE_JPEG",
-			})
 
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
+		It("propagates a failure along a chain of jobs", func() {
+			root, err := services.NewSyncJob(newTaskList(failWith(errors.New("cannot create album"))), nil)
 			Expect(err).To(BeNil())
-
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+			child, err := services.NewSyncJob(newTaskList(succeed("album")), nil)
 			Expect(err).To(BeNil())
-
-			// Check initial status
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusPending))
-			Expect(status.Total).To(Equal(0))
-			Expect(status.Remaining).To(Equal(0))
-
-			// Start the job
-			err = syncJob.Start(context.TODO())
+			grandchild, err := services.NewSyncJob(newTaskList(succeed("album")), nil)
 			Expect(err).To(BeNil())
+			child.DependsOn(root, 1)
+			grandchild.DependsOn(child, 1)
+
+			runJobs(context.Background(), []*services.SyncJob{root, child, grandchild})
 
-			// Check final status - 2 child albums + 2 media files
-			finalStatus := syncJob.Status()
-			Expect(finalStatus.Status).To(Equal(entity.StatusCompleted))
-			Expect(finalStatus.Total).To(Equal(4))
-			Expect(finalStatus.Remaining).To(Equal(0))
-			Expect(finalStatus.Results).To(HaveLen(4))
-			Expect(finalStatus.StartedAt).ToNot(BeNil())
-			Expect(finalStatus.CompletedAt).ToNot(BeNil())
-			Expect(finalStatus.CompletedAt.After(*finalStatus.StartedAt)).To(BeTrue())
+			Expect(root.Status().Status).To(Equal(entity.StatusCompleted))
+			Expect(child.Status().Status).To(Equal(entity.StatusFailed))
+			Expect(grandchild.Status().Status).To(Equal(entity.StatusFailed))
+			Expect(grandchild.Status().Results).To(BeEmpty())
 		})
 	})
 
-	Context("Error Handling", func() {
-		It("handles non-existent root album", func() {
-			nonExistentAlbum := entity.NewAlbum("nonexistent")
-
-			syncJob, err := services.NewSyncJob(nonExistentAlbum, albumService, mediaService, realFs)
+	Context("Fail", func() {
+		It("fails a pending job with the reason", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a")), nil)
 			Expect(err).To(BeNil())
 
-			// Start should fail because root album doesn't exist
-			err = syncJob.Start(context.TODO())
-			Expect(err).ToNot(BeNil())
+			syncJob.Fail("parent job failed")
 
 			status := syncJob.Status()
 			Expect(status.Status).To(Equal(entity.StatusFailed))
+			Expect(status.Reason).To(Equal("parent job failed"))
+			Expect(status.CompletedAt).ToNot(BeNil())
+			Expect(status.Remaining).To(Equal(1))
 		})
 
-		It("continues processing despite individual media failures", func() {
-			// Create structure with problematic file that can't be processed - one level
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/test": {"good.jpg", "bad.jpg", "another_good.jpg"},
-			})
-
-			// Create files - one with bad content
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/test/good.jpg":         "SAMPLE_JPEG",
-				"photos/test/bad.jpg":          "completely-invalid-binary-data",
-				"photos/test/another_good.jpg": "SAMPLE_JPEG",
-			})
-
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
+		It("does not fail a job which already ran", func() {
+			syncJob, err := services.NewSyncJob(newTaskList(succeed("a")), nil)
 			Expect(err).To(BeNil())
+			Expect(syncJob.Start(context.Background())).To(Succeed())
 
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
-			Expect(err).To(BeNil())
+			syncJob.Fail("too late")
 
-			// Start the job
-			err = syncJob.Start(context.TODO())
-			Expect(err).To(BeNil())
-
-			// Job should complete despite failures
 			status := syncJob.Status()
 			Expect(status.Status).To(Equal(entity.StatusCompleted))
-
-			// Some tasks should have failed, but job continues - 1 child album + 3 media files
-			Expect(status.Results).To(HaveLen(4))
-
-			errorCount := 0
-			for _, result := range status.Results {
-				if result.Err != nil {
-					errorCount++
-				}
-			}
-			Expect(errorCount).To(BeNumerically(">=", 1)) // At least one media processing failure
+			Expect(status.Reason).To(BeEmpty())
 		})
 	})
 
-	Context("Complex Directory Structures", func() {
-		It("handles deeply nested directory structures", func() {
-			// Create deep nested structure
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023/summer/vacation/beach":     {"sunset.jpg"},
-				"photos/2023/summer/vacation/mountain":  {"hike.jpg"},
-				"photos/2023/winter/holidays/christmas": {"tree.jpg"},
-				"photos/2024/work/conference":           {"presentation.jpg"},
-			})
-
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/summer/vacation/beach/sunset.jpg":   "SAMPLE_JPEG",
-				"photos/2023/summer/vacation/mountain/hike.jpg":  "SAMPLE_JPEG",
-				"photos/2023/winter/holidays/christmas/tree.jpg": "SAMPLE_JPEG",
-				"photos/2024/work/conference/presentation.jpg":   "SAMPLE_JPEG",
-			})
-
-			// Create root album in database
-			_, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
-			Expect(err).To(BeNil())
-
-			syncJob, err := services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+	Context("Thread Safety", func() {
+		It("should handle concurrent status checks safely", func() {
+			tasks := []services.Task[string]{}
+			for range 20 {
+				tasks = append(tasks, func(ctx context.Context) entity.Result[string] {
+					time.Sleep(time.Millisecond)
+					return entity.NewResult("photo")
+				})
+			}
+			syncJob, err := services.NewSyncJob(newTaskList(tasks...), nil)
 			Expect(err).To(BeNil())
 
-			// Start the sync job
-			err = syncJob.Start(context.TODO())
-			Expect(err).To(BeNil())
+			// Start job
+			done := make(chan error, 1)
+			go func() {
+				done <- syncJob.Start(context.Background())
+			}()
 
-			// Verify job completed successfully
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
+			// Concurrently check status multiple times
+			statusChecks := make(chan entity.JobProgress, 50)
+			for i := 0; i < 10; i++ {
+				go func() {
+					for j := 0; j < 5; j++ {
+						statusChecks <- syncJob.Status()
+						time.Sleep(1 * time.Millisecond)
+					}
+				}()
+			}
 
-			// The sync job processes all nested directories and creates them as albums
-			// We verify this by checking that all media files were processed
-			allMedia, err := mediaService.GetMedia(context.TODO(), &services.MediaOptions{MediaLimit: 100})
-			Expect(err).To(BeNil())
-			Expect(allMedia).To(HaveLen(4))
+			// Collect results
+			var statuses []entity.JobProgress
+			timeout := time.After(5 * time.Second)
+			for i := 0; i < 50; i++ {
+				select {
+				case status := <-statusChecks:
+					statuses = append(statuses, status)
+				case <-timeout:
+					Fail("Timeout waiting for status checks")
+				}
+			}
 
-			// Verify that the job processed all directories and media files
-			// The exact number will be: all nested directories + all media files
-			Expect(status.Results).To(HaveLen(15)) // 11 directories + 4 media files
+			Eventually(done).Should(Receive(BeNil()))
 
-			// Count successful tasks - should be high since these are valid structures
-			successfulTasks := 0
-			for _, result := range status.Results {
-				if result.Err == nil {
-					successfulTasks++
-				}
+			// All status checks should have valid data
+			Expect(len(statuses)).To(Equal(50))
+			for _, status := range statuses {
+				Expect(status.Id).To(Equal(syncJob.ID))
+				Expect(status.Total).To(Equal(20))
+				Expect(status.Remaining).To(BeNumerically(">=", 0))
+				Expect(status.Remaining).To(BeNumerically("<=", status.Total))
 			}
-			Expect(successfulTasks).To(BeNumerically(">=", 10)) // Most tasks should succeed
 		})
 	})
 })
 
-// Helper functions
+var _ = Describe("JobGenerator", Ordered, func() {
+	var (
+		albumService *services.AlbumService
+		mediaService *services.MediaService
+		generator    *services.JobGenerator
+		syncService  *services.SyncService
+		dt           *pg.Datastore
+		pgPool       *pgxpool.Pool
+		tmpDir       string
+	)
 
-func createTestDirectoryStructure(basePath string, structure map[string][]string) {
-	for dirPath, files := range structure {
-		// Clean the directory path to handle both absolute and relative paths
-		cleanDirPath := strings.TrimPrefix(dirPath, "/")
-		fullDirPath := filepath.Join(basePath, cleanDirPath)
-		err := os.MkdirAll(fullDirPath, 0755)
+	BeforeAll(func() {
+		// Set up PostgreSQL connection
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
+		Expect(pgDt).ToNot(BeNil())
 
-		// Create empty files for now
-		for _, fileName := range files {
-			fullFilePath := filepath.Join(fullDirPath, fileName)
-			file, err := os.Create(fullFilePath)
-			Expect(err).To(BeNil())
-			file.Close()
-		}
-	}
-}
-
-func createTestMediaFiles(basePath string, files map[string]string) {
-	for filePath, content := range files {
-		// Clean the file path to handle both absolute and relative paths
-		cleanFilePath := strings.TrimPrefix(filePath, "/")
-		fullPath := filepath.Join(basePath, cleanFilePath)
+		pgxConfig, err := pgxpool.ParseConfig(pgUri)
+		Expect(err).To(BeNil())
 
-		// Ensure directory exists
-		dir := filepath.Dir(fullPath)
-		err := os.MkdirAll(dir, 0755)
+		pool, err := pgxpool.NewWithConfig(context.TODO(), pgxConfig)
 		Expect(err).To(BeNil())
+		Expect(pool).ToNot(BeNil())
 
-		var data []byte
-		if content == "SAMPLE_JPEG" {
-			// Copy the real JPEG file
-			data, err = os.ReadFile(getSampleJPEGPath())
-			Expect(err).To(BeNil())
-		} else if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
-			// Decode base64 content if it looks like base64
-			data = decoded
-		} else {
-			// Use content as raw bytes
-			data = []byte(content)
-		}
+		dt = pgDt
+		pgPool = pool
 
-		err = os.WriteFile(fullPath, data, 0644)
+		// Clean up any existing data
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
 		Expect(err).To(BeNil())
-	}
-}
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+	})
 
-var _ = Describe("JobProgress and Progress Meter", func() {
-	var (
-		albumService *services.AlbumService
-		mediaService *services.MediaService
-		realFs       *fs.Datastore
-		tmpDir       string
-		syncJob      *services.SyncAlbumJob
-		rootAlbum    entity.Album
-	)
+	AfterAll(func() {
+		dt.Close()
+		if tmpDir != "" {
+			os.RemoveAll(tmpDir)
+		}
+	})
 
 	BeforeEach(func() {
-		// Set up test directory
+		// Create a temporary directory structure for testing
 		var err error
-		tmpDir, err = os.MkdirTemp("", "job-progress-test")
+		tmpDir, err = os.MkdirTemp("", "photos-ng-sync-test-*")
 		Expect(err).To(BeNil())
 
+		// Set up filesystem datastore
+		realFs := fs.NewFsDatastore(tmpDir)
+
 		// Set up services
-		realFs = fs.NewFsDatastore(tmpDir)
+		albumService = services.NewAlbumService(dt, realFs)
+		mediaService = services.NewMediaService(dt, realFs)
+		generator = services.NewJobGenerator(albumService, mediaService, realFs, nil)
+		syncService = services.NewSyncService(albumService, mediaService, realFs, nil)
+	})
 
-		realPg, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+	AfterEach(func() {
+		// Clean up database
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
 		Expect(err).To(BeNil())
-
-		albumService = services.NewAlbumService(realPg, realFs)
-		mediaService = services.NewMediaService(realPg, realFs)
-
-		// Create root album
-		rootAlbum = entity.NewAlbum("photos")
-		createdRootAlbum, err := albumService.CreateAlbum(context.TODO(), rootAlbum)
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
 		Expect(err).To(BeNil())
-		rootAlbum = *createdRootAlbum
-	})
 
-	AfterEach(func() {
-		os.RemoveAll(tmpDir)
+		// Clean up filesystem
+		if tmpDir != "" {
+			os.RemoveAll(tmpDir)
+		}
 	})
 
-	Context("Progress Meter State Transitions", func() {
-		BeforeEach(func() {
-			var err error
-			syncJob, err = services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
-			Expect(err).To(BeNil())
-		})
+	// syncFolder generates the jobs of the folder and runs them
+	syncFolder := func(albumPath string) []*services.SyncJob {
+		jobs, err := generator.Generate(context.TODO(), albumPath, nil)
+		Expect(err).To(BeNil())
+		runJobs(context.TODO(), jobs)
+		return jobs
+	}
 
-		It("should start in pending status", func() {
-			status := syncJob.Status()
+	albumPaths := func(albumPath string) []string {
+		albums, err := albumService.ListFolders(context.TODO(), albumPath)
+		Expect(err).To(BeNil())
+		paths := []string{}
+		for _, album := range albums {
+			paths = append(paths, album.Path)
+		}
+		return paths
+	}
 
-			Expect(status.Id).To(Equal(syncJob.ID))
-			Expect(status.Status).To(Equal(entity.StatusPending))
-			Expect(status.Total).To(Equal(0))
-			Expect(status.Remaining).To(Equal(0))
-			Expect(status.StartedAt).To(BeNil())
-			Expect(status.CompletedAt).To(BeNil())
-			Expect(status.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
-		})
+	mediaFilenames := func(albumPath string) []string {
+		media, err := mediaService.ListFiles(context.TODO(), albumPath)
+		Expect(err).To(BeNil())
+		filenames := []string{}
+		for _, m := range media {
+			filenames = append(filenames, m.Filename)
+		}
+		return filenames
+	}
 
-		It("should transition to running status when job starts", func() {
-			// Create test structure with few items for predictable task count
+	Context("Album Discovery and Creation", func() {
+		It("discovers and creates albums from directory structure", func() {
+			// Create directory structure - only one level of sub-albums
 			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {"photo1.jpg", "photo2.jpg"},
-			})
-			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
-				"photos/2023/photo2.jpg": "SAMPLE_JPEG",
+				"photos/2023": {},
+				"photos/2024": {},
+				"photos/2025": {},
 			})
 
-			// Start job in background to check running status
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			jobs := syncFolder("photos")
 
-			// Start job in goroutine
-			done := make(chan error, 1)
-			go func() {
-				done <- syncJob.Start(ctx)
-			}()
+			// one job by folder
+			Expect(jobs).To(HaveLen(4))
+			for _, job := range jobs {
+				status := job.Status()
+				Expect(status.Status).To(Equal(entity.StatusCompleted))
+				Expect(status.StartedAt).ToNot(BeNil())
+				Expect(status.CompletedAt).ToNot(BeNil())
+			}
 
-			// Give it a moment to start
-			time.Sleep(100 * time.Millisecond)
+			Expect(albumPaths("photos")).To(ConsistOf("photos", "photos/2023", "photos/2024", "photos/2025"))
 
-			// Check that it's running or completed (depending on timing)
-			status := syncJob.Status()
-			Expect(status.Status).To(SatisfyAny(
-				Equal(entity.StatusRunning),
-				Equal(entity.StatusCompleted),
-			))
+			parent, err := albumService.GetByPath(context.TODO(), "photos")
+			Expect(err).To(BeNil())
+			children, _, err := albumService.List(context.TODO(), &services.ListOptions{ParentID: &parent.ID, HasParent: true})
+			Expect(err).To(BeNil())
+			Expect(children).To(HaveLen(3))
+		})
 
-			if status.Status == entity.StatusRunning {
-				Expect(status.StartedAt).ToNot(BeNil())
-				Expect(status.Total).To(BeNumerically(">", 0))
-				Expect(status.Remaining).To(BeNumerically(">=", 0))
-				Expect(status.Remaining).To(BeNumerically("<=", status.Total))
-			}
+		It("handles empty directory structure", func() {
+			// Create only root directory
+			err := os.MkdirAll(filepath.Join(tmpDir, "photos"), 0755)
+			Expect(err).To(BeNil())
 
-			// Wait for completion
-			cancel()
-			<-done
+			jobs := syncFolder("photos")
+
+			// the album of the folder is created
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].Status().Status).To(Equal(entity.StatusCompleted))
+			Expect(jobs[0].Status().Total).To(Equal(1))
+			Expect(albumPaths("photos")).To(ConsistOf("photos"))
 		})
 
-		It("should transition to completed status when job finishes successfully", func() {
-			// Create simple test structure
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {"photo1.jpg"},
-			})
+		It("handles root album with empty path (starts from root data folder)", func() {
+			// Create some media files in various directories (directories will be created automatically)
+			// NOTE: NO media files in root data folder - they only exist in subdirectories
 			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
+				"2023/summer/beach.jpg":    "SAMPLE_JPEG",
+				"2023/summer/vacation.jpg": "SAMPLE_JPEG",
+				"2023/winter/skiing.jpg":   "SAMPLE_JPEG",
+				"2024/spring/flowers.jpg":  "SAMPLE_JPEG",
+				"documents/readme.txt":     "SAMPLE_JPEG", // Non-media file, should be ignored
 			})
 
-			err := syncJob.Start(context.Background())
-			Expect(err).To(BeNil())
+			syncFolder("")
 
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			Expect(status.CompletedAt).ToNot(BeNil())
-			Expect(status.Total).To(BeNumerically(">", 0))
-			Expect(status.Remaining).To(Equal(0))
-		})
-	})
+			// The data folder is not an album: 2023, 2023/summer, 2023/winter, 2024, 2024/spring, documents
+			Expect(albumPaths("")).To(ConsistOf("2023", "2023/summer", "2023/winter", "2024", "2024/spring", "documents"))
+			Expect(mediaFilenames("")).To(ConsistOf("beach.jpg", "vacation.jpg", "skiing.jpg", "flowers.jpg"))
 
-	Context("Progress Counting", func() {
-		BeforeEach(func() {
-			var err error
-			syncJob, err = services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+			// Top-level albums should be: 2023, 2024, documents
+			topLevelAlbums, _, err := albumService.List(context.TODO(), &services.ListOptions{})
 			Expect(err).To(BeNil())
+			topLevelPaths := []string{}
+			for _, album := range topLevelAlbums {
+				topLevelPaths = append(topLevelPaths, album.Path)
+			}
+			Expect(topLevelPaths).To(ConsistOf("2023", "2024", "documents"))
 		})
+	})
 
-		It("should correctly count total tasks for albums and media", func() {
-			// Create known structure: 2 albums + 3 media files = 5 total tasks
+	Context("Media Processing", func() {
+		It("processes media files in album directories", func() {
+			// Create directory structure with media files - only one level
 			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023":        {"photo1.jpg"},
-				"photos/2023/summer": {"photo2.jpg", "photo3.jpg"},
+				"photos/2023": {"document.txt"},
 			})
+
+			// Create actual media files
 			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg":        "SAMPLE_JPEG",
-				"photos/2023/summer/photo2.jpg": "SAMPLE_JPEG",
-				"photos/2023/summer/photo3.jpg": "SAMPLE_JPEG",
+				"photos/2023/photo1.jpg":    "SAMPLE_JPEG",
+				"photos/2023/photo2.jpeg":   "SAMPLE_JPEG",
+				"photos/2023/photo3.png":    "SAMPLE_JPEG", // Will be treated as JPEG for simplicity
+				"photos/2024/vacation1.jpg": "SAMPLE_JPEG",
+				"photos/2024/vacation2.JPG": "SAMPLE_JPEG",
 			})
 
-			err := syncJob.Start(context.Background())
-			Expect(err).To(BeNil())
+			jobs := syncFolder("photos")
 
-			status := syncJob.Status()
-			// Should have: 2 albums (2023, summer) + 3 media files = 5 tasks
-			Expect(status.Total).To(Equal(5))
-			Expect(status.Remaining).To(Equal(0)) // All completed
+			Expect(albumPaths("photos")).To(ConsistOf("photos", "photos/2023", "photos/2024"))
+
+			// document.txt should not be processed
+			Expect(mediaFilenames("photos")).To(ConsistOf("photo1.jpg", "photo2.jpeg", "photo3.png", "vacation1.jpg", "vacation2.JPG"))
+
+			// Count successful tasks: 3 albums + 5 media files
+			successfulTasks := 0
+			for _, job := range jobs {
+				status := job.Status()
+				Expect(status.Status).To(Equal(entity.StatusCompleted))
+				for _, result := range status.Results {
+					if result.Err == nil {
+						successfulTasks++
+					}
+				}
+			}
+			Expect(successfulTasks).To(Equal(8))
 		})
 
-		It("should correctly track progress during execution", func() {
-			// Create larger structure to monitor progress
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023":        {"photo1.jpg", "photo2.jpg"},
-				"photos/2024":        {"photo3.jpg"},
-				"photos/2023/summer": {"photo4.jpg"},
-			})
+		It("continues processing despite individual media failures", func() {
+			// Create files - one with bad content
 			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg":        "SAMPLE_JPEG",
-				"photos/2023/photo2.jpg":        "SAMPLE_JPEG",
-				"photos/2024/photo3.jpg":        "SAMPLE_JPEG",
-				"photos/2023/summer/photo4.jpg": "SAMPLE_JPEG",
+				"photos/test/good.jpg":         "SAMPLE_JPEG",
+				"photos/test/bad.jpg":          "completely-invalid-binary-data",
+				"photos/test/another_good.jpg": "SAMPLE_JPEG",
 			})
 
-			// Track progress during execution
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			jobs := syncFolder("photos")
+			Expect(jobs).To(HaveLen(2))
 
-			progressSnapshots := []entity.JobProgress{}
-			done := make(chan error, 1)
+			// Job should complete despite failures: 1 album + 3 media files
+			status := jobs[1].Status()
+			Expect(status.Status).To(Equal(entity.StatusCompleted))
+			Expect(status.Results).To(HaveLen(4))
 
-			go func() {
-				// Sample progress multiple times during execution
-				for i := 0; i < 10; i++ {
-					progressSnapshots = append(progressSnapshots, syncJob.Status())
-					time.Sleep(10 * time.Millisecond)
+			errorCount := 0
+			for _, result := range status.Results {
+				if result.Err != nil {
+					errorCount++
 				}
-				done <- syncJob.Start(ctx)
-			}()
-
-			<-done
+			}
+			Expect(errorCount).To(Equal(1))
+			Expect(mediaFilenames("photos")).To(ConsistOf("good.jpg", "another_good.jpg"))
+		})
+	})
 
-			finalStatus := syncJob.Status()
+	Context("Multi-Level Nested Albums", func() {
+		It("processes deeply nested album structures (3+ levels)", func() {
+			// Create actual media files for all locations
+			createTestMediaFiles(tmpDir, map[string]string{
+				"photos/2023/summer/vacation1.jpg":           "SAMPLE_JPEG",
+				"photos/2023/summer/beach.jpg":               "SAMPLE_JPEG",
+				"photos/2023/summer/europe/paris.jpg":        "SAMPLE_JPEG",
+				"photos/2023/summer/europe/rome.jpeg":        "SAMPLE_JPEG",
+				"photos/2023/winter/skiing.jpg":              "SAMPLE_JPEG",
+				"photos/2023/winter/alps/mountain1.jpg":      "SAMPLE_JPEG",
+				"photos/2023/winter/alps/mountain2.JPG":      "SAMPLE_JPEG",
+				"photos/2024/work/conference.jpg":            "SAMPLE_JPEG",
+				"photos/2024/work/projects/demo.jpg":         "SAMPLE_JPEG",
+				"photos/2024/work/projects/presentation.png": "SAMPLE_JPEG",
+			})
 
-			// Final status should show completion
-			Expect(finalStatus.Status).To(Equal(entity.StatusCompleted))
-			Expect(finalStatus.Total).To(Equal(7)) // 3 albums + 4 media files
-			Expect(finalStatus.Remaining).To(Equal(0))
+			jobs := syncFolder("photos")
 
-			// At least one snapshot should show the job in progress
-			for _, snapshot := range progressSnapshots {
-				if snapshot.Status == entity.StatusRunning {
-					Expect(snapshot.Remaining).To(BeNumerically("<=", snapshot.Total))
-					Expect(snapshot.Remaining).To(BeNumerically(">=", 0))
+			for _, job := range jobs {
+				status := job.Status()
+				Expect(status.Status).To(Equal(entity.StatusCompleted))
+				for _, result := range status.Results {
+					Expect(result.Err).To(BeNil())
 				}
 			}
-			// Note: Depending on timing, the job might complete too fast to catch running state
-			// This is acceptable for fast operations
+
+			// With multi-level nesting, we should have discovered ALL albums recursively
+			Expect(albumPaths("photos")).To(ConsistOf(
+				"photos",
+				"photos/2023", "photos/2023/summer", "photos/2023/summer/europe", "photos/2023/winter", "photos/2023/winter/alps",
+				"photos/2024", "photos/2024/work", "photos/2024/work/projects",
+			))
+			Expect(mediaFilenames("photos")).To(HaveLen(10))
+			Expect(mediaFilenames("photos/2023/winter")).To(ConsistOf("skiing.jpg", "mountain1.jpg", "mountain2.JPG"))
 		})
 	})
 
-	Context("Edge Cases", func() {
-		BeforeEach(func() {
-			var err error
-			syncJob, err = services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
-			Expect(err).To(BeNil())
-		})
+	Context("Incremental Sync", func() {
+		It("generates no job when nothing changed since the last sync", func() {
+			createTestMediaFiles(tmpDir, map[string]string{
+				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
+			})
 
-		It("should handle empty directory structure", func() {
-			// Create empty photos directory
-			err := os.MkdirAll(filepath.Join(tmpDir, "photos"), 0755)
-			Expect(err).To(BeNil())
+			syncFolder("photos")
 
-			err = syncJob.Start(context.Background())
+			jobs, err := generator.Generate(context.TODO(), "photos", nil)
 			Expect(err).To(BeNil())
+			Expect(jobs).To(BeEmpty())
 
-			status := syncJob.Status()
-			Expect(status.Status).To(Equal(entity.StatusCompleted))
-			Expect(status.Total).To(Equal(0)) // No tasks to process
-			Expect(status.Remaining).To(Equal(0))
+			// a full rescan processes the files again
+			jobs, err = generator.Generate(context.TODO(), "photos", &services.SyncOptions{FullRescan: true})
+			Expect(err).To(BeNil())
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].Metadata()["path"]).To(Equal("photos/2023"))
 		})
 
-		It("should handle job cancellation", func() {
-			// Create structure that would take some time
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {"photo1.jpg", "photo2.jpg", "photo3.jpg"},
-				"photos/2024": {"photo4.jpg", "photo5.jpg"},
-			})
+		It("puts the albums of the removed folders in the trash", func() {
 			createTestMediaFiles(tmpDir, map[string]string{
 				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
-				"photos/2023/photo2.jpg": "SAMPLE_JPEG",
-				"photos/2023/photo3.jpg": "SAMPLE_JPEG",
-				"photos/2024/photo4.jpg": "SAMPLE_JPEG",
-				"photos/2024/photo5.jpg": "SAMPLE_JPEG",
+				"photos/2024/photo2.jpg": "SAMPLE_JPEG",
 			})
 
-			ctx, cancel := context.WithCancel(context.Background())
+			syncFolder("photos")
+			Expect(os.RemoveAll(filepath.Join(tmpDir, "photos/2024"))).To(Succeed())
 
-			// Start job and cancel quickly
-			done := make(chan error, 1)
-			go func() {
-				done <- syncJob.Start(ctx)
-			}()
+			jobs := syncFolder("photos")
 
-			// Cancel after short delay
-			time.Sleep(50 * time.Millisecond)
-			cancel()
+			// the removed album is reconciled by the last job
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].Metadata()["type"]).To(Equal("reconcile"))
+			Expect(albumPaths("photos")).To(ConsistOf("photos", "photos/2023"))
+			Expect(mediaFilenames("photos")).To(ConsistOf("photo1.jpg"))
+		})
+
+		It("refuses to sync an empty folder while albums are known below it", func() {
+			createTestMediaFiles(tmpDir, map[string]string{
+				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
+			})
 
-			err := <-done
-			// Should return context cancellation error
-			Expect(err).To(MatchError(context.Canceled))
+			syncFolder("photos")
+			Expect(os.RemoveAll(filepath.Join(tmpDir, "photos/2023"))).To(Succeed())
 
-			status := syncJob.Status()
-			// Job should still report its progress state even after cancellation
-			Expect(status.Total).To(BeNumerically(">=", 0))
-			Expect(status.Remaining).To(BeNumerically(">=", 0))
+			_, err := generator.Generate(context.TODO(), "photos", nil)
+			Expect(err).ToNot(BeNil())
+			Expect(albumPaths("photos")).To(ConsistOf("photos", "photos/2023"))
 		})
 	})
 
-	Context("Thread Safety", func() {
-		BeforeEach(func() {
-			var err error
-			syncJob, err = services.NewSyncJob(rootAlbum, albumService, mediaService, realFs)
+	Context("Dry Run", func() {
+		// jpeg returns a copy of the sample JPEG made unique by trailing bytes, so the files have distinct hashes
+		jpeg := func(n int) string {
+			data, err := os.ReadFile(getSampleJPEGPath())
 			Expect(err).To(BeNil())
+			return base64.StdEncoding.EncodeToString(append(data, []byte(fmt.Sprintf("unique-%d", n))...))
+		}
 
-			// Create test structure
-			createTestDirectoryStructure(tmpDir, map[string][]string{
-				"photos/2023": {"photo1.jpg", "photo2.jpg"},
+		// snapshot returns the albums by path and the media by id known below the folder
+		snapshot := func(albumPath string) (map[string]bool, map[string]entity.Media) {
+			albums := map[string]bool{}
+			for _, p := range albumPaths(albumPath) {
+				albums[p] = true
+			}
+			media, err := mediaService.ListFiles(context.TODO(), albumPath)
+			Expect(err).To(BeNil())
+			byID := map[string]entity.Media{}
+			for _, m := range media {
+				byID[m.ID] = m
+			}
+			return albums, byID
+		}
+
+		It("reports what the sync does", func() {
+			createTestMediaFiles(tmpDir, map[string]string{
+				"photos/2023/kept.jpg":    jpeg(1),
+				"photos/2023/touched.jpg": jpeg(2),
+				"photos/2023/moved.jpg":   jpeg(3),
+				"photos/2023/removed.jpg": jpeg(4),
+				"photos/old/gone.jpg":     jpeg(5),
 			})
+			syncFolder("photos")
+
+			// change the folder in every way a sync handles
+			later := time.Now().Add(time.Hour)
+			Expect(os.Chtimes(filepath.Join(tmpDir, "photos/2023/touched.jpg"), later, later)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tmpDir, "photos/2024"), 0755)).To(Succeed())
+			Expect(os.Rename(filepath.Join(tmpDir, "photos/2023/moved.jpg"), filepath.Join(tmpDir, "photos/2024/moved.jpg"))).To(Succeed())
+			Expect(os.Remove(filepath.Join(tmpDir, "photos/2023/removed.jpg"))).To(Succeed())
+			Expect(os.RemoveAll(filepath.Join(tmpDir, "photos/old"))).To(Succeed())
 			createTestMediaFiles(tmpDir, map[string]string{
-				"photos/2023/photo1.jpg": "SAMPLE_JPEG",
-				"photos/2023/photo2.jpg": "SAMPLE_JPEG",
+				"photos/2024/new.jpg":   jpeg(6),
+				"photos/2024/notes.txt": "notes",
 			})
-		})
 
-		It("should handle concurrent status checks safely", func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			// Start job
-			done := make(chan error, 1)
-			go func() {
-				done <- syncJob.Start(ctx)
-			}()
+			albumsBefore, mediaBefore := snapshot("photos")
 
-			// Concurrently check status multiple times
-			statusChecks := make(chan entity.JobProgress, 50)
-			for i := 0; i < 10; i++ {
-				go func() {
-					for j := 0; j < 5; j++ {
-						statusChecks <- syncJob.Status()
-						time.Sleep(1 * time.Millisecond)
-					}
-				}()
+			report, err := syncService.DryRun(context.TODO(), "photos", nil)
+			Expect(err).To(BeNil())
+			Expect(report.Path).To(Equal("photos"))
+			Expect(report.UnsupportedFiles).To(ConsistOf("photos/2024/notes.txt"))
+			Expect(report.UnreadableItems).To(BeEmpty())
+
+			// the dry run writes nothing
+			albumsReported, mediaReported := snapshot("photos")
+			Expect(albumsReported).To(Equal(albumsBefore))
+			Expect(mediaReported).To(Equal(mediaBefore))
+
+			jobs := syncFolder("photos")
+			for _, job := range jobs {
+				for _, result := range job.Status().Results {
+					Expect(result.Err).To(BeNil())
+				}
 			}
+			albumsAfter, mediaAfter := snapshot("photos")
 
-			// Collect results
-			var statuses []entity.JobProgress
-			timeout := time.After(5 * time.Second)
-			for i := 0; i < 50; i++ {
-				select {
-				case status := <-statusChecks:
-					statuses = append(statuses, status)
-				case <-timeout:
-					Fail("Timeout waiting for status checks")
+			// compare the report with the changes of the sync
+			created, removed := []string{}, []string{}
+			for p := range albumsAfter {
+				if !albumsBefore[p] {
+					created = append(created, p)
+				}
+			}
+			for p := range albumsBefore {
+				if !albumsAfter[p] {
+					removed = append(removed, p)
+				}
+			}
+			Expect(report.AlbumsToCreate).To(ConsistOf(created))
+			Expect(report.AlbumsToRemove).To(ConsistOf(removed))
+
+			added, updated, removedMedia := []string{}, []string{}, []string{}
+			moved := []entity.SyncMove{}
+			for id, after := range mediaAfter {
+				before, found := mediaBefore[id]
+				switch {
+				case !found:
+					added = append(added, after.Filepath())
+				case before.Filepath() != after.Filepath():
+					moved = append(moved, entity.SyncMove{From: before.Filepath(), To: after.Filepath()})
+				case before.File == nil || after.File == nil || !before.File.Same(*after.File):
+					updated = append(updated, after.Filepath())
 				}
 			}
+			for id, before := range mediaBefore {
+				if _, found := mediaAfter[id]; found {
+					continue
+				}
+				// the media of the removed albums are removed with them and are not reported
+				if !slices.ContainsFunc(removed, func(p string) bool { return strings.HasPrefix(before.Filepath(), p+"/") }) {
+					removedMedia = append(removedMedia, before.Filepath())
+				}
+			}
+			Expect(report.MediaToAdd).To(ConsistOf(added))
+			Expect(report.MediaToUpdate).To(ConsistOf(updated))
+			Expect(report.MediaToMove).To(ConsistOf(moved))
+			Expect(report.MediaToRemove).To(ConsistOf(removedMedia))
+
+			// every kind of change was part of the comparison
+			Expect(created).To(ConsistOf("photos/2024"))
+			Expect(removed).To(ConsistOf("photos/old"))
+			Expect(added).To(ConsistOf("photos/2024/new.jpg"))
+			Expect(updated).To(ConsistOf("photos/2023/touched.jpg"))
+			Expect(moved).To(ConsistOf(entity.SyncMove{From: "photos/2023/moved.jpg", To: "photos/2024/moved.jpg"}))
+			Expect(removedMedia).To(ConsistOf("photos/2023/removed.jpg"))
+		})
 
-			// Wait for job completion
-			cancel()
-			<-done
+		It("reports nothing to do once the folder is synced", func() {
+			createTestMediaFiles(tmpDir, map[string]string{
+				"photos/2023/photo1.jpg": jpeg(1),
+				"photos/2024/photo2.jpg": jpeg(2),
+			})
+			syncFolder("photos")
 
-			// All status checks should have valid data
-			Expect(len(statuses)).To(Equal(50))
-			for _, status := range statuses {
-				Expect(status.Id).To(Equal(syncJob.ID))
-				Expect(status.Total).To(BeNumerically(">=", 0))
-				Expect(status.Remaining).To(BeNumerically(">=", 0))
-				Expect(status.Remaining).To(BeNumerically("<=", status.Total))
-			}
+			report, err := syncService.DryRun(context.TODO(), "photos", nil)
+			Expect(err).To(BeNil())
+			Expect(report.AlbumsToCreate).To(BeEmpty())
+			Expect(report.AlbumsToRemove).To(BeEmpty())
+			Expect(report.MediaToAdd).To(BeEmpty())
+			Expect(report.MediaToUpdate).To(BeEmpty())
+			Expect(report.MediaToMove).To(BeEmpty())
+			Expect(report.MediaToRemove).To(BeEmpty())
+
+			// a full rescan processes every file again
+			report, err = syncService.DryRun(context.TODO(), "photos", &services.SyncOptions{FullRescan: true})
+			Expect(err).To(BeNil())
+			Expect(report.MediaToUpdate).To(ConsistOf("photos/2023/photo1.jpg", "photos/2024/photo2.jpg"))
+		})
+	})
+
+	Context("Error Handling", func() {
+		It("returns an error for a folder which does not exist", func() {
+			jobs, err := generator.Generate(context.TODO(), "nonexistent", nil)
+
+			Expect(err).ToNot(BeNil())
+			Expect(jobs).To(BeEmpty())
 		})
 	})
 })
+
+// Helper functions
+
+// newTaskList returns a list of the tasks in order
+func newTaskList(tasks ...services.Task[string]) *entity.LinkedList[services.Task[string]] {
+	list := entity.NewLinkedList[services.Task[string]]()
+	for _, task := range tasks {
+		list.PushBack(task)
+	}
+	return list
+}
+
+// succeed returns a task returning data
+func succeed(data string) services.Task[string] {
+	return func(ctx context.Context) entity.Result[string] {
+		return entity.NewResult(data)
+	}
+}
+
+// failWith returns a task failing with err
+func failWith(err error) services.Task[string] {
+	return func(ctx context.Context) entity.Result[string] {
+		return entity.Result[string]{Err: err}
+	}
+}
+
+// blockUntilCancelled returns a task running until its context is cancelled
+func blockUntilCancelled() services.Task[string] {
+	return func(ctx context.Context) entity.Result[string] {
+		<-ctx.Done()
+		return entity.Result[string]{Err: ctx.Err()}
+	}
+}
+
+// runJobs runs the jobs one after the other in order, the jobs whose dependencies failed fail as well
+func runJobs(ctx context.Context, jobs []*services.SyncJob) {
+	for _, job := range jobs {
+		ready, err := job.Ready()
+		if err != nil {
+			job.Fail(err.Error())
+			continue
+		}
+		Expect(ready).To(BeTrue())
+		Expect(job.Start(ctx)).To(Succeed())
+	}
+}
+
+// recordingListener records what the jobs tell their listener
+type recordingListener struct {
+	mu      sync.Mutex
+	changes map[uuid.UUID][]entity.JobStatus
+	done    []int
+}
+
+func (l *recordingListener) JobChanged(job services.Job) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.changes == nil {
+		l.changes = make(map[uuid.UUID][]entity.JobStatus)
+	}
+	l.changes[job.GetID()] = append(l.changes[job.GetID()], job.Status().Status)
+}
+
+func (l *recordingListener) TaskDone(job services.Job, position int, result entity.JobResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.done = append(l.done, position)
+}
+
+// statuses returns the statuses of the job the listener was told about, in order
+func (l *recordingListener) statuses(id uuid.UUID) []entity.JobStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.changes[id])
+}
+
+// positions returns the positions of the tasks the listener was told about
+func (l *recordingListener) positions() []int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.done)
+}
+
+func createTestDirectoryStructure(basePath string, structure map[string][]string) {
+	for dirPath, files := range structure {
+		// Clean the directory path to handle both absolute and relative paths
+		cleanDirPath := strings.TrimPrefix(dirPath, "/")
+		fullDirPath := filepath.Join(basePath, cleanDirPath)
+		err := os.MkdirAll(fullDirPath, 0755)
+		Expect(err).To(BeNil())
+
+		// Create empty files for now
+		for _, fileName := range files {
+			fullFilePath := filepath.Join(fullDirPath, fileName)
+			file, err := os.Create(fullFilePath)
+			Expect(err).To(BeNil())
+			file.Close()
+		}
+	}
+}
+
+func createTestMediaFiles(basePath string, files map[string]string) {
+	for filePath, content := range files {
+		// Clean the file path to handle both absolute and relative paths
+		cleanFilePath := strings.TrimPrefix(filePath, "/")
+		fullPath := filepath.Join(basePath, cleanFilePath)
+
+		// Ensure directory exists
+		dir := filepath.Dir(fullPath)
+		err := os.MkdirAll(dir, 0755)
+		Expect(err).To(BeNil())
+
+		var data []byte
+		if content == "SAMPLE_JPEG" {
+			// Copy the real JPEG file
+			data, err = os.ReadFile(getSampleJPEGPath())
+			Expect(err).To(BeNil())
+		} else if decoded, err := base64.StdEncoding.DecodeString(content); err == nil {
+			// Decode base64 content if it looks like base64
+			data = decoded
+		} else {
+			// Use content as raw bytes
+			data = []byte(content)
+		}
+
+		err = os.WriteFile(fullPath, data, 0644)
+		Expect(err).To(BeNil())
+	}
+}
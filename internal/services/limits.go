@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+)
+
+const (
+	defaultTaskWorkers = 4
+	defaultIOLimit     = 8
+)
+
+// TaskLimits bounds how many tasks run at the same time
+type TaskLimits struct {
+	// Workers is how many tasks of a job run in parallel
+	Workers int
+	// CPU is how many CPU bound tasks, like processing a media file, run at the same time across all the jobs.
+	// 0 uses the number of CPUs.
+	CPU int
+	// IO is how many IO bound tasks, like moving or removing a media, run at the same time across all the jobs
+	IO int
+}
+
+type taskLimiter struct {
+	m       sync.RWMutex
+	workers int
+	cpu     *semaphore.Weighted
+	io      *semaphore.Weighted
+}
+
+var limiter = newTaskLimiter(TaskLimits{Workers: defaultTaskWorkers, IO: defaultIOLimit})
+
+func newTaskLimiter(limits TaskLimits) *taskLimiter {
+	if limits.Workers < 1 {
+		limits.Workers = 1
+	}
+	if limits.CPU < 1 {
+		limits.CPU = runtime.NumCPU()
+	}
+	if limits.IO < 1 {
+		limits.IO = defaultIOLimit
+	}
+	return &taskLimiter{
+		workers: limits.Workers,
+		cpu:     semaphore.NewWeighted(int64(limits.CPU)),
+		io:      semaphore.NewWeighted(int64(limits.IO)),
+	}
+}
+
+// SetTaskLimits sets the limits of the tasks. It must be called at startup before any job runs.
+func SetTaskLimits(limits TaskLimits) {
+	l := newTaskLimiter(limits)
+
+	limiter.m.Lock()
+	defer limiter.m.Unlock()
+
+	limiter.workers = l.workers
+	limiter.cpu = l.cpu
+	limiter.io = l.io
+}
+
+// taskWorkers returns how many tasks of a job run in parallel
+func taskWorkers() int {
+	limiter.m.RLock()
+	defer limiter.m.RUnlock()
+	return limiter.workers
+}
+
+// cpuBound makes the task wait for a free CPU slot before it runs
+func cpuBound(task Task[string]) Task[string] {
+	limiter.m.RLock()
+	defer limiter.m.RUnlock()
+	return limited(limiter.cpu, task)
+}
+
+// ioBound makes the task wait for a free IO slot before it runs
+func ioBound(task Task[string]) Task[string] {
+	limiter.m.RLock()
+	defer limiter.m.RUnlock()
+	return limited(limiter.io, task)
+}
+
+func limited(sem *semaphore.Weighted, task Task[string]) Task[string] {
+	return func(ctx context.Context) entity.Result[string] {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+		defer sem.Release(1)
+		return task(ctx)
+	}
+}
@@ -32,6 +32,25 @@ const (
 	Skipped         = "skipped"
 	Reason          = "reason"
 	
+	// Collection service specific
+	CollectionID     = "collection_id"
+	TotalCollections = "total_collections"
+
+	// Smart album service specific
+	SmartAlbumID     = "smart_album_id"
+	TotalSmartAlbums = "total_smart_albums"
+
+	// Comment service specific
+	CommentID     = "comment_id"
+	TotalComments = "total_comments"
+
+	// Search service specific
+	SearchText    = "search_text"
+	SearchMatches = "search_matches"
+
+	// Trash service specific
+	TotalMedia = "total_media"
+
 	// Sync service specific
 	Status         = "status"
 	Total          = "total"
@@ -8,11 +8,13 @@ import (
 	"io"
 	"path"
 	"slices"
+	"strings"
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/query"
 	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
 )
 
@@ -62,13 +64,22 @@ func (m *MediaService) List(ctx context.Context, filter *MediaOptions) ([]entity
 		}
 	}
 
+	queries := filter.QueriesFn()
+	if filter.Query != "" {
+		parsedQuery, err := query.Parse(filter.Query)
+		if err != nil {
+			return nil, nil, NewQuerySyntaxError(ctx, "get_media", filter.Query, err)
+		}
+		queries = append(queries, parsedQuery.QueryOptions()...)
+	}
+
 	// Database query with debug timing
 	logger.Step("database_query").
 		WithString("query_type", "list_media").
-		WithInt("filters", len(filter.QueriesFn())).
+		WithInt("filters", len(queries)).
 		Log()
 
-	media, err := m.dt.QueryMedia(ctx, filter.QueriesFn()...)
+	media, err := m.dt.QueryMedia(ctx, queries...)
 	if err != nil {
 		return nil, nil, NewDatabaseWriteError(ctx, "get_media", err).
 			AtStep("query_media")
@@ -89,35 +100,6 @@ func (m *MediaService) List(ctx context.Context, filter *MediaOptions) ([]entity
 			Log()
 	}
 
-	// Apply date filtering in-memory for now
-	// TODO: Move this to database-level filtering
-	if filter.StartDate != nil || filter.EndDate != nil {
-		logger.Step("date_filtering").
-			WithInt("total_before_filter", len(media)).
-			WithBool("has_start_date", filter.StartDate != nil).
-			WithBool("has_end_date", filter.EndDate != nil).
-			Log()
-
-		filteredMedia := make([]entity.Media, 0, len(media))
-		for _, item := range media {
-			if filter.StartDate != nil && item.CapturedAt.Before(*filter.StartDate) {
-				continue
-			}
-			if filter.EndDate != nil && item.CapturedAt.After(*filter.EndDate) {
-				continue
-			}
-			filteredMedia = append(filteredMedia, item)
-		}
-
-		logger.Step("applied date filtering").
-			WithInt("total_before", len(media)).
-			WithInt("total_after", len(filteredMedia)).
-			WithInt("filtered_out", len(media)-len(filteredMedia)).
-			Log()
-
-		media = filteredMedia
-	}
-
 	// Generate next cursor if we have more items than requested
 	var nextCursor *PaginationCursor
 	if len(media) > originalLimit {
@@ -139,6 +121,68 @@ func (m *MediaService) List(ctx context.Context, filter *MediaOptions) ([]entity
 	return media, nextCursor, nil
 }
 
+// Memories returns the media captured on the day of opts.MemoriesDay in the previous years, grouped by year,
+// the most recent year first. The media of hidden albums are left out.
+func (m *MediaService) Memories(ctx context.Context, opts *MemoriesOptions) ([]entity.Memory, error) {
+	media, err := m.memories(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewMemories(media), nil
+}
+
+// memories returns the media of the memories sorted by capture date descending.
+func (m *MediaService) memories(ctx context.Context, opts *MemoriesOptions) ([]entity.Media, error) {
+	day := opts.MemoriesDay
+	if day.IsZero() {
+		day = time.Now().UTC()
+	}
+
+	logger := m.logger.WithContext(ctx).Debug("memories").
+		WithParam("day", day).
+		WithBool("favorite", opts.MemoriesFavorite).
+		WithInt("limit", opts.MemoriesLimit).
+		WithInt("offset", opts.MemoriesOffset).
+		Build()
+
+	if opts.MemoriesMinRating != nil && (*opts.MemoriesMinRating < 0 || *opts.MemoriesMinRating > 5) {
+		err := NewValidationError(ctx, "memories", "invalid_input")
+		err.WithContext("validation_error", "rating_out_of_range")
+		return nil, err
+	}
+
+	limit := opts.MemoriesLimit
+	if limit <= 0 {
+		limit = maxPageSize
+	}
+
+	// the years to look at start with the oldest media, which is read from the captured_at index
+	oldest, err := m.dt.OldestCapture(ctx)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "memories", err).AtStep("query_oldest_capture")
+	}
+
+	if oldest == nil {
+		logger.Success().WithInt(MediaReturned, 0).Log()
+		return []entity.Media{}, nil
+	}
+
+	queries := NewMemoriesOptionsWithOptions(opts.ToOption(), WithMemoriesLimit(limit)).QueriesFn()
+	queries = append(queries, pg.FilterByOnThisDay(day, oldest.Year()))
+
+	media, err := m.dt.QueryMedia(ctx, queries...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "memories", err).AtStep("query_media")
+	}
+
+	logger.Success().
+		WithInt(MediaReturned, len(media)).
+		WithInt("from_year", oldest.Year()).
+		Log()
+
+	return media, nil
+}
+
 func (m *MediaService) Get(ctx context.Context, id string) (*entity.Media, error) {
 	logger := m.logger.WithContext(ctx).Debug("get_media_by_id").
 		WithString(MediaID, id).
@@ -184,8 +228,91 @@ func (m *MediaService) Get(ctx context.Context, id string) (*entity.Media, error
 	return &processedMedia, nil
 }
 
+// ListFiles returns the media of the album folder and of its subfolders with only their file attributes.
+// An empty path returns the media of every album.
+func (m *MediaService) ListFiles(ctx context.Context, albumPath string) ([]entity.Media, error) {
+	media, err := m.dt.QueryMediaFiles(ctx, pg.FilterByAlbumFolder(albumPath))
+	if err != nil {
+		return nil, NewInternalError(ctx, "list_media_files", "query_media_files", err).
+			WithContext(AlbumPath, albumPath)
+	}
+	return media, nil
+}
+
+// Relocate records that the file of a media was moved to another folder outside the app: the media is moved
+// to the album of that folder in the database only, keeping its id, hash, thumbnail and relationships.
+func (m *MediaService) Relocate(ctx context.Context, id string, album entity.Album, filename string) (*entity.Media, error) {
+	logger := m.logger.WithContext(ctx).Debug("relocate_media").
+		WithString(MediaID, id).
+		WithString(AlbumID, album.ID).
+		WithString(Filename, filename).
+		Build()
+
+	media := entity.Media{ID: id, Filename: filename, Album: album}
+	file, err := m.fs.Stat(ctx, media.Filepath())
+	if err != nil {
+		return nil, NewFilesystemError(ctx, "relocate_media", "stat_file", media.Filepath(), err)
+	}
+	media.File = &file
+
+	err = m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		logger.Step("database_move").WithString("table", "media").Log()
+
+		if err := writer.MoveMedia(ctx, id, album.ID, filename); err != nil {
+			return NewDatabaseWriteError(ctx, "relocate_media", err).
+				WithMediaID(id).
+				AtStep("move_media")
+		}
+		if err := writer.WriteMediaFile(ctx, id, media.File); err != nil {
+			return NewDatabaseWriteError(ctx, "relocate_media", err).
+				WithMediaID(id).
+				AtStep("write_media_file")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, NewInternalError(ctx, "relocate_media", "transaction", err).
+			WithMediaID(id).
+			WithFilepath(media.Filepath())
+	}
+
+	logger.Success().
+		WithString(Filepath, media.Filepath()).
+		Log()
+
+	return &media, nil
+}
+
+// Forget puts in the trash a media whose file was removed outside the app. Only the database is changed,
+// unlike Delete there is no file to move to the trash folder. The media can be restored from the trash if the
+// file comes back.
+func (m *MediaService) Forget(ctx context.Context, id string) error {
+	logger := m.logger.WithContext(ctx).Debug("forget_media").
+		WithString(MediaID, id).
+		Build()
+
+	err := m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.TrashMedia(ctx, id, time.Now())
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, "forget_media", err).
+			WithMediaID(id).
+			AtStep("trash_media")
+	}
+
+	logger.Success().Log()
+
+	return nil
+}
+
 // WriteMedia creates or updates a media item and writes its content to disk
 func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*entity.Media, error) {
+	return m.writeMedia(ctx, media, true)
+}
+
+// writeMedia writes a media like WriteMedia. The attributes of its file are recorded only when recordFile is set,
+// they are set on the returned media either way so they can be recorded later with recordFiles.
+func (m *MediaService) writeMedia(ctx context.Context, media entity.Media, recordFile bool) (*entity.Media, error) {
 	logger := m.logger.WithContext(ctx).Debug("write_media").
 		WithString(MediaID, media.ID).
 		WithString(Filename, media.Filename).
@@ -201,6 +328,20 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 	if err != nil {
 		switch err.(type) {
 		case *NotFoundError:
+			// ids are random, the file may already be known under another id
+			existing, err := m.dt.QueryMedia(ctx, pg.FilterMediaByFilename(media.Album.ID, media.Filename), pg.Limit(1))
+			if err != nil {
+				return nil, NewInternalError(ctx, "write_media", "check_existing", err).
+					WithMediaID(media.ID)
+			}
+			if len(existing) > 0 {
+				oldMedia = &existing[0]
+				logger.Step("media exists under another id").
+					WithString(MediaID, oldMedia.ID).
+					Log()
+				media.ID = oldMedia.ID
+				break
+			}
 			logger.Step("media does not exist, proceeding with creation").
 				WithString(MediaID, media.ID).
 				Log()
@@ -232,6 +373,16 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 	hashStr := fmt.Sprintf("%x", hash)
 
 	if oldMedia != nil && hashStr == oldMedia.Hash {
+		// the attributes of the file are recorded so the next sync skips it without reading it
+		if file, err := m.fs.Stat(ctx, oldMedia.Filepath()); err == nil && (oldMedia.File == nil || !oldMedia.File.Same(file)) {
+			if recordFile {
+				if err := m.recordFiles(ctx, map[string]entity.FileInfo{oldMedia.ID: file}); err != nil {
+					return nil, err
+				}
+			}
+			oldMedia.File = &file
+		}
+
 		logger.Success().
 			WithString(MediaID, oldMedia.ID).
 			WithString(Filename, oldMedia.Filename).
@@ -242,6 +393,20 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 		return oldMedia, nil
 	}
 
+	if oldMedia != nil {
+		// caption, tags, rating and favorite may have been edited by the user, keep them when the content changes
+		media.Favorite = media.Favorite || oldMedia.Favorite
+		if media.Caption == nil {
+			media.Caption = oldMedia.Caption
+		}
+		if len(media.Tags) == 0 {
+			media.Tags = oldMedia.Tags
+		}
+		if media.Rating == nil {
+			media.Rating = oldMedia.Rating
+		}
+	}
+
 	logger.Step("transaction_start").
 		WithInt("content_size", len(contentBytes)).
 		WithString(Hash, hashStr).
@@ -296,6 +461,23 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 			WithInt("exif_fields", len(exif)).
 			Log()
 
+		// Searchable metadata comes from exif unless it was already set on the media
+		if media.Caption == nil {
+			media.Caption = media.GetCaption()
+		}
+		if media.Camera == nil {
+			media.Camera = media.GetCamera()
+		}
+		if len(media.Tags) == 0 {
+			media.Tags = media.GetTags()
+		}
+		if media.Rating == nil {
+			media.Rating = media.GetRating()
+		}
+		if media.Location == nil {
+			media.Location = media.GetLocation()
+		}
+
 		if captureAt, err := media.GetCapturedTime(); err != nil {
 			logger.Step("capture_time_extraction_failed").
 				WithString("filename", media.Filename).
@@ -317,11 +499,23 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 			return NewFilesystemError(ctx, "write_media", "filesystem_write", media.Filepath(), err)
 		}
 
+		// the attributes are read after the write which changes the modification time
+		file, err := m.fs.Stat(ctx, media.Filepath())
+		if err != nil {
+			return NewFilesystemError(ctx, "write_media", "filesystem_stat", media.Filepath(), err)
+		}
+		media.File = &file
+
 		logger.Step("database_write").
 			WithString("table", "media").
+			WithBool("record_file", recordFile).
 			Log()
 
-		if err := writer.WriteMedia(ctx, media); err != nil {
+		written := media
+		if !recordFile {
+			written.File = nil
+		}
+		if err := writer.WriteMedia(ctx, written); err != nil {
 			return NewDatabaseWriteError(ctx, "write_media", err).
 				WithMediaID(media.ID).
 				WithFilename(media.Filename)
@@ -353,12 +547,36 @@ func (m *MediaService) WriteMedia(ctx context.Context, media entity.Media) (*ent
 	return &media, nil
 }
 
+// recordFiles records the attributes of the files of media whose content did not change, by media id.
+func (m *MediaService) recordFiles(ctx context.Context, files map[string]entity.FileInfo) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	return m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		for id, file := range files {
+			if err := writer.WriteMediaFile(ctx, id, &file); err != nil {
+				return NewDatabaseWriteError(ctx, "write_media", err).
+					WithMediaID(id).
+					AtStep("write_media_file")
+			}
+		}
+		return nil
+	})
+}
+
 func (m *MediaService) Update(ctx context.Context, media entity.Media) (*entity.Media, error) {
 	logger := m.logger.WithContext(ctx).Debug("update_media").
 		WithString(MediaID, media.ID).
 		WithString(Filename, media.Filename).
 		Build()
 
+	if media.Rating != nil && (*media.Rating < 0 || *media.Rating > 5) {
+		err := NewValidationError(ctx, "update_media", "invalid_input")
+		err.WithContext(MediaID, media.ID).WithContext("validation_error", "rating_out_of_range")
+		return nil, err
+	}
+
 	// Clear the content function to avoid writing file content during update
 	media.Content = nil
 
@@ -397,6 +615,8 @@ func (m *MediaService) Update(ctx context.Context, media entity.Media) (*entity.
 	return &media, nil
 }
 
+// Delete puts a media in the trash. The file is moved to the trash folder of the data folder and the media
+// is hidden until it is restored or purged by the TrashService.
 func (m *MediaService) Delete(ctx context.Context, id string) error {
 	logger := m.logger.WithContext(ctx).Debug("delete_media").
 		WithString(MediaID, id).
@@ -412,40 +632,31 @@ func (m *MediaService) Delete(ctx context.Context, id string) error {
 			WithMediaID(id)
 	}
 
-	logger.Step("media found, proceeding with deletion").
+	logger.Step("media found, moving to trash").
 		WithString(MediaID, media.ID).
 		WithString(Filename, media.Filename).
 		WithString(Filepath, media.Filepath()).
 		Log()
 
-	logger.Step("transaction_start").
-		WithString(Filepath, media.Filepath()).
-		WithParam("operations", []string{"filesystem_delete", "database_delete"}).
-		Log()
-
-	logger.Step("starting").
-		WithString("operation", "delete_media").
-		Log()
-
 	err = m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
-		logger.Step("filesystem_delete").
-			WithString(Filepath, media.Filepath()).
-			Log()
-
-		if err := m.fs.DeleteMedia(ctx, media.Filepath()); err != nil {
-			return NewFilesystemError(ctx, "delete_media", "filesystem_delete", media.Filepath(), err)
-		}
-
-		logger.Step("database_delete").
+		logger.Step("database_trash").
 			WithString("table", "media").
 			WithString(MediaID, id).
 			Log()
 
-		if err := writer.DeleteMedia(ctx, id); err != nil {
+		if err := writer.TrashMedia(ctx, id, time.Now()); err != nil {
 			return NewDatabaseWriteError(ctx, "delete_media", err).
 				WithMediaID(id)
 		}
 
+		logger.Step("filesystem_trash").
+			WithString(Filepath, media.Filepath()).
+			Log()
+
+		if err := m.fs.MoveToTrash(ctx, media.Filepath(), media.ID); err != nil {
+			return NewFilesystemError(ctx, "delete_media", "filesystem_trash", media.Filepath(), err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -454,21 +665,211 @@ func (m *MediaService) Delete(ctx context.Context, id string) error {
 			WithFilepath(media.Filepath())
 	}
 
-	logger.Step("completed").
-		WithString("operation", "delete_media").
-		WithBool("success", true).
-		Log()
-
 	logger.Success().
 		WithString(MediaID, id).
 		WithString(Filepath, media.Filepath()).
-		WithBool("filesystem_deleted", true).
-		WithBool("database_deleted", true).
+		WithBool("trashed", true).
 		Log()
 
 	return nil
 }
 
+// Move moves media into another album. The files are moved on disk and the media keep their id,
+// hash, thumbnail and metadata so nothing is processed again.
+// A media is renamed if its file name is already used in the target album.
+// All the media are checked before anything is moved, a failure while moving stops at the failing media.
+func (m *MediaService) Move(ctx context.Context, ids []string, albumID string) ([]entity.Media, error) {
+	logger := m.logger.WithContext(ctx).Debug("move_media").
+		WithString(AlbumID, albumID).
+		WithInt("count", len(ids)).
+		Build()
+
+	album, media, err := m.relocationSources(ctx, "move_media", ids, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := make([]entity.Media, 0, len(media))
+	for _, item := range media {
+		if item.Album.ID == album.ID {
+			logger.Step("media already in the album").WithString(MediaID, item.ID).Log()
+			moved = append(moved, item)
+			continue
+		}
+
+		filename, err := m.freeFilename(ctx, "move_media", *album, item.Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Step("move").
+			WithString(MediaID, item.ID).
+			WithString("from", item.Filepath()).
+			WithString("to", path.Join(album.Path, filename)).
+			Log()
+
+		err = m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+			if err := writer.MoveMedia(ctx, item.ID, album.ID, filename); err != nil {
+				return NewDatabaseWriteError(ctx, "move_media", err).
+					WithMediaID(item.ID)
+			}
+
+			if err := m.fs.Rename(ctx, item.Filepath(), path.Join(album.Path, filename)); err != nil {
+				return NewFilesystemError(ctx, "move_media", "filesystem_rename", item.Filepath(), err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, NewInternalError(ctx, "move_media", "transaction", err).
+				WithMediaID(item.ID).
+				WithFilepath(item.Filepath())
+		}
+
+		item.Album = *album
+		item.Filename = filename
+		item.Content = m.fs.Read(ctx, item.Filepath())
+		moved = append(moved, item)
+	}
+
+	logger.Success().
+		WithInt("moved", len(moved)).
+		Log()
+
+	return moved, nil
+}
+
+// Copy copies media into another album. The copies get new ids and reuse the hash, thumbnail and metadata
+// of the originals. Caption, tags, rating and favorite are copied too.
+// A copy is renamed if its file name is already used in the target album.
+func (m *MediaService) Copy(ctx context.Context, ids []string, albumID string) ([]entity.Media, error) {
+	logger := m.logger.WithContext(ctx).Debug("copy_media").
+		WithString(AlbumID, albumID).
+		WithInt("count", len(ids)).
+		Build()
+
+	album, media, err := m.relocationSources(ctx, "copy_media", ids, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := make([]entity.Media, 0, len(media))
+	for _, item := range media {
+		filename, err := m.freeFilename(ctx, "copy_media", *album, item.Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		copied := item
+		copied.ID = entity.NewId()
+		copied.Album = *album
+		copied.Filename = filename
+		copied.Tags = slices.Clone(item.Tags)
+
+		logger.Step("copy").
+			WithString(MediaID, item.ID).
+			WithString("copy_id", copied.ID).
+			WithString("to", copied.Filepath()).
+			Log()
+
+		err = m.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+			if err := writer.WriteMedia(ctx, copied); err != nil {
+				return NewDatabaseWriteError(ctx, "copy_media", err).
+					WithMediaID(copied.ID)
+			}
+
+			if err := m.fs.Copy(ctx, item.Filepath(), copied.Filepath()); err != nil {
+				return NewFilesystemError(ctx, "copy_media", "filesystem_copy", item.Filepath(), err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, NewInternalError(ctx, "copy_media", "transaction", err).
+				WithMediaID(item.ID).
+				WithFilepath(copied.Filepath())
+		}
+
+		copied.Content = m.fs.Read(ctx, copied.Filepath())
+		copies = append(copies, copied)
+	}
+
+	logger.Success().
+		WithInt("copied", len(copies)).
+		Log()
+
+	return copies, nil
+}
+
+// relocationSources returns the target album and the media to move or copy into it.
+func (m *MediaService) relocationSources(ctx context.Context, operation string, ids []string, albumID string) (*entity.Album, []entity.Media, error) {
+	if len(ids) == 0 {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_media_ids")
+		return nil, nil, err
+	}
+
+	if albumID == "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_album_id")
+		return nil, nil, err
+	}
+
+	album, err := m.dt.QueryAlbum(ctx, pg.FilterByAlbumId(albumID))
+	if err != nil {
+		return nil, nil, NewDatabaseWriteError(ctx, operation, err).
+			WithAlbumID(albumID).
+			AtStep("query_album")
+	}
+
+	if album == nil {
+		return nil, nil, NewAlbumNotFoundError(ctx, albumID)
+	}
+
+	media := make([]entity.Media, 0, len(ids))
+	for _, id := range ids {
+		if slices.ContainsFunc(media, func(item entity.Media) bool { return item.ID == id }) {
+			continue
+		}
+
+		item, err := m.Get(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		media = append(media, *item)
+	}
+
+	return album, media, nil
+}
+
+// freeFilename returns filename if it is not used in the album, or the first free "name (n).ext" otherwise.
+// Both the database and the album folder are checked since the folder may hold files which are not synced yet.
+func (m *MediaService) freeFilename(ctx context.Context, operation string, album entity.Album, filename string) (string, error) {
+	ext := path.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+
+	candidate := filename
+	for i := 1; ; i++ {
+		existing, err := m.dt.QueryMediaIDs(ctx, pg.FilterMediaByFilename(album.ID, candidate))
+		if err != nil {
+			return "", NewDatabaseWriteError(ctx, operation, err).
+				WithAlbumID(album.ID).
+				AtStep("check_filename")
+		}
+
+		onDisk, err := m.fs.Exists(ctx, path.Join(album.Path, candidate))
+		if err != nil {
+			return "", NewFilesystemError(ctx, operation, "check_filename", path.Join(album.Path, candidate), err)
+		}
+
+		if len(existing) == 0 && !onDisk {
+			return candidate, nil
+		}
+
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+}
+
 func (m *MediaService) GetContentFn(ctx context.Context, media entity.Media) entity.MediaContentFn {
 	filepath := path.Join(media.Album.Path, media.Filename)
 	return m.fs.Read(ctx, filepath)
@@ -28,7 +28,7 @@ var _ = Describe("MediaService", Ordered, func() {
 	)
 
 	BeforeAll(func() {
-		pgDt, err := pg.NewPostgresDatastore(context.TODO(), pgUri)
+		pgDt, err := newDatastore(context.TODO(), pgUri)
 		Expect(err).To(BeNil())
 		Expect(pgDt).ToNot(BeNil())
 
@@ -95,7 +95,7 @@ var _ = Describe("MediaService", Ordered, func() {
 		}
 	})
 
-	Context("GetMedia", func() {
+	Context("List", func() {
 		It("retrieves media successfully", func() {
 			// Insert test media
 			exifJSON, err := json.Marshal(testMedia.Exif)
@@ -110,7 +110,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			Expect(err).To(BeNil())
 
 			options := &services.MediaOptions{MediaLimit: 10}
-			media, err := mediaService.GetMedia(context.TODO(), options)
+			media, _, err := mediaService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(media).To(HaveLen(1))
@@ -121,7 +121,7 @@ var _ = Describe("MediaService", Ordered, func() {
 
 		It("returns empty result when no media exist", func() {
 			options := &services.MediaOptions{MediaLimit: 10}
-			media, err := mediaService.GetMedia(context.TODO(), options)
+			media, _, err := mediaService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(media).To(HaveLen(0))
@@ -149,7 +149,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			options := &services.MediaOptions{
 				MediaLimit: 2,
 			}
-			media, err := mediaService.GetMedia(context.TODO(), options)
+			media, _, err := mediaService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(media).To(HaveLen(2))
@@ -159,7 +159,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			options = &services.MediaOptions{
 				MediaType: &photoType,
 			}
-			media, err = mediaService.GetMedia(context.TODO(), options)
+			media, _, err = mediaService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(media).To(HaveLen(2)) // Should only return photos
@@ -190,7 +190,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			options := &services.MediaOptions{
 				StartDate: &now,
 			}
-			media, err := mediaService.GetMedia(context.TODO(), options)
+			media, _, err := mediaService.List(context.TODO(), options)
 
 			Expect(err).To(BeNil())
 			Expect(media).To(HaveLen(1))
@@ -205,7 +205,7 @@ var _ = Describe("MediaService", Ordered, func() {
 		})
 	})
 
-	Context("GetMediaByID", func() {
+	Context("Get", func() {
 		It("retrieves single media successfully", func() {
 			// Insert test media
 			exifJSON, err := json.Marshal(testMedia.Exif)
@@ -217,7 +217,7 @@ var _ = Describe("MediaService", Ordered, func() {
 				testMedia.ID, now, testMedia.CapturedAt, testMedia.Album.ID, testMedia.Filename, testMedia.Thumbnail, exifJSON, string(testMedia.MediaType))
 			Expect(err).To(BeNil())
 
-			media, err := mediaService.GetMediaByID(context.TODO(), testMedia.ID)
+			media, err := mediaService.Get(context.TODO(), testMedia.ID)
 
 			Expect(err).To(BeNil())
 			Expect(media).ToNot(BeNil())
@@ -227,10 +227,10 @@ var _ = Describe("MediaService", Ordered, func() {
 		})
 
 		It("returns not found error when media doesn't exist", func() {
-			media, err := mediaService.GetMediaByID(context.TODO(), "non-existent-id")
+			media, err := mediaService.Get(context.TODO(), "non-existent-id")
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 			Expect(media).To(BeNil())
 		})
 
@@ -360,7 +360,7 @@ var _ = Describe("MediaService", Ordered, func() {
 		})
 	})
 
-	Context("UpdateMedia", func() {
+	Context("Update", func() {
 		It("updates existing media successfully", func() {
 			// First create the media
 			exifJSON, err := json.Marshal(testMedia.Exif)
@@ -383,7 +383,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			updatedTime := time.Now().Add(time.Hour)
 			updatedMedia.CapturedAt = updatedTime
 
-			result, err := mediaService.UpdateMedia(context.TODO(), updatedMedia)
+			result, err := mediaService.Update(context.TODO(), updatedMedia)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -419,7 +419,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			}
 			updatedMedia.Exif = map[string]string{"Updated": "true"}
 
-			result, err := mediaService.UpdateMedia(context.TODO(), updatedMedia)
+			result, err := mediaService.Update(context.TODO(), updatedMedia)
 
 			Expect(err).To(BeNil())
 			Expect(result).ToNot(BeNil())
@@ -434,7 +434,7 @@ var _ = Describe("MediaService", Ordered, func() {
 		})
 	})
 
-	Context("DeleteMedia", func() {
+	Context("Delete", func() {
 		It("deletes existing media successfully", func() {
 			// First create the media
 			exifJSON, err := json.Marshal(testMedia.Exif)
@@ -446,7 +446,7 @@ var _ = Describe("MediaService", Ordered, func() {
 				testMedia.ID, now, testMedia.CapturedAt, testMedia.Album.ID, testMedia.Filename, testMedia.Thumbnail, exifJSON, string(testMedia.MediaType))
 			Expect(err).To(BeNil())
 
-			err = mediaService.DeleteMedia(context.TODO(), testMedia.ID)
+			err = mediaService.Delete(context.TODO(), testMedia.ID)
 
 			Expect(err).To(BeNil())
 
@@ -458,10 +458,10 @@ var _ = Describe("MediaService", Ordered, func() {
 		})
 
 		It("returns error when media doesn't exist", func() {
-			err := mediaService.DeleteMedia(context.TODO(), "non-existent-id")
+			err := mediaService.Delete(context.TODO(), "non-existent-id")
 
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 		})
 
 		It("handles deletion of media used as album thumbnail", func() {
@@ -480,7 +480,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			Expect(err).To(BeNil())
 
 			// Delete the media (should succeed due to ON DELETE SET NULL)
-			err = mediaService.DeleteMedia(context.TODO(), testMedia.ID)
+			err = mediaService.Delete(context.TODO(), testMedia.ID)
 
 			Expect(err).To(BeNil())
 
@@ -513,7 +513,7 @@ var _ = Describe("MediaService", Ordered, func() {
 			Expect(result.ID).To(Equal(testMedia.ID))
 
 			// 2. Retrieve by ID
-			retrieved, err := mediaService.GetMediaByID(context.TODO(), testMedia.ID)
+			retrieved, err := mediaService.Get(context.TODO(), testMedia.ID)
 			Expect(err).To(BeNil())
 			Expect(retrieved.ID).To(Equal(testMedia.ID))
 			Expect(retrieved.Filename).To(Equal(testMedia.Filename))
@@ -526,23 +526,23 @@ var _ = Describe("MediaService", Ordered, func() {
 				"Updated": "true",
 			}
 
-			updated, err := mediaService.UpdateMedia(context.TODO(), updatedMedia)
+			updated, err := mediaService.Update(context.TODO(), updatedMedia)
 			Expect(err).To(BeNil())
 			Expect(updated.Exif).To(HaveKeyWithValue("Updated", "true"))
 
 			// 4. Verify update persisted
-			retrieved2, err := mediaService.GetMediaByID(context.TODO(), testMedia.ID)
+			retrieved2, err := mediaService.Get(context.TODO(), testMedia.ID)
 			Expect(err).To(BeNil())
 			Expect(retrieved2.Exif).To(HaveKeyWithValue("Updated", "true"))
 
 			// 5. Delete media
-			err = mediaService.DeleteMedia(context.TODO(), testMedia.ID)
+			err = mediaService.Delete(context.TODO(), testMedia.ID)
 			Expect(err).To(BeNil())
 
 			// 6. Verify deletion
-			_, err = mediaService.GetMediaByID(context.TODO(), testMedia.ID)
+			_, err = mediaService.Get(context.TODO(), testMedia.ID)
 			Expect(err).ToNot(BeNil())
-			Expect(services.IsErrResourceNotFound(err)).To(BeTrue())
+			Expect(isNotFound(err)).To(BeTrue())
 		})
 
 		AfterEach(func() {
@@ -0,0 +1,222 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Memories", Ordered, func() {
+	var (
+		mediaService *services.MediaService
+		dt           *pg.Datastore
+		pgPool       *pgxpool.Pool
+		media        map[string]entity.Media
+	)
+
+	const tmpDir = "/tmp/photos-ng-memories-test"
+
+	ctx := user.ToContext(context.Background(), &entity.User{ID: "alice-id", Username: "alice"})
+	day := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+
+		Expect(os.MkdirAll(tmpDir, 0755)).To(Succeed())
+		mediaService = services.NewMediaService(dt, fs.NewFsDatastore(tmpDir))
+
+		// Clean up any existing data
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		summer := entity.NewAlbum("summer")
+		secret := entity.NewAlbum("secret")
+		for _, album := range []entity.Album{summer, secret} {
+			sql, args, err := insertAlbumStmt.Values(album.ID, time.Now(), album.Path, nil, nil, nil).ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+		}
+		_, err = pgPool.Exec(context.TODO(), "UPDATE albums SET hidden = true WHERE id = $1", secret.ID)
+		Expect(err).To(BeNil())
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		media = map[string]entity.Media{}
+		for _, m := range []struct {
+			name       string
+			album      entity.Album
+			capturedAt time.Time
+			favorite   bool
+			rating     *int
+			trashed    bool
+		}{
+			{"sunset.jpg", summer, time.Date(2024, 7, 1, 20, 0, 0, 0, time.UTC), true, nil, false},
+			{"dune.jpg", summer, time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC), false, func() *int { r := 4; return &r }(), false},
+			{"waves.jpg", summer, time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC), false, nil, false},
+			{"shells.jpg", summer, time.Date(2022, 7, 1, 10, 0, 0, 0, time.UTC), false, func() *int { r := 2; return &r }(), false},
+			// not on the day of the memories
+			{"later.jpg", summer, time.Date(2022, 7, 2, 10, 0, 0, 0, time.UTC), true, nil, false},
+			{"today.jpg", summer, time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC), true, nil, false},
+			// left out of the memories
+			{"hidden.jpg", secret, time.Date(2021, 7, 1, 10, 0, 0, 0, time.UTC), true, nil, false},
+			{"blurry.jpg", summer, time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC), true, nil, true},
+		} {
+			created := entity.NewMedia(m.name, m.album)
+			sql, args, err := insertMediaStmt.
+				Values(created.ID, time.Now(), m.capturedAt, m.album.ID, created.Filename, []byte("thumb"), exifJSON, string(entity.Photo)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+
+			_, err = pgPool.Exec(context.TODO(), "UPDATE media SET favorite = $1, rating = $2 WHERE id = $3", m.favorite, m.rating, created.ID)
+			Expect(err).To(BeNil())
+
+			if m.trashed {
+				_, err = pgPool.Exec(context.TODO(), "UPDATE media SET trashed_at = now() WHERE id = $1", created.ID)
+				Expect(err).To(BeNil())
+			}
+			media[m.name] = created
+		}
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	// filenames returns the years of the memories with the names of the files of their media
+	filenames := func(memories []entity.Memory) map[int][]string {
+		names := map[int][]string{}
+		for _, memory := range memories {
+			names[memory.Year] = []string{}
+			for _, m := range memory.Media {
+				names[memory.Year] = append(names[memory.Year], m.Filename)
+			}
+		}
+		return names
+	}
+
+	Context("MediaService", func() {
+		It("groups the media of the day in the previous years, the most recent first", func() {
+			memories, err := mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day})
+			Expect(err).To(BeNil())
+			Expect(memories).To(HaveLen(3))
+			Expect(memories[0].Year).To(Equal(2024))
+			Expect(memories[1].Year).To(Equal(2023))
+			Expect(memories[2].Year).To(Equal(2022))
+			Expect(filenames(memories)).To(Equal(map[int][]string{
+				2024: {"sunset.jpg", "dune.jpg"},
+				2023: {"waves.jpg"},
+				2022: {"shells.jpg"},
+			}))
+		})
+
+		It("keeps the favorite media or the media rated at least the rating", func() {
+			memories, err := mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesFavorite: true})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"sunset.jpg"}}))
+
+			rating := 2
+			memories, err = mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesMinRating: &rating})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"dune.jpg"}, 2022: {"shells.jpg"}}))
+
+			memories, err = mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesFavorite: true, MemoriesMinRating: &rating})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"sunset.jpg", "dune.jpg"}, 2022: {"shells.jpg"}}))
+		})
+
+		It("returns the most recent media up to the limit", func() {
+			memories, err := mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesLimit: 3})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"sunset.jpg", "dune.jpg"}, 2023: {"waves.jpg"}}))
+		})
+
+		It("returns no memories on a day without media", func() {
+			memories, err := mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)})
+			Expect(err).To(BeNil())
+			Expect(memories).To(BeEmpty())
+		})
+
+		It("refuses a rating out of range", func() {
+			rating := 6
+			_, err := mediaService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesMinRating: &rating})
+			var validation *services.ValidationError
+			Expect(err).To(BeAssignableToTypeOf(validation))
+		})
+	})
+
+	Context("with authorization", func() {
+		var (
+			authz        *viewAuthz
+			authzService *services.AuthzMediaService
+		)
+
+		BeforeEach(func() {
+			authz = &viewAuthz{}
+			authzService = services.NewAuthzMediaService(authz, mediaService)
+		})
+
+		It("returns only the media the user can view", func() {
+			authz.viewable = []entity.Resource{entity.NewMediaResource(media["dune.jpg"].ID), entity.NewMediaResource(media["shells.jpg"].ID)}
+
+			memories, err := authzService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"dune.jpg"}, 2022: {"shells.jpg"}}))
+		})
+
+		It("reads the next pages until the limit is reached", func() {
+			// the first page of twice the limit has no media the user can view
+			authz.viewable = []entity.Resource{entity.NewMediaResource(media["shells.jpg"].ID)}
+
+			memories, err := authzService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesLimit: 1})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2022: {"shells.jpg"}}))
+		})
+
+		It("stops at the limit", func() {
+			authz.viewable = []entity.Resource{
+				entity.NewMediaResource(media["sunset.jpg"].ID),
+				entity.NewMediaResource(media["waves.jpg"].ID),
+				entity.NewMediaResource(media["shells.jpg"].ID),
+			}
+
+			memories, err := authzService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesLimit: 2})
+			Expect(err).To(BeNil())
+			Expect(filenames(memories)).To(Equal(map[int][]string{2024: {"sunset.jpg"}, 2023: {"waves.jpg"}}))
+		})
+
+		It("returns no memories when the user can view no media", func() {
+			memories, err := authzService.Memories(ctx, &services.MemoriesOptions{MemoriesDay: day, MemoriesLimit: 1})
+			Expect(err).To(BeNil())
+			Expect(memories).To(BeEmpty())
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 )
 
 const (
@@ -75,6 +76,14 @@ type MediaOptions struct {
 	MediaType  *string           `debugmap:"visible"`
 	StartDate  *time.Time        `debugmap:"visible"`
 	EndDate    *time.Time        `debugmap:"visible"`
+	// AlbumTreeID includes the media of the album and of all its descendants
+	AlbumTreeID *string             `debugmap:"visible"`
+	Camera      *string             `debugmap:"visible"`
+	Tags        []string            `debugmap:"visible"`
+	MinRating   *int                `debugmap:"visible"`
+	Area        *entity.BoundingBox `debugmap:"visible"`
+	// Query is a filter written in the query language of the query package
+	Query string `debugmap:"visible"`
 }
 
 // QueriesFn returns a slice of query options based on the media filter criteria
@@ -91,9 +100,30 @@ func (mf *MediaOptions) QueriesFn() []pg.QueryOption {
 		qf = append(qf, pg.FilterByColumnName("media_type", *mf.MediaType))
 	}
 
+	if mf.AlbumTreeID != nil {
+		qf = append(qf, pg.FilterByAlbumSubtree(*mf.AlbumTreeID))
+	}
+
 	// Add date range filters
-	// TODO: Implement date range filtering in query options
-	// For now, we'll filter in the service layer after querying
+	if mf.StartDate != nil || mf.EndDate != nil {
+		qf = append(qf, pg.FilterByMediaDate(mf.StartDate, mf.EndDate))
+	}
+
+	if mf.Camera != nil {
+		qf = append(qf, pg.FilterByCamera(*mf.Camera))
+	}
+
+	if len(mf.Tags) > 0 {
+		qf = append(qf, pg.FilterByTags(mf.Tags))
+	}
+
+	if mf.MinRating != nil {
+		qf = append(qf, pg.FilterByMinRating(*mf.MinRating))
+	}
+
+	if mf.Area != nil {
+		qf = append(qf, pg.FilterByArea(*mf.Area))
+	}
 
 	// Add cursor-based filtering
 	if mf.Cursor != nil {
@@ -123,31 +153,353 @@ func (mf *MediaOptions) QueriesFn() []pg.QueryOption {
 	return qf
 }
 
+// TimelineOptions represents filtering criteria for the timeline
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.timeline_options.go . TimelineOptions
+type TimelineOptions struct {
+	// TimelineDaily splits the timeline in days instead of months
+	TimelineDaily bool `debugmap:"visible"`
+	// TimelineAlbumTreeID restricts the timeline to the media of the album and of all its descendants
+	TimelineAlbumTreeID *string    `debugmap:"visible"`
+	TimelineStartDate   *time.Time `debugmap:"visible"`
+	TimelineEndDate     *time.Time `debugmap:"visible"`
+	// AllowedTimelineAlbumIDs restricts the timeline to the media of these albums. Nil means no restriction.
+	AllowedTimelineAlbumIDs []string `debugmap:"visible"`
+}
+
+// QueriesFn returns a slice of query options based on the timeline filter criteria
+func (to *TimelineOptions) QueriesFn() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	if to.AllowedTimelineAlbumIDs != nil {
+		qf = append(qf, pg.FilterMediaByAlbumIDs(to.AllowedTimelineAlbumIDs))
+	}
+
+	if to.TimelineAlbumTreeID != nil {
+		qf = append(qf, pg.FilterByAlbumSubtree(*to.TimelineAlbumTreeID))
+	}
+
+	if to.TimelineStartDate != nil || to.TimelineEndDate != nil {
+		qf = append(qf, pg.FilterByMediaDate(to.TimelineStartDate, to.TimelineEndDate))
+	}
+
+	return qf
+}
+
+// MemoriesOptions represents filtering criteria for the memories
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.memories_options.go . MemoriesOptions
+type MemoriesOptions struct {
+	// MemoriesDay is the day whose memories are returned. Zero means today.
+	MemoriesDay time.Time `debugmap:"visible"`
+	// MemoriesFavorite keeps the favorite media. With MemoriesMinRating, media matching either are kept.
+	MemoriesFavorite  bool `debugmap:"visible"`
+	MemoriesMinRating *int `debugmap:"visible"`
+	MemoriesLimit     int  `debugmap:"visible"`
+	// MemoriesOffset skips the first media, it is used to read the memories in pages
+	MemoriesOffset int `debugmap:"visible"`
+}
+
+// QueriesFn returns a slice of query options based on the memories filter criteria.
+// The day filter needs the oldest capture date and is added by the service.
+func (mo *MemoriesOptions) QueriesFn() []pg.QueryOption {
+	qf := []pg.QueryOption{
+		pg.ExcludeHiddenAlbums(),
+		pg.FilterByHighlights(mo.MemoriesFavorite, mo.MemoriesMinRating),
+		pg.SortByColumn("media.captured_at", true),
+		pg.SortByColumn("media.id", true),
+	}
+
+	if mo.MemoriesLimit > 0 {
+		qf = append(qf, pg.Limit(mo.MemoriesLimit))
+	}
+
+	if mo.MemoriesOffset > 0 {
+		qf = append(qf, pg.Offset(mo.MemoriesOffset))
+	}
+
+	return qf
+}
+
+// AlbumCursor is the position of the last album of a page of a sorted album listing
+type AlbumCursor struct {
+	SortBy entity.AlbumSortBy `json:"sort_by"`
+	Value  string             `json:"value"`
+	ID     string             `json:"id"`
+}
+
+// Encode converts the cursor to a base64 encoded string for URL usage
+func (c *AlbumCursor) Encode() (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeAlbumCursor parses a base64 encoded album cursor string
+func DecodeAlbumCursor(encoded string) (*AlbumCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor AlbumCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
 // ListOptions represents optionsing criteria for album queries
 //
 //go:generate go run github.com/ecordell/optgen -output zz_generated.album_options.go . ListOptions
 type ListOptions struct {
-	Limit      int      `debugmap:"visible"`
-	Offset     int      `debugmap:"visible"`
-	ParentID   *string  `debugmap:"visible"`
-	HasParent  bool     `debugmap:"visible"`
+	Limit int `debugmap:"visible"`
+	// Offset is ignored when AlbumCursor is set
+	Offset    int     `debugmap:"visible"`
+	ParentID  *string `debugmap:"visible"`
+	HasParent bool    `debugmap:"visible"`
+	// AllowedIDs restricts the result to these albums. Nil means no restriction.
 	AllowedIDs []string `debugmap:"visible"`
+	// AlbumSortBy defaults to entity.AlbumSortByName
+	AlbumSortBy         entity.AlbumSortBy `debugmap:"visible"`
+	AlbumSortDescending bool               `debugmap:"visible"`
+	AlbumCursor         *AlbumCursor       `debugmap:"visible"`
 }
 
-// QueriesFn returns a slice of query options based on the album filter criteria
-// Note: Pagination (Limit/Offset) is removed because it interferes with JOIN queries
-// and causes incorrect media counts. Album pagination should be handled at the application level.
-func (af *ListOptions) QueriesFn() []pg.QueryOption {
+// FiltersFn returns the query options restricting the set of albums, without pagination and sorting
+func (af *ListOptions) FiltersFn() []pg.QueryOption {
 	qf := []pg.QueryOption{}
 
-	// Note: Pagination removed due to JOIN query issues
-	// TODO: Implement pagination at application level after query execution
-
 	qf = append(qf, pg.FilterAlbumWithParents(af.HasParent))
 
 	// Add parent filter
 	if af.ParentID != nil {
-		qf = append(qf, pg.FilterByColumnName("parent", *af.ParentID))
+		qf = append(qf, pg.FilterByColumnName("albums.parent_id", *af.ParentID))
+	}
+
+	if af.AllowedIDs != nil {
+		qf = append(qf, pg.FilterAlbumsByIDs(af.AllowedIDs))
+	}
+
+	return qf
+}
+
+// QueriesFn returns a slice of query options based on the album filter criteria.
+// Sorting and pagination apply to pg.Datastore.QueryAlbumKeys: the join of QueryAlbums returns
+// one row per child album and media, which cannot be paginated.
+func (af *ListOptions) QueriesFn() []pg.QueryOption {
+	qf := af.FiltersFn()
+
+	qf = append(qf, pg.SortAlbums(af.sortBy(), af.AlbumSortDescending))
+
+	if af.AlbumCursor != nil {
+		qf = append(qf, pg.FilterByAlbumCursor(af.sortBy(), af.AlbumSortDescending, af.AlbumCursor.Value, af.AlbumCursor.ID))
+	} else if af.Offset > 0 {
+		qf = append(qf, pg.Offset(af.Offset))
+	}
+
+	if af.Limit > 0 {
+		qf = append(qf, pg.Limit(af.Limit))
+	}
+
+	return qf
+}
+
+func (af *ListOptions) sortBy() entity.AlbumSortBy {
+	if af.AlbumSortBy == "" {
+		return entity.AlbumSortByName
+	}
+	return af.AlbumSortBy
+}
+
+// AlbumTreeOptions represents filtering criteria for the album tree
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.album_tree_options.go . AlbumTreeOptions
+type AlbumTreeOptions struct {
+	// AlbumTreeRootID restricts the tree to the album and its descendants. Nil returns the whole tree.
+	AlbumTreeRootID *string `debugmap:"visible"`
+	// AlbumTreeDepth limits the number of levels of the tree. 0 means no limit.
+	AlbumTreeDepth int `debugmap:"visible"`
+	// AllowedAlbumTreeIDs restricts the tree to these albums. Nil means no restriction.
+	AllowedAlbumTreeIDs []string `debugmap:"visible"`
+}
+
+// QueriesFn returns a slice of query options based on the album tree filter criteria
+func (to *AlbumTreeOptions) QueriesFn() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	if to.AllowedAlbumTreeIDs != nil {
+		qf = append(qf, pg.FilterAlbumsByIDs(to.AllowedAlbumTreeIDs))
+	}
+
+	if to.AlbumTreeRootID != nil {
+		qf = append(qf, pg.FilterAlbumsBySubtree(*to.AlbumTreeRootID))
+	}
+
+	return qf
+}
+
+// SyncOptions represents the options of a sync job
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.sync_options.go . SyncOptions
+type SyncOptions struct {
+	// FullRescan reads and hashes every file. By default the files whose size, modification time
+	// and inode did not change since the last sync are skipped.
+	FullRescan bool `debugmap:"visible"`
+}
+
+// CollectionOptions represents filtering criteria for collection queries
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.collection_options.go . CollectionOptions
+type CollectionOptions struct {
+	CollectionLimit  int `debugmap:"visible"`
+	CollectionOffset int `debugmap:"visible"`
+	// AllowedCollectionIDs restricts the result to these collections. Nil means no restriction.
+	AllowedCollectionIDs []string `debugmap:"visible"`
+}
+
+// FiltersFn returns the query options restricting the set of collections, without pagination and sorting
+func (co *CollectionOptions) FiltersFn() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	if co.AllowedCollectionIDs != nil {
+		qf = append(qf, pg.FilterCollectionsByIDs(co.AllowedCollectionIDs))
+	}
+
+	return qf
+}
+
+// QueriesFn returns a slice of query options based on the collection filter criteria
+func (co *CollectionOptions) QueriesFn() []pg.QueryOption {
+	qf := co.FiltersFn()
+
+	// Newest collections first
+	qf = append(qf, pg.SortByColumn("collections.created_at", true))
+	qf = append(qf, pg.SortByColumn("collections.id", true))
+
+	if co.CollectionLimit > 0 {
+		qf = append(qf, pg.Limit(co.CollectionLimit))
+	}
+
+	if co.CollectionOffset > 0 {
+		qf = append(qf, pg.Offset(co.CollectionOffset))
+	}
+
+	return qf
+}
+
+// SmartAlbumOptions represents filtering criteria for smart album queries
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.smart_album_options.go . SmartAlbumOptions
+type SmartAlbumOptions struct {
+	SmartAlbumLimit  int `debugmap:"visible"`
+	SmartAlbumOffset int `debugmap:"visible"`
+	// AllowedSmartAlbumIDs restricts the result to these smart albums. Nil means no restriction.
+	AllowedSmartAlbumIDs []string `debugmap:"visible"`
+}
+
+// FiltersFn returns the query options restricting the set of smart albums, without pagination and sorting
+func (so *SmartAlbumOptions) FiltersFn() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	if so.AllowedSmartAlbumIDs != nil {
+		qf = append(qf, pg.FilterSmartAlbumsByIDs(so.AllowedSmartAlbumIDs))
+	}
+
+	return qf
+}
+
+// QueriesFn returns a slice of query options based on the smart album filter criteria
+func (so *SmartAlbumOptions) QueriesFn() []pg.QueryOption {
+	qf := so.FiltersFn()
+
+	qf = append(qf, pg.SortByColumn("smart_albums.name", false))
+	qf = append(qf, pg.SortByColumn("smart_albums.id", false))
+
+	if so.SmartAlbumLimit > 0 {
+		qf = append(qf, pg.Limit(so.SmartAlbumLimit))
+	}
+
+	if so.SmartAlbumOffset > 0 {
+		qf = append(qf, pg.Offset(so.SmartAlbumOffset))
+	}
+
+	return qf
+}
+
+// SearchCursor represents a cursor for search pagination based on the rank, kind and id of the last match
+type SearchCursor struct {
+	Rank float64                 `json:"rank"`
+	Kind entity.SearchResultKind `json:"kind"`
+	ID   string                  `json:"id"`
+}
+
+// Encode converts the cursor to a base64 encoded string for URL usage
+func (c *SearchCursor) Encode() (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeSearchCursor parses a base64 encoded search cursor string
+func DecodeSearchCursor(encoded string) (*SearchCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	data, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor SearchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// SearchOptions represents the criteria of a full-text search
+//
+//go:generate go run github.com/ecordell/optgen -output zz_generated.search_options.go . SearchOptions
+type SearchOptions struct {
+	SearchText   string        `debugmap:"visible"`
+	SearchLimit  int           `debugmap:"visible"`
+	SearchCursor *SearchCursor `debugmap:"visible"`
+	// SearchKind restricts the matches to albums or media. Nil means both.
+	SearchKind *entity.SearchResultKind `debugmap:"visible"`
+}
+
+// QueriesFn returns a slice of query options based on the search criteria
+func (so *SearchOptions) QueriesFn() []pg.QueryOption {
+	qf := []pg.QueryOption{}
+
+	if so.SearchKind != nil {
+		qf = append(qf, pg.FilterSearchByKind(*so.SearchKind))
+	}
+
+	if so.SearchCursor != nil {
+		qf = append(qf, pg.FilterBySearchCursor(so.SearchCursor.Rank, so.SearchCursor.Kind, so.SearchCursor.ID))
+	}
+
+	if so.SearchLimit > 0 {
+		qf = append(qf, pg.Limit(so.SearchLimit))
 	}
 
 	return qf
@@ -125,6 +125,13 @@ func (p *ProcessingMediaService) Process(ctx context.Context, content io.Reader)
 			exif[k] = fmt.Sprintf("%d", val)
 		case float32, float64:
 			exif[k] = fmt.Sprintf("%f", val)
+		case []any:
+			// multi-valued fields like Keywords are kept as a comma separated list
+			values := make([]string, 0, len(val))
+			for _, item := range val {
+				values = append(values, fmt.Sprintf("%v", item))
+			}
+			exif[k] = strings.Join(values, ", ")
 		default:
 			unsupportedCount++
 			logger.Step("unsupported_exif_value_type").
@@ -14,8 +14,8 @@ import (
 )
 
 const (
-	defaultKeepPeriod   = 600 // seconds
-	maxRunningPipelines = 1
+	defaultKeepPeriod     = 600 // seconds
+	defaultMaxRunningJobs = 1
 )
 
 var (
@@ -30,11 +30,20 @@ type Job interface {
 	Cancel() error
 	Status() entity.JobProgress
 	Metadata() map[string]string
+	SetListener(listener JobListener)
+	// Ready tells if the jobs the job depends on are far enough, an error means they will never be
+	Ready() (bool, error)
+	// Fail marks a job which did not start as failed
+	Fail(reason string)
+	// SubJobs returns the jobs run for a parent job, nil for the jobs running their own tasks
+	SubJobs() []Job
 }
 
 type Scheduler struct {
 	m          sync.Mutex
 	queue      *list.List
+	store      *JobStore // Keeps the job history in the database, nil keeps the jobs in memory only
+	maxRunning int       // How many jobs run at the same time
 	done       chan chan struct{}
 	logger     *logger.StructuredLogger
 	infoLogger *logger.StructuredLogger
@@ -49,13 +58,14 @@ func GetScheduler() *Scheduler {
 
 		scheduler = &Scheduler{
 			queue:      list.New(),
+			maxRunning: defaultMaxRunningJobs,
 			logger:     debugLogger,
 			infoLogger: infoLogger,
 		}
 
 		tracer.Success().
 			WithInt("tick_interval_seconds", 2).
-			WithInt("max_running_pipelines", maxRunningPipelines).
+			WithInt("max_running_jobs", defaultMaxRunningJobs).
 			WithInt("default_keep_period_seconds", defaultKeepPeriod).
 			Log()
 
@@ -81,12 +91,31 @@ func GetScheduler() *Scheduler {
 func (s *Scheduler) Add(j Job) error {
 	tracer := s.logger.Operation("add_job").
 		WithString(JobID, j.GetID().String()).
+		WithInt("sub_jobs", len(j.SubJobs())).
 		Build()
 
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	if s.store != nil {
+		j.SetListener(s.store)
+		s.store.JobChanged(j)
+		for _, sub := range j.SubJobs() {
+			s.store.JobChanged(sub)
+		}
+	}
+
+	// the sub-jobs are run by the scheduler like the other jobs
 	s.queue.PushBack(j)
+	for _, sub := range j.SubJobs() {
+		s.queue.PushBack(sub)
+	}
+
+	events := GetJobEventBus()
+	events.Publish(entity.NewJobEvent(entity.JobAddedEvent, j.Status()))
+	for _, sub := range j.SubJobs() {
+		events.Publish(entity.NewJobEvent(entity.JobAddedEvent, sub.Status()))
+	}
 
 	tracer.Success().
 		WithInt("queue_length", s.queue.Len()).
@@ -95,6 +124,87 @@ func (s *Scheduler) Add(j Job) error {
 	return nil
 }
 
+// SetConcurrency sets how many jobs run at the same time
+func (s *Scheduler) SetConcurrency(jobs int) {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	s.m.Lock()
+	s.maxRunning = jobs
+	s.m.Unlock()
+
+	s.infoLogger.Operation("set_concurrency").Build().Success().
+		WithInt("max_running_jobs", jobs).
+		Log()
+}
+
+// UseStore keeps the history of the jobs in the store from now on. The jobs of the history which were not
+// finished, because the server stopped while they were running or waiting, are marked as interrupted and
+// returned. It must be called at startup before any job is added.
+func (s *Scheduler) UseStore(ctx context.Context, store *JobStore) ([]entity.JobProgress, error) {
+	interrupted, err := store.Interrupt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.m.Lock()
+	s.store = store
+	s.m.Unlock()
+
+	s.infoLogger.Operation("use_job_store").Build().Success().
+		WithInt("interrupted_jobs", len(interrupted)).
+		Log()
+
+	return interrupted, nil
+}
+
+// GetHistory returns a page of the jobs of the history having one of the statuses, all of them if there are
+// none, without the results of their tasks. The jobs of the excluded types are left out. The jobs still known
+// by the scheduler are not returned, their status in memory is the current one.
+func (s *Scheduler) GetHistory(ctx context.Context, limit, offset int, excludedTypes []string, statuses ...entity.JobStatus) ([]entity.JobProgress, error) {
+	if s.store == nil {
+		return []entity.JobProgress{}, nil
+	}
+	return s.store.List(ctx, limit, offset, s.knownIDs(), excludedTypes, statuses...)
+}
+
+// CountHistory returns the number of jobs of the history having one of the statuses, all of them if there
+// are none. The jobs of the excluded types and the jobs still known by the scheduler are not counted.
+func (s *Scheduler) CountHistory(ctx context.Context, excludedTypes []string, statuses ...entity.JobStatus) (int, error) {
+	if s.store == nil {
+		return 0, nil
+	}
+	return s.store.Count(ctx, s.knownIDs(), excludedTypes, statuses...)
+}
+
+// knownIDs returns the ids of the jobs known by the scheduler
+func (s *Scheduler) knownIDs() []string {
+	jobs := s.GetAll()
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.GetID().String())
+	}
+	return ids
+}
+
+// GetFromHistory returns a job of the history, nil if it is not found or there is no history.
+func (s *Scheduler) GetFromHistory(ctx context.Context, id string) (*entity.JobProgress, error) {
+	if s.store == nil {
+		return nil, nil
+	}
+	return s.store.Get(ctx, id)
+}
+
+// ClearHistory deletes the finished jobs of the history.
+func (s *Scheduler) ClearHistory(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	_, err := s.store.Purge(ctx, time.Now())
+	return err
+}
+
 func (s *Scheduler) GetByAlbumID(albumID string) []Job {
 	tracer := s.logger.Operation("get_jobs_by_album_id").
 		WithString("album_id", albumID).
@@ -198,6 +308,7 @@ func (s *Scheduler) Remove(id string) error {
 		j := element.Value.(Job)
 		if j.GetID().String() == id {
 			s.queue.Remove(element)
+			GetJobEventBus().Publish(entity.NewJobEvent(entity.JobRemovedEvent, j.Status()))
 
 			tracer.Success().
 				WithBool("job_found", true).
@@ -267,28 +378,52 @@ func (s *Scheduler) find(f func(j Job) bool) []Job {
 }
 
 func (s *Scheduler) run() {
+	s.m.Lock()
+	maxRunning := s.maxRunning
+	s.m.Unlock()
+
+	running := s.countByStatus(entity.StatusRunning) + s.countByStatus(entity.StatusPause)
+	waiting := []Job{}
+
 start:
 	e := s.queue.Front()
 	for e != nil {
 		j := e.Value.(Job)
 
+		// a parent job is finished with its sub-jobs, it only needs to be cleaned up
+		if len(j.SubJobs()) > 0 && !j.Status().Status.Finished() {
+			e = e.Next()
+			continue
+		}
+
 		switch j.Status().Status {
 		case entity.StatusPending:
-			currentRunning := s.countByStatus(entity.StatusRunning)
-			if currentRunning < maxRunningPipelines {
+			ready, err := j.Ready()
+			if err != nil {
+				s.infoLogger.Operation("failing_job").Build().Success().
+					WithString(JobID, j.GetID().String()).
+					WithString("reason", err.Error()).
+					Log()
+
+				j.Fail(err.Error())
+				break
+			}
+			if ready && running < maxRunning && !waitsFor(j, waiting) {
 				// Log info-level message for job state change
 				s.infoLogger.Operation("starting_job").Build().Success().
 					WithString(JobID, j.GetID().String()).
-					WithInt("running_jobs", currentRunning).
+					WithInt("running_jobs", running).
 					Log()
 
+				running++
 				go func(job Job) {
 					ctx := context.Background()
 					job.Start(ctx)
 				}(j)
 			}
-
-			return
+			waiting = append(waiting, j)
+		case entity.StatusRunning, entity.StatusPause:
+			waiting = append(waiting, j)
 		case entity.StatusCompleted:
 			fallthrough
 		case entity.StatusStopped:
@@ -305,9 +440,40 @@ start:
 				s.m.Lock()
 				_ = s.queue.Remove(e)
 				s.m.Unlock()
+				GetJobEventBus().Publish(entity.NewJobEvent(entity.JobRemovedEvent, j.Status()))
 				goto start
 			}
 		}
 		e = e.Next()
 	}
 }
+
+// waitsFor tells if the job must wait for one of the unfinished jobs added before it by another operation.
+// The job of a folder waits for the jobs of its parent folders, which create the parent albums, and the
+// reconcile job of a folder waits for the jobs of the folders inside it, which may move media out of the
+// removed albums. The sub-jobs of the same parent job wait for their dependencies instead.
+func waitsFor(j Job, before []Job) bool {
+	p, ok := j.Metadata()["path"]
+	if !ok {
+		return false
+	}
+	parentID := j.Metadata()["parentId"]
+
+	for _, b := range before {
+		bp, ok := b.Metadata()["path"]
+		if !ok || (parentID != "" && b.Metadata()["parentId"] == parentID) {
+			continue
+		}
+		if j.Metadata()["type"] == reconcileJob {
+			if isInFolders(bp, []string{p}) {
+				return true
+			}
+			continue
+		}
+		// the data folder itself is not an album
+		if bp != "" && bp != p && isInFolders(p, []string{bp}) {
+			return true
+		}
+	}
+	return false
+}
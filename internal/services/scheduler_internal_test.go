@@ -0,0 +1,68 @@
+package services
+
+import (
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("waitsFor", func() {
+	// newJob returns a job without tasks with the metadata
+	newJob := func(opts map[string]string) Job {
+		job, err := NewSyncJob(entity.NewLinkedList[Task[string]](), opts)
+		Expect(err).To(BeNil())
+		return job
+	}
+
+	folder := func(path string) Job {
+		return newJob(map[string]string{"path": path, "type": syncJob})
+	}
+
+	DescribeTable("the job of a folder",
+		func(path string, before []string, expected bool) {
+			jobs := []Job{}
+			for _, p := range before {
+				jobs = append(jobs, folder(p))
+			}
+			Expect(waitsFor(folder(path), jobs)).To(Equal(expected))
+		},
+		Entry("waits for the job of its parent folder", "a/b", []string{"a"}, true),
+		Entry("waits for the job of an ancestor folder", "a/b/c", []string{"x", "a"}, true),
+		Entry("does not wait for the job of the same folder", "a/b", []string{"a/b"}, false),
+		Entry("does not wait for the jobs of sibling folders", "a/b", []string{"a/c", "a/bb"}, false),
+		Entry("does not wait for the jobs of its subfolders", "a", []string{"a/b"}, false),
+		Entry("does not wait for the job of the data folder", "a", []string{""}, false),
+		Entry("does not wait without jobs before it", "a", []string{}, false),
+	)
+
+	DescribeTable("the reconcile job of a folder",
+		func(path string, before []string, expected bool) {
+			jobs := []Job{}
+			for _, p := range before {
+				jobs = append(jobs, folder(p))
+			}
+			reconcile := newJob(map[string]string{"path": path, "type": reconcileJob})
+			Expect(waitsFor(reconcile, jobs)).To(Equal(expected))
+		},
+		Entry("waits for the job of a subfolder", "a", []string{"a/b"}, true),
+		Entry("waits for the job of the same folder", "a", []string{"a"}, true),
+		Entry("waits for every job below the data folder", "", []string{"a/b"}, true),
+		Entry("does not wait for the jobs of other folders", "a", []string{"b", "ab"}, false),
+		Entry("does not wait for the jobs of its parent folders", "a/b", []string{"a"}, false),
+	)
+
+	It("does not wait for the sub-jobs of the same parent job", func() {
+		parentJob := newJob(map[string]string{"path": "a", "type": syncJob, "parentId": "parent"})
+		child := newJob(map[string]string{"path": "a/b", "type": syncJob, "parentId": "parent"})
+		Expect(waitsFor(child, []Job{parentJob})).To(BeFalse())
+
+		other := newJob(map[string]string{"path": "a", "type": syncJob, "parentId": "other"})
+		Expect(waitsFor(child, []Job{other})).To(BeTrue())
+	})
+
+	It("does not wait for the jobs without path", func() {
+		Expect(waitsFor(newJob(nil), []Job{folder("a")})).To(BeFalse())
+		Expect(waitsFor(folder("a/b"), []Job{newJob(nil)})).To(BeFalse())
+	})
+})
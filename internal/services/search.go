@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// SearchService provides full-text search over albums and media without authorization.
+// Matching and ranking are done by Postgres; the service resolves the matches into albums and media.
+type SearchService struct {
+	dt     *pg.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(dt *pg.Datastore) *SearchService {
+	return &SearchService{
+		dt:     dt,
+		logger: logger.New("search_service"),
+	}
+}
+
+// Search returns a page of the albums and media matching the search text, the most relevant first.
+// The returned cursor points to the last match of the page and is nil if there are no more matches.
+func (s *SearchService) Search(ctx context.Context, opts *SearchOptions) ([]entity.SearchResult, *SearchCursor, error) {
+	logger := s.logger.WithContext(ctx).Debug("search").
+		WithString(SearchText, opts.SearchText).
+		WithInt("limit", opts.SearchLimit).
+		Build()
+
+	limit := opts.SearchLimit
+	if limit <= 0 {
+		limit = maxPageSize
+	}
+
+	// Request one extra match to know if there is a next page
+	results, err := s.match(ctx, NewSearchOptionsWithOptions(opts.ToOption(), WithSearchLimit(limit+1)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *SearchCursor
+	if len(results) > limit {
+		results = results[:limit]
+		nextCursor = newSearchCursor(results[len(results)-1])
+	}
+
+	results, err = s.resolve(ctx, results)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.Success().
+		WithInt(SearchMatches, len(results)).
+		WithBool("has_next_cursor", nextCursor != nil).
+		Log()
+
+	return results, nextCursor, nil
+}
+
+// match returns the ranked matches without resolving them into albums and media.
+func (s *SearchService) match(ctx context.Context, opts *SearchOptions) ([]entity.SearchResult, error) {
+	if strings.TrimSpace(opts.SearchText) == "" {
+		err := NewValidationError(ctx, "search", "invalid_input")
+		err.WithContext("validation_error", "empty_search_text")
+		return nil, err
+	}
+
+	results, err := s.dt.Search(ctx, opts.SearchText, opts.QueriesFn()...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "search", err).
+			WithContext(SearchText, opts.SearchText).
+			AtStep("query_search")
+	}
+
+	return results, nil
+}
+
+// resolve loads the albums and media of the matches keeping the order of the matches.
+// Matches whose resource was deleted in the meantime are dropped.
+func (s *SearchService) resolve(ctx context.Context, results []entity.SearchResult) ([]entity.SearchResult, error) {
+	albumIDs := []string{}
+	mediaIDs := []string{}
+	for _, result := range results {
+		switch result.Kind {
+		case entity.AlbumSearchResult:
+			albumIDs = append(albumIDs, result.ID)
+		case entity.MediaSearchResult:
+			mediaIDs = append(mediaIDs, result.ID)
+		}
+	}
+
+	albums := make(map[string]entity.Album, len(albumIDs))
+	if len(albumIDs) > 0 {
+		list, err := s.dt.QueryAlbums(ctx, pg.FilterAlbumsByIDs(albumIDs))
+		if err != nil {
+			return nil, NewDatabaseWriteError(ctx, "search", err).AtStep("query_albums")
+		}
+		for _, album := range list {
+			albums[album.ID] = album
+		}
+	}
+
+	media := make(map[string]entity.Media, len(mediaIDs))
+	if len(mediaIDs) > 0 {
+		list, err := s.dt.QueryMedia(ctx, pg.FilterMediaByIDs(mediaIDs))
+		if err != nil {
+			return nil, NewDatabaseWriteError(ctx, "search", err).AtStep("query_media")
+		}
+		for _, m := range list {
+			media[m.ID] = m
+		}
+	}
+
+	resolved := make([]entity.SearchResult, 0, len(results))
+	for _, result := range results {
+		switch result.Kind {
+		case entity.AlbumSearchResult:
+			album, found := albums[result.ID]
+			if !found {
+				continue
+			}
+			result.Album = &album
+		case entity.MediaSearchResult:
+			m, found := media[result.ID]
+			if !found {
+				continue
+			}
+			result.Media = &m
+		}
+		resolved = append(resolved, result)
+	}
+
+	return resolved, nil
+}
+
+func newSearchCursor(result entity.SearchResult) *SearchCursor {
+	return &SearchCursor{
+		Rank: result.Rank,
+		Kind: result.Kind,
+		ID:   result.ID,
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// SmartAlbumService provides business logic for smart album operations without authorization.
+// The media of a smart album are never stored: they are selected by evaluating its filter
+// through the same MediaOptions pipeline used to list media.
+type SmartAlbumService struct {
+	dt       *pg.Datastore
+	mediaSrv *MediaService
+	logger   *logger.StructuredLogger
+}
+
+// NewSmartAlbumService creates a new instance of SmartAlbumService
+func NewSmartAlbumService(dt *pg.Datastore, mediaSrv *MediaService) *SmartAlbumService {
+	return &SmartAlbumService{
+		dt:       dt,
+		mediaSrv: mediaSrv,
+		logger:   logger.New("smart_album_service"),
+	}
+}
+
+func (s *SmartAlbumService) List(ctx context.Context, opts *SmartAlbumOptions) ([]entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("list_smart_albums").
+		WithInt("limit", opts.SmartAlbumLimit).
+		WithInt("offset", opts.SmartAlbumOffset).
+		Build()
+
+	logger.Step("database_query").
+		WithString("query_type", "list_smart_albums").
+		Log()
+
+	smartAlbums, err := s.dt.QuerySmartAlbums(ctx, opts.QueriesFn()...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_smart_albums", err).
+			AtStep("query_smart_albums")
+	}
+
+	logger.Success().
+		WithInt(TotalSmartAlbums, len(smartAlbums)).
+		Log()
+
+	return smartAlbums, nil
+}
+
+func (s *SmartAlbumService) Count(ctx context.Context, opts *SmartAlbumOptions) (int, error) {
+	logger := s.logger.WithContext(ctx).Debug("count_smart_albums").Build()
+
+	count, err := s.dt.CountSmartAlbums(ctx, opts.FiltersFn()...)
+	if err != nil {
+		return 0, NewDatabaseWriteError(ctx, "count_smart_albums", err).
+			AtStep("count_smart_albums")
+	}
+
+	logger.Success().
+		WithInt(TotalSmartAlbums, count).
+		Log()
+
+	return count, nil
+}
+
+func (s *SmartAlbumService) Get(ctx context.Context, id string) (*entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("get_smart_album").
+		WithString(SmartAlbumID, id).
+		Build()
+
+	if id == "" {
+		err := NewValidationError(ctx, "get_smart_album", "invalid_input")
+		err.WithContext("validation_error", "empty_smart_album_id")
+		return nil, err
+	}
+
+	smartAlbums, err := s.dt.QuerySmartAlbums(ctx, pg.FilterSmartAlbumById(id), pg.Limit(1))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "get_smart_album", err).
+			WithContext(SmartAlbumID, id).
+			AtStep("query_smart_album")
+	}
+
+	if len(smartAlbums) == 0 {
+		return nil, NewSmartAlbumNotFoundError(ctx, id)
+	}
+
+	logger.Success().
+		WithString(SmartAlbumID, id).
+		Log()
+
+	return &smartAlbums[0], nil
+}
+
+func (s *SmartAlbumService) Create(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("create_smart_album").
+		WithString(SmartAlbumID, smartAlbum.ID).
+		WithString("name", smartAlbum.Name).
+		Build()
+
+	if err := s.validate(ctx, "create_smart_album", smartAlbum); err != nil {
+		return nil, err
+	}
+
+	logger.Step("database_write").
+		WithString("table", "smart_albums").
+		Log()
+
+	err := s.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteSmartAlbum(ctx, smartAlbum)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "create_smart_album", err).
+			WithContext(SmartAlbumID, smartAlbum.ID)
+	}
+
+	logger.Success().
+		WithString(SmartAlbumID, smartAlbum.ID).
+		Log()
+
+	return &smartAlbum, nil
+}
+
+// Update replaces the name, description and filter of a smart album.
+func (s *SmartAlbumService) Update(ctx context.Context, smartAlbum entity.SmartAlbum) (*entity.SmartAlbum, error) {
+	logger := s.logger.WithContext(ctx).Debug("update_smart_album").
+		WithString(SmartAlbumID, smartAlbum.ID).
+		Build()
+
+	existing, err := s.Get(ctx, smartAlbum.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(ctx, "update_smart_album", smartAlbum); err != nil {
+		return nil, err
+	}
+
+	existing.Name = smartAlbum.Name
+	existing.Description = smartAlbum.Description
+	existing.Filter = smartAlbum.Filter
+
+	err = s.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.WriteSmartAlbum(ctx, *existing)
+	})
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "update_smart_album", err).
+			WithContext(SmartAlbumID, smartAlbum.ID)
+	}
+
+	logger.Success().
+		WithString(SmartAlbumID, smartAlbum.ID).
+		Log()
+
+	return existing, nil
+}
+
+// Delete removes the smart album. The media matched by its filter are not affected.
+func (s *SmartAlbumService) Delete(ctx context.Context, id string) error {
+	logger := s.logger.WithContext(ctx).Debug("delete_smart_album").
+		WithString(SmartAlbumID, id).
+		Build()
+
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	err := s.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		return writer.DeleteSmartAlbum(ctx, id)
+	})
+	if err != nil {
+		return NewDatabaseWriteError(ctx, "delete_smart_album", err).
+			WithContext(SmartAlbumID, id)
+	}
+
+	logger.Success().
+		WithString(SmartAlbumID, id).
+		WithBool(DatabaseDeleted, true).
+		Log()
+
+	return nil
+}
+
+// ListMedia evaluates the filter of the smart album and returns a page of the matching media.
+// Pagination options (limit, cursor and direction) are taken from opts; the filter criteria of opts are replaced
+// by the ones of the smart album.
+func (s *SmartAlbumService) ListMedia(ctx context.Context, id string, opts *MediaOptions) ([]entity.Media, *PaginationCursor, error) {
+	smartAlbum, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.mediaSrv.List(ctx, withSmartAlbumFilter(opts, smartAlbum.Filter))
+}
+
+func (s *SmartAlbumService) validate(ctx context.Context, operation string, smartAlbum entity.SmartAlbum) error {
+	invalid := func(reason string) error {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext(SmartAlbumID, smartAlbum.ID).WithContext("validation_error", reason)
+		return err
+	}
+
+	if smartAlbum.Name == "" {
+		return invalid("empty_smart_album_name")
+	}
+
+	filter := smartAlbum.Filter
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return invalid("end_date_before_start_date")
+	}
+
+	if filter.MinRating != nil && (*filter.MinRating < 0 || *filter.MinRating > 5) {
+		return invalid("rating_out_of_range")
+	}
+
+	if area := filter.Area; area != nil {
+		if area.MinLatitude < -90 || area.MaxLatitude > 90 || area.MinLatitude > area.MaxLatitude {
+			return invalid("invalid_area_latitude")
+		}
+		if area.MinLongitude < -180 || area.MaxLongitude > 180 {
+			return invalid("invalid_area_longitude")
+		}
+	}
+
+	if filter.AlbumID != nil {
+		count, err := s.dt.CountAlbums(ctx, pg.FilterByAlbumId(*filter.AlbumID))
+		if err != nil {
+			return NewDatabaseWriteError(ctx, operation, err).
+				WithAlbumID(*filter.AlbumID).
+				AtStep("validate_album")
+		}
+		if count == 0 {
+			return invalid("album_not_found")
+		}
+	}
+
+	return nil
+}
+
+// withSmartAlbumFilter returns a copy of the pagination options of opts restricted by the filter of a smart album.
+func withSmartAlbumFilter(opts *MediaOptions, filter entity.SmartAlbumFilter) *MediaOptions {
+	return &MediaOptions{
+		MediaLimit:  opts.MediaLimit,
+		Cursor:      opts.Cursor,
+		Direction:   opts.Direction,
+		SortBy:      opts.SortBy,
+		AlbumTreeID: filter.AlbumID,
+		MediaType:   filter.MediaType,
+		StartDate:   filter.StartDate,
+		EndDate:     filter.EndDate,
+		Camera:      filter.Camera,
+		Tags:        entity.NormalizeTags(filter.Tags),
+		MinRating:   filter.MinRating,
+		Area:        filter.Area,
+	}
+}
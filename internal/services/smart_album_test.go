@@ -0,0 +1,256 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SmartAlbumService", Ordered, func() {
+	var (
+		smartAlbumService *services.SmartAlbumService
+		dt                *pg.Datastore
+		pgPool            *pgxpool.Pool
+		summer            entity.Album
+		beach             entity.Album
+		winter            entity.Album
+		media             map[string]entity.Media
+	)
+
+	const tmpDir = "/tmp/photos-ng-smart-album-test"
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+
+		Expect(os.MkdirAll(tmpDir, 0755)).To(Succeed())
+		smartAlbumService = services.NewSmartAlbumService(dt, services.NewMediaService(dt, fs.NewFsDatastore(tmpDir)))
+
+		// Clean up any existing data
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM smart_albums;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		// summer/beach is below summer, winter is on its own
+		summer = entity.NewAlbum("summer")
+		beach = entity.NewAlbum("summer/beach")
+		winter = entity.NewAlbum("winter")
+		for _, album := range []struct {
+			album  entity.Album
+			parent *string
+		}{{summer, nil}, {beach, &summer.ID}, {winter, nil}} {
+			sql, args, err := insertAlbumStmt.Values(album.album.ID, time.Now(), album.album.Path, nil, album.parent, nil).ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+		}
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		media = map[string]entity.Media{}
+		for _, m := range []struct {
+			name       string
+			album      entity.Album
+			mediaType  entity.MediaType
+			capturedAt time.Time
+			tags       []string
+			rating     int
+		}{
+			{"sunset.jpg", summer, entity.Photo, time.Date(2024, 7, 1, 20, 0, 0, 0, time.UTC), []string{"sunset"}, 5},
+			{"waves.mp4", beach, entity.Video, time.Date(2024, 7, 2, 10, 0, 0, 0, time.UTC), []string{"sea"}, 3},
+			{"shells.jpg", beach, entity.Photo, time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC), []string{"sea", "sunset"}, 4},
+			{"snow.jpg", winter, entity.Photo, time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC), nil, 1},
+		} {
+			created := entity.NewMedia(m.name, m.album)
+			sql, args, err := insertMediaStmt.
+				Values(created.ID, time.Now(), m.capturedAt, m.album.ID, created.Filename, []byte("thumb"), exifJSON, string(m.mediaType)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+
+			_, err = pgPool.Exec(context.TODO(), "UPDATE media SET tags = $1, rating = $2 WHERE id = $3", m.tags, m.rating, created.ID)
+			Expect(err).To(BeNil())
+			media[m.name] = created
+		}
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM smart_albums;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	AfterEach(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM smart_albums;")
+		Expect(err).To(BeNil())
+	})
+
+	// filenames returns the names of the files of the media matched by the filter of a new smart album
+	filenames := func(filter entity.SmartAlbumFilter) []string {
+		smartAlbum, err := smartAlbumService.Create(context.TODO(), entity.NewSmartAlbum("smart", filter))
+		Expect(err).To(BeNil())
+
+		matched, _, err := smartAlbumService.ListMedia(context.TODO(), smartAlbum.ID, &services.MediaOptions{MediaLimit: 10})
+		Expect(err).To(BeNil())
+
+		names := []string{}
+		for _, m := range matched {
+			names = append(names, m.Filename)
+		}
+		return names
+	}
+
+	Context("ListMedia", func() {
+		It("matches every media with an empty filter", func() {
+			Expect(filenames(entity.SmartAlbumFilter{})).To(ConsistOf("sunset.jpg", "waves.mp4", "shells.jpg", "snow.jpg"))
+		})
+
+		It("matches the media of an album and of its subalbums", func() {
+			Expect(filenames(entity.SmartAlbumFilter{AlbumID: &summer.ID})).To(ConsistOf("sunset.jpg", "waves.mp4", "shells.jpg"))
+			Expect(filenames(entity.SmartAlbumFilter{AlbumID: &beach.ID})).To(ConsistOf("waves.mp4", "shells.jpg"))
+		})
+
+		It("matches the media captured between the dates", func() {
+			start := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+			end := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+			Expect(filenames(entity.SmartAlbumFilter{StartDate: &start, EndDate: &end})).To(ConsistOf("waves.mp4", "shells.jpg"))
+		})
+
+		It("matches the media of the type", func() {
+			video := string(entity.Video)
+			Expect(filenames(entity.SmartAlbumFilter{MediaType: &video})).To(ConsistOf("waves.mp4"))
+		})
+
+		It("matches the media with the tags whatever their case", func() {
+			Expect(filenames(entity.SmartAlbumFilter{Tags: []string{"Sea", "sunset"}})).To(ConsistOf("shells.jpg"))
+		})
+
+		It("matches the media rated at least the rating and combines the criteria", func() {
+			rating := 4
+			Expect(filenames(entity.SmartAlbumFilter{MinRating: &rating})).To(ConsistOf("sunset.jpg", "shells.jpg"))
+			Expect(filenames(entity.SmartAlbumFilter{MinRating: &rating, AlbumID: &beach.ID})).To(ConsistOf("shells.jpg"))
+		})
+
+		It("evaluates the filter saved by the last update", func() {
+			smartAlbum, err := smartAlbumService.Create(context.TODO(), entity.NewSmartAlbum("smart", entity.SmartAlbumFilter{AlbumID: &winter.ID}))
+			Expect(err).To(BeNil())
+
+			smartAlbum.Filter = entity.SmartAlbumFilter{Tags: []string{"sea"}}
+			_, err = smartAlbumService.Update(context.TODO(), *smartAlbum)
+			Expect(err).To(BeNil())
+
+			matched, _, err := smartAlbumService.ListMedia(context.TODO(), smartAlbum.ID, &services.MediaOptions{MediaLimit: 10})
+			Expect(err).To(BeNil())
+			Expect(matched).To(HaveLen(2))
+		})
+
+		It("takes the pagination of the options and the criteria of the filter", func() {
+			smartAlbum, err := smartAlbumService.Create(context.TODO(), entity.NewSmartAlbum("smart", entity.SmartAlbumFilter{AlbumID: &summer.ID}))
+			Expect(err).To(BeNil())
+
+			// the album of the options is replaced by the one of the filter
+			matched, cursor, err := smartAlbumService.ListMedia(context.TODO(), smartAlbum.ID, &services.MediaOptions{MediaLimit: 2, AlbumID: &winter.ID})
+			Expect(err).To(BeNil())
+			Expect(matched).To(HaveLen(2))
+			Expect(cursor).ToNot(BeNil())
+
+			next, _, err := smartAlbumService.ListMedia(context.TODO(), smartAlbum.ID, &services.MediaOptions{MediaLimit: 2, Cursor: cursor})
+			Expect(err).To(BeNil())
+			Expect(next).To(HaveLen(1))
+			Expect([]string{matched[0].ID, matched[1].ID}).ToNot(ContainElement(next[0].ID))
+		})
+
+		It("does not find an unknown smart album", func() {
+			_, _, err := smartAlbumService.ListMedia(context.TODO(), entity.NewId(), &services.MediaOptions{MediaLimit: 10})
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("Create", func() {
+		DescribeTable("refuses an invalid filter",
+			func(filter entity.SmartAlbumFilter) {
+				_, err := smartAlbumService.Create(context.TODO(), entity.NewSmartAlbum("smart", filter))
+				var validation *services.ValidationError
+				Expect(err).To(BeAssignableToTypeOf(validation))
+			},
+			Entry("with the end before the start", entity.SmartAlbumFilter{
+				StartDate: func() *time.Time { t := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); return &t }(),
+				EndDate:   func() *time.Time { t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); return &t }(),
+			}),
+			Entry("with a rating out of range", entity.SmartAlbumFilter{MinRating: func() *int { r := 6; return &r }()}),
+			Entry("with an unknown album", entity.SmartAlbumFilter{AlbumID: func() *string { id := entity.NewId(); return &id }()}),
+			Entry("with an invalid area", entity.SmartAlbumFilter{Area: &entity.BoundingBox{MinLatitude: 10, MaxLatitude: -10}}),
+		)
+	})
+
+	Context("List", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"a", "b", "c", "d", "e"} {
+				_, err := smartAlbumService.Create(context.TODO(), entity.NewSmartAlbum(name, entity.SmartAlbumFilter{}))
+				Expect(err).To(BeNil())
+			}
+		})
+
+		// names returns the names of a page of smart albums
+		names := func(opts *services.SmartAlbumOptions) []string {
+			smartAlbums, err := smartAlbumService.List(context.TODO(), opts)
+			Expect(err).To(BeNil())
+			names := []string{}
+			for _, smartAlbum := range smartAlbums {
+				names = append(names, smartAlbum.Name)
+			}
+			return names
+		}
+
+		It("pages the smart albums by name", func() {
+			Expect(names(services.NewSmartAlbumOptionsWithOptions(services.WithSmartAlbumLimit(2)))).To(Equal([]string{"a", "b"}))
+			Expect(names(services.NewSmartAlbumOptionsWithOptions(services.WithSmartAlbumLimit(2), services.WithSmartAlbumOffset(2)))).To(Equal([]string{"c", "d"}))
+			Expect(names(services.NewSmartAlbumOptionsWithOptions(services.WithSmartAlbumLimit(2), services.WithSmartAlbumOffset(4)))).To(Equal([]string{"e"}))
+			Expect(names(services.NewSmartAlbumOptionsWithOptions(services.WithSmartAlbumOffset(5)))).To(BeEmpty())
+		})
+
+		It("lists every smart album without limit", func() {
+			Expect(names(services.NewSmartAlbumOptionsWithOptions())).To(HaveLen(5))
+		})
+
+		It("counts every smart album whatever the page", func() {
+			count, err := smartAlbumService.Count(context.TODO(), services.NewSmartAlbumOptionsWithOptions(services.WithSmartAlbumLimit(2), services.WithSmartAlbumOffset(4)))
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(5))
+		})
+
+		It("lists and counts only the allowed smart albums", func() {
+			all, err := smartAlbumService.List(context.TODO(), services.NewSmartAlbumOptionsWithOptions())
+			Expect(err).To(BeNil())
+
+			opts := &services.SmartAlbumOptions{AllowedSmartAlbumIDs: []string{all[1].ID, all[3].ID}}
+			Expect(names(opts)).To(Equal([]string{"b", "d"}))
+
+			count, err := smartAlbumService.Count(context.TODO(), opts)
+			Expect(err).To(BeNil())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,31 +12,64 @@ import (
 	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
 )
 
+// Relationships writes, moves and removes the authorization relationships of the albums and media a sync creates
+// and moves. It is implemented by AuthzService.
+type Relationships interface {
+	WriteRelationships(ctx context.Context, relationships ...entity.Relationship) error
+	UpdateRelationships(ctx context.Context, removed []entity.Relationship, added []entity.Relationship) error
+	DeleteRelationships(ctx context.Context, resource entity.Resource) error
+}
+
+// otherJobTypes are the types of the jobs of the scheduler which are not sync jobs, the sync service leaves them out
+var otherJobTypes = []string{purgeTrashJob}
+
+// isSyncJob checks if the job with the metadata is a sync job
+func isSyncJob(metadata map[string]string) bool {
+	return !slices.Contains(otherJobTypes, metadata["type"])
+}
+
+// syncJobs returns the sync jobs of jobs
+func syncJobs(jobs []Job) []Job {
+	return slices.DeleteFunc(jobs, func(j Job) bool { return !isSyncJob(j.Metadata()) })
+}
+
 // SyncService manages sync operations using a single scheduler instance without authorization
 type SyncService struct {
-	albumService *AlbumService
-	mediaService *MediaService
-	fsDatastore  *fs.Datastore
-	scheduler    *Scheduler
-	logger       *logger.StructuredLogger
+	albumService  *AlbumService
+	mediaService  *MediaService
+	fsDatastore   *fs.Datastore
+	relationships Relationships
+	scheduler     *Scheduler
+	logger        *logger.StructuredLogger
 }
 
-// NewSyncService creates a new sync service that manages the job scheduler
-func NewSyncService(albumService *AlbumService, mediaService *MediaService, fsDatastore *fs.Datastore) *SyncService {
+// NewSyncService creates a new sync service that manages the job scheduler.
+// relationships keeps the relationships of the albums and media a sync creates and moves, it is nil
+// when authorization is disabled.
+func NewSyncService(albumService *AlbumService, mediaService *MediaService, fsDatastore *fs.Datastore, relationships Relationships) *SyncService {
 	return &SyncService{
-		albumService: albumService,
-		mediaService: mediaService,
-		fsDatastore:  fsDatastore,
-		scheduler:    GetScheduler(),
-		logger:       logger.New("sync_service"),
+		albumService:  albumService,
+		mediaService:  mediaService,
+		fsDatastore:   fsDatastore,
+		relationships: relationships,
+		scheduler:     GetScheduler(),
+		logger:        logger.New("sync_service"),
 	}
 }
 
-// StartSync starts a new sync job for the given album path
-// Returns the job ID and any error that occurred during job creation
-func (s *SyncService) StartSync(ctx context.Context, albumPath string) (string, error) {
+// StartSync starts a new sync job for the given album path. Nil opts syncs with the defaults.
+// The folders of the tree are synced by the sub-jobs of the returned job, the job of a folder waits for the
+// job of its parent folder to create the parent album.
+// Returns the job ID, the album path if nothing changed since the last sync, and any error that occurred
+// during job creation
+func (s *SyncService) StartSync(ctx context.Context, albumPath string, opts *SyncOptions) (string, error) {
+	if opts == nil {
+		opts = NewSyncOptionsWithOptionsAndDefaults()
+	}
+
 	logger := s.logger.WithContext(ctx).Operation("start_sync").
 		WithString(AlbumPath, albumPath).
+		WithBool("full_rescan", opts.FullRescan).
 		Build()
 
 	// Create a root album entity for the sync operation
@@ -55,9 +89,13 @@ func (s *SyncService) StartSync(ctx context.Context, albumPath string) (string,
 		WithString(AlbumID, album.ID).
 		Log()
 
-	generator := NewJobGenerator(s.albumService, s.mediaService, s.fsDatastore)
-	syncJobs, err := generator.Generate(ctx, albumPath)
+	generator := NewJobGenerator(s.albumService, s.mediaService, s.fsDatastore, s.relationships)
+	syncJobs, err := generator.Generate(ctx, albumPath, opts)
 	if err != nil {
+		// an empty folder with albums below it is refused as is
+		if conflict, ok := err.(*ConflictError); ok {
+			return "", conflict
+		}
 		// Return ServiceError (handlers will log the error)
 		return "", NewSyncJobError(ctx, "generate_jobs", "", err).
 			WithContext(AlbumPath, albumPath)
@@ -68,46 +106,118 @@ func (s *SyncService) StartSync(ctx context.Context, albumPath string) (string,
 		WithInt("job_count", len(syncJobs)).
 		Log()
 
+	// nothing changed since the last sync
 	if len(syncJobs) == 0 {
-		return "", NewSyncJobError(ctx, "generate_jobs", "", fmt.Errorf("no sync jobs generated for path: %s", albumPath)).
-			WithContext(AlbumPath, albumPath)
+		logger.Success().
+			WithInt("total_jobs", 0).
+			WithString(AlbumPath, albumPath).
+			Log()
+		return albumPath, nil
 	}
 
-	// Add all jobs to scheduler and log each one
+	// The jobs of the folders are tracked as the sub-jobs of one job
+	parentJob := NewParentJob(syncJobs, map[string]string{
+		"path": album.Path,
+		"type": syncJob,
+	})
+	jobID := parentJob.GetID().String()
+
 	logger.Step("schedule_jobs").
+		WithString(JobID, jobID).
 		WithInt("job_count", len(syncJobs)).
 		WithString("scheduler", "background").
 		Log()
 
-	for i, syncJob := range syncJobs {
-		if err := s.scheduler.Add(syncJob); err != nil {
-			// Return ServiceError (handlers will log the error)
-			return "", NewSyncJobError(ctx, "schedule_job", syncJob.GetID().String(), err).
-				WithContext(AlbumPath, albumPath).
-				WithContext("job_index", i)
-		}
+	if err := s.scheduler.Add(parentJob); err != nil {
+		// Return ServiceError (handlers will log the error)
+		return "", NewSyncJobError(ctx, "schedule_job", jobID, err).
+			WithContext(AlbumPath, albumPath)
+	}
 
-		jobID := syncJob.GetID().String()
+	logger.Success().
+		WithString(JobID, jobID).
+		WithInt("total_jobs", len(syncJobs)).
+		WithString(AlbumPath, albumPath).
+		Log()
 
-		logger.Step("sync job scheduled").
-			WithString(JobID, jobID).
-			WithInt("job_index", i).
-			Log()
+	return jobID, nil
+}
+
+// DryRun compares the folder at albumPath with the database and reports what StartSync would do with the
+// same options. Nothing is written and no job is scheduled.
+func (s *SyncService) DryRun(ctx context.Context, albumPath string, opts *SyncOptions) (*entity.SyncReport, error) {
+	if opts == nil {
+		opts = NewSyncOptionsWithOptionsAndDefaults()
 	}
 
-	logger.Step("all sync jobs scheduled successfully").
-		WithInt("total_jobs", len(syncJobs)).
+	logger := s.logger.WithContext(ctx).Operation("dry_run_sync").
 		WithString(AlbumPath, albumPath).
-		WithString(AlbumID, album.ID).
+		WithBool("full_rescan", opts.FullRescan).
+		Build()
+
+	if exists, err := s.fsDatastore.Exists(ctx, albumPath); err != nil || !exists {
+		err := NewNotFoundError(ctx, "dry_run_sync", "folder_not_found")
+		err.WithAlbumPath(albumPath)
+		return nil, err
+	}
+
+	generator := NewJobGenerator(s.albumService, s.mediaService, s.fsDatastore, s.relationships)
+	report, err := generator.Report(ctx, strings.TrimSuffix(albumPath, "/"), opts)
+	if err != nil {
+		if conflict, ok := err.(*ConflictError); ok {
+			return nil, conflict
+		}
+		return nil, NewSyncJobError(ctx, "report_sync", "", err).
+			WithContext(AlbumPath, albumPath)
+	}
+
+	logger.Success().
+		WithInt("albums_to_create", len(report.AlbumsToCreate)).
+		WithInt("albums_to_remove", len(report.AlbumsToRemove)).
+		WithInt("media_to_add", len(report.MediaToAdd)).
+		WithInt("media_to_update", len(report.MediaToUpdate)).
+		WithInt("media_to_move", len(report.MediaToMove)).
+		WithInt("media_to_remove", len(report.MediaToRemove)).
+		WithInt("unreadable_items", len(report.UnreadableItems)).
+		WithInt("unsupported_files", len(report.UnsupportedFiles)).
 		Log()
 
+	return report, nil
+}
+
+// StartSyncFiles starts a sync job processing only the given media files of an album folder.
+// Returns the job ID and any error that occurred during job creation
+func (s *SyncService) StartSyncFiles(ctx context.Context, albumPath string, files []string) (string, error) {
+	logger := s.logger.WithContext(ctx).Operation("start_sync_files").
+		WithString(AlbumPath, albumPath).
+		WithInt("file_count", len(files)).
+		Build()
+
+	if albumPath == "" || len(files) == 0 {
+		return "", NewValidationError(ctx, "start_sync_files", "invalid_input").
+			WithContext(AlbumPath, albumPath).
+			WithContext("file_count", len(files))
+	}
+
+	generator := NewJobGenerator(s.albumService, s.mediaService, s.fsDatastore, s.relationships)
+	syncJob, err := generator.GenerateForFiles(ctx, strings.TrimSuffix(albumPath, "/"), files)
+	if err != nil {
+		return "", NewSyncJobError(ctx, "generate_jobs", "", err).
+			WithContext(AlbumPath, albumPath)
+	}
+
+	jobID := syncJob.GetID().String()
+	if err := s.scheduler.Add(syncJob); err != nil {
+		return "", NewSyncJobError(ctx, "schedule_job", jobID, err).
+			WithContext(AlbumPath, albumPath)
+	}
+
 	logger.Success().
-		WithInt("total_jobs", len(syncJobs)).
+		WithString(JobID, jobID).
 		WithString(AlbumPath, albumPath).
 		Log()
 
-	// Return a batch identifier or the album path since we have multiple jobs
-	return albumPath, nil
+	return jobID, nil
 }
 
 // GetJobStatus returns the status of a job by ID
@@ -128,19 +238,35 @@ func (s *SyncService) GetJobStatus(ctx context.Context, jobID string) (*entity.J
 		Log()
 
 	syncJob := s.scheduler.Get(jobID)
+	if syncJob != nil && !isSyncJob(syncJob.Metadata()) {
+		return nil, NewNotFoundError(ctx, "get_sync_job_status", "job_not_found").
+			WithContext(JobID, jobID)
+	}
 
 	logger.Step("scheduler job lookup").
 		WithString(JobID, jobID).
 		WithBool("found", syncJob != nil).
 		Log()
 
+	// the jobs which are not in the scheduler anymore are read from the history
 	if syncJob == nil {
-		// Return ServiceError (handlers will log the error)
-		return nil, NewNotFoundError(ctx, "get_sync_job_status", "job_not_found").
-			WithContext(JobID, jobID)
+		job, err := s.scheduler.GetFromHistory(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil || !isSyncJob(job.Metadata) {
+			// Return ServiceError (handlers will log the error)
+			return nil, NewNotFoundError(ctx, "get_sync_job_status", "job_not_found").
+				WithContext(JobID, jobID)
+		}
+		return job, nil
 	}
 
 	status := syncJob.Status()
+	// the results of a parent job are the ones of its sub-jobs, they are only gathered for its full status
+	if parentJob, ok := syncJob.(*ParentJob); ok {
+		status.Results = parentJob.Results()
+	}
 
 	logger.Success().
 		WithString(JobID, jobID).
@@ -152,24 +278,175 @@ func (s *SyncService) GetJobStatus(ctx context.Context, jobID string) (*entity.J
 	return &status, nil
 }
 
-// ListJobStatuses returns statuses of all jobs
-func (s *SyncService) ListJobStatuses(_ context.Context) ([]entity.JobProgress, error) {
-	jobs := s.scheduler.GetAll()
-	statuses := make([]entity.JobProgress, len(jobs))
-	for i, syncJob := range jobs {
-		statuses[i] = syncJob.Status()
+// WatchJobs returns the events of the jobs until ctx is done, or the events of a job and of its sub-jobs
+// if jobID is not empty. The current status of the jobs is sent first as JobChangedEvent events. The
+// events of a job end once it is finished.
+func (s *SyncService) WatchJobs(ctx context.Context, jobID string) (<-chan entity.JobEvent, error) {
+	logger := s.logger.WithContext(ctx).Operation("watch_sync_jobs").
+		WithString(JobID, jobID).
+		Build()
+
+	jobs := syncJobs(s.scheduler.GetAll())
+	if jobID != "" {
+		jobs = slices.DeleteFunc(jobs, func(j Job) bool {
+			return j.GetID().String() != jobID && j.Metadata()["parentId"] != jobID
+		})
+	}
+
+	// the events are published from now on, nothing happening after the current status is lost
+	events, unsubscribe := GetJobEventBus().Subscribe()
+
+	current := make([]entity.JobEvent, 0, len(jobs))
+	for _, j := range jobs {
+		current = append(current, entity.NewJobEvent(entity.JobChangedEvent, j.Status()))
 	}
-	return statuses, nil
+
+	// a job which is not in the scheduler anymore is finished, its status is the one of the history
+	if jobID != "" && len(jobs) == 0 {
+		unsubscribe()
+
+		job, err := s.GetJobStatus(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		events := make(chan entity.JobEvent, 1)
+		events <- entity.NewJobEvent(entity.JobChangedEvent, *job)
+		close(events)
+		return events, nil
+	}
+
+	out := make(chan entity.JobEvent)
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		send := func(event entity.JobEvent) bool {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return false
+			}
+			// the watched job is done
+			return jobID == "" || event.Job.Id.String() != jobID || !event.Job.Status.Finished()
+		}
+
+		for _, event := range current {
+			if !send(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if !isSyncJob(event.Job.Metadata) || (jobID != "" && !event.Concerns(jobID)) {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}()
+
+	logger.Success().
+		WithInt("jobs", len(jobs)).
+		Log()
+
+	return out, nil
 }
 
-// ListJobStatusesByStatus returns job statuses filtered by status
-func (s *SyncService) ListJobStatusesByStatus(_ context.Context, status entity.JobStatus) ([]entity.JobProgress, error) {
-	jobs := s.scheduler.GetByStatus(status)
-	statuses := make([]entity.JobProgress, len(jobs))
-	for i, syncJob := range jobs {
-		statuses[i] = syncJob.Status()
+// ListJobStatuses returns statuses of all the jobs of the scheduler and of a page of the history, the oldest
+// first. The results of the tasks of the jobs of the history are not loaded, see GetJobStatus.
+// Like every listing of the sync service, it leaves out the jobs which are not sync jobs, like the trash purge.
+func (s *SyncService) ListJobStatuses(ctx context.Context, limit, offset int) ([]entity.JobProgress, error) {
+	history, err := s.scheduler.GetHistory(ctx, limit, offset, otherJobTypes)
+	if err != nil {
+		return nil, err
 	}
-	return statuses, nil
+	return withJobHistory(syncJobs(s.scheduler.GetAll()), history), nil
+}
+
+// ListJobStatusesByStatus returns job statuses filtered by status, the ones of the scheduler and a page of
+// the ones of the history
+func (s *SyncService) ListJobStatusesByStatus(ctx context.Context, status entity.JobStatus, limit, offset int) ([]entity.JobProgress, error) {
+	history, err := s.scheduler.GetHistory(ctx, limit, offset, otherJobTypes, status)
+	if err != nil {
+		return nil, err
+	}
+	return withJobHistory(syncJobs(s.scheduler.GetByStatus(status)), history), nil
+}
+
+// CountJobs returns the number of jobs having one of the statuses, the ones of the scheduler and the ones
+// of the history
+func (s *SyncService) CountJobs(ctx context.Context, statuses ...entity.JobStatus) (int, error) {
+	count, err := s.scheduler.CountHistory(ctx, otherJobTypes, statuses...)
+	if err != nil {
+		return 0, err
+	}
+	for _, status := range statuses {
+		count += len(syncJobs(s.scheduler.GetByStatus(status)))
+	}
+	return count, nil
+}
+
+// withJobHistory merges the statuses of the jobs of the scheduler with the jobs of the history, the oldest first
+func withJobHistory(jobs []Job, history []entity.JobProgress) []entity.JobProgress {
+	statuses := make([]entity.JobProgress, 0, len(jobs)+len(history))
+	for _, syncJob := range jobs {
+		statuses = append(statuses, syncJob.Status())
+	}
+	statuses = append(statuses, history...)
+
+	slices.SortStableFunc(statuses, func(a, b entity.JobProgress) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return statuses
+}
+
+// Resume syncs again the folders of the interrupted sync jobs, each folder once. The sync skips the files
+// synced before the interruption. The other interrupted jobs, like bulk jobs, are not resumed.
+// It returns the number of folders synced again.
+func (s *SyncService) Resume(ctx context.Context, interrupted []entity.JobProgress) int {
+	logger := s.logger.WithContext(ctx).Operation("resume_sync_jobs").
+		WithInt("interrupted_jobs", len(interrupted)).
+		Build()
+
+	folders := []string{}
+	for _, job := range interrupted {
+		if jobType := job.Metadata["type"]; jobType == syncJob || jobType == reconcileJob {
+			folders = append(folders, job.Path)
+		}
+	}
+
+	// the folders inside another resumed folder are synced with it
+	slices.Sort(folders)
+	resumed := []string{}
+	for _, folder := range folders {
+		if !isInFolders(folder, resumed) {
+			resumed = append(resumed, folder)
+		}
+	}
+
+	for _, folder := range resumed {
+		if _, err := s.StartSync(ctx, folder, nil); err != nil {
+			logger.Step("failed to resume sync").
+				WithString(AlbumPath, folder).
+				WithString("error", err.Error()).
+				Log()
+		}
+	}
+
+	logger.Success().
+		WithInt("resumed_folders", len(resumed)).
+		Log()
+
+	return len(resumed)
 }
 
 // StopJob stops a specific job
@@ -243,8 +520,8 @@ func (s *SyncService) StopAllJobs(ctx context.Context) error {
 	// Get all active jobs (running and pending)
 	logger.Step("get_active_jobs").Log()
 
-	runningJobs := s.scheduler.GetByStatus(entity.StatusRunning)
-	pendingJobs := s.scheduler.GetByStatus(entity.StatusPending)
+	runningJobs := syncJobs(s.scheduler.GetByStatus(entity.StatusRunning))
+	pendingJobs := syncJobs(s.scheduler.GetByStatus(entity.StatusPending))
 	activeJobs := append(runningJobs, pendingJobs...)
 
 	logger.Step("found active jobs to stop").
@@ -304,9 +581,9 @@ func (s *SyncService) ClearFinishedJobs(ctx context.Context) error {
 	// Get all finished jobs (completed, stopped, failed)
 	logger.Step("get_finished_jobs").Log()
 
-	completedJobs := s.scheduler.GetByStatus(entity.StatusCompleted)
-	stoppedJobs := s.scheduler.GetByStatus(entity.StatusStopped)
-	failedJobs := s.scheduler.GetByStatus(entity.StatusFailed)
+	completedJobs := syncJobs(s.scheduler.GetByStatus(entity.StatusCompleted))
+	stoppedJobs := syncJobs(s.scheduler.GetByStatus(entity.StatusStopped))
+	failedJobs := syncJobs(s.scheduler.GetByStatus(entity.StatusFailed))
 
 	finishedJobs := append(completedJobs, stoppedJobs...)
 	finishedJobs = append(finishedJobs, failedJobs...)
@@ -318,6 +595,13 @@ func (s *SyncService) ClearFinishedJobs(ctx context.Context) error {
 		WithInt("total_finished", len(finishedJobs)).
 		Log()
 
+	// the history of the finished jobs is cleared as well
+	logger.Step("clear_job_history").Log()
+
+	if err := s.scheduler.ClearHistory(ctx); err != nil {
+		return err
+	}
+
 	if len(finishedJobs) == 0 {
 		logger.Success().
 			WithInt("cleared_count", 0).
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// noopPurger purges nothing
+type noopPurger struct{}
+
+func (noopPurger) Purge(ctx context.Context, before time.Time) ([]entity.Resource, error) {
+	return nil, nil
+}
+
+var _ = Describe("syncJobs", func() {
+	It("leaves out the jobs purging the trash", func() {
+		folder, err := NewSyncJob(entity.NewLinkedList[Task[string]](), map[string]string{"path": "a", "type": syncJob})
+		Expect(err).To(BeNil())
+		reconcile, err := NewSyncJob(entity.NewLinkedList[Task[string]](), map[string]string{"path": "a", "type": reconcileJob})
+		Expect(err).To(BeNil())
+		purge, err := newPurgeJob(noopPurger{}, time.Hour)
+		Expect(err).To(BeNil())
+
+		Expect(isSyncJob(purge.Status().Metadata)).To(BeFalse())
+		Expect(syncJobs([]Job{folder, purge, reconcile})).To(Equal([]Job{folder, reconcile}))
+	})
+})
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// TimelineService provides the media counts per month or per day without authorization.
+// The counts are computed by Postgres so the timeline of the whole library is returned in one query.
+type TimelineService struct {
+	dt     *pg.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewTimelineService creates a new instance of TimelineService
+func NewTimelineService(dt *pg.Datastore) *TimelineService {
+	return &TimelineService{
+		dt:     dt,
+		logger: logger.New("timeline_service"),
+	}
+}
+
+// Timeline returns the number of media captured in every month, or every day if opts.TimelineDaily is set,
+// the most recent first. Months or days without media are not returned.
+func (s *TimelineService) Timeline(ctx context.Context, opts *TimelineOptions) (entity.Buckets, error) {
+	logger := s.logger.WithContext(ctx).Debug("timeline").
+		WithBool("daily", opts.TimelineDaily).
+		Build()
+
+	if opts.TimelineAlbumTreeID != nil {
+		logger.Step("check_album").WithString(AlbumID, *opts.TimelineAlbumTreeID).Log()
+
+		album, err := s.dt.QueryAlbum(ctx, pg.FilterByAlbumId(*opts.TimelineAlbumTreeID))
+		if err != nil {
+			return nil, NewDatabaseWriteError(ctx, "timeline", err).
+				WithAlbumID(*opts.TimelineAlbumTreeID).
+				AtStep("query_album")
+		}
+
+		if album == nil {
+			return nil, NewAlbumNotFoundError(ctx, *opts.TimelineAlbumTreeID)
+		}
+	}
+
+	buckets, err := s.dt.Timeline(ctx, opts.TimelineDaily, opts.QueriesFn()...)
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "timeline", err).AtStep("query_timeline")
+	}
+
+	logger.Success().
+		WithInt("buckets", len(buckets)).
+		Log()
+
+	return buckets, nil
+}
@@ -0,0 +1,230 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/services"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/context/user"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// viewAuthz grants the view permission on the resources of viewable only
+type viewAuthz struct {
+	relationshipsAuthz
+	viewable []entity.Resource
+}
+
+func (v *viewAuthz) HasPermission(ctx context.Context, u entity.User, resource entity.Resource, permission entity.Permission) (bool, error) {
+	return permission == entity.ViewPermission && slices.Contains(v.viewable, resource), nil
+}
+
+func (v *viewAuthz) GetPermissions(ctx context.Context, zedToken string, u entity.User, resources []entity.Resource) (map[entity.Resource][]entity.Permission, error) {
+	permissions := make(map[entity.Resource][]entity.Permission)
+	for _, resource := range resources {
+		if slices.Contains(v.viewable, resource) {
+			permissions[resource] = []entity.Permission{entity.ViewPermission}
+		}
+	}
+	return permissions, nil
+}
+
+func (v *viewAuthz) ListResources(ctx context.Context, zedToken string, u entity.User, permission entity.Permission, resourceKind entity.ResourceKind) ([]string, error) {
+	ids := []string{}
+	for _, resource := range v.viewable {
+		if permission == entity.ViewPermission && resource.Kind == resourceKind {
+			ids = append(ids, resource.ID)
+		}
+	}
+	return ids, nil
+}
+
+var _ = Describe("TimelineService", Ordered, func() {
+	var (
+		timelineService *services.TimelineService
+		dt              *pg.Datastore
+		pgPool          *pgxpool.Pool
+		summer          entity.Album
+		beach           entity.Album
+		winter          entity.Album
+	)
+
+	ctx := user.ToContext(context.Background(), &entity.User{ID: "alice-id", Username: "alice"})
+
+	BeforeAll(func() {
+		pgDt, err := newDatastore(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		pool, err := pgxpool.New(context.TODO(), pgUri)
+		Expect(err).To(BeNil())
+
+		dt = pgDt
+		pgPool = pool
+		timelineService = services.NewTimelineService(dt)
+
+		// Clean up any existing data
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+
+		// summer/beach is below summer, winter is on its own
+		summer = entity.NewAlbum("summer")
+		beach = entity.NewAlbum("summer/beach")
+		winter = entity.NewAlbum("winter")
+		for _, album := range []struct {
+			album  entity.Album
+			parent *string
+		}{{summer, nil}, {beach, &summer.ID}, {winter, nil}} {
+			sql, args, err := insertAlbumStmt.Values(album.album.ID, time.Now(), album.album.Path, nil, album.parent, nil).ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+		}
+
+		exifJSON, err := json.Marshal(map[string]string{})
+		Expect(err).To(BeNil())
+
+		for _, m := range []struct {
+			name       string
+			album      entity.Album
+			capturedAt time.Time
+			trashed    bool
+		}{
+			{"sunset.jpg", summer, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), false},
+			{"dune.jpg", summer, time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC), false},
+			{"waves.jpg", beach, time.Date(2024, 7, 3, 12, 0, 0, 0, time.UTC), false},
+			{"shells.jpg", beach, time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC), false},
+			{"blurry.jpg", beach, time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC), true},
+			{"snow.jpg", winter, time.Date(2023, 12, 24, 12, 0, 0, 0, time.UTC), false},
+		} {
+			created := entity.NewMedia(m.name, m.album)
+			sql, args, err := insertMediaStmt.
+				Values(created.ID, time.Now(), m.capturedAt, m.album.ID, created.Filename, []byte("thumb"), exifJSON, string(entity.Photo)).
+				ToSql()
+			Expect(err).To(BeNil())
+			_, err = pgPool.Exec(context.TODO(), sql, args...)
+			Expect(err).To(BeNil())
+
+			if m.trashed {
+				_, err = pgPool.Exec(context.TODO(), "UPDATE media SET trashed_at = now() WHERE id = $1", created.ID)
+				Expect(err).To(BeNil())
+			}
+		}
+	})
+
+	AfterAll(func() {
+		_, err := pgPool.Exec(context.TODO(), "DELETE FROM media;")
+		Expect(err).To(BeNil())
+		_, err = pgPool.Exec(context.TODO(), "DELETE FROM albums;")
+		Expect(err).To(BeNil())
+		pgPool.Close()
+		dt.Close()
+	})
+
+	Context("Timeline", func() {
+		It("counts the media of every month, the most recent first, without the trashed media", func() {
+			buckets, err := timelineService.Timeline(ctx, &services.TimelineOptions{})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{
+				{Year: 2024, Month: 8, Count: 1},
+				{Year: 2024, Month: 7, Count: 3},
+				{Year: 2023, Month: 12, Count: 1},
+			}))
+			Expect(buckets.Total()).To(Equal(5))
+		})
+
+		It("counts the media of every day", func() {
+			buckets, err := timelineService.Timeline(ctx, &services.TimelineOptions{TimelineDaily: true})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{
+				{Year: 2024, Month: 8, Day: 15, Count: 1},
+				{Year: 2024, Month: 7, Day: 3, Count: 1},
+				{Year: 2024, Month: 7, Day: 1, Count: 2},
+				{Year: 2023, Month: 12, Day: 24, Count: 1},
+			}))
+		})
+
+		It("counts the media of an album and of its subalbums", func() {
+			buckets, err := timelineService.Timeline(ctx, &services.TimelineOptions{TimelineAlbumTreeID: &summer.ID})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{
+				{Year: 2024, Month: 8, Count: 1},
+				{Year: 2024, Month: 7, Count: 3},
+			}))
+
+			buckets, err = timelineService.Timeline(ctx, &services.TimelineOptions{TimelineAlbumTreeID: &beach.ID})
+			Expect(err).To(BeNil())
+			Expect(buckets.Total()).To(Equal(2))
+		})
+
+		It("counts the media captured between the dates", func() {
+			start := time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+			end := time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)
+			buckets, err := timelineService.Timeline(ctx, &services.TimelineOptions{TimelineStartDate: &start, TimelineEndDate: &end})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{
+				{Year: 2024, Month: 8, Count: 1},
+				{Year: 2024, Month: 7, Count: 1},
+			}))
+		})
+
+		It("does not find an unknown album", func() {
+			id := entity.NewId()
+			_, err := timelineService.Timeline(ctx, &services.TimelineOptions{TimelineAlbumTreeID: &id})
+			Expect(isNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("with authorization", func() {
+		var (
+			authz        *viewAuthz
+			authzService *services.AuthzTimelineService
+		)
+
+		BeforeEach(func() {
+			authz = &viewAuthz{}
+			authzService = services.NewAuthzTimelineService(authz, timelineService)
+		})
+
+		It("counts only the media of the albums the user can view", func() {
+			authz.viewable = []entity.Resource{entity.NewAlbumResource(beach.ID), entity.NewAlbumResource(winter.ID)}
+
+			buckets, err := authzService.Timeline(ctx, &services.TimelineOptions{})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{
+				{Year: 2024, Month: 8, Count: 1},
+				{Year: 2024, Month: 7, Count: 1},
+				{Year: 2023, Month: 12, Count: 1},
+			}))
+		})
+
+		It("does not count the media of the subalbums the user cannot view", func() {
+			authz.viewable = []entity.Resource{entity.NewAlbumResource(summer.ID)}
+
+			buckets, err := authzService.Timeline(ctx, &services.TimelineOptions{TimelineAlbumTreeID: &summer.ID})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(Equal(entity.Buckets{{Year: 2024, Month: 7, Count: 2}}))
+		})
+
+		It("counts nothing when the user can view no album", func() {
+			buckets, err := authzService.Timeline(ctx, &services.TimelineOptions{})
+			Expect(err).To(BeNil())
+			Expect(buckets).To(BeEmpty())
+		})
+
+		It("refuses an album tree the user cannot view", func() {
+			authz.viewable = []entity.Resource{entity.NewAlbumResource(beach.ID)}
+
+			_, err := authzService.Timeline(ctx, &services.TimelineOptions{TimelineAlbumTreeID: &summer.ID})
+			var forbidden *services.ForbiddenAccessError
+			Expect(err).To(BeAssignableToTypeOf(forbidden))
+		})
+	})
+})
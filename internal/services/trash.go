@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/pg"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// TrashService provides business logic for the trash without authorization.
+// Albums and media are put in the trash by AlbumService.Delete and MediaService.Delete. They stay there,
+// hidden from every listing, until they are restored or purged.
+type TrashService struct {
+	dt     *pg.Datastore
+	fs     *fs.Datastore
+	logger *logger.StructuredLogger
+}
+
+// NewTrashService creates a new instance of TrashService
+func NewTrashService(dt *pg.Datastore, fs *fs.Datastore) *TrashService {
+	return &TrashService{
+		dt:     dt,
+		fs:     fs,
+		logger: logger.New("trash_service"),
+	}
+}
+
+// ListAlbums returns the albums in the trash. Subalbums trashed with their parent are not listed,
+// they are restored with it.
+func (t *TrashService) ListAlbums(ctx context.Context) ([]entity.Album, error) {
+	logger := t.logger.WithContext(ctx).Debug("list_trashed_albums").Build()
+
+	albums, err := t.dt.QueryTrashedAlbums(ctx, pg.SortByColumn("albums.trashed_at", true))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_trashed_albums", err).
+			AtStep("query_albums")
+	}
+
+	logger.Success().
+		WithInt(TotalAlbums, len(albums)).
+		Log()
+
+	return albums, nil
+}
+
+// ListMedia returns the media in the trash. Media trashed with their album are not listed,
+// they are restored with it.
+func (t *TrashService) ListMedia(ctx context.Context) ([]entity.Media, error) {
+	logger := t.logger.WithContext(ctx).Debug("list_trashed_media").Build()
+
+	media, err := t.dt.QueryTrashedMedia(ctx, pg.SortByColumn("media.trashed_at", true))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "list_trashed_media", err).
+			AtStep("query_media")
+	}
+
+	logger.Success().
+		WithInt(TotalMedia, len(media)).
+		Log()
+
+	return media, nil
+}
+
+// RestoreAlbum takes an album out of the trash with the subalbums and media which were trashed with it.
+// The parent of the album must not be in the trash and its path must be free.
+func (t *TrashService) RestoreAlbum(ctx context.Context, id string) (*entity.Album, error) {
+	logger := t.logger.WithContext(ctx).Debug("restore_album").
+		WithString(AlbumID, id).
+		Build()
+
+	album, err := t.trashedAlbum(ctx, "restore_album", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if album.ParentId != nil {
+		logger.Step("check_parent").WithString("parent_id", *album.ParentId).Log()
+		parent, err := t.dt.QueryAlbum(ctx, pg.FilterByAlbumId(*album.ParentId))
+		if err != nil {
+			return nil, NewDatabaseWriteError(ctx, "restore_album", err).
+				WithParentID(*album.ParentId).
+				AtStep("query_parent")
+		}
+		if parent == nil {
+			err := NewConflictError(ctx, "restore_album", "parent_album_in_trash")
+			err.WithAlbumID(id).WithParentID(*album.ParentId)
+			return nil, err
+		}
+	}
+
+	logger.Step("check_path").WithString(AlbumPath, album.Path).Log()
+	existing, err := t.dt.QueryAlbum(ctx, pg.FilterAlbumByPath(album.Path))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "restore_album", err).
+			WithAlbumPath(album.Path).
+			AtStep("query_path")
+	}
+	if existing != nil {
+		return nil, NewAlbumExistsError(ctx, existing.ID, album.Path)
+	}
+
+	err = t.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		logger.Step("database_restore").WithString("table", "albums").Log()
+
+		if err := writer.RestoreAlbum(ctx, album.Path, *album.TrashedAt); err != nil {
+			return NewDatabaseWriteError(ctx, "restore_album", err).
+				WithAlbumID(id)
+		}
+
+		logger.Step("filesystem_restore").WithString("folder_path", album.Path).Log()
+
+		return t.restoreFromTrash(ctx, "restore_album", album.ID, album.Path)
+	})
+	if err != nil {
+		return nil, NewInternalError(ctx, "restore_album", "transaction", err).
+			WithAlbumID(id).
+			WithAlbumPath(album.Path)
+	}
+
+	restored, err := t.dt.QueryAlbum(ctx, pg.FilterByAlbumId(id))
+	if err != nil || restored == nil {
+		return nil, NewInternalError(ctx, "restore_album", "query_restored_album", err).
+			WithAlbumID(id)
+	}
+
+	logger.Success().
+		WithString(AlbumPath, restored.Path).
+		Log()
+
+	return restored, nil
+}
+
+// RestoreMedia takes a media out of the trash.
+// The album of the media must not be in the trash and its file name must be free.
+func (t *TrashService) RestoreMedia(ctx context.Context, id string) (*entity.Media, error) {
+	logger := t.logger.WithContext(ctx).Debug("restore_media").
+		WithString(MediaID, id).
+		Build()
+
+	media, err := t.trashedMedia(ctx, "restore_media", id)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Step("check_album").WithString(AlbumID, media.Album.ID).Log()
+	album, err := t.dt.QueryAlbum(ctx, pg.FilterByAlbumId(media.Album.ID))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "restore_media", err).
+			WithAlbumID(media.Album.ID).
+			AtStep("query_album")
+	}
+	if album == nil {
+		err := NewConflictError(ctx, "restore_media", "album_in_trash")
+		err.WithMediaID(id).WithAlbumID(media.Album.ID)
+		return nil, err
+	}
+
+	logger.Step("check_filename").WithString(Filename, media.Filename).Log()
+	existing, err := t.dt.QueryMediaIDs(ctx, pg.FilterMediaByFilename(media.Album.ID, media.Filename))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "restore_media", err).
+			WithMediaID(id).
+			AtStep("query_filename")
+	}
+	if len(existing) > 0 {
+		err := NewConflictError(ctx, "restore_media", "media_exists")
+		err.WithMediaID(existing[0]).WithFilepath(media.Filepath())
+		return nil, err
+	}
+
+	err = t.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		logger.Step("database_restore").WithString("table", "media").Log()
+
+		if err := writer.RestoreMedia(ctx, id); err != nil {
+			return NewDatabaseWriteError(ctx, "restore_media", err).
+				WithMediaID(id)
+		}
+
+		logger.Step("filesystem_restore").WithString(Filepath, media.Filepath()).Log()
+
+		return t.restoreFromTrash(ctx, "restore_media", media.ID, media.Filepath())
+	})
+	if err != nil {
+		return nil, NewInternalError(ctx, "restore_media", "transaction", err).
+			WithMediaID(id).
+			WithFilepath(media.Filepath())
+	}
+
+	media.TrashedAt = nil
+	media.Content = t.fs.Read(ctx, media.Filepath())
+
+	logger.Success().
+		WithString(Filepath, media.Filepath()).
+		Log()
+
+	return media, nil
+}
+
+// Purge deletes for good the albums and media put in the trash before the time.
+// It returns every album and media deleted, including the ones trashed with an album.
+func (t *TrashService) Purge(ctx context.Context, before time.Time) ([]entity.Resource, error) {
+	logger := t.logger.WithContext(ctx).Debug("purge_trash").
+		WithParam("before", before).
+		Build()
+
+	media, err := t.dt.QueryTrashedMedia(ctx, pg.FilterMediaTrashedBefore(before))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "purge_trash", err).
+			AtStep("query_media")
+	}
+
+	albums, err := t.dt.QueryTrashedAlbums(ctx, pg.FilterAlbumsTrashedBefore(before))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, "purge_trash", err).
+			AtStep("query_albums")
+	}
+
+	logger.Step("database_delete").
+		WithInt(TotalMedia, len(media)).
+		WithInt(TotalAlbums, len(albums)).
+		Log()
+
+	purged := []entity.Resource{}
+	err = t.dt.WriteTx(ctx, func(ctx context.Context, writer *pg.Writer) error {
+		for _, m := range media {
+			if err := writer.DeleteMedia(ctx, m.ID); err != nil {
+				return NewDatabaseWriteError(ctx, "purge_trash", err).
+					WithMediaID(m.ID)
+			}
+			purged = append(purged, entity.NewMediaResource(m.ID))
+		}
+
+		for _, album := range albums {
+			resources, err := writer.PurgeAlbum(ctx, album.ID)
+			if err != nil {
+				return NewDatabaseWriteError(ctx, "purge_trash", err).
+					WithAlbumID(album.ID)
+			}
+			purged = append(purged, resources...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, NewInternalError(ctx, "purge_trash", "transaction", err)
+	}
+
+	// the files are removed once the rows are gone: a row without its file could not be restored
+	if err := t.cleanTrashFolder(ctx, purged); err != nil {
+		return purged, err
+	}
+
+	logger.Success().
+		WithInt("purged", len(purged)).
+		Log()
+
+	return purged, nil
+}
+
+// cleanTrashFolder removes from the trash folder the items of the purged albums and media.
+// Subalbums and media trashed before their album are in the trash folder under their own id.
+func (t *TrashService) cleanTrashFolder(ctx context.Context, purged []entity.Resource) error {
+	names, err := t.fs.ListTrash(ctx)
+	if err != nil {
+		return NewFilesystemError(ctx, "purge_trash", "list_trash", fs.TrashFolder, err)
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(purged, func(r entity.Resource) bool { return r.ID == name }) {
+			continue
+		}
+
+		if err := t.fs.DeleteFromTrash(ctx, name); err != nil {
+			return NewFilesystemError(ctx, "purge_trash", "filesystem_delete", name, err)
+		}
+	}
+
+	return nil
+}
+
+// restoreFromTrash moves back the item saved in the trash folder under the name. The albums and media put in
+// the trash by a sync have nothing in the trash folder, their folder or file was gone: only their rows are
+// restored and the next sync puts them in the trash again if the folder or file is still missing.
+func (t *TrashService) restoreFromTrash(ctx context.Context, operation, name, relativePath string) error {
+	inTrash, err := t.fs.Exists(ctx, path.Join(fs.TrashFolder, name))
+	if err != nil {
+		return NewFilesystemError(ctx, operation, "check_trash", relativePath, err)
+	}
+	if !inTrash {
+		return nil
+	}
+
+	if err := t.fs.RestoreFromTrash(ctx, name, relativePath); err != nil {
+		return NewFilesystemError(ctx, operation, "filesystem_restore", relativePath, err)
+	}
+	return nil
+}
+
+func (t *TrashService) trashedAlbum(ctx context.Context, operation, id string) (*entity.Album, error) {
+	if id == "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_album_id")
+		return nil, err
+	}
+
+	albums, err := t.dt.QueryTrashedAlbums(ctx, pg.FilterByAlbumId(id))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, operation, err).
+			WithAlbumID(id).
+			AtStep("query_album")
+	}
+
+	if len(albums) == 0 {
+		return nil, NewAlbumNotFoundError(ctx, id)
+	}
+
+	return &albums[0], nil
+}
+
+func (t *TrashService) trashedMedia(ctx context.Context, operation, id string) (*entity.Media, error) {
+	if id == "" {
+		err := NewValidationError(ctx, operation, "invalid_input")
+		err.WithContext("validation_error", "empty_media_id")
+		return nil, err
+	}
+
+	media, err := t.dt.QueryTrashedMedia(ctx, pg.FilterByMediaId(id))
+	if err != nil {
+		return nil, NewDatabaseWriteError(ctx, operation, err).
+			WithMediaID(id).
+			AtStep("query_media")
+	}
+
+	if len(media) == 0 {
+		return nil, NewMediaNotFoundError(ctx, id)
+	}
+
+	return &media[0], nil
+}
+
+// purgeTrashJob is the type of the jobs purging the trash
+const purgeTrashJob = "purge_trash"
+
+// PurgeJob is the job purging the trash. It runs its task like a SyncJob but it is not a sync job,
+// the sync service leaves it out of the sync jobs.
+type PurgeJob struct {
+	*SyncJob
+}
+
+// TrashPurger purges the trash. It is implemented by TrashService and AuthzTrashService.
+type TrashPurger interface {
+	Purge(ctx context.Context, before time.Time) ([]entity.Resource, error)
+}
+
+// SchedulePurge adds to the scheduler, right away and then every interval until the context is done,
+// a job purging the albums and media trashed for longer than the retention period.
+// A new job is not added while the previous one has not finished.
+func SchedulePurge(ctx context.Context, purger TrashPurger, retention, interval time.Duration) {
+	scheduler := GetScheduler()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pending := scheduler.find(func(j Job) bool {
+			if _, ok := j.(*PurgeJob); !ok {
+				return false
+			}
+			status := j.Status().Status
+			return status == entity.StatusPending || status == entity.StatusRunning
+		})
+		if len(pending) == 0 {
+			if job, err := newPurgeJob(purger, retention); err == nil {
+				_ = scheduler.Add(job)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func newPurgeJob(purger TrashPurger, retention time.Duration) (*PurgeJob, error) {
+	tasks := entity.NewLinkedList[Task[string]]()
+	tasks.PushBack(func(ctx context.Context) entity.Result[string] {
+		purged, err := purger.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return entity.NewResultWithError[string](err)
+		}
+		return entity.NewResult(fmt.Sprintf("%d albums and media purged from the trash", len(purged)))
+	})
+
+	job, err := NewSyncJob(tasks, map[string]string{"type": purgeTrashJob})
+	if err != nil {
+		return nil, err
+	}
+	return &PurgeJob{SyncJob: job}, nil
+}
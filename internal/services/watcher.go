@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"git.tls.tupangiu.ro/cosmin/photos-ng/internal/datastore/fs"
+	"git.tls.tupangiu.ro/cosmin/photos-ng/pkg/logger"
+)
+
+// Watcher syncs the folders and media files of the data folder when they change instead of waiting
+// for a manual sync. The changes are debounced: they are synced once nothing changed for a while so
+// a folder being copied is synced once, and only the changed folders and files are synced.
+type Watcher struct {
+	syncSrv  *SyncService
+	fs       *fs.Datastore
+	paths    []string
+	opts     fs.WatchOptions
+	debounce time.Duration
+	logger   *logger.StructuredLogger
+}
+
+// NewWatcher creates a watcher of the given folders, relative to the data folder. It watches the whole
+// data folder if there are none.
+func NewWatcher(syncSrv *SyncService, fsDatastore *fs.Datastore, paths []string, opts fs.WatchOptions, debounce time.Duration) *Watcher {
+	return &Watcher{
+		syncSrv:  syncSrv,
+		fs:       fsDatastore,
+		paths:    paths,
+		opts:     opts,
+		debounce: debounce,
+		logger:   logger.New("watcher"),
+	}
+}
+
+// Run watches the folders and schedules the sync jobs of the changes until the context is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	tracer := w.logger.WithContext(ctx).Operation("watch").
+		WithParam("paths", w.paths).
+		WithBool("polling", w.opts.Polling).
+		Build()
+
+	events, err := w.fs.Watch(ctx, w.paths, w.opts)
+	if err != nil {
+		return NewFilesystemError(ctx, "watch", "start_watch", strings.Join(w.paths, ","), err)
+	}
+
+	tracer.Step("watching folders").Log()
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	changes := make(map[string]fs.WatchEvent)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				tracer.Success().Log()
+				return nil
+			}
+			changes[event.Path] = event
+			timer.Reset(w.debounce)
+		case <-timer.C:
+			w.sync(ctx, slices.Collect(maps.Values(changes)))
+			changes = make(map[string]fs.WatchEvent)
+		}
+	}
+}
+
+// sync schedules the sync jobs of the changes: a full sync of each new or changed folder and a sync of
+// the changed files of each other folder. The folder which contained a removed folder or file is synced
+// so its album or media is removed, or moved if the file was moved elsewhere.
+func (w *Watcher) sync(ctx context.Context, changes []fs.WatchEvent) {
+	tracer := w.logger.WithContext(ctx).Operation("sync_changes").
+		WithInt("change_count", len(changes)).
+		Build()
+
+	folders := []string{}
+	files := make(map[string][]string)
+	for _, change := range changes {
+		switch {
+		case change.Removed:
+			folders = append(folders, w.existingFolder(ctx, filepath.Dir(change.Path)))
+		case change.IsDirectory:
+			folders = append(folders, change.Path)
+		default:
+			dir := filepath.Dir(change.Path)
+			files[dir] = append(files[dir], change.Path)
+		}
+	}
+
+	// a folder whose album is not known yet is synced as a whole so its album is created with its parent
+	for dir := range files {
+		if dir == "." {
+			continue
+		}
+		if _, err := w.syncSrv.albumService.GetByPath(ctx, dir); err != nil {
+			folders = append(folders, dir)
+		}
+	}
+
+	// the folders inside another synced folder are synced with it
+	slices.Sort(folders)
+	synced := []string{}
+	for _, folder := range folders {
+		if !isInFolders(folder, synced) {
+			synced = append(synced, folder)
+		}
+	}
+
+	for _, folder := range synced {
+		if _, err := w.syncSrv.StartSync(ctx, folder, nil); err != nil {
+			tracer.Step("failed to sync folder").
+				WithString(AlbumPath, folder).
+				WithString("error", err.Error()).
+				Log()
+		}
+	}
+
+	for dir, dirFiles := range files {
+		// the media files of the data folder itself do not belong to any album
+		if dir == "." || isInFolders(dir, synced) {
+			continue
+		}
+
+		slices.Sort(dirFiles)
+		if _, err := w.syncSrv.StartSyncFiles(ctx, dir, dirFiles); err != nil {
+			tracer.Step("failed to sync files").
+				WithString(AlbumPath, dir).
+				WithString("error", err.Error()).
+				Log()
+		}
+	}
+
+	tracer.Success().
+		WithInt("synced_folders", len(synced)).
+		Log()
+}
+
+// existingFolder returns the folder, or its closest parent still on disk when it was removed as well.
+// The data folder itself is the empty path.
+func (w *Watcher) existingFolder(ctx context.Context, folder string) string {
+	for folder != "." && folder != "/" {
+		if exists, err := w.fs.Exists(ctx, folder); err == nil && exists {
+			return folder
+		}
+		folder = filepath.Dir(folder)
+	}
+	return ""
+}
+
+// isInFolders checks if the path is one of the folders or is inside one of them.
+// The empty folder is the data folder, every path is inside it.
+func isInFolders(path string, folders []string) bool {
+	for _, folder := range folders {
+		if folder == "" || path == folder || strings.HasPrefix(path, folder+"/") {
+			return true
+		}
+	}
+	return false
+}
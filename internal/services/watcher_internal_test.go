@@ -0,0 +1,21 @@
+package services
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isInFolders", func() {
+	DescribeTable("checks if a path is one of the folders or inside one of them",
+		func(path string, folders []string, expected bool) {
+			Expect(isInFolders(path, folders)).To(Equal(expected))
+		},
+		Entry("the folder itself", "a/b", []string{"a/b"}, true),
+		Entry("a file of the folder", "a/b/1.jpg", []string{"a/b"}, true),
+		Entry("a subfolder", "a/b/c/d", []string{"x", "a/b"}, true),
+		Entry("the parent folder", "a", []string{"a/b"}, false),
+		Entry("a folder sharing the prefix of the name", "a/bc", []string{"a/b"}, false),
+		Entry("any path of the data folder", "a/b", []string{""}, true),
+		Entry("no folders", "a", []string{}, false),
+	)
+})
@@ -5,6 +5,7 @@
 package services
 
 import (
+	entity "git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 	defaults "github.com/creasty/defaults"
 	helpers "github.com/ecordell/optgen/helpers"
 )
@@ -38,6 +39,9 @@ func (l *ListOptions) ToOption() ListOptionsOption {
 		to.ParentID = l.ParentID
 		to.HasParent = l.HasParent
 		to.AllowedIDs = l.AllowedIDs
+		to.AlbumSortBy = l.AlbumSortBy
+		to.AlbumSortDescending = l.AlbumSortDescending
+		to.AlbumCursor = l.AlbumCursor
 	}
 }
 
@@ -49,6 +53,9 @@ func (l *ListOptions) DebugMap() map[string]any {
 	debugMap["ParentID"] = helpers.DebugValue(l.ParentID, false)
 	debugMap["HasParent"] = helpers.DebugValue(l.HasParent, false)
 	debugMap["AllowedIDs"] = helpers.DebugValue(l.AllowedIDs, false)
+	debugMap["AlbumSortBy"] = helpers.DebugValue(l.AlbumSortBy, false)
+	debugMap["AlbumSortDescending"] = helpers.DebugValue(l.AlbumSortDescending, false)
+	debugMap["AlbumCursor"] = helpers.DebugValue(l.AlbumCursor, false)
 	return debugMap
 }
 
@@ -109,3 +116,24 @@ func SetAllowedIDs(allowedIDs []string) ListOptionsOption {
 		l.AllowedIDs = allowedIDs
 	}
 }
+
+// WithAlbumSortBy returns an option that can set AlbumSortBy on a ListOptions
+func WithAlbumSortBy(albumSortBy entity.AlbumSortBy) ListOptionsOption {
+	return func(l *ListOptions) {
+		l.AlbumSortBy = albumSortBy
+	}
+}
+
+// WithAlbumSortDescending returns an option that can set AlbumSortDescending on a ListOptions
+func WithAlbumSortDescending(albumSortDescending bool) ListOptionsOption {
+	return func(l *ListOptions) {
+		l.AlbumSortDescending = albumSortDescending
+	}
+}
+
+// WithAlbumCursor returns an option that can set AlbumCursor on a ListOptions
+func WithAlbumCursor(albumCursor *AlbumCursor) ListOptionsOption {
+	return func(l *ListOptions) {
+		l.AlbumCursor = albumCursor
+	}
+}
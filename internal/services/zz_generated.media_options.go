@@ -5,6 +5,7 @@
 package services
 
 import (
+	entity "git.tls.tupangiu.ro/cosmin/photos-ng/internal/entity"
 	defaults "github.com/creasty/defaults"
 	helpers "github.com/ecordell/optgen/helpers"
 	"time"
@@ -42,6 +43,12 @@ func (m *MediaOptions) ToOption() MediaOptionsOption {
 		to.MediaType = m.MediaType
 		to.StartDate = m.StartDate
 		to.EndDate = m.EndDate
+		to.AlbumTreeID = m.AlbumTreeID
+		to.Camera = m.Camera
+		to.Tags = m.Tags
+		to.MinRating = m.MinRating
+		to.Area = m.Area
+		to.Query = m.Query
 	}
 }
 
@@ -56,6 +63,12 @@ func (m *MediaOptions) DebugMap() map[string]any {
 	debugMap["MediaType"] = helpers.DebugValue(m.MediaType, false)
 	debugMap["StartDate"] = helpers.DebugValue(m.StartDate, false)
 	debugMap["EndDate"] = helpers.DebugValue(m.EndDate, false)
+	debugMap["AlbumTreeID"] = helpers.DebugValue(m.AlbumTreeID, false)
+	debugMap["Camera"] = helpers.DebugValue(m.Camera, false)
+	debugMap["Tags"] = helpers.DebugValue(m.Tags, false)
+	debugMap["MinRating"] = helpers.DebugValue(m.MinRating, false)
+	debugMap["Area"] = helpers.DebugValue(m.Area, false)
+	debugMap["Query"] = helpers.DebugValue(m.Query, false)
 	return debugMap
 }
 
@@ -130,3 +143,52 @@ func WithEndDate(endDate *time.Time) MediaOptionsOption {
 		m.EndDate = endDate
 	}
 }
+
+// WithAlbumTreeID returns an option that can set AlbumTreeID on a MediaOptions
+func WithAlbumTreeID(albumTreeID *string) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.AlbumTreeID = albumTreeID
+	}
+}
+
+// WithCamera returns an option that can set Camera on a MediaOptions
+func WithCamera(camera *string) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.Camera = camera
+	}
+}
+
+// WithTags returns an option that can append Tagss to MediaOptions.Tags
+func WithTags(tags string) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.Tags = append(m.Tags, tags)
+	}
+}
+
+// SetTags returns an option that can set Tags on a MediaOptions
+func SetTags(tags []string) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.Tags = tags
+	}
+}
+
+// WithMinRating returns an option that can set MinRating on a MediaOptions
+func WithMinRating(minRating *int) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.MinRating = minRating
+	}
+}
+
+// WithArea returns an option that can set Area on a MediaOptions
+func WithArea(area *entity.BoundingBox) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.Area = area
+	}
+}
+
+// WithQuery returns an option that can set Query on a MediaOptions
+func WithQuery(query string) MediaOptionsOption {
+	return func(m *MediaOptions) {
+		m.Query = query
+	}
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE collections (
+    id VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    thumbnail_id VARCHAR(255) REFERENCES media(id) ON DELETE SET NULL
+);
+
+-- Media of a collection. Position defines the order of the media inside the collection.
+CREATE TABLE collection_media (
+    collection_id VARCHAR(255) NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    media_id VARCHAR(255) NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    added_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    PRIMARY KEY (collection_id, media_id)
+);
+
+CREATE INDEX idx_collection_media_position ON collection_media(collection_id, position);
+CREATE INDEX idx_collection_media_media_id ON collection_media(media_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_media;
+DROP TABLE collections;
+-- +goose StatementEnd
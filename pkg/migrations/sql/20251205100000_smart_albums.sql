@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Searchable metadata extracted from exif when the media is written
+ALTER TABLE media
+    ADD COLUMN camera TEXT,
+    ADD COLUMN tags TEXT[] DEFAULT '{}' NOT NULL,
+    ADD COLUMN rating SMALLINT,
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION;
+
+-- Backfill the existing media from the stored exif
+UPDATE media SET camera = NULLIF(trim(exif->>'Model'), '');
+
+UPDATE media SET rating = (exif->>'Rating')::SMALLINT
+WHERE exif->>'Rating' ~ '^[0-5]$';
+
+UPDATE media SET tags = ARRAY(
+    SELECT DISTINCT lower(trim(tag))
+    FROM unnest(string_to_array(COALESCE(exif->>'Keywords', exif->>'Subject'), ',')) AS tag
+    WHERE trim(tag) <> ''
+)
+WHERE COALESCE(exif->>'Keywords', exif->>'Subject') IS NOT NULL;
+
+-- exiftool renders coordinates like 46 deg 46' 12.34" N
+UPDATE media SET
+    latitude = (
+        SELECT (c[1]::DOUBLE PRECISION + c[2]::DOUBLE PRECISION / 60 + c[3]::DOUBLE PRECISION / 3600)
+            * CASE WHEN c[4] = 'S' THEN -1 ELSE 1 END
+        FROM regexp_match(exif->>'GPSLatitude', '^([0-9.]+) deg ([0-9.]+)'' ([0-9.]+)" ([NS])$') AS c
+    ),
+    longitude = (
+        SELECT (c[1]::DOUBLE PRECISION + c[2]::DOUBLE PRECISION / 60 + c[3]::DOUBLE PRECISION / 3600)
+            * CASE WHEN c[4] = 'W' THEN -1 ELSE 1 END
+        FROM regexp_match(exif->>'GPSLongitude', '^([0-9.]+) deg ([0-9.]+)'' ([0-9.]+)" ([EW])$') AS c
+    )
+WHERE exif->>'GPSLatitude' IS NOT NULL AND exif->>'GPSLongitude' IS NOT NULL;
+
+CREATE INDEX idx_media_camera ON media(lower(camera));
+CREATE INDEX idx_media_tags ON media USING GIN(tags);
+CREATE INDEX idx_media_rating ON media(rating);
+CREATE INDEX idx_media_location ON media(latitude, longitude);
+
+CREATE TABLE smart_albums (
+    id VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    filter JSONB DEFAULT '{}' NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE smart_albums;
+
+DROP INDEX IF EXISTS idx_media_location;
+DROP INDEX IF EXISTS idx_media_rating;
+DROP INDEX IF EXISTS idx_media_tags;
+DROP INDEX IF EXISTS idx_media_camera;
+
+ALTER TABLE media
+    DROP COLUMN longitude,
+    DROP COLUMN latitude,
+    DROP COLUMN rating,
+    DROP COLUMN tags,
+    DROP COLUMN camera;
+-- +goose StatementEnd
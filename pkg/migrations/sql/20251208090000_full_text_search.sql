@@ -0,0 +1,98 @@
+-- +goose Up
+-- +goose StatementBegin
+-- search_words splits paths and file names into words: "Trips/2023/IMG_0042.jpg" -> "Trips 2023 IMG 0042 jpg"
+CREATE OR REPLACE FUNCTION search_words(value TEXT)
+RETURNS TEXT AS $$
+    SELECT regexp_replace(COALESCE(value, ''), '[/_.\-]+', ' ', 'g');
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION album_search_vector(path TEXT, description TEXT)
+RETURNS tsvector AS $$
+BEGIN
+    RETURN setweight(to_tsvector('simple', search_words(path)), 'A') ||
+           setweight(to_tsvector('simple', COALESCE(description, '')), 'B');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Only a few exif fields are indexed, most of them are numbers which are not worth searching
+CREATE OR REPLACE FUNCTION media_search_vector(file_name TEXT, tags TEXT[], exif JSONB)
+RETURNS tsvector AS $$
+BEGIN
+    RETURN setweight(to_tsvector('simple', search_words(file_name)), 'A') ||
+           setweight(to_tsvector('simple', array_to_string(tags, ' ')), 'A') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               exif->>'ImageDescription',
+               exif->>'Caption-Abstract',
+               exif->>'Title',
+               exif->>'Headline'
+           )), 'B') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               exif->>'Make',
+               exif->>'Model',
+               exif->>'LensModel',
+               exif->>'Artist',
+               exif->>'City',
+               exif->>'Country'
+           )), 'C');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE albums ADD COLUMN search_vector tsvector;
+ALTER TABLE media ADD COLUMN search_vector tsvector;
+
+UPDATE albums SET search_vector = album_search_vector(path, description);
+UPDATE media SET search_vector = media_search_vector(file_name, tags, exif);
+
+CREATE OR REPLACE FUNCTION albums_search_vector_trigger()
+RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := album_search_vector(NEW.path, NEW.description);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION media_search_vector_trigger()
+RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := media_search_vector(NEW.file_name, NEW.tags, NEW.exif);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER albums_search_vector_update
+    BEFORE INSERT OR UPDATE OF path, description ON albums
+    FOR EACH ROW EXECUTE FUNCTION albums_search_vector_trigger();
+
+CREATE TRIGGER media_search_vector_update
+    BEFORE INSERT OR UPDATE OF file_name, tags, exif ON media
+    FOR EACH ROW EXECUTE FUNCTION media_search_vector_trigger();
+
+CREATE INDEX idx_albums_search_vector ON albums USING GIN(search_vector);
+CREATE INDEX idx_media_search_vector ON media USING GIN(search_vector);
+
+-- search_documents gathers every searchable resource so they can be ranked together
+CREATE VIEW search_documents AS
+    SELECT 'album' AS kind, id, search_vector AS document FROM albums
+    UNION ALL
+    SELECT 'media' AS kind, id, search_vector AS document FROM media;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW search_documents;
+
+DROP INDEX IF EXISTS idx_media_search_vector;
+DROP INDEX IF EXISTS idx_albums_search_vector;
+
+DROP TRIGGER media_search_vector_update ON media;
+DROP TRIGGER albums_search_vector_update ON albums;
+DROP FUNCTION media_search_vector_trigger;
+DROP FUNCTION albums_search_vector_trigger;
+
+ALTER TABLE media DROP COLUMN search_vector;
+ALTER TABLE albums DROP COLUMN search_vector;
+
+DROP FUNCTION media_search_vector;
+DROP FUNCTION album_search_vector;
+DROP FUNCTION search_words;
+-- +goose StatementEnd
@@ -0,0 +1,112 @@
+-- +goose Up
+-- +goose StatementBegin
+-- caption is editable by the users, it is imported from the description embedded in the file
+ALTER TABLE media ADD COLUMN caption TEXT;
+
+UPDATE media SET caption = NULLIF(TRIM(COALESCE(
+    exif->>'ImageDescription',
+    exif->>'Caption-Abstract',
+    exif->>'Description'
+)), '');
+
+-- the caption replaces the exif descriptions in the search document
+DROP TRIGGER media_search_vector_update ON media;
+DROP FUNCTION media_search_vector_trigger;
+DROP FUNCTION media_search_vector(TEXT, TEXT[], JSONB);
+
+CREATE OR REPLACE FUNCTION media_search_vector(file_name TEXT, caption TEXT, tags TEXT[], exif JSONB)
+RETURNS tsvector AS $$
+BEGIN
+    RETURN setweight(to_tsvector('simple', search_words(file_name)), 'A') ||
+           setweight(to_tsvector('simple', array_to_string(tags, ' ')), 'A') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               caption,
+               exif->>'Title',
+               exif->>'Headline'
+           )), 'B') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               exif->>'Make',
+               exif->>'Model',
+               exif->>'LensModel',
+               exif->>'Artist',
+               exif->>'City',
+               exif->>'Country'
+           )), 'C');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION media_search_vector_trigger()
+RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := media_search_vector(NEW.file_name, NEW.caption, NEW.tags, NEW.exif);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER media_search_vector_update
+    BEFORE INSERT OR UPDATE OF file_name, caption, tags, exif ON media
+    FOR EACH ROW EXECUTE FUNCTION media_search_vector_trigger();
+
+UPDATE media SET search_vector = media_search_vector(file_name, caption, tags, exif);
+
+-- Comments on media. A reply references the comment it answers, replies are deleted with their parent.
+CREATE TABLE media_comments (
+    id VARCHAR(255) PRIMARY KEY,
+    media_id VARCHAR(255) NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    parent_id VARCHAR(255) REFERENCES media_comments(id) ON DELETE CASCADE,
+    author TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL,
+    updated_at TIMESTAMP DEFAULT (now() AT TIME ZONE 'UTC') NOT NULL
+);
+
+CREATE INDEX idx_media_comments_media_id ON media_comments(media_id, created_at);
+CREATE INDEX idx_media_comments_parent_id ON media_comments(parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE media_comments;
+
+DROP TRIGGER media_search_vector_update ON media;
+DROP FUNCTION media_search_vector_trigger;
+DROP FUNCTION media_search_vector(TEXT, TEXT, TEXT[], JSONB);
+
+CREATE OR REPLACE FUNCTION media_search_vector(file_name TEXT, tags TEXT[], exif JSONB)
+RETURNS tsvector AS $$
+BEGIN
+    RETURN setweight(to_tsvector('simple', search_words(file_name)), 'A') ||
+           setweight(to_tsvector('simple', array_to_string(tags, ' ')), 'A') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               exif->>'ImageDescription',
+               exif->>'Caption-Abstract',
+               exif->>'Title',
+               exif->>'Headline'
+           )), 'B') ||
+           setweight(to_tsvector('simple', concat_ws(' ',
+               exif->>'Make',
+               exif->>'Model',
+               exif->>'LensModel',
+               exif->>'Artist',
+               exif->>'City',
+               exif->>'Country'
+           )), 'C');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION media_search_vector_trigger()
+RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := media_search_vector(NEW.file_name, NEW.tags, NEW.exif);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER media_search_vector_update
+    BEFORE INSERT OR UPDATE OF file_name, tags, exif ON media
+    FOR EACH ROW EXECUTE FUNCTION media_search_vector_trigger();
+
+UPDATE media SET search_vector = media_search_vector(file_name, tags, exif);
+
+ALTER TABLE media DROP COLUMN caption;
+-- +goose StatementEnd
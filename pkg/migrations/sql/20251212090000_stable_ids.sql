@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Album and media ids used to be hashes of their paths. From now on new ids are random and an id never
+-- changes when an album is renamed or moved. Existing ids are kept as they are: they are opaque to the
+-- clients and to SpiceDB, so shared links, favorites and relationships remain valid.
+--
+-- Since the id does not tell anymore which folder or file a row stands for, the uniqueness that the hash
+-- used to guarantee is enforced by the database.
+CREATE UNIQUE INDEX idx_albums_path_unique ON albums(path);
+CREATE UNIQUE INDEX idx_media_album_file_name_unique ON media(album_id, file_name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_media_album_file_name_unique;
+DROP INDEX IF EXISTS idx_albums_path_unique;
+-- +goose StatementEnd
@@ -0,0 +1,94 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted albums and media are kept in the trash until they are purged.
+-- Every row trashed by the same operation gets the same trashed_at so an album is restored
+-- with exactly the subalbums and media which were trashed with it.
+ALTER TABLE albums ADD COLUMN trashed_at TIMESTAMP;
+ALTER TABLE media ADD COLUMN trashed_at TIMESTAMP;
+
+CREATE INDEX idx_albums_trashed_at ON albums(trashed_at) WHERE trashed_at IS NOT NULL;
+CREATE INDEX idx_media_trashed_at ON media(trashed_at) WHERE trashed_at IS NOT NULL;
+
+-- A trashed album or media does not prevent a new one with the same path
+DROP INDEX idx_albums_path_unique;
+DROP INDEX idx_media_album_file_name_unique;
+CREATE UNIQUE INDEX idx_albums_path_unique ON albums(path) WHERE trashed_at IS NULL;
+CREATE UNIQUE INDEX idx_media_album_file_name_unique ON media(album_id, file_name) WHERE trashed_at IS NULL;
+
+CREATE OR REPLACE VIEW search_documents AS
+    SELECT 'album' AS kind, id, search_vector AS document FROM albums WHERE trashed_at IS NULL
+    UNION ALL
+    SELECT 'media' AS kind, id, search_vector AS document FROM media WHERE trashed_at IS NULL;
+
+CREATE OR REPLACE FUNCTION count_media_in_album_with_children(album_id_param VARCHAR(255))
+RETURNS INTEGER AS $$
+DECLARE
+    total_count INTEGER := 0;
+BEGIN
+    WITH RECURSIVE album_tree AS (
+        SELECT id, path
+        FROM albums
+        WHERE id = album_id_param AND trashed_at IS NULL
+
+        UNION ALL
+
+        SELECT a.id, a.path
+        FROM albums a
+        INNER JOIN album_tree at ON a.parent_id = at.id
+        WHERE a.trashed_at IS NULL
+    )
+    SELECT COUNT(m.id) INTO total_count
+    FROM album_tree at
+    INNER JOIN media m ON m.album_id = at.id AND m.trashed_at IS NULL;
+
+    RETURN total_count;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION count_media_in_album_with_children(album_id_param VARCHAR(255))
+RETURNS INTEGER AS $$
+DECLARE
+    total_count INTEGER := 0;
+BEGIN
+    WITH RECURSIVE album_tree AS (
+        SELECT id, path
+        FROM albums
+        WHERE id = album_id_param
+
+        UNION ALL
+
+        SELECT a.id, a.path
+        FROM albums a
+        INNER JOIN album_tree at ON a.parent_id = at.id
+    )
+    SELECT COUNT(m.id) INTO total_count
+    FROM album_tree at
+    LEFT JOIN media m ON m.album_id = at.id;
+
+    RETURN total_count;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE VIEW search_documents AS
+    SELECT 'album' AS kind, id, search_vector AS document FROM albums
+    UNION ALL
+    SELECT 'media' AS kind, id, search_vector AS document FROM media;
+
+-- trashed rows would break the unique indexes, they are removed first
+DELETE FROM media WHERE trashed_at IS NOT NULL;
+DELETE FROM albums WHERE trashed_at IS NOT NULL;
+
+DROP INDEX idx_media_album_file_name_unique;
+DROP INDEX idx_albums_path_unique;
+CREATE UNIQUE INDEX idx_albums_path_unique ON albums(path);
+CREATE UNIQUE INDEX idx_media_album_file_name_unique ON media(album_id, file_name);
+
+DROP INDEX IF EXISTS idx_media_trashed_at;
+DROP INDEX IF EXISTS idx_albums_trashed_at;
+
+ALTER TABLE media DROP COLUMN trashed_at;
+ALTER TABLE albums DROP COLUMN trashed_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE media ADD COLUMN favorite BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_media_favorite ON media(captured_at DESC) WHERE favorite;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_media_favorite;
+ALTER TABLE media DROP COLUMN favorite;
+-- +goose StatementEnd
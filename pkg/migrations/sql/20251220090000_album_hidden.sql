@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE albums ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_albums_hidden ON albums(id) WHERE hidden;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_albums_hidden;
+ALTER TABLE albums DROP COLUMN hidden;
+-- +goose StatementEnd
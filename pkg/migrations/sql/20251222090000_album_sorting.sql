@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_media_album_captured_at ON media(album_id, captured_at DESC) WHERE trashed_at IS NULL;
+
+CREATE OR REPLACE FUNCTION latest_capture_in_album_with_children(album_id_param VARCHAR(255))
+RETURNS TIMESTAMP AS $$
+DECLARE
+    latest TIMESTAMP;
+BEGIN
+    WITH RECURSIVE album_tree AS (
+        SELECT id
+        FROM albums
+        WHERE id = album_id_param AND trashed_at IS NULL
+
+        UNION ALL
+
+        SELECT a.id
+        FROM albums a
+        INNER JOIN album_tree at ON a.parent_id = at.id
+        WHERE a.trashed_at IS NULL
+    )
+    SELECT MAX(m.captured_at) INTO latest
+    FROM album_tree at
+    INNER JOIN media m ON m.album_id = at.id AND m.trashed_at IS NULL;
+
+    RETURN latest;
+END;
+$$ LANGUAGE plpgsql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS latest_capture_in_album_with_children;
+DROP INDEX IF EXISTS idx_media_album_captured_at;
+-- +goose StatementEnd
@@ -0,0 +1,125 @@
+-- +goose Up
+-- +goose StatementBegin
+-- album_closure pairs every album with itself and with each of its descendants so a subtree is
+-- a lookup instead of a recursive query. It is maintained by the writer together with the albums.
+CREATE TABLE album_closure (
+    ancestor_id VARCHAR(255) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    descendant_id VARCHAR(255) NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    depth INTEGER NOT NULL,
+    PRIMARY KEY (ancestor_id, descendant_id)
+);
+
+CREATE INDEX idx_album_closure_descendant ON album_closure(descendant_id);
+
+-- Cached statistics of the media which are not in the trash. media_count and latest_captured_at
+-- are about the album itself, the subtree ones include the media of all the descendants.
+ALTER TABLE albums
+    ADD COLUMN media_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN latest_captured_at TIMESTAMP,
+    ADD COLUMN subtree_media_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN subtree_latest_captured_at TIMESTAMP;
+
+CREATE INDEX idx_albums_subtree_media_count ON albums(subtree_media_count);
+CREATE INDEX idx_albums_subtree_latest_captured_at ON albums(subtree_latest_captured_at);
+
+WITH RECURSIVE closure AS (
+    SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth FROM albums
+    UNION ALL
+    SELECT closure.ancestor_id, albums.id, closure.depth + 1
+    FROM closure
+    INNER JOIN albums ON albums.parent_id = closure.descendant_id
+)
+INSERT INTO album_closure (ancestor_id, descendant_id, depth)
+SELECT ancestor_id, descendant_id, depth FROM closure;
+
+UPDATE albums SET
+    media_count = own.media_count,
+    latest_captured_at = own.latest
+FROM (
+    SELECT album_id, count(*)::int AS media_count, max(captured_at) AS latest
+    FROM media
+    WHERE trashed_at IS NULL
+    GROUP BY album_id
+) own
+WHERE own.album_id = albums.id;
+
+UPDATE albums SET
+    subtree_media_count = subtree.media_count,
+    subtree_latest_captured_at = subtree.latest
+FROM (
+    SELECT album_closure.ancestor_id,
+        coalesce(sum(descendant.media_count), 0)::int AS media_count,
+        max(descendant.latest_captured_at) AS latest
+    FROM album_closure
+    INNER JOIN albums descendant ON descendant.id = album_closure.descendant_id AND descendant.trashed_at IS NULL
+    GROUP BY album_closure.ancestor_id
+) subtree
+WHERE subtree.ancestor_id = albums.id;
+
+DROP FUNCTION IF EXISTS latest_capture_in_album_with_children;
+DROP FUNCTION IF EXISTS count_media_in_album_with_children;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION count_media_in_album_with_children(album_id_param VARCHAR(255))
+RETURNS INTEGER AS $$
+DECLARE
+    total_count INTEGER := 0;
+BEGIN
+    WITH RECURSIVE album_tree AS (
+        SELECT id, path
+        FROM albums
+        WHERE id = album_id_param AND trashed_at IS NULL
+
+        UNION ALL
+
+        SELECT a.id, a.path
+        FROM albums a
+        INNER JOIN album_tree at ON a.parent_id = at.id
+        WHERE a.trashed_at IS NULL
+    )
+    SELECT COUNT(m.id) INTO total_count
+    FROM album_tree at
+    INNER JOIN media m ON m.album_id = at.id AND m.trashed_at IS NULL;
+
+    RETURN total_count;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION latest_capture_in_album_with_children(album_id_param VARCHAR(255))
+RETURNS TIMESTAMP AS $$
+DECLARE
+    latest TIMESTAMP;
+BEGIN
+    WITH RECURSIVE album_tree AS (
+        SELECT id
+        FROM albums
+        WHERE id = album_id_param AND trashed_at IS NULL
+
+        UNION ALL
+
+        SELECT a.id
+        FROM albums a
+        INNER JOIN album_tree at ON a.parent_id = at.id
+        WHERE a.trashed_at IS NULL
+    )
+    SELECT MAX(m.captured_at) INTO latest
+    FROM album_tree at
+    INNER JOIN media m ON m.album_id = at.id AND m.trashed_at IS NULL;
+
+    RETURN latest;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+DROP INDEX IF EXISTS idx_albums_subtree_latest_captured_at;
+DROP INDEX IF EXISTS idx_albums_subtree_media_count;
+
+ALTER TABLE albums
+    DROP COLUMN subtree_latest_captured_at,
+    DROP COLUMN subtree_media_count,
+    DROP COLUMN latest_captured_at,
+    DROP COLUMN media_count;
+
+DROP TABLE album_closure;
+-- +goose StatementEnd
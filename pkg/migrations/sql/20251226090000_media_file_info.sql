@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Attributes of the media file when it was last synced. A sync skips the files whose attributes
+-- did not change instead of reading and hashing them. They are null until the media is synced again.
+ALTER TABLE media
+    ADD COLUMN file_size BIGINT,
+    ADD COLUMN file_modified_at TIMESTAMP,
+    ADD COLUMN file_inode BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE media
+    DROP COLUMN file_inode,
+    DROP COLUMN file_modified_at,
+    DROP COLUMN file_size;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- History of the scheduler jobs. A job is written when its status changes and job_results gets
+-- a row for every task it ran, so the history and the progress survive a restart.
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    type VARCHAR(64) NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    total INTEGER NOT NULL DEFAULT 0,
+    remaining INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_status ON jobs(status);
+CREATE INDEX idx_jobs_completed_at ON jobs(completed_at);
+
+CREATE TABLE job_results (
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    result TEXT NOT NULL DEFAULT '',
+    error TEXT,
+    started_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (job_id, position)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE job_results;
+DROP TABLE jobs;
+-- +goose StatementEnd